@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/handler"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/rules"
+	"github.com/argonsecurity/pipeline-parser/pkg/sarif"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	severity             string
+	severityFlagName     = "severity"
+	severityDefaultValue = string(rules.LowSeverity)
+	severityUsage        = fmt.Sprintf("Minimum severity of the reported findings - %v", rules.Severities)
+
+	ruleIDs          []string
+	ruleFlagName     = "rule"
+	ruleDefaultValue = []string{}
+	ruleUsage        = "ID of a rule to check, may be repeated. All the built-in rules are checked by default"
+
+	checkOutputFileName = "findings"
+
+	checkOutputFormats = []consts.OutputFormat{consts.JSONFormat, consts.SARIFFormat}
+)
+
+func GetCheckCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "check <file>...",
+		Short: "Checks pipeline files against security rules",
+		Long: `Parses pipeline files and checks them against the built-in security rules, such as unpinned actions, write-all token permissions and scripts piped from the network into a shell.
+The output is a single JSON document of the findings keyed by file path, or a SARIF log of the findings and the parse diagnostics of the files.
+The command exits with a non-zero code if any finding is at least as severe as the severity flag.`,
+		Example: `pipeline-parser check -p github .github/workflows/*.yml
+pipeline-parser check -p auto --severity high --rule unpinned-action .gitlab-ci.yml
+pipeline-parser check -p github --format sarif --output file .github/workflows/*.yml`,
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: checkPreRun,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			selectedRules, err := getSelectedRules()
+			if err != nil {
+				return err
+			}
+
+			results := map[string][]*rules.Finding{}
+			var files []*sarif.File
+			count := 0
+			for _, pipelinePath := range args {
+				buf, err := ioutil.ReadFile(pipelinePath)
+				if err != nil {
+					return err
+				}
+				pipelinePlatform, err := getPlatform(pipelinePath, buf)
+				if err != nil {
+					return err
+				}
+				pipeline, err := handler.Handle(buf, pipelinePlatform, &models.Credentials{Token: token}, &organization, &baseProviderUrl)
+				if err != nil {
+					return err
+				}
+
+				findings := []*rules.Finding{}
+				for _, finding := range rules.Evaluate(pipeline, selectedRules...) {
+					if finding.Severity.IsAtLeast(rules.Severity(severity)) {
+						findings = append(findings, finding)
+					}
+				}
+				results[pipelinePath] = findings
+				files = append(files, &sarif.File{Path: pipelinePath, Findings: findings, Diagnostics: pipeline.Diagnostics})
+				count += len(findings)
+			}
+
+			outputTarget := consts.OutputTarget(output)
+			outputFormat := getOutputFormat(outputTarget)
+			var checkOutput any = results
+			if outputFormat == consts.SARIFFormat {
+				checkOutput = sarif.NewLog(files...)
+			}
+			if err := writeCheckResultsToOutput(checkOutput, outputTarget, outputFormat); err != nil {
+				return err
+			}
+			if count > 0 {
+				return consts.NewErrPolicyViolations(count)
+			}
+			return nil
+		},
+	}
+
+	command.Flags().StringVar(&severity, severityFlagName, severityDefaultValue, severityUsage)
+	command.Flags().StringSliceVar(&ruleIDs, ruleFlagName, ruleDefaultValue, ruleUsage)
+
+	return command
+}
+
+func checkPreRun(cmd *cobra.Command, args []string) error {
+	if models.Platform(platform) != consts.AutoPlatform && !slices.Contains(consts.Platforms, models.Platform(platform)) {
+		return consts.NewErrInvalidPlatform(models.Platform(platform))
+	}
+
+	if !slices.Contains(consts.OutputTargets, consts.OutputTarget(output)) {
+		return consts.NewErrInvalidOutputTarget(consts.OutputTarget(output))
+	}
+
+	if !slices.Contains(checkOutputFormats, consts.OutputFormat(format)) {
+		return consts.NewErrInvalidOutputFormat(consts.OutputFormat(format))
+	}
+
+	if !slices.Contains(rules.Severities, rules.Severity(severity)) {
+		return consts.NewErrInvalidSeverity(severity, utils.Map(rules.Severities, func(s rules.Severity) string { return string(s) }))
+	}
+
+	return nil
+}
+
+// getSelectedRules returns the rules of the rule flag, or nil to check all the built-in rules
+func getSelectedRules() ([]rules.Rule, error) {
+	var selectedRules []rules.Rule
+	for _, id := range ruleIDs {
+		rule, err := rules.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		selectedRules = append(selectedRules, rule)
+	}
+	return selectedRules, nil
+}
+
+func writeCheckResultsToOutput(checkOutput any, outputTarget consts.OutputTarget, outputFormat consts.OutputFormat) error {
+	buf, err := json.MarshalIndent(checkOutput, "", " ")
+	if err != nil {
+		return err
+	}
+
+	switch outputTarget {
+	case consts.Stdout:
+		fmt.Println(string(buf))
+	case consts.File, consts.CodeScanning:
+		outputFilePath := fmt.Sprintf("%s.%s", checkOutputFileName, outputFormatExtensions[outputFormat])
+		if err = ioutil.WriteFile(outputFilePath, buf, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -8,8 +8,11 @@ import (
 	"path/filepath"
 
 	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/detector"
 	"github.com/argonsecurity/pipeline-parser/pkg/handler"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/render"
+	"github.com/argonsecurity/pipeline-parser/pkg/sarif"
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/slices"
 )
@@ -19,7 +22,7 @@ var (
 	platformFlagName      = "platform"
 	platformShortFlagName = "p"
 	platformDefaultValue  = string(consts.GitHubPlatform)
-	platformUsage         = fmt.Sprintf("CI platform to parse - %v", consts.Platforms)
+	platformUsage         = fmt.Sprintf("CI platform to parse - %v, or %s to detect it from the file path and content", consts.Platforms, consts.AutoPlatform)
 
 	output              string
 	outputFlagName      = "output"
@@ -27,6 +30,12 @@ var (
 	outputDefaultValue  = string(consts.Stdout)
 	outputUsage         = fmt.Sprintf("Output target - %v", consts.OutputTargets)
 
+	format              string
+	formatFlagName      = "format"
+	formatShortFlagName = "f"
+	formatDefaultValue  = string(consts.JSONFormat)
+	formatUsage         = fmt.Sprintf("Output format - %v", consts.OutputFormats)
+
 	fileSuffix             string
 	fileSuffixFlagName     = "file-suffix"
 	fileSuffixDefaultValue = "parsed"
@@ -47,12 +56,26 @@ var (
 	baseProviderUrlDefaultValue = ""
 	baseProviderUrlUsage        = "base api url for the pipeline provider (used for pasring remote templates)"
 
+	failOnError             bool
+	failOnErrorFlagName     = "fail-on-error"
+	failOnErrorDefaultValue = false
+	failOnErrorUsage        = "Fail if an imported pipeline could not be fetched or parsed"
+
 	version string
+
+	outputFormatExtensions = map[consts.OutputFormat]string{
+		consts.JSONFormat:    "json",
+		consts.DotFormat:     "dot",
+		consts.MermaidFormat: "mmd",
+		consts.SARIFFormat:   "sarif",
+	}
 )
 
 func main() {
 	c := GetCommand(version)
-	c.Execute()
+	if err := c.Execute(); err != nil {
+		os.Exit(1)
+	}
 }
 
 func GetCommand(version string) *cobra.Command {
@@ -62,9 +85,13 @@ func GetCommand(version string) *cobra.Command {
 		Long:  "Parses a pipeline file",
 		Example: `pipeline-parser --platform github workflow.yml
 pipeline-parser --platform gitlab .gitlab-ci.yml
-pipeline-parser --platform azure azure-pipelines.yml`,
+pipeline-parser --platform azure azure-pipelines.yml
+pipeline-parser --platform github --format mermaid workflow.yml
+pipeline-parser --platform github --format sarif workflow.yml
+pipeline-parser --platform auto .gitlab-ci.yml`,
 		SilenceUsage: true,
 		Version:      version,
+		Args:         cobra.ArbitraryArgs,
 		PreRunE:      preRun,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			for _, pipelinePath := range args {
@@ -73,7 +100,15 @@ pipeline-parser --platform azure azure-pipelines.yml`,
 					if err != nil {
 						return nil
 					}
-					pipeline, err := handler.Handle(buf, models.Platform(platform), &models.Credentials{Token: token}, &organization, &baseProviderUrl)
+					pipelinePlatform, err := getPlatform(pipelinePath, buf)
+					if err != nil {
+						return err
+					}
+					var opts []handler.Option
+					if failOnError {
+						opts = append(opts, handler.WithFailOnError())
+					}
+					pipeline, err := handler.Handle(buf, pipelinePlatform, &models.Credentials{Token: token}, &organization, &baseProviderUrl, opts...)
 					if err != nil {
 						return err
 					}
@@ -90,10 +125,17 @@ pipeline-parser --platform azure azure-pipelines.yml`,
 
 	command.PersistentFlags().StringVarP(&platform, platformFlagName, platformShortFlagName, platformDefaultValue, platformUsage)
 	command.PersistentFlags().StringVarP(&output, outputFlagName, outputShortFlagName, outputDefaultValue, outputUsage)
+	command.PersistentFlags().StringVarP(&format, formatFlagName, formatShortFlagName, formatDefaultValue, formatUsage)
 	command.PersistentFlags().StringVar(&fileSuffix, fileSuffixFlagName, fileSuffixDefaultValue, fileSuffixUsage)
 	command.PersistentFlags().StringVar(&token, tokenFlagName, tokenDefaultValue, tokenUsage)
 	command.PersistentFlags().StringVar(&organization, organizationFlagName, organizationDefaultValue, organizationUsage)
 	command.PersistentFlags().StringVar(&baseProviderUrl, baseProviderUrlFlagName, baseProviderUrlDefaultValue, baseProviderUrlUsage)
+	command.Flags().BoolVar(&failOnError, failOnErrorFlagName, failOnErrorDefaultValue, failOnErrorUsage)
+
+	command.AddCommand(GetScanCommand())
+	command.AddCommand(GetCheckCommand())
+	command.AddCommand(GetPolicyCommand())
+	command.AddCommand(GetPinCommand())
 
 	return command
 }
@@ -103,7 +145,7 @@ func preRun(cmd *cobra.Command, args []string) error {
 		return consts.NewErrInvalidArgumentsCount(len(args))
 	}
 
-	if !slices.Contains(consts.Platforms, models.Platform(platform)) {
+	if models.Platform(platform) != consts.AutoPlatform && !slices.Contains(consts.Platforms, models.Platform(platform)) {
 		return consts.NewErrInvalidPlatform(models.Platform(platform))
 	}
 
@@ -111,11 +153,29 @@ func preRun(cmd *cobra.Command, args []string) error {
 		return consts.NewErrInvalidOutputTarget(consts.OutputTarget(output))
 	}
 
+	if !slices.Contains(consts.OutputFormats, consts.OutputFormat(format)) {
+		return consts.NewErrInvalidOutputFormat(consts.OutputFormat(format))
+	}
+
 	return nil
 }
 
+// getPlatform returns the platform flag, or the detected platform of the file if the flag is set to auto
+func getPlatform(pipelinePath string, data []byte) (models.Platform, error) {
+	if models.Platform(platform) != consts.AutoPlatform {
+		return models.Platform(platform), nil
+	}
+
+	detection, err := detector.Detect(pipelinePath, data)
+	if err != nil {
+		return "", err
+	}
+	return detection.Platform, nil
+}
+
 func writePipelineToOutput(pipeline *models.Pipeline, outputTarget consts.OutputTarget, pipelinePath string) error {
-	jsonPipeline, err := json.MarshalIndent(pipeline, "", " ")
+	outputFormat := getOutputFormat(outputTarget)
+	formattedPipeline, err := formatPipeline(pipeline, outputFormat, pipelinePath)
 	if err != nil {
 		return err
 	}
@@ -123,10 +183,10 @@ func writePipelineToOutput(pipeline *models.Pipeline, outputTarget consts.Output
 	switch outputTarget {
 	case consts.Stdout:
 		fmt.Printf("%s:\n", pipelinePath)
-		fmt.Println(string(jsonPipeline))
-	case consts.File:
-		outputFilePath := getOutputFilePath(pipelinePath, fileSuffix)
-		if err = ioutil.WriteFile(outputFilePath, jsonPipeline, 0644); err != nil {
+		fmt.Println(string(formattedPipeline))
+	case consts.File, consts.CodeScanning:
+		outputFilePath := getOutputFilePath(pipelinePath, fileSuffix, outputFormatExtensions[outputFormat])
+		if err = ioutil.WriteFile(outputFilePath, formattedPipeline, 0644); err != nil {
 			return err
 		}
 	}
@@ -134,9 +194,33 @@ func writePipelineToOutput(pipeline *models.Pipeline, outputTarget consts.Output
 	return nil
 }
 
-func getOutputFilePath(pipelinePath string, fileSuffix string) string {
+// getOutputFormat returns the format flag, or the SARIF format for the code scanning output target
+func getOutputFormat(outputTarget consts.OutputTarget) consts.OutputFormat {
+	if outputTarget == consts.CodeScanning {
+		return consts.SARIFFormat
+	}
+	return consts.OutputFormat(format)
+}
+
+func formatPipeline(pipeline *models.Pipeline, outputFormat consts.OutputFormat, pipelinePath string) ([]byte, error) {
+	switch outputFormat {
+	case consts.JSONFormat:
+		return json.MarshalIndent(pipeline, "", " ")
+	case consts.SARIFFormat:
+		// the SARIF output of a parsed pipeline holds its parse diagnostics
+		return json.MarshalIndent(sarif.NewLog(&sarif.File{Path: pipelinePath, Diagnostics: pipeline.Diagnostics}), "", " ")
+	}
+
+	diagram, err := render.Render(pipeline, outputFormat)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(diagram), nil
+}
+
+func getOutputFilePath(pipelinePath string, fileSuffix string, extension string) string {
 	ext := filepath.Ext(pipelinePath)
 	base := filepath.Base(pipelinePath)
 
-	return filepath.Join(filepath.Dir(pipelinePath), fmt.Sprintf("%s_%s.json", base[0:len(base)-len(ext)], fileSuffix))
+	return filepath.Join(filepath.Dir(pipelinePath), fmt.Sprintf("%s_%s.%s", base[0:len(base)-len(ext)], fileSuffix, extension))
 }
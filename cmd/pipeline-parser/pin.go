@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/pin"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	lockfile             string
+	lockfileFlagName     = "lockfile"
+	lockfileDefaultValue = ""
+	lockfileUsage        = "YAML lockfile of '<repository>@<ref>: <sha>' entries to resolve refs from"
+
+	gitMirror             string
+	gitMirrorFlagName     = "git-mirror"
+	gitMirrorDefaultValue = ""
+	gitMirrorUsage        = "Directory of git mirrors, at <dir>/<owner>/<repository>, to resolve refs from"
+
+	dryRun             bool
+	dryRunFlagName     = "dry-run"
+	dryRunDefaultValue = false
+	dryRunUsage        = "Report the pinned refs without rewriting the files"
+
+	pinOutputFileName = "pins"
+)
+
+// pinnedFile is the pinned content of a workflow file, before it is written
+type pinnedFile struct {
+	path string
+	data []byte
+	mode fs.FileMode
+}
+
+func GetPinCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "pin <file>...",
+		Short: "Pins the actions and reusable workflows of GitHub workflows to commit SHAs",
+		Long: `Rewrites GitHub workflow files in place, replacing the tag and branch refs of actions and reusable workflows with the commit SHAs they resolve to.
+The original ref is kept as a trailing comment, and the rest of the file, including comments and formatting, is left as is.
+Refs are resolved from a static lockfile or from local git mirrors of the repositories, without accessing the network.
+The output is a single JSON document of the pinned refs keyed by file path.`,
+		Example: `pipeline-parser pin --lockfile actions.lock.yml .github/workflows/*.yml
+pipeline-parser pin --git-mirror ~/mirrors --dry-run .github/workflows/ci.yml`,
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: pinPreRun,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resolver, err := getResolver()
+			if err != nil {
+				return err
+			}
+
+			// all the files are pinned before any of them is written, so a file that fails to pin leaves every file untouched
+			results := map[string][]*pin.Edit{}
+			var pinnedFiles []*pinnedFile
+			for _, pipelinePath := range args {
+				fi, err := os.Stat(pipelinePath)
+				if err != nil {
+					return err
+				}
+				buf, err := ioutil.ReadFile(pipelinePath)
+				if err != nil {
+					return err
+				}
+
+				pinned, edits, err := pin.Pin(buf, resolver)
+				if err != nil {
+					return fmt.Errorf("%s: %w", pipelinePath, err)
+				}
+				results[pipelinePath] = edits
+
+				if len(edits) > 0 {
+					pinnedFiles = append(pinnedFiles, &pinnedFile{path: pipelinePath, data: pinned, mode: fi.Mode()})
+				}
+			}
+
+			if !dryRun {
+				for _, file := range pinnedFiles {
+					if err := ioutil.WriteFile(file.path, file.data, file.mode); err != nil {
+						return err
+					}
+				}
+			}
+
+			return writePinResultsToOutput(results, consts.OutputTarget(output))
+		},
+	}
+
+	command.Flags().StringVar(&lockfile, lockfileFlagName, lockfileDefaultValue, lockfileUsage)
+	command.Flags().StringVar(&gitMirror, gitMirrorFlagName, gitMirrorDefaultValue, gitMirrorUsage)
+	command.Flags().BoolVar(&dryRun, dryRunFlagName, dryRunDefaultValue, dryRunUsage)
+
+	return command
+}
+
+func pinPreRun(cmd *cobra.Command, args []string) error {
+	// the pinned refs have no SARIF form to upload to code scanning dashboards
+	if !slices.Contains(consts.OutputTargets, consts.OutputTarget(output)) || consts.OutputTarget(output) == consts.CodeScanning {
+		return consts.NewErrInvalidOutputTarget(consts.OutputTarget(output))
+	}
+
+	// the pinned refs of multiple files can only be written as a single JSON document
+	if consts.OutputFormat(format) != consts.JSONFormat {
+		return consts.NewErrInvalidOutputFormat(consts.OutputFormat(format))
+	}
+
+	if (lockfile == "") == (gitMirror == "") {
+		return fmt.Errorf("exactly one of --%s and --%s must be set", lockfileFlagName, gitMirrorFlagName)
+	}
+
+	return nil
+}
+
+func getResolver() (pin.Resolver, error) {
+	if lockfile != "" {
+		return pin.LoadLockfile(lockfile)
+	}
+	return &pin.GitResolver{Root: gitMirror}, nil
+}
+
+func writePinResultsToOutput(results map[string][]*pin.Edit, outputTarget consts.OutputTarget) error {
+	buf, err := json.MarshalIndent(results, "", " ")
+	if err != nil {
+		return err
+	}
+
+	switch outputTarget {
+	case consts.Stdout:
+		fmt.Println(string(buf))
+	case consts.File:
+		outputFilePath := fmt.Sprintf("%s.%s", pinOutputFileName, outputFormatExtensions[consts.JSONFormat])
+		if err = ioutil.WriteFile(outputFilePath, buf, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
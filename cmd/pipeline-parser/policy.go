@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/handler"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/policy"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	policiesDir          string
+	policiesFlagName     = "policies"
+	policiesDefaultValue = ""
+	policiesUsage        = "Directory of Rego policies to evaluate. The policy inputs are printed if it is not set"
+	policyOutputFileName = "policy-results"
+	policyInputFileName  = "policy-inputs"
+)
+
+func GetPolicyCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "policy <file>...",
+		Short: "Evaluates Rego policies against pipeline files",
+		Long: `Parses pipeline files into the policy input, a stable JSON view of the pipeline with its resolved imports inlined, and evaluates a directory of Rego policies against it.
+A policy is a Rego package, and it fails if its deny rule holds any violation.
+The output is a single JSON document of the results of every policy keyed by file path, or of the policy inputs if no policies are given.
+The command exits with a non-zero code if any policy fails.`,
+		Example: `pipeline-parser policy -p github --policies ./policies .github/workflows/*.yml
+pipeline-parser policy -p auto .gitlab-ci.yml`,
+		Args:    cobra.MinimumNArgs(1),
+		PreRunE: policyPreRun,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var evaluator *policy.Evaluator
+			if policiesDir != "" {
+				var err error
+				if evaluator, err = policy.LoadPolicies(cmd.Context(), policiesDir); err != nil {
+					return err
+				}
+			}
+
+			inputs := map[string]*policy.Input{}
+			results := map[string][]*policy.Result{}
+			count := 0
+			for _, pipelinePath := range args {
+				buf, err := ioutil.ReadFile(pipelinePath)
+				if err != nil {
+					return err
+				}
+				pipelinePlatform, err := getPlatform(pipelinePath, buf)
+				if err != nil {
+					return err
+				}
+				pipeline, err := handler.Handle(buf, pipelinePlatform, &models.Credentials{Token: token}, &organization, &baseProviderUrl)
+				if err != nil {
+					return err
+				}
+
+				input := policy.NewInput(pipeline, pipelinePath)
+				if evaluator == nil {
+					inputs[pipelinePath] = input
+					continue
+				}
+
+				fileResults, err := evaluator.Evaluate(cmd.Context(), input)
+				if err != nil {
+					return err
+				}
+				for _, result := range fileResults {
+					if !result.Passed {
+						count++
+					}
+				}
+				results[pipelinePath] = fileResults
+			}
+
+			if evaluator == nil {
+				return writePolicyOutput(inputs, consts.OutputTarget(output), policyInputFileName)
+			}
+			if err := writePolicyOutput(results, consts.OutputTarget(output), policyOutputFileName); err != nil {
+				return err
+			}
+			if count > 0 {
+				return consts.NewErrPolicyViolations(count)
+			}
+			return nil
+		},
+	}
+
+	command.Flags().StringVar(&policiesDir, policiesFlagName, policiesDefaultValue, policiesUsage)
+
+	return command
+}
+
+func policyPreRun(cmd *cobra.Command, args []string) error {
+	if models.Platform(platform) != consts.AutoPlatform && !slices.Contains(consts.Platforms, models.Platform(platform)) {
+		return consts.NewErrInvalidPlatform(models.Platform(platform))
+	}
+
+	// the policy inputs and results have no SARIF form to upload to code scanning dashboards
+	if !slices.Contains(consts.OutputTargets, consts.OutputTarget(output)) || consts.OutputTarget(output) == consts.CodeScanning {
+		return consts.NewErrInvalidOutputTarget(consts.OutputTarget(output))
+	}
+
+	// the policy inputs and results can only be written as a JSON document
+	if consts.OutputFormat(format) != consts.JSONFormat {
+		return consts.NewErrInvalidOutputFormat(consts.OutputFormat(format))
+	}
+
+	return nil
+}
+
+func writePolicyOutput(policyOutput any, outputTarget consts.OutputTarget, outputFileName string) error {
+	buf, err := json.MarshalIndent(policyOutput, "", " ")
+	if err != nil {
+		return err
+	}
+
+	switch outputTarget {
+	case consts.Stdout:
+		fmt.Println(string(buf))
+	case consts.File:
+		outputFilePath := fmt.Sprintf("%s.%s", outputFileName, outputFormatExtensions[consts.JSONFormat])
+		if err = ioutil.WriteFile(outputFilePath, buf, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
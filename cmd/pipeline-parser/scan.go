@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/scanner"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	workers              int
+	workersFlagName      = "workers"
+	workersShortFlagName = "w"
+	workersDefaultValue  = runtime.NumCPU()
+	workersUsage         = "Number of files to parse concurrently"
+
+	scanOutputFileName = "pipelines.json"
+)
+
+func GetScanCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "scan <dir>",
+		Short: "Parses all the pipeline files of a repository",
+		Long: `Walks a checked-out repository, discovers the pipeline files of every supported platform by their well-known paths and parses them.
+The output is a single JSON document keyed by the path of the file relative to the repository root.
+Files that fail to parse are reported with their error instead of aborting the scan.`,
+		Example: `pipeline-parser scan .
+pipeline-parser scan --workers 4 --output file path/to/repository`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: scanPreRun,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := args[0]
+			if fi, err := os.Stat(root); err != nil {
+				return err
+			} else if !fi.IsDir() {
+				return fmt.Errorf("%s is not a directory", root)
+			}
+
+			results, err := scanner.Scan(root, scanner.Options{
+				Workers:      workers,
+				Credentials:  &models.Credentials{Token: token},
+				Organization: &organization,
+				BaseUrl:      &baseProviderUrl,
+			})
+			if err != nil {
+				return err
+			}
+
+			return writeScanResultsToOutput(results, consts.OutputTarget(output), root)
+		},
+	}
+
+	command.Flags().IntVarP(&workers, workersFlagName, workersShortFlagName, workersDefaultValue, workersUsage)
+
+	return command
+}
+
+func scanPreRun(cmd *cobra.Command, args []string) error {
+	// the scan results have no SARIF form to upload to code scanning dashboards
+	if !slices.Contains(consts.OutputTargets, consts.OutputTarget(output)) || consts.OutputTarget(output) == consts.CodeScanning {
+		return consts.NewErrInvalidOutputTarget(consts.OutputTarget(output))
+	}
+
+	// the scan results of multiple files can only be written as a single JSON document
+	if consts.OutputFormat(format) != consts.JSONFormat {
+		return consts.NewErrInvalidOutputFormat(consts.OutputFormat(format))
+	}
+
+	return nil
+}
+
+func writeScanResultsToOutput(results map[string]*scanner.Result, outputTarget consts.OutputTarget, root string) error {
+	buf, err := json.MarshalIndent(results, "", " ")
+	if err != nil {
+		return err
+	}
+
+	switch outputTarget {
+	case consts.Stdout:
+		fmt.Println(string(buf))
+	case consts.File:
+		outputFilePath := getOutputFilePath(filepath.Join(root, scanOutputFileName), fileSuffix, outputFormatExtensions[consts.JSONFormat])
+		if err = ioutil.WriteFile(outputFilePath, buf, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
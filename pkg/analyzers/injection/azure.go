@@ -0,0 +1,35 @@
+package injection
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+var (
+	azureMacroRegex = regexp.MustCompile(`\$\(([A-Za-z_][\w.]*)\)`)
+
+	// azureUntrustedInputs are the predefined variables an attacker can control, by their lower cased name
+	azureUntrustedInputs = map[string]*untrustedInput{
+		"build.sourcebranch":              {name: "Build.SourceBranch", events: commitEvents},
+		"build.sourcebranchname":          {name: "Build.SourceBranchName", events: commitEvents},
+		"build.sourceversionmessage":      {name: "Build.SourceVersionMessage", events: commitEvents},
+		"build.sourceversionauthor":       {name: "Build.SourceVersionAuthor", events: commitEvents},
+		"system.pullrequest.sourcebranch": {name: "System.PullRequest.SourceBranch", events: []models.EventType{models.PullRequestEvent}},
+	}
+)
+
+// findAzureInterpolations returns the untrusted predefined variables a step interpolates with $( ) macros into its script,
+// which are replaced before the script runs
+func findAzureInterpolations(step *models.Step) []*interpolation {
+	var interpolations []*interpolation
+	for _, script := range getStepScripts(step) {
+		for _, match := range azureMacroRegex.FindAllStringSubmatch(script, -1) {
+			if input, ok := azureUntrustedInputs[strings.ToLower(match[1])]; ok {
+				interpolations = append(interpolations, &interpolation{input: input, expression: match[0]})
+			}
+		}
+	}
+	return interpolations
+}
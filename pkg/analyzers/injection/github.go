@@ -0,0 +1,106 @@
+package injection
+
+import (
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/expressions"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+const (
+	issuesEvent                   models.EventType = "issues"
+	issueCommentEvent             models.EventType = "issue_comment"
+	pullRequestTargetEvent        models.EventType = "pull_request_target"
+	pullRequestReviewEvent        models.EventType = "pull_request_review"
+	pullRequestReviewCommentEvent models.EventType = "pull_request_review_comment"
+	commitCommentEvent            models.EventType = "commit_comment"
+	discussionEvent               models.EventType = "discussion"
+	discussionCommentEvent        models.EventType = "discussion_comment"
+	gollumEvent                   models.EventType = "gollum"
+
+	githubScriptAction = "actions/github-script"
+)
+
+var (
+	pullRequestEvents = []models.EventType{models.PullRequestEvent, pullRequestTargetEvent, pullRequestReviewEvent, pullRequestReviewCommentEvent}
+	commentEvents     = []models.EventType{issueCommentEvent, pullRequestReviewCommentEvent, commitCommentEvent, discussionCommentEvent}
+
+	// githubUntrustedInputs are the context paths an attacker can control, where "*" matches any single path element
+	githubUntrustedInputs = []*untrustedInput{
+		{name: "github.head_ref", events: pullRequestEvents},
+		{name: "github.event.issue.title", events: []models.EventType{issuesEvent, issueCommentEvent}},
+		{name: "github.event.issue.body", events: []models.EventType{issuesEvent, issueCommentEvent}},
+		{name: "github.event.pull_request.title", events: pullRequestEvents},
+		{name: "github.event.pull_request.body", events: pullRequestEvents},
+		{name: "github.event.pull_request.head.ref", events: pullRequestEvents},
+		{name: "github.event.pull_request.head.label", events: pullRequestEvents},
+		{name: "github.event.pull_request.head.repo.default_branch", events: pullRequestEvents},
+		{name: "github.event.comment.body", events: commentEvents},
+		{name: "github.event.review.body", events: []models.EventType{pullRequestReviewEvent}},
+		{name: "github.event.discussion.title", events: []models.EventType{discussionEvent, discussionCommentEvent}},
+		{name: "github.event.discussion.body", events: []models.EventType{discussionEvent, discussionCommentEvent}},
+		{name: "github.event.pages.*.page_name", events: []models.EventType{gollumEvent}},
+		{name: "github.event.commits.*.message", events: []models.EventType{models.PushEvent}},
+		{name: "github.event.commits.*.author.email", events: []models.EventType{models.PushEvent}},
+		{name: "github.event.commits.*.author.name", events: []models.EventType{models.PushEvent}},
+		{name: "github.event.head_commit.message", events: []models.EventType{models.PushEvent}},
+		{name: "github.event.head_commit.author.email", events: []models.EventType{models.PushEvent}},
+		{name: "github.event.head_commit.author.name", events: []models.EventType{models.PushEvent}},
+		{name: "github.event.workflow_run.head_branch", events: []models.EventType{models.PipelineRunEvent}},
+		{name: "github.event.workflow_run.head_commit.message", events: []models.EventType{models.PipelineRunEvent}},
+		{name: "github.event.workflow_run.head_commit.author.email", events: []models.EventType{models.PipelineRunEvent}},
+		{name: "github.event.workflow_run.head_commit.author.name", events: []models.EventType{models.PipelineRunEvent}},
+		{name: "github.event.workflow_run.pull_requests.*.head.ref", events: []models.EventType{models.PipelineRunEvent}},
+	}
+)
+
+// findGitHubInterpolations returns the untrusted contexts a step interpolates with ${{ }} into its run script,
+// or into the script of actions/github-script, which is evaluated as JavaScript
+func findGitHubInterpolations(step *models.Step) []*interpolation {
+	scripts := getStepScripts(step)
+	if step.Task != nil && step.Task.Name != nil && *step.Task.Name == githubScriptAction {
+		for _, input := range step.Task.Inputs {
+			if input != nil && input.Name != nil && *input.Name == "script" {
+				if script, ok := input.Value.(string); ok {
+					scripts = append(scripts, script)
+				}
+			}
+		}
+	}
+
+	var interpolations []*interpolation
+	for _, script := range scripts {
+		parsed, _ := expressions.ParseTemplate(script, nil)
+		for _, expression := range parsed {
+			for _, reference := range expression.ContextReferences() {
+				if input := findGitHubUntrustedInput(reference); input != nil {
+					interpolations = append(interpolations, &interpolation{input: input, expression: "${{ " + expression.Raw + " }}"})
+				}
+			}
+		}
+	}
+	return interpolations
+}
+
+// findGitHubUntrustedInput returns the untrusted input a context reference reads, including any of its nested properties
+func findGitHubUntrustedInput(reference *expressions.ContextReference) *untrustedInput {
+	path := append([]string{reference.Context}, reference.Path...)
+	for _, input := range githubUntrustedInputs {
+		pattern := strings.Split(input.name, ".")
+		if len(path) < len(pattern) {
+			continue
+		}
+
+		matches := true
+		for i, element := range pattern {
+			if element != "*" && !strings.EqualFold(element, path[i]) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return input
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package injection
+
+import (
+	"regexp"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+var (
+	gitlabVariableRegex = regexp.MustCompile(`\$(?:\{([A-Za-z_][A-Za-z0-9_]*)\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+	commitEvents = []models.EventType{models.PushEvent, models.PullRequestEvent}
+
+	// gitlabUntrustedInputs are the predefined variables an attacker can control
+	gitlabUntrustedInputs = map[string]*untrustedInput{
+		"CI_COMMIT_MESSAGE":                           {name: "CI_COMMIT_MESSAGE", events: commitEvents},
+		"CI_COMMIT_TITLE":                             {name: "CI_COMMIT_TITLE", events: commitEvents},
+		"CI_COMMIT_DESCRIPTION":                       {name: "CI_COMMIT_DESCRIPTION", events: commitEvents},
+		"CI_COMMIT_AUTHOR":                            {name: "CI_COMMIT_AUTHOR", events: commitEvents},
+		"CI_COMMIT_BRANCH":                            {name: "CI_COMMIT_BRANCH", events: []models.EventType{models.PushEvent}},
+		"CI_COMMIT_REF_NAME":                          {name: "CI_COMMIT_REF_NAME", events: commitEvents},
+		"CI_COMMIT_TAG_MESSAGE":                       {name: "CI_COMMIT_TAG_MESSAGE", events: []models.EventType{models.PushEvent}},
+		"CI_MERGE_REQUEST_TITLE":                      {name: "CI_MERGE_REQUEST_TITLE", events: []models.EventType{models.PullRequestEvent}},
+		"CI_MERGE_REQUEST_DESCRIPTION":                {name: "CI_MERGE_REQUEST_DESCRIPTION", events: []models.EventType{models.PullRequestEvent}},
+		"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME":         {name: "CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", events: []models.EventType{models.PullRequestEvent}},
+		"CI_EXTERNAL_PULL_REQUEST_SOURCE_BRANCH_NAME": {name: "CI_EXTERNAL_PULL_REQUEST_SOURCE_BRANCH_NAME", events: []models.EventType{models.PullRequestEvent}},
+	}
+)
+
+// findGitLabInterpolations returns the untrusted predefined variables a step expands in its script
+func findGitLabInterpolations(step *models.Step) []*interpolation {
+	var interpolations []*interpolation
+	for _, script := range getStepScripts(step) {
+		for _, match := range gitlabVariableRegex.FindAllStringSubmatch(script, -1) {
+			name := match[1] + match[2]
+			if input, ok := gitlabUntrustedInputs[name]; ok {
+				interpolations = append(interpolations, &interpolation{input: input, expression: match[0]})
+			}
+		}
+	}
+	return interpolations
+}
@@ -0,0 +1,136 @@
+package injection
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+// Finding is an attacker controlled input that is interpolated into a script
+type Finding struct {
+	JobID         string                `json:"job_id,omitempty"`
+	StepName      *string               `json:"step_name,omitempty"`
+	Input         string                `json:"input,omitempty"`      // The untrusted input, such as github.event.issue.title
+	Expression    string                `json:"expression,omitempty"` // The interpolation of the input in the script
+	Message       string                `json:"message,omitempty"`
+	Events        []models.EventType    `json:"events,omitempty"` // The triggering events that make the injection exploitable
+	FileReference *models.FileReference `json:"file_reference,omitempty"`
+}
+
+// untrustedInput is an input an attacker can control when the pipeline is triggered by one of its events
+type untrustedInput struct {
+	name   string
+	events []models.EventType
+}
+
+// interpolation is a use of an untrusted input within a script
+type interpolation struct {
+	input      *untrustedInput
+	expression string
+}
+
+// Analyze returns the steps of the pipeline that interpolate untrusted inputs into their scripts.
+// An input is exploitable when the pipeline is triggered by an event that lets an attacker control it.
+// When the pipeline does not declare its triggers, every event of the input is considered.
+func Analyze(pipeline *models.Pipeline) []*Finding {
+	if pipeline == nil {
+		return nil
+	}
+
+	var findInterpolations func(step *models.Step) []*interpolation
+	switch pipeline.Platform {
+	case consts.GitHubPlatform:
+		findInterpolations = findGitHubInterpolations
+	case consts.GitLabPlatform:
+		findInterpolations = findGitLabInterpolations
+	case consts.AzurePlatform:
+		findInterpolations = findAzureInterpolations
+	default:
+		return nil
+	}
+
+	triggerEvents := getTriggerEvents(pipeline.Triggers)
+	var findings []*Finding
+	for _, job := range pipeline.Jobs {
+		if job == nil {
+			continue
+		}
+
+		jobID := ""
+		if job.ID != nil {
+			jobID = *job.ID
+		}
+		for _, steps := range [][]*models.Step{job.PreSteps, job.Steps, job.PostSteps} {
+			for _, step := range steps {
+				if step == nil {
+					continue
+				}
+				for _, found := range findInterpolations(step) {
+					events := getExploitableEvents(found.input, triggerEvents)
+					if events == nil {
+						continue
+					}
+					findings = append(findings, &Finding{
+						JobID:         jobID,
+						StepName:      step.Name,
+						Input:         found.input.name,
+						Expression:    found.expression,
+						Message:       fmt.Sprintf("untrusted input %s is interpolated into a script of job %s", found.input.name, jobID),
+						Events:        events,
+						FileReference: step.FileReference,
+					})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// getStepScripts returns the scripts a step runs
+func getStepScripts(step *models.Step) []string {
+	var scripts []string
+	for _, shell := range []*models.Shell{step.Shell, step.AfterScript} {
+		if shell != nil && shell.Script != nil {
+			scripts = append(scripts, *shell.Script)
+		}
+	}
+	return scripts
+}
+
+func getTriggerEvents(triggers *models.Triggers) map[models.EventType]bool {
+	if triggers == nil || len(triggers.Triggers) == 0 {
+		return nil
+	}
+
+	events := map[models.EventType]bool{}
+	for _, trigger := range triggers.Triggers {
+		if trigger != nil && trigger.Event != "" {
+			events[trigger.Event] = true
+		}
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	return events
+}
+
+// getExploitableEvents returns the trigger events that let an attacker control an input, or nil if there are none.
+// Reusable workflows run with the event of their caller, so any event of the input may trigger them.
+func getExploitableEvents(input *untrustedInput, triggerEvents map[models.EventType]bool) []models.EventType {
+	var events []models.EventType
+	for _, event := range input.events {
+		if triggerEvents == nil || triggerEvents[event] {
+			events = append(events, event)
+		}
+	}
+	if triggerEvents[models.PipelineTriggerEvent] && !utils.SliceContains(events, models.PipelineTriggerEvent) {
+		events = append(events, models.PipelineTriggerEvent)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i] < events[j]
+	})
+	return events
+}
@@ -0,0 +1,193 @@
+package injection
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func createScriptStep(name, script string) *models.Step {
+	return &models.Step{
+		Name:          utils.GetPtr(name),
+		Type:          models.ShellStepType,
+		Shell:         &models.Shell{Script: utils.GetPtr(script)},
+		FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+	}
+}
+
+func createTriggers(events ...models.EventType) *models.Triggers {
+	return &models.Triggers{
+		Triggers: utils.Map(events, func(event models.EventType) *models.Trigger {
+			return &models.Trigger{Event: event}
+		}),
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	testCases := []struct {
+		name             string
+		pipeline         *models.Pipeline
+		expectedFindings []*Finding
+	}{
+		{
+			name:             "Pipeline is nil",
+			pipeline:         nil,
+			expectedFindings: nil,
+		},
+		{
+			name: "GitHub untrusted contexts in run scripts",
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Triggers: createTriggers(models.PullRequestEvent, issuesEvent),
+				Jobs: []*models.Job{
+					{
+						ID: utils.GetPtr("triage"),
+						Steps: []*models.Step{
+							createScriptStep("title", `echo "${{ github.event.pull_request.title }}"`),
+							createScriptStep("safe", `echo "$TITLE" ${{ github.event.pull_request.number }}`),
+							createScriptStep("body", "echo '${{ toJSON(github.event.issue.body) }}'\necho ${{ github.event.commits[0].message }}"),
+						},
+					},
+				},
+			},
+			expectedFindings: []*Finding{
+				{
+					JobID:         "triage",
+					StepName:      utils.GetPtr("title"),
+					Input:         "github.event.pull_request.title",
+					Expression:    "${{ github.event.pull_request.title }}",
+					Message:       "untrusted input github.event.pull_request.title is interpolated into a script of job triage",
+					Events:        []models.EventType{models.PullRequestEvent},
+					FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+				},
+				{
+					JobID:         "triage",
+					StepName:      utils.GetPtr("body"),
+					Input:         "github.event.issue.body",
+					Expression:    "${{ toJSON(github.event.issue.body) }}",
+					Message:       "untrusted input github.event.issue.body is interpolated into a script of job triage",
+					Events:        []models.EventType{issuesEvent},
+					FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+				},
+			},
+		},
+		{
+			name: "GitHub reusable workflow and github-script",
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Triggers: createTriggers(models.PipelineTriggerEvent),
+				Jobs: []*models.Job{
+					{
+						ID: utils.GetPtr("comment"),
+						Steps: []*models.Step{
+							{
+								Name: utils.GetPtr("script"),
+								Type: models.TaskStepType,
+								Task: &models.Task{
+									Name: utils.GetPtr("actions/github-script"),
+									Inputs: []*models.Parameter{
+										{Name: utils.GetPtr("script"), Value: "console.log('${{ github.head_ref }}')"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedFindings: []*Finding{
+				{
+					JobID:      "comment",
+					StepName:   utils.GetPtr("script"),
+					Input:      "github.head_ref",
+					Expression: "${{ github.head_ref }}",
+					Message:    "untrusted input github.head_ref is interpolated into a script of job comment",
+					Events:     []models.EventType{models.PipelineTriggerEvent},
+				},
+			},
+		},
+		{
+			name: "GitHub input that is not controlled by the triggering events",
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Triggers: createTriggers(models.PushEvent),
+				Jobs: []*models.Job{
+					{
+						ID:    utils.GetPtr("build"),
+						Steps: []*models.Step{createScriptStep("title", "echo ${{ github.event.issue.title }}")},
+					},
+				},
+			},
+			expectedFindings: nil,
+		},
+		{
+			name: "GitLab untrusted variables without triggers",
+			pipeline: &models.Pipeline{
+				Platform: consts.GitLabPlatform,
+				Jobs: []*models.Job{
+					{
+						ID:       utils.GetPtr("build"),
+						PreSteps: []*models.Step{createScriptStep("before_script", "echo $CI_PROJECT_NAME")},
+						Steps:    []*models.Step{createScriptStep("script", `git tag "${CI_MERGE_REQUEST_TITLE}" && echo $CI_COMMIT_MESSAGE`)},
+					},
+				},
+			},
+			expectedFindings: []*Finding{
+				{
+					JobID:         "build",
+					StepName:      utils.GetPtr("script"),
+					Input:         "CI_MERGE_REQUEST_TITLE",
+					Expression:    "${CI_MERGE_REQUEST_TITLE}",
+					Message:       "untrusted input CI_MERGE_REQUEST_TITLE is interpolated into a script of job build",
+					Events:        []models.EventType{models.PullRequestEvent},
+					FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+				},
+				{
+					JobID:         "build",
+					StepName:      utils.GetPtr("script"),
+					Input:         "CI_COMMIT_MESSAGE",
+					Expression:    "$CI_COMMIT_MESSAGE",
+					Message:       "untrusted input CI_COMMIT_MESSAGE is interpolated into a script of job build",
+					Events:        []models.EventType{models.PullRequestEvent, models.PushEvent},
+					FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+				},
+			},
+		},
+		{
+			name: "Azure untrusted macros",
+			pipeline: &models.Pipeline{
+				Platform: consts.AzurePlatform,
+				Triggers: createTriggers(models.PushEvent),
+				Jobs: []*models.Job{
+					{
+						ID: utils.GetPtr("build"),
+						Steps: []*models.Step{
+							createScriptStep("script", "echo $(build.sourceBranchName) $(Build.BuildId) $(System.PullRequest.SourceBranch)"),
+						},
+					},
+				},
+			},
+			expectedFindings: []*Finding{
+				{
+					JobID:         "build",
+					StepName:      utils.GetPtr("script"),
+					Input:         "Build.SourceBranchName",
+					Expression:    "$(build.sourceBranchName)",
+					Message:       "untrusted input Build.SourceBranchName is interpolated into a script of job build",
+					Events:        []models.EventType{models.PushEvent},
+					FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := Analyze(testCase.pipeline)
+
+			testutils.DeepCompare(t, testCase.expectedFindings, got)
+		})
+	}
+}
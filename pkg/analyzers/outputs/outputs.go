@@ -0,0 +1,296 @@
+package outputs
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/expressions"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+type ReferenceType string
+
+const (
+	JobOutputReference  ReferenceType = "job_output"
+	StepOutputReference ReferenceType = "step_output"
+)
+
+type DiagnosticType string
+
+const (
+	UndefinedJobDiagnostic    DiagnosticType = "undefined_job"
+	UndefinedStepDiagnostic   DiagnosticType = "undefined_step"
+	UndefinedOutputDiagnostic DiagnosticType = "undefined_output"
+	MissingNeedsDiagnostic    DiagnosticType = "missing_needs"
+)
+
+// Reference is a use of a job output (needs.<job>.outputs.<name>) or a step output (steps.<step>.outputs.<name>)
+type Reference struct {
+	Type          ReferenceType         `json:"type,omitempty"`
+	Expression    string                `json:"expression,omitempty"`
+	JobID         string                `json:"job_id,omitempty"`         // The job the reference is used in
+	SourceJobID   string                `json:"source_job_id,omitempty"`  // The job that produces the output
+	SourceStepID  *string               `json:"source_step_id,omitempty"` // The step that produces the output, for step outputs
+	OutputName    string                `json:"output_name,omitempty"`
+	Output        *models.Output        `json:"output,omitempty"` // The referenced output, when it is defined in the pipeline
+	FileReference *models.FileReference `json:"file_reference,omitempty"`
+}
+
+type Diagnostic struct {
+	Type          DiagnosticType        `json:"type,omitempty"`
+	Message       string                `json:"message,omitempty"`
+	Reference     *Reference            `json:"reference,omitempty"`
+	FileReference *models.FileReference `json:"file_reference,omitempty"`
+}
+
+type Result struct {
+	References  []*Reference  `json:"references,omitempty"`
+	Diagnostics []*Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// location is a value of a job or a step that may contain output references
+type location struct {
+	value         string
+	isCondition   bool // conditions may omit the ${{ }} around their expression
+	fileReference *models.FileReference
+}
+
+// Trace resolves every job and step output reference of a GitHub workflow to the job or step that produces it.
+// References to jobs that are not listed in the job's needs, to jobs or steps that do not exist
+// and to outputs their producer does not define are reported as diagnostics.
+// The outputs of actions and of reusable workflows are not known, so references to them are never reported as undefined.
+func Trace(pipeline *models.Pipeline) *Result {
+	result := &Result{}
+	if pipeline == nil || pipeline.Platform != consts.GitHubPlatform {
+		return result
+	}
+
+	jobs := map[string]*models.Job{}
+	for _, job := range pipeline.Jobs {
+		if job != nil && job.ID != nil {
+			jobs[*job.ID] = job
+		}
+	}
+
+	jobIDs := utils.GetMapKeys(jobs)
+	sort.Strings(jobIDs)
+	for _, jobID := range jobIDs {
+		job := jobs[jobID]
+		for _, loc := range getJobLocations(job) {
+			result.traceLocation(loc, jobs, job, job.Steps)
+		}
+		for i, step := range job.Steps {
+			if step == nil {
+				continue
+			}
+			for _, loc := range getStepLocations(step) {
+				result.traceLocation(loc, jobs, job, job.Steps[:i])
+			}
+		}
+		// job outputs are evaluated after all the steps of the job ran
+		for _, output := range job.Outputs {
+			if output != nil && output.Value != nil {
+				result.traceLocation(location{value: *output.Value, fileReference: output.FileReference}, jobs, job, job.Steps)
+			}
+		}
+	}
+	return result
+}
+
+func (r *Result) traceLocation(loc location, jobs map[string]*models.Job, job *models.Job, previousSteps []*models.Step) {
+	for _, contextReference := range findOutputReferences(loc) {
+		reference := &Reference{
+			Expression:    contextReference.String(),
+			JobID:         *job.ID,
+			OutputName:    contextReference.Path[2],
+			FileReference: loc.fileReference,
+		}
+		r.References = append(r.References, reference)
+
+		if contextReference.Context == "needs" {
+			reference.Type = JobOutputReference
+			reference.SourceJobID = contextReference.Path[0]
+			r.resolveJobOutput(reference, jobs, job)
+		} else {
+			reference.Type = StepOutputReference
+			reference.SourceJobID = *job.ID
+			reference.SourceStepID = utils.GetPtr(contextReference.Path[0])
+			r.resolveStepOutput(reference, previousSteps)
+		}
+	}
+}
+
+func (r *Result) resolveJobOutput(reference *Reference, jobs map[string]*models.Job, job *models.Job) {
+	if !isDependency(job, reference.SourceJobID) {
+		r.addDiagnostic(MissingNeedsDiagnostic, reference, "job %s references the outputs of job %s which is not listed in its needs", reference.JobID, reference.SourceJobID)
+	}
+
+	sourceJob, ok := jobs[reference.SourceJobID]
+	if !ok {
+		r.addDiagnostic(UndefinedJobDiagnostic, reference, "job %s references the outputs of job %s which does not exist", reference.JobID, reference.SourceJobID)
+		return
+	}
+
+	reference.Output = findOutput(sourceJob.Outputs, reference.OutputName)
+	if reference.Output == nil && sourceJob.Imports == nil {
+		r.addDiagnostic(UndefinedOutputDiagnostic, reference, "job %s references output %s which is not defined by job %s", reference.JobID, reference.OutputName, reference.SourceJobID)
+	}
+}
+
+func (r *Result) resolveStepOutput(reference *Reference, previousSteps []*models.Step) {
+	var sourceStep *models.Step
+	for _, step := range previousSteps {
+		if step != nil && step.ID != nil && *step.ID == *reference.SourceStepID {
+			sourceStep = step
+		}
+	}
+
+	if sourceStep == nil {
+		r.addDiagnostic(UndefinedStepDiagnostic, reference, "job %s references the outputs of step %s which does not run before the reference", reference.JobID, *reference.SourceStepID)
+		return
+	}
+
+	reference.Output = findOutput(sourceStep.Outputs, reference.OutputName)
+	if reference.Output == nil && sourceStep.Task == nil {
+		r.addDiagnostic(UndefinedOutputDiagnostic, reference, "job %s references output %s which is not set by step %s", reference.JobID, reference.OutputName, *reference.SourceStepID)
+	}
+}
+
+func (r *Result) addDiagnostic(diagnosticType DiagnosticType, reference *Reference, format string, args ...any) {
+	r.Diagnostics = append(r.Diagnostics, &Diagnostic{
+		Type:          diagnosticType,
+		Message:       fmt.Sprintf(format, args...),
+		Reference:     reference,
+		FileReference: reference.FileReference,
+	})
+}
+
+// findOutputReferences returns the needs.<job>.outputs.<name> and steps.<step>.outputs.<name> references of a location.
+// Values with syntax errors are skipped, as are references whose job, step or output name is not a literal.
+func findOutputReferences(loc location) []*expressions.ContextReference {
+	var parsed []*expressions.Expression
+	if loc.isCondition {
+		parsed, _ = expressions.ParseCondition(loc.value, loc.fileReference)
+	} else {
+		parsed, _ = expressions.ParseTemplate(loc.value, loc.fileReference)
+	}
+
+	var references []*expressions.ContextReference
+	for _, expression := range parsed {
+		for _, reference := range expression.ContextReferences() {
+			if isOutputReference(reference) {
+				references = append(references, reference)
+			}
+		}
+	}
+	return references
+}
+
+func isOutputReference(reference *expressions.ContextReference) bool {
+	if reference.Context != "needs" && reference.Context != "steps" {
+		return false
+	}
+	return len(reference.Path) >= 3 && reference.Path[1] == "outputs" && reference.Path[0] != "*" && reference.Path[2] != "*"
+}
+
+func findOutput(outputs []*models.Output, name string) *models.Output {
+	for _, output := range outputs {
+		if output != nil && output.Name != nil && *output.Name == name {
+			return output
+		}
+	}
+	return nil
+}
+
+func isDependency(job *models.Job, jobID string) bool {
+	for _, dependency := range job.Dependencies {
+		if dependency != nil && dependency.JobID != nil && *dependency.JobID == jobID {
+			return true
+		}
+	}
+	return false
+}
+
+func getJobLocations(job *models.Job) []location {
+	var locations []location
+	for _, condition := range job.Conditions {
+		if condition != nil {
+			locations = append(locations, location{value: condition.Statement, isCondition: true, fileReference: job.FileReference})
+		}
+	}
+	locations = append(locations, getEnvironmentVariablesLocations(job.EnvironmentVariables, job.FileReference)...)
+	if job.Environment != nil {
+		for _, value := range []*string{job.Environment.Name, job.Environment.URL} {
+			if value != nil {
+				locations = append(locations, location{value: *value, fileReference: job.Environment.FileReference})
+			}
+		}
+	}
+	if job.Imports != nil {
+		for _, value := range job.Imports.Parameters {
+			if value, ok := value.(string); ok {
+				locations = append(locations, location{value: value, fileReference: job.FileReference})
+			}
+		}
+	}
+	return locations
+}
+
+func getStepLocations(step *models.Step) []location {
+	var locations []location
+	if step.Name != nil {
+		locations = append(locations, location{value: *step.Name, fileReference: step.FileReference})
+	}
+	if step.Conditions != nil {
+		for _, condition := range *step.Conditions {
+			locations = append(locations, location{value: condition.Statement, isCondition: true, fileReference: step.FileReference})
+		}
+	}
+	locations = append(locations, getEnvironmentVariablesLocations(step.EnvironmentVariables, step.FileReference)...)
+	if step.WorkingDirectory != nil {
+		locations = append(locations, location{value: *step.WorkingDirectory, fileReference: step.FileReference})
+	}
+	if step.Shell != nil && step.Shell.Script != nil {
+		fileReference := step.Shell.FileReference
+		if fileReference == nil {
+			fileReference = step.FileReference
+		}
+		locations = append(locations, location{value: *step.Shell.Script, fileReference: fileReference})
+	}
+	if step.Task != nil {
+		for _, input := range step.Task.Inputs {
+			if input == nil {
+				continue
+			}
+			if value, ok := input.Value.(string); ok {
+				fileReference := input.FileReference
+				if fileReference == nil {
+					fileReference = step.FileReference
+				}
+				locations = append(locations, location{value: value, fileReference: fileReference})
+			}
+		}
+	}
+	return locations
+}
+
+func getEnvironmentVariablesLocations(environmentVariables *models.EnvironmentVariablesRef, fileReference *models.FileReference) []location {
+	if environmentVariables == nil {
+		return nil
+	}
+	if environmentVariables.FileReference != nil {
+		fileReference = environmentVariables.FileReference
+	}
+
+	names := utils.GetMapKeys(environmentVariables.EnvironmentVariables)
+	sort.Strings(names)
+	var locations []location
+	for _, name := range names {
+		if value, ok := environmentVariables.EnvironmentVariables[name].(string); ok {
+			locations = append(locations, location{value: value, fileReference: fileReference})
+		}
+	}
+	return locations
+}
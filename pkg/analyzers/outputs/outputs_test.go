@@ -0,0 +1,259 @@
+package outputs
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestTrace(t *testing.T) {
+	versionOutput := &models.Output{Name: utils.GetPtr("version"), FileReference: testutils.CreateFileReference(3, 14, 3, 50)}
+	jobOutput := &models.Output{
+		Name:          utils.GetPtr("version"),
+		Value:         utils.GetPtr("${{ steps.version.outputs.version }}"),
+		FileReference: testutils.CreateFileReference(1, 7, 1, 52),
+	}
+	scriptReference := testutils.CreateFileReference(10, 14, 10, 60)
+
+	buildJob := &models.Job{
+		ID:      utils.GetPtr("build"),
+		Outputs: []*models.Output{jobOutput},
+		Steps: []*models.Step{
+			{
+				ID:      utils.GetPtr("version"),
+				Shell:   &models.Shell{Script: utils.GetPtr(`echo "version=1.0.0" >> $GITHUB_OUTPUT`)},
+				Outputs: []*models.Output{versionOutput},
+			},
+			{
+				ID:   utils.GetPtr("checkout"),
+				Task: &models.Task{Name: utils.GetPtr("actions/checkout")},
+			},
+		},
+	}
+	createDeployJob := func(script string, needs ...string) *models.Job {
+		job := &models.Job{
+			ID: utils.GetPtr("deploy"),
+			Steps: []*models.Step{
+				{
+					Shell: &models.Shell{Script: utils.GetPtr(script), FileReference: scriptReference},
+				},
+			},
+		}
+		for _, need := range needs {
+			job.Dependencies = append(job.Dependencies, &models.JobDependency{JobID: utils.GetPtr(need)})
+		}
+		return job
+	}
+
+	testCases := []struct {
+		name     string
+		pipeline *models.Pipeline
+		expected *Result
+	}{
+		{
+			name:     "Pipeline is nil",
+			pipeline: nil,
+			expected: &Result{},
+		},
+		{
+			name: "Other platforms are not traced",
+			pipeline: &models.Pipeline{
+				Platform: consts.GitLabPlatform,
+				Jobs:     []*models.Job{createDeployJob("echo ${{ needs.build.outputs.version }}")},
+			},
+			expected: &Result{},
+		},
+		{
+			name: "Job and step outputs are resolved",
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Jobs: []*models.Job{
+					buildJob,
+					createDeployJob("./deploy.sh ${{ needs.build.outputs.version }}", "build"),
+				},
+			},
+			expected: &Result{
+				References: []*Reference{
+					{
+						Type:          StepOutputReference,
+						Expression:    "steps.version.outputs.version",
+						JobID:         "build",
+						SourceJobID:   "build",
+						SourceStepID:  utils.GetPtr("version"),
+						OutputName:    "version",
+						Output:        versionOutput,
+						FileReference: jobOutput.FileReference,
+					},
+					{
+						Type:          JobOutputReference,
+						Expression:    "needs.build.outputs.version",
+						JobID:         "deploy",
+						SourceJobID:   "build",
+						OutputName:    "version",
+						Output:        jobOutput,
+						FileReference: scriptReference,
+					},
+				},
+			},
+		},
+		{
+			name: "Missing needs and undefined job output",
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Jobs: []*models.Job{
+					buildJob,
+					createDeployJob("./deploy.sh ${{ needs.build.outputs.digest }}"),
+				},
+			},
+			expected: &Result{
+				References: []*Reference{
+					{
+						Type:          StepOutputReference,
+						Expression:    "steps.version.outputs.version",
+						JobID:         "build",
+						SourceJobID:   "build",
+						SourceStepID:  utils.GetPtr("version"),
+						OutputName:    "version",
+						Output:        versionOutput,
+						FileReference: jobOutput.FileReference,
+					},
+					{
+						Type:          JobOutputReference,
+						Expression:    "needs.build.outputs.digest",
+						JobID:         "deploy",
+						SourceJobID:   "build",
+						OutputName:    "digest",
+						FileReference: scriptReference,
+					},
+				},
+				Diagnostics: []*Diagnostic{
+					{
+						Type:    MissingNeedsDiagnostic,
+						Message: "job deploy references the outputs of job build which is not listed in its needs",
+						Reference: &Reference{
+							Type:          JobOutputReference,
+							Expression:    "needs.build.outputs.digest",
+							JobID:         "deploy",
+							SourceJobID:   "build",
+							OutputName:    "digest",
+							FileReference: scriptReference,
+						},
+						FileReference: scriptReference,
+					},
+					{
+						Type:    UndefinedOutputDiagnostic,
+						Message: "job deploy references output digest which is not defined by job build",
+						Reference: &Reference{
+							Type:          JobOutputReference,
+							Expression:    "needs.build.outputs.digest",
+							JobID:         "deploy",
+							SourceJobID:   "build",
+							OutputName:    "digest",
+							FileReference: scriptReference,
+						},
+						FileReference: scriptReference,
+					},
+				},
+			},
+		},
+		{
+			name: "Undefined job and steps",
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Jobs: []*models.Job{
+					createDeployJob("${{ needs.test.outputs.result }} ${{ steps.later.outputs.url }}", "test"),
+				},
+			},
+			expected: &Result{
+				References: []*Reference{
+					{
+						Type:          JobOutputReference,
+						Expression:    "needs.test.outputs.result",
+						JobID:         "deploy",
+						SourceJobID:   "test",
+						OutputName:    "result",
+						FileReference: scriptReference,
+					},
+					{
+						Type:          StepOutputReference,
+						Expression:    "steps.later.outputs.url",
+						JobID:         "deploy",
+						SourceJobID:   "deploy",
+						SourceStepID:  utils.GetPtr("later"),
+						OutputName:    "url",
+						FileReference: scriptReference,
+					},
+				},
+				Diagnostics: []*Diagnostic{
+					{
+						Type:    UndefinedJobDiagnostic,
+						Message: "job deploy references the outputs of job test which does not exist",
+						Reference: &Reference{
+							Type:          JobOutputReference,
+							Expression:    "needs.test.outputs.result",
+							JobID:         "deploy",
+							SourceJobID:   "test",
+							OutputName:    "result",
+							FileReference: scriptReference,
+						},
+						FileReference: scriptReference,
+					},
+					{
+						Type:    UndefinedStepDiagnostic,
+						Message: "job deploy references the outputs of step later which does not run before the reference",
+						Reference: &Reference{
+							Type:          StepOutputReference,
+							Expression:    "steps.later.outputs.url",
+							JobID:         "deploy",
+							SourceJobID:   "deploy",
+							SourceStepID:  utils.GetPtr("later"),
+							OutputName:    "url",
+							FileReference: scriptReference,
+						},
+						FileReference: scriptReference,
+					},
+				},
+			},
+		},
+		{
+			name: "Outputs of actions are unknown",
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Jobs: []*models.Job{
+					{
+						ID: utils.GetPtr("build"),
+						Steps: []*models.Step{
+							buildJob.Steps[1],
+							{
+								Conditions: &[]models.Condition{{Statement: "steps.checkout.outputs.ref != ''"}},
+							},
+						},
+					},
+				},
+			},
+			expected: &Result{
+				References: []*Reference{
+					{
+						Type:         StepOutputReference,
+						Expression:   "steps.checkout.outputs.ref",
+						JobID:        "build",
+						SourceJobID:  "build",
+						SourceStepID: utils.GetPtr("checkout"),
+						OutputName:   "ref",
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := Trace(testCase.pipeline)
+
+			testutils.DeepCompare(t, testCase.expected, got)
+		})
+	}
+}
@@ -30,6 +30,18 @@ func NewErrInvalidOutputTarget(outputTarget OutputTarget) error {
 	return &ErrInvalidOutputTarget{OutputTarget: outputTarget}
 }
 
+type ErrInvalidOutputFormat struct {
+	OutputFormat OutputFormat
+}
+
+func (e *ErrInvalidOutputFormat) Error() string {
+	return fmt.Sprintf("invalid output format: %s. Supported output formats: %v", e.OutputFormat, OutputFormats)
+}
+
+func NewErrInvalidOutputFormat(outputFormat OutputFormat) error {
+	return &ErrInvalidOutputFormat{OutputFormat: outputFormat}
+}
+
 type ErrInvalidYaml struct {
 	Message string
 }
@@ -42,6 +54,18 @@ func NewErrInvalidYaml(message string) error {
 	return &ErrInvalidYaml{Message: message}
 }
 
+type ErrInvalidJenkinsfile struct {
+	Message string
+}
+
+func (e *ErrInvalidJenkinsfile) Error() string {
+	return fmt.Sprintf("invalid Jenkinsfile: %s", e.Message)
+}
+
+func NewErrInvalidJenkinsfile(message string) error {
+	return &ErrInvalidJenkinsfile{Message: message}
+}
+
 type ErrInvalidYamlTag struct {
 	Tag  string
 	Type string
@@ -77,3 +101,106 @@ func (e *ErrEmptyData) Error() string {
 func NewErrEmptyData() error {
 	return &ErrEmptyData{}
 }
+
+type ErrFetchImport struct {
+	Source  string
+	Message string
+}
+
+func (e *ErrFetchImport) Error() string {
+	return fmt.Sprintf("failed fetching %s: %s", e.Source, e.Message)
+}
+
+func NewErrFetchImport(source string, message string) error {
+	return &ErrFetchImport{Source: source, Message: message}
+}
+
+type ErrFatalDiagnostic struct {
+	Diagnostic *models.Diagnostic
+}
+
+func (e *ErrFatalDiagnostic) Error() string {
+	return fmt.Sprintf("%s: %s", e.Diagnostic.Code, e.Diagnostic.Message)
+}
+
+func NewErrFatalDiagnostic(diagnostic *models.Diagnostic) error {
+	return &ErrFatalDiagnostic{Diagnostic: diagnostic}
+}
+
+type ErrInvalidExpression struct {
+	Expression    string
+	Message       string
+	Offset        int
+	FileReference *models.FileReference
+}
+
+func (e *ErrInvalidExpression) Error() string {
+	return fmt.Sprintf("invalid expression '%s': %s at position %d", e.Expression, e.Message, e.Offset)
+}
+
+func NewErrInvalidExpression(expression string, message string, offset int) error {
+	return &ErrInvalidExpression{Expression: expression, Message: message, Offset: offset}
+}
+
+type ErrDuplicateRule struct {
+	RuleID string
+}
+
+func (e *ErrDuplicateRule) Error() string {
+	return fmt.Sprintf("rule %s is already registered", e.RuleID)
+}
+
+func NewErrDuplicateRule(ruleID string) error {
+	return &ErrDuplicateRule{RuleID: ruleID}
+}
+
+type ErrUnknownRule struct {
+	RuleID string
+}
+
+func (e *ErrUnknownRule) Error() string {
+	return fmt.Sprintf("unknown rule: %s", e.RuleID)
+}
+
+func NewErrUnknownRule(ruleID string) error {
+	return &ErrUnknownRule{RuleID: ruleID}
+}
+
+type ErrPolicyViolations struct {
+	Count int
+}
+
+func (e *ErrPolicyViolations) Error() string {
+	return fmt.Sprintf("found %d policy violations", e.Count)
+}
+
+func NewErrPolicyViolations(count int) error {
+	return &ErrPolicyViolations{Count: count}
+}
+
+type ErrInvalidSeverity struct {
+	Severity   string
+	Severities []string
+}
+
+func (e *ErrInvalidSeverity) Error() string {
+	return fmt.Sprintf("invalid severity: %s. Supported severities: %v", e.Severity, e.Severities)
+}
+
+func NewErrInvalidSeverity(severity string, severities []string) error {
+	return &ErrInvalidSeverity{Severity: severity, Severities: severities}
+}
+
+type ErrResolveRef struct {
+	Repository string
+	Ref        string
+	Message    string
+}
+
+func (e *ErrResolveRef) Error() string {
+	return fmt.Sprintf("failed resolving %s@%s: %s", e.Repository, e.Ref, e.Message)
+}
+
+func NewErrResolveRef(repository string, ref string, message string) error {
+	return &ErrResolveRef{Repository: repository, Ref: ref, Message: message}
+}
@@ -3,11 +3,29 @@ package consts
 type OutputTarget string
 
 const (
-	Stdout OutputTarget = "stdout"
-	File   OutputTarget = "file"
+	Stdout       OutputTarget = "stdout"
+	File         OutputTarget = "file"
+	CodeScanning OutputTarget = "code-scanning" // A SARIF log file, for uploading to code scanning dashboards
 )
 
 var OutputTargets = []OutputTarget{
 	Stdout,
 	File,
+	CodeScanning,
+}
+
+type OutputFormat string
+
+const (
+	JSONFormat    OutputFormat = "json"
+	DotFormat     OutputFormat = "dot"
+	MermaidFormat OutputFormat = "mermaid"
+	SARIFFormat   OutputFormat = "sarif"
+)
+
+var OutputFormats = []OutputFormat{
+	JSONFormat,
+	DotFormat,
+	MermaidFormat,
+	SARIFFormat,
 }
@@ -7,6 +7,11 @@ const (
 	GitLabPlatform    models.Platform = "gitlab"
 	AzurePlatform     models.Platform = "azure"
 	BitbucketPlatform models.Platform = "bitbucket"
+	JenkinsPlatform   models.Platform = "jenkins"
+	CircleCIPlatform  models.Platform = "circleci"
+
+	// AutoPlatform detects the platform from the file path and content
+	AutoPlatform models.Platform = "auto"
 )
 
 var Platforms = []models.Platform{
@@ -14,4 +19,6 @@ var Platforms = []models.Platform{
 	GitLabPlatform,
 	AzurePlatform,
 	BitbucketPlatform,
+	JenkinsPlatform,
+	CircleCIPlatform,
 }
@@ -7,4 +7,5 @@ const (
 	SequenceTag = "!!seq"
 	BooleanTag  = "!!bool"
 	MapTag      = "!!map"
+	NullTag     = "!!null"
 )
@@ -0,0 +1,222 @@
+package detector
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	pathConfidence           = 1.0
+	jenkinsContentConfidence = 0.9 // A `pipeline {` block is likely, but not certainly, a declarative Jenkinsfile
+)
+
+// Detection is the detected platform of a pipeline file and how confident the detection is, between 0 and 1
+type Detection struct {
+	Platform   models.Platform `json:"platform,omitempty"`
+	Confidence float64         `json:"confidence,omitempty"`
+}
+
+type pathRule struct {
+	platform models.Platform
+	match    func(filePath string) bool
+}
+
+var (
+	pathRules = []pathRule{
+		{platform: consts.GitHubPlatform, match: func(filePath string) bool {
+			return isYamlFile(filePath) && strings.HasSuffix(path.Dir(filePath), ".github/workflows")
+		}},
+		{platform: consts.GitLabPlatform, match: func(filePath string) bool {
+			return path.Base(filePath) == ".gitlab-ci.yml"
+		}},
+		{platform: consts.AzurePlatform, match: func(filePath string) bool {
+			base := path.Base(filePath)
+			return base == "azure-pipelines.yml" || base == "azure-pipelines.yaml"
+		}},
+		{platform: consts.BitbucketPlatform, match: func(filePath string) bool {
+			return path.Base(filePath) == "bitbucket-pipelines.yml"
+		}},
+		{platform: consts.CircleCIPlatform, match: func(filePath string) bool {
+			return isYamlFile(filePath) && strings.HasSuffix(path.Dir(filePath), ".circleci")
+		}},
+		{platform: consts.JenkinsPlatform, match: func(filePath string) bool {
+			base := strings.ToLower(path.Base(filePath))
+			return base == "jenkinsfile" || strings.HasSuffix(base, ".jenkinsfile")
+		}},
+	}
+
+	// the weight each top level key adds to the score of the platforms that use it
+	keyWeights = map[string]map[models.Platform]int{
+		"on":            {consts.GitHubPlatform: 3},
+		"permissions":   {consts.GitHubPlatform: 1},
+		"concurrency":   {consts.GitHubPlatform: 1},
+		"run-name":      {consts.GitHubPlatform: 3},
+		"include":       {consts.GitLabPlatform: 2},
+		"default":       {consts.GitLabPlatform: 2},
+		"workflow":      {consts.GitLabPlatform: 2},
+		"before_script": {consts.GitLabPlatform: 2},
+		"after_script":  {consts.GitLabPlatform: 2},
+		"services":      {consts.GitLabPlatform: 1},
+		"variables":     {consts.GitLabPlatform: 1, consts.AzurePlatform: 1},
+		"image":         {consts.GitLabPlatform: 1, consts.BitbucketPlatform: 1},
+		"trigger":       {consts.AzurePlatform: 2},
+		"pr":            {consts.AzurePlatform: 2},
+		"pool":          {consts.AzurePlatform: 2},
+		"steps":         {consts.AzurePlatform: 2},
+		"resources":     {consts.AzurePlatform: 2},
+		"schedules":     {consts.AzurePlatform: 2},
+		"extends":       {consts.AzurePlatform: 2},
+		"parameters":    {consts.AzurePlatform: 1},
+		"pipelines":     {consts.BitbucketPlatform: 3},
+		"definitions":   {consts.BitbucketPlatform: 2},
+		"clone":         {consts.BitbucketPlatform: 2},
+		"options":       {consts.BitbucketPlatform: 1},
+		"orbs":          {consts.CircleCIPlatform: 3},
+		"workflows":     {consts.CircleCIPlatform: 3},
+		"executors":     {consts.CircleCIPlatform: 3},
+		"commands":      {consts.CircleCIPlatform: 2},
+		"version":       {consts.CircleCIPlatform: 1},
+	}
+
+	githubJobKeys   = []string{"runs-on", "uses"}
+	circleciJobKeys = []string{"docker", "machine", "macos", "executor"}
+	gitlabJobKeys   = []string{"script", "stage", "extends", "trigger", "rules", "needs"}
+
+	jenkinsPipelineRegex = regexp.MustCompile(`(?m)^\s*pipeline\s*\{`)
+)
+
+// Detect detects the platform of a pipeline file.
+// Well-known file paths are detected with full confidence, any other file is detected by the top level keys of its content,
+// or by the pipeline block of a Jenkinsfile.
+// An ErrInvalidPlatform is returned when the platform cannot be determined or more than one platform is equally likely.
+func Detect(filePath string, data []byte) (*Detection, error) {
+	if platform, ok := DetectByPath(filePath); ok {
+		return &Detection{Platform: platform, Confidence: pathConfidence}, nil
+	}
+
+	return detectByContent(data)
+}
+
+// DetectByPath detects the platform of a pipeline file by its well-known path, e.g. `.gitlab-ci.yml`
+func DetectByPath(filePath string) (models.Platform, bool) {
+	if filePath == "" {
+		return "", false
+	}
+
+	filePath = filepath.ToSlash(filePath)
+	for _, rule := range pathRules {
+		if rule.match(filePath) {
+			return rule.platform, true
+		}
+	}
+	return "", false
+}
+
+func detectByContent(data []byte) (*Detection, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil || len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode {
+		if jenkinsPipelineRegex.Match(data) {
+			return &Detection{Platform: consts.JenkinsPlatform, Confidence: jenkinsContentConfidence}, nil
+		}
+		return nil, consts.NewErrInvalidPlatform(consts.AutoPlatform)
+	}
+
+	scores := scoreKeys(node.Content[0])
+	platforms := make([]models.Platform, 0, len(scores))
+	total := 0
+	for platform, score := range scores {
+		platforms = append(platforms, platform)
+		total += score
+	}
+	sort.Slice(platforms, func(i, j int) bool {
+		return scores[platforms[i]] > scores[platforms[j]]
+	})
+
+	if len(platforms) == 0 || (len(platforms) > 1 && scores[platforms[0]] == scores[platforms[1]]) {
+		return nil, consts.NewErrInvalidPlatform(consts.AutoPlatform)
+	}
+
+	return &Detection{
+		Platform:   platforms[0],
+		Confidence: float64(scores[platforms[0]]) / float64(total),
+	}, nil
+}
+
+func scoreKeys(mapping *yaml.Node) map[models.Platform]int {
+	scores := map[models.Platform]int{}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i].Value, mapping.Content[i+1]
+		switch key {
+		case "jobs":
+			scoreJobs(value, scores)
+		case "stages":
+			scoreStages(value, scores)
+		default:
+			if weights, ok := keyWeights[key]; ok {
+				for platform, weight := range weights {
+					scores[platform] += weight
+				}
+			} else if value.Kind == yaml.MappingNode && hasAnyKey(value, gitlabJobKeys) {
+				// gitlab jobs are top level keys
+				scores[consts.GitLabPlatform] += 2
+			}
+		}
+	}
+	return scores
+}
+
+// scoreJobs scores the jobs key by its structure - azure jobs are a list, github and circleci jobs are a map
+// and only circleci jobs define an executor
+func scoreJobs(jobs *yaml.Node, scores map[models.Platform]int) {
+	if jobs.Kind == yaml.SequenceNode {
+		scores[consts.AzurePlatform] += 3
+		return
+	}
+	if jobs.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 1; i < len(jobs.Content); i += 2 {
+		job := jobs.Content[i]
+		if job.Kind == yaml.MappingNode && hasAnyKey(job, circleciJobKeys) && !hasAnyKey(job, githubJobKeys) {
+			scores[consts.CircleCIPlatform] += 3
+			return
+		}
+	}
+	scores[consts.GitHubPlatform] += 3
+}
+
+// scoreStages scores the stages key by its structure - gitlab stages are a list of names, azure stages are a list of maps
+func scoreStages(stages *yaml.Node, scores map[models.Platform]int) {
+	if stages.Kind != yaml.SequenceNode || len(stages.Content) == 0 {
+		return
+	}
+
+	if stages.Content[0].Kind == yaml.ScalarNode {
+		scores[consts.GitLabPlatform] += 2
+	} else {
+		scores[consts.AzurePlatform] += 3
+	}
+}
+
+func hasAnyKey(mapping *yaml.Node, keys []string) bool {
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if utils.SliceContains(keys, mapping.Content[i].Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func isYamlFile(filePath string) bool {
+	ext := path.Ext(filePath)
+	return ext == ".yml" || ext == ".yaml"
+}
@@ -0,0 +1,110 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+)
+
+func TestDetect(t *testing.T) {
+	testCases := []struct {
+		name              string
+		filePath          string
+		data              string
+		expectedDetection *Detection
+		expectedError     error
+	}{
+		{
+			name:              "GitHub workflow path",
+			filePath:          "repo/.github/workflows/ci.yml",
+			expectedDetection: &Detection{Platform: consts.GitHubPlatform, Confidence: 1},
+		},
+		{
+			name:              "GitLab path",
+			filePath:          "service/.gitlab-ci.yml",
+			expectedDetection: &Detection{Platform: consts.GitLabPlatform, Confidence: 1},
+		},
+		{
+			name:              "Azure path",
+			filePath:          "azure-pipelines.yaml",
+			expectedDetection: &Detection{Platform: consts.AzurePlatform, Confidence: 1},
+		},
+		{
+			name:              "Bitbucket path",
+			filePath:          "bitbucket-pipelines.yml",
+			expectedDetection: &Detection{Platform: consts.BitbucketPlatform, Confidence: 1},
+		},
+		{
+			name:              "CircleCI path",
+			filePath:          ".circleci/config.yml",
+			expectedDetection: &Detection{Platform: consts.CircleCIPlatform, Confidence: 1},
+		},
+		{
+			name:              "Jenkinsfile path",
+			filePath:          "ci/Jenkinsfile",
+			expectedDetection: &Detection{Platform: consts.JenkinsPlatform, Confidence: 1},
+		},
+		{
+			name:              "GitHub content",
+			filePath:          "ci.yml",
+			data:              "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+			expectedDetection: &Detection{Platform: consts.GitHubPlatform, Confidence: 1},
+		},
+		{
+			name:              "Azure content",
+			data:              "trigger: [main]\npool: ubuntu-latest\nvariables:\n  a: b\n",
+			expectedDetection: &Detection{Platform: consts.AzurePlatform, Confidence: 5.0 / 6.0},
+		},
+		{
+			name:              "Azure stages content",
+			data:              "stages:\n  - stage: build\n",
+			expectedDetection: &Detection{Platform: consts.AzurePlatform, Confidence: 1},
+		},
+		{
+			name:              "GitLab content",
+			data:              "stages: [build]\nbuild:\n  script: make\n",
+			expectedDetection: &Detection{Platform: consts.GitLabPlatform, Confidence: 1},
+		},
+		{
+			name:              "Bitbucket content",
+			data:              "image: node\npipelines:\n  default: []\n",
+			expectedDetection: &Detection{Platform: consts.BitbucketPlatform, Confidence: 0.8},
+		},
+		{
+			name:              "CircleCI content",
+			data:              "version: 2.1\njobs:\n  build:\n    docker:\n      - image: node\n",
+			expectedDetection: &Detection{Platform: consts.CircleCIPlatform, Confidence: 1},
+		},
+		{
+			name:              "Jenkins content",
+			filePath:          "ci.groovy",
+			data:              "pipeline {\n  agent any\n}\n",
+			expectedDetection: &Detection{Platform: consts.JenkinsPlatform, Confidence: jenkinsContentConfidence},
+		},
+		{
+			name:          "Ambiguous content",
+			data:          "variables:\n  a: b\n",
+			expectedError: consts.NewErrInvalidPlatform(consts.AutoPlatform),
+		},
+		{
+			name:          "Unknown content",
+			data:          "foo: bar\n",
+			expectedError: consts.NewErrInvalidPlatform(consts.AutoPlatform),
+		},
+		{
+			name:          "Empty content",
+			data:          "",
+			expectedError: consts.NewErrInvalidPlatform(consts.AutoPlatform),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := Detect(testCase.filePath, []byte(testCase.data))
+
+			testutils.DeepCompare(t, testCase.expectedError, err)
+			testutils.DeepCompare(t, testCase.expectedDetection, got)
+		})
+	}
+}
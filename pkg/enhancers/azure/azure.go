@@ -4,14 +4,15 @@ import (
 	"reflect"
 
 	"github.com/argonsecurity/pipeline-parser/pkg/enhancers"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
 	"github.com/argonsecurity/pipeline-parser/pkg/utils"
 )
 
 type AzureEnhancer struct{}
 
-func (a *AzureEnhancer) LoadImportedPipelines(data *models.Pipeline, credentials *models.Credentials, organization, baseUrl *string) ([]*enhancers.ImportedPipeline, error) {
-	importedPipelines, err := getTemplates(data, credentials, organization, baseUrl)
+func (a *AzureEnhancer) LoadImportedPipelines(data *models.Pipeline, fetcher fetcher.Fetcher) ([]*enhancers.ImportedPipeline, error) {
+	importedPipelines, err := getTemplates(data, fetcher)
 	if err != nil {
 		return importedPipelines, err
 	}
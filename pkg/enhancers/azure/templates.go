@@ -2,23 +2,17 @@ package azure
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/argonsecurity/pipeline-parser/pkg/consts"
 	"github.com/argonsecurity/pipeline-parser/pkg/enhancers"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
 	"github.com/argonsecurity/pipeline-parser/pkg/utils"
 	"github.com/pkg/errors"
 )
 
-var (
-	AZURE_SAAS_BASE_URL = "https://dev.azure.com/"
-	ITEMS_API           = "{PROJECT}/_apis/git/repositories/{REPOSITORY}/items?path={PATH}"
-	VERSION_QUERY       = "&versionDescriptor.versionType=tag&version="
-)
-
-func getTemplates(pipeline *models.Pipeline, credentials *models.Credentials, organization, baseUrl *string) ([]*enhancers.ImportedPipeline, error) {
+func getTemplates(pipeline *models.Pipeline, fetcher fetcher.Fetcher) ([]*enhancers.ImportedPipeline, error) {
 	var errs error
 	importedPipelines := []*enhancers.ImportedPipeline{}
 	var resources *models.Resources
@@ -30,37 +24,37 @@ func getTemplates(pipeline *models.Pipeline, credentials *models.Credentials, or
 	if pipeline.Defaults != nil &&
 		pipeline.Defaults.EnvironmentVariables != nil &&
 		pipeline.Defaults.EnvironmentVariables.Imports != nil {
-		importedPipelines, errs = appendImports(importedPipelines, pipeline.Defaults.EnvironmentVariables.Imports, resources, credentials, organization, baseUrl, errs)
+		importedPipelines, errs = appendImports(importedPipelines, pipeline.Defaults.EnvironmentVariables.Imports, resources, fetcher, errs)
 	}
 
 	// main imports (extends field)
 	for _, imported := range pipeline.Imports {
-		importedPipelines, errs = appendImports(importedPipelines, imported, resources, credentials, organization, baseUrl, errs)
+		importedPipelines, errs = appendImports(importedPipelines, imported, resources, fetcher, errs)
 	}
 
 	// job imports (job, step, variable imports)
 	for _, job := range pipeline.Jobs {
 		if job != nil {
 			if job.Imports != nil {
-				importedPipelines, errs = appendImports(importedPipelines, job.Imports, resources, credentials, organization, baseUrl, errs)
+				importedPipelines, errs = appendImports(importedPipelines, job.Imports, resources, fetcher, errs)
 			}
 		}
 
 		if job.EnvironmentVariables != nil && job.EnvironmentVariables.Imports != nil {
-			importedPipelines, errs = appendImports(importedPipelines, job.EnvironmentVariables.Imports, resources, credentials, organization, baseUrl, errs)
+			importedPipelines, errs = appendImports(importedPipelines, job.EnvironmentVariables.Imports, resources, fetcher, errs)
 		}
 
 		if len(job.PreSteps) > 0 {
-			importedPipelines, errs = iterateSteps(job.PreSteps, importedPipelines, resources, credentials, organization, baseUrl, errs)
+			importedPipelines, errs = iterateSteps(job.PreSteps, importedPipelines, resources, fetcher, errs)
 		}
 
 		if len(job.Steps) > 0 {
-			importedPipelines, errs = iterateSteps(job.Steps, importedPipelines, resources, credentials, organization, baseUrl, errs)
+			importedPipelines, errs = iterateSteps(job.Steps, importedPipelines, resources, fetcher, errs)
 
 		}
 
 		if len(job.PostSteps) > 0 {
-			importedPipelines, errs = iterateSteps(job.PostSteps, importedPipelines, resources, credentials, organization, baseUrl, errs)
+			importedPipelines, errs = iterateSteps(job.PostSteps, importedPipelines, resources, fetcher, errs)
 
 		}
 	}
@@ -68,62 +62,36 @@ func getTemplates(pipeline *models.Pipeline, credentials *models.Credentials, or
 	return importedPipelines, errs
 }
 
-func handleImport(jobImport *models.Import, resources *models.Resources, credentials *models.Credentials, organization, baseUrl *string) ([]byte, error) {
+func handleImport(jobImport *models.Import, resources *models.Resources, fetcher fetcher.Fetcher) ([]byte, error) {
 	if jobImport == nil || jobImport.Source == nil {
 		return nil, nil
 	}
 
 	if jobImport.Source.Type == models.SourceTypeRemote && resources != nil && len(resources.Repositories) > 0 {
-		return loadRemoteFile(jobImport, resources, credentials, organization, baseUrl)
+		return loadRemoteFile(jobImport, resources, fetcher)
 	}
 
-	if jobImport.Source.Type == models.SourceTypeLocal && jobImport.Source.Path != nil {
-		return loadLocalFile(*jobImport.Source.Path)
+	if jobImport.Source.Type == models.SourceTypeLocal && jobImport.Source.Path != nil && *jobImport.Source.Path != "" {
+		return fetcher.Fetch(jobImport.Source, nil)
 	}
 
 	return nil, nil
 }
 
-func loadRemoteFile(jobImport *models.Import, resources *models.Resources, credentials *models.Credentials, organization, baseUrl *string) ([]byte, error) {
+// loadRemoteFile fetches a template from a repository resource, where the organization of the source is the azure project
+func loadRemoteFile(jobImport *models.Import, resources *models.Resources, fetcher fetcher.Fetcher) ([]byte, error) {
 	project, repo, path, version, _ := extractRemoteParams(jobImport, resources)
-	if project == "" || repo == "" || path == "" || organization == nil || *organization == "" {
-		return nil, nil
-	}
-	if baseUrl == nil || *baseUrl == "" {
-		baseUrl = &AZURE_SAAS_BASE_URL
-	}
-	url := generateRequestUrl(project, repo, path, version, *organization, *baseUrl)
-
-	fmt.Printf("fetching template from %s\n", url)
-	client := utils.GetHttpClientWithBasicAuth(credentials)
-	resp, err := client.R().Get(url)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.IsErrorState() {
-		return nil, errors.New(resp.Response.Status)
-	}
-
-	buf := resp.Bytes()
-	return buf, nil
-}
-
-func loadLocalFile(path string) ([]byte, error) {
-	if path == "" {
+	if project == "" || repo == "" || path == "" {
 		return nil, nil
 	}
 
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return nil, err
-	}
-
-	buf, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-
-	return buf, nil
+	return fetcher.Fetch(&models.ImportSource{
+		SCM:          consts.AzurePlatform,
+		Organization: &project,
+		Repository:   &repo,
+		Path:         &path,
+		Type:         models.SourceTypeRemote,
+	}, utils.GetPtrOrNil(version))
 }
 
 func extractRemoteParams(jobImport *models.Import, resources *models.Resources) (string, string, string, string, models.Platform) {
@@ -158,40 +126,19 @@ func extractRemoteParams(jobImport *models.Import, resources *models.Resources)
 	return proj, repo, path, version, platform
 }
 
-func generateRequestUrl(proj, repo, path, version, organization, baseUrl string) string {
-	url := baseUrl
-	if !strings.HasSuffix(url, "/") {
-		url = url + "/"
-	}
-	if !strings.Contains(url, organization) {
-		url = url + organization + "/"
-	}
-	url = url + ITEMS_API
-	url = strings.Replace(url, "{PROJECT}", proj, 1)
-	url = strings.Replace(url, "{REPOSITORY}", repo, 1)
-	url = strings.Replace(url, "{PATH}", path, 1)
-	if version != "" {
-		url = url + VERSION_QUERY + version
-	}
-	return url
-}
-
 func getImportedData(
 	imported *models.Import,
 	resources *models.Resources,
-	credentials *models.Credentials,
-	organization,
-	baseUrl *string) (*enhancers.ImportedPipeline, error) {
+	fetcher fetcher.Fetcher) (*enhancers.ImportedPipeline, error) {
 	if imported != nil && imported.Source != nil && imported.Source.Path != nil {
-		importedPipelineBuf, err := handleImport(imported, resources, credentials, organization, baseUrl)
-		if err != nil {
-			return nil, err
-		}
+		importedPipelineBuf, err := handleImport(imported, resources, fetcher)
 		return &enhancers.ImportedPipeline{
 			JobName:             *imported.Source.Path,
 			OriginFileReference: imported.FileReference,
+			Import:              imported,
 			Data:                importedPipelineBuf,
-		}, nil
+			Error:               err,
+		}, err
 	}
 	return nil, nil
 }
@@ -200,11 +147,9 @@ func appendImports(
 	list []*enhancers.ImportedPipeline,
 	imports *models.Import,
 	resources *models.Resources,
-	credentials *models.Credentials,
-	organization,
-	baseUrl *string,
+	fetcher fetcher.Fetcher,
 	errs error) ([]*enhancers.ImportedPipeline, error) {
-	importedPipeline, err := getImportedData(imports, resources, credentials, organization, baseUrl)
+	importedPipeline, err := getImportedData(imports, resources, fetcher)
 	if err != nil {
 		if errs == nil {
 			errs = errors.New("got error(s) importing pipeline(s):")
@@ -222,16 +167,14 @@ func iterateSteps(
 	steps []*models.Step,
 	list []*enhancers.ImportedPipeline,
 	resources *models.Resources,
-	credentials *models.Credentials,
-	organization,
-	baseUrl *string,
+	fetcher fetcher.Fetcher,
 	errs error) ([]*enhancers.ImportedPipeline, error) {
 	for _, step := range steps {
 		if step != nil && step.Imports != nil {
-			list, errs = appendImports(list, step.Imports, resources, credentials, organization, baseUrl, errs)
+			list, errs = appendImports(list, step.Imports, resources, fetcher, errs)
 		}
 		if step != nil && step.EnvironmentVariables != nil && step.EnvironmentVariables.Imports != nil {
-			list, errs = appendImports(list, step.EnvironmentVariables.Imports, resources, credentials, organization, baseUrl, errs)
+			list, errs = appendImports(list, step.EnvironmentVariables.Imports, resources, fetcher, errs)
 		}
 	}
 
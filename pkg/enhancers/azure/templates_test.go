@@ -7,87 +7,13 @@ import (
 
 	"github.com/argonsecurity/pipeline-parser/pkg/consts"
 	"github.com/argonsecurity/pipeline-parser/pkg/enhancers"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
 	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
 	"github.com/argonsecurity/pipeline-parser/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_loadLocalFile(t *testing.T) {
-	type args struct {
-		path string
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    []byte
-		wantErr bool
-	}{
-		{
-			name: "happy flow - relative path",
-			args: args{
-				path: "../azure/testdata/file",
-			},
-			want:    []byte("file content"),
-			wantErr: false,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := loadLocalFile(tt.args.path)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("loadLocalFile() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
-func Test_generateRequestUrl(t *testing.T) {
-	type args struct {
-		proj         string
-		repo         string
-		path         string
-		version      string
-		organization string
-	}
-	tests := []struct {
-		name string
-		args args
-		want string
-	}{
-		{
-			name: "happy flow - w/o version",
-			args: args{
-				proj:         "proj",
-				repo:         "repo",
-				path:         "file",
-				version:      "",
-				organization: "azure-org",
-			},
-			want: "https://dev.azure.com/azure-org/proj/_apis/git/repositories/repo/items?path=file",
-		},
-		{
-			name: "happy flow - w/ string",
-			args: args{
-				proj:         "proj",
-				repo:         "repo",
-				path:         "file",
-				version:      "refs/head/3",
-				organization: "azure-org",
-			},
-			want: "https://dev.azure.com/azure-org/proj/_apis/git/repositories/repo/items?path=file&versionDescriptor.versionType=tag&version=refs/head/3",
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := generateRequestUrl(tt.args.proj, tt.args.repo, tt.args.path, tt.args.version, tt.args.organization, "https://dev.azure.com/")
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func Test_extractRemoteParams(t *testing.T) {
 	type args struct {
 		jobImport *models.Import
@@ -268,13 +194,28 @@ func Test_getTemplates(t *testing.T) {
 			want: []*enhancers.ImportedPipeline{
 				{
 					OriginFileReference: testutils.CreateFileReference(0, 1, 2, 3),
-					Data:                []byte("file content"),
-					JobName:             "file",
+					Import: &models.Import{
+						FileReference: testutils.CreateFileReference(0, 1, 2, 3),
+						Source: &models.ImportSource{
+							RepositoryAlias: utils.GetPtr("templates"),
+							Path:            utils.GetPtr("file"),
+							Type:            models.SourceTypeRemote,
+						},
+					},
+					Data:    []byte("file content"),
+					JobName: "file",
 				},
 				{
 					OriginFileReference: testutils.CreateFileReference(3, 2, 1, 0),
-					Data:                []byte("file content"),
-					JobName:             "testdata/file",
+					Import: &models.Import{
+						FileReference: testutils.CreateFileReference(3, 2, 1, 0),
+						Source: &models.ImportSource{
+							Path: utils.GetPtr("testdata/file"),
+							Type: models.SourceTypeLocal,
+						},
+					},
+					Data:    []byte("file content"),
+					JobName: "testdata/file",
 				},
 			},
 		},
@@ -477,51 +418,121 @@ func Test_getTemplates(t *testing.T) {
 					JobName:             "testdata/file",
 					Data:                []byte("file content"),
 					OriginFileReference: testutils.CreateFileReference(1, 1, 1, 1),
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Path: utils.GetPtr("testdata/file"),
+							Type: models.SourceTypeLocal,
+						},
+						FileReference: testutils.CreateFileReference(1, 1, 1, 1),
+					},
 				},
 				{
 					JobName:             "testdata/file",
 					Data:                []byte("file content"),
 					OriginFileReference: testutils.CreateFileReference(1, 1, 1, 2),
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Path: utils.GetPtr("testdata/file"),
+							Type: models.SourceTypeLocal,
+						},
+						FileReference: testutils.CreateFileReference(1, 1, 1, 2),
+					},
 				},
 				{
 					JobName:             "testdata/file",
 					Data:                []byte("file content"),
 					OriginFileReference: testutils.CreateFileReference(1, 1, 1, 3),
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Path: utils.GetPtr("testdata/file"),
+							Type: models.SourceTypeLocal,
+						},
+						FileReference: testutils.CreateFileReference(1, 1, 1, 3),
+					},
 				},
 				{
 					JobName:             "testdata/file",
 					Data:                []byte("file content"),
 					OriginFileReference: testutils.CreateFileReference(1, 1, 1, 4),
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Path: utils.GetPtr("testdata/file"),
+							Type: models.SourceTypeLocal,
+						},
+						FileReference: testutils.CreateAliasFileReference(1, 1, 1, 4, false),
+					},
 				},
 				{
 					JobName:             "testdata/file",
 					Data:                []byte("file content"),
 					OriginFileReference: testutils.CreateFileReference(1, 1, 1, 5),
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Path: utils.GetPtr("testdata/file"),
+							Type: models.SourceTypeLocal,
+						},
+						FileReference: testutils.CreateFileReference(1, 1, 1, 5),
+					},
 				},
 				{
 					JobName:             "testdata/file",
 					Data:                []byte("file content"),
 					OriginFileReference: testutils.CreateFileReference(1, 1, 1, 6),
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Path: utils.GetPtr("testdata/file"),
+							Type: models.SourceTypeLocal,
+						},
+						FileReference: testutils.CreateFileReference(1, 1, 1, 6),
+					},
 				},
 				{
 					JobName:             "testdata/file",
 					Data:                []byte("file content"),
 					OriginFileReference: testutils.CreateFileReference(1, 1, 1, 7),
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Path: utils.GetPtr("testdata/file"),
+							Type: models.SourceTypeLocal,
+						},
+						FileReference: testutils.CreateFileReference(1, 1, 1, 7),
+					},
 				},
 				{
 					JobName:             "testdata/file",
 					Data:                []byte("file content"),
 					OriginFileReference: testutils.CreateFileReference(1, 1, 1, 8),
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Path: utils.GetPtr("testdata/file"),
+							Type: models.SourceTypeLocal,
+						},
+						FileReference: testutils.CreateFileReference(1, 1, 1, 8),
+					},
 				},
 				{
 					JobName:             "testdata/file",
 					Data:                []byte("file content"),
 					OriginFileReference: testutils.CreateFileReference(1, 1, 1, 9),
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Path: utils.GetPtr("testdata/file"),
+							Type: models.SourceTypeLocal,
+						},
+						FileReference: testutils.CreateFileReference(1, 1, 1, 9),
+					},
 				},
 				{
 					JobName:             "testdata/file",
 					Data:                []byte("file content"),
 					OriginFileReference: testutils.CreateFileReference(1, 1, 1, 10),
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Path: utils.GetPtr("testdata/file"),
+							Type: models.SourceTypeLocal,
+						},
+						FileReference: testutils.CreateFileReference(1, 1, 1, 10),
+					},
 				},
 			},
 		},
@@ -531,9 +542,9 @@ func Test_getTemplates(t *testing.T) {
 			h := http.FileServer(http.Dir("testdata"))
 			ts := httptest.NewServer(h)
 			defer ts.Close()
-			AZURE_SAAS_BASE_URL = ts.URL
+			fetcher.AZURE_SAAS_BASE_URL = ts.URL
 
-			got, err := getTemplates(tt.args.pipeline, tt.args.credentials, utils.GetPtr("azure-org"), &AZURE_SAAS_BASE_URL)
+			got, err := getTemplates(tt.args.pipeline, fetcher.NewDefaultFetcher(tt.args.credentials, utils.GetPtr("azure-org"), &fetcher.AZURE_SAAS_BASE_URL))
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -2,12 +2,13 @@ package bitbucket
 
 import (
 	"github.com/argonsecurity/pipeline-parser/pkg/enhancers"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
 )
 
 type BitbucketEnhancer struct{}
 
-func (b *BitbucketEnhancer) LoadImportedPipelines(data *models.Pipeline, credentials *models.Credentials, _, _ *string) ([]*enhancers.ImportedPipeline, error) {
+func (b *BitbucketEnhancer) LoadImportedPipelines(data *models.Pipeline, _ fetcher.Fetcher) ([]*enhancers.ImportedPipeline, error) {
 	return nil, nil
 }
 
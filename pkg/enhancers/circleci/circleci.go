@@ -0,0 +1,21 @@
+package circleci
+
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/enhancers"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+type CircleCIEnhancer struct{}
+
+func (c *CircleCIEnhancer) LoadImportedPipelines(data *models.Pipeline, _ fetcher.Fetcher) ([]*enhancers.ImportedPipeline, error) {
+	return nil, nil
+}
+
+func (c *CircleCIEnhancer) Enhance(data *models.Pipeline, importedPipelines []*enhancers.ImportedPipeline) (*models.Pipeline, error) {
+	return data, nil
+}
+
+func (c *CircleCIEnhancer) InheritParentPipelineData(parent, child *models.Pipeline) *models.Pipeline {
+	return child
+}
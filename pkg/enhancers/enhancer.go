@@ -1,16 +1,22 @@
 package enhancers
 
-import "github.com/argonsecurity/pipeline-parser/pkg/models"
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
 
+// ImportedPipeline is the data of an imported pipeline, or the error that occurred while fetching it
 type ImportedPipeline struct {
 	JobName             string
 	OriginFileReference *models.FileReference
+	Import              *models.Import
 	Data                []byte
 	Pipeline            *models.Pipeline
+	Error               error
 }
 
 type Enhancer interface {
 	InheritParentPipelineData(parent, child *models.Pipeline) *models.Pipeline
-	LoadImportedPipelines(data *models.Pipeline, credentials *models.Credentials, organization, baseUrl *string) ([]*ImportedPipeline, error)
+	LoadImportedPipelines(data *models.Pipeline, fetcher fetcher.Fetcher) ([]*ImportedPipeline, error)
 	Enhance(data *models.Pipeline, importedPipelines []*ImportedPipeline) (*models.Pipeline, error)
 }
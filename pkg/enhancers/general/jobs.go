@@ -15,7 +15,7 @@ func enhanceJob(job *models.Job, config *config.EnhancementConfiguration) *model
 		job.Metadata.Test = true
 	}
 
-	if utils.AnyMatch(config.Deploy.Names, job.Name) {
+	if utils.AnyMatch(config.Deploy.Names, job.Name) || isDeployment(job.Environment) {
 		job.Metadata.Deploy = true
 	}
 
@@ -37,3 +37,12 @@ func enhanceJob(job *models.Job, config *config.EnhancementConfiguration) *model
 
 	return job
 }
+
+// isDeployment reports whether a job deploys to its environment, rather than stopping or only accessing it
+func isDeployment(environment *models.Environment) bool {
+	if environment == nil || environment.Name == nil {
+		return false
+	}
+
+	return environment.Action == nil || *environment.Action == "start"
+}
@@ -16,6 +16,33 @@ func TestEnhanceJob(t *testing.T) {
 		config      *config.EnhancementConfiguration
 		expectedJob *models.Job
 	}{
+		{
+			name: "Job with environment",
+			job: &models.Job{
+				Name:        utils.GetPtr("release"),
+				Environment: &models.Environment{Name: utils.GetPtr("production")},
+			},
+			config: config.CommonConfiguration,
+			expectedJob: &models.Job{
+				Name:        utils.GetPtr("release"),
+				Environment: &models.Environment{Name: utils.GetPtr("production")},
+				Metadata: models.Metadata{
+					Deploy: true,
+				},
+			},
+		},
+		{
+			name: "Job stopping its environment",
+			job: &models.Job{
+				Name:        utils.GetPtr("stop review"),
+				Environment: &models.Environment{Name: utils.GetPtr("review"), Action: utils.GetPtr("stop")},
+			},
+			config: config.CommonConfiguration,
+			expectedJob: &models.Job{
+				Name:        utils.GetPtr("stop review"),
+				Environment: &models.Environment{Name: utils.GetPtr("review"), Action: utils.GetPtr("stop")},
+			},
+		},
 		{
 			name: "Job name contains build (lowercase)",
 			job: &models.Job{
@@ -2,13 +2,14 @@ package github
 
 import (
 	"github.com/argonsecurity/pipeline-parser/pkg/enhancers"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
 )
 
 type GitHubEnhancer struct{}
 
-func (g *GitHubEnhancer) LoadImportedPipelines(data *models.Pipeline, credentials *models.Credentials, _, baseUrl *string) ([]*enhancers.ImportedPipeline, error) {
-	importedPipelines, err := getReusableWorkflows(data, credentials, baseUrl)
+func (g *GitHubEnhancer) LoadImportedPipelines(data *models.Pipeline, fetcher fetcher.Fetcher) ([]*enhancers.ImportedPipeline, error) {
+	importedPipelines, err := getReusableWorkflows(data, fetcher)
 	if err != nil {
 		return importedPipelines, err
 	}
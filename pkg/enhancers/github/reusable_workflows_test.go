@@ -5,7 +5,9 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
 	"github.com/argonsecurity/pipeline-parser/pkg/enhancers"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
 	"github.com/argonsecurity/pipeline-parser/pkg/utils"
 	"github.com/stretchr/testify/assert"
@@ -35,6 +37,7 @@ func Test_getReusableWorkflows(t *testing.T) {
 									Repository:   utils.GetPtr("repo"),
 									Path:         utils.GetPtr("file"),
 									Type:         models.SourceTypeRemote,
+									SCM:          consts.GitHubPlatform,
 								},
 								Version: utils.GetPtr("version"),
 							},
@@ -54,11 +57,27 @@ func Test_getReusableWorkflows(t *testing.T) {
 			want: []*enhancers.ImportedPipeline{
 				{
 					JobName: "remote",
-					Data:    []byte("file data"),
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Organization: utils.GetPtr("org"),
+							Repository:   utils.GetPtr("repo"),
+							Path:         utils.GetPtr("file"),
+							Type:         models.SourceTypeRemote,
+							SCM:          consts.GitHubPlatform,
+						},
+						Version: utils.GetPtr("version"),
+					},
+					Data: []byte("file data"),
 				},
 				{
 					JobName: "local",
-					Data:    []byte("file data"),
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Path: utils.GetPtr("testdata/org/repo/version/file"),
+							Type: models.SourceTypeLocal,
+						},
+					},
+					Data: []byte("file data"),
 				},
 			},
 		},
@@ -75,6 +94,7 @@ func Test_getReusableWorkflows(t *testing.T) {
 									Repository:   utils.GetPtr("repo"),
 									Path:         utils.GetPtr("file"),
 									Type:         models.SourceTypeRemote,
+									SCM:          consts.GitHubPlatform,
 								},
 								Version: utils.GetPtr("version"),
 							},
@@ -116,6 +136,7 @@ func Test_getReusableWorkflows(t *testing.T) {
 									Repository:   utils.GetPtr("repo"),
 									Path:         utils.GetPtr("does-not-exist"),
 									Type:         models.SourceTypeRemote,
+									SCM:          consts.GitHubPlatform,
 								},
 								Version: utils.GetPtr("version"),
 							},
@@ -150,12 +171,15 @@ func Test_getReusableWorkflows(t *testing.T) {
 			h := http.FileServer(http.Dir("testdata"))
 			ts := httptest.NewServer(h)
 			defer ts.Close()
-			GITHUB_BASE_URL = ts.URL
+			fetcher.GITHUB_BASE_URL = ts.URL
 
-			got, err := getReusableWorkflows(tt.args.pipeline, tt.args.credentials, nil)
+			got, err := getReusableWorkflows(tt.args.pipeline, fetcher.NewDefaultFetcher(tt.args.credentials, nil, nil))
 
 			if tt.wantErr {
 				assert.Error(t, err)
+				for i, importedPipeline := range got {
+					assert.Equal(t, tt.want[i].Data == nil, importedPipeline.Error != nil)
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.want, got)
@@ -184,6 +208,7 @@ func Test_handleImport(t *testing.T) {
 						Repository:   utils.GetPtr("repo"),
 						Path:         utils.GetPtr("file"),
 						Type:         models.SourceTypeRemote,
+						SCM:          consts.GitHubPlatform,
 					},
 					Version: utils.GetPtr("version"),
 				},
@@ -199,6 +224,7 @@ func Test_handleImport(t *testing.T) {
 						Repository:   utils.GetPtr("repo"),
 						Path:         utils.GetPtr("does-not-exist"),
 						Type:         models.SourceTypeRemote,
+						SCM:          consts.GitHubPlatform,
 					},
 					Version: utils.GetPtr("version"),
 				},
@@ -231,6 +257,7 @@ func Test_handleImport(t *testing.T) {
 						Repository:   utils.GetPtr("repo"),
 						Path:         utils.GetPtr("file"),
 						Type:         models.SourceTypeRemote,
+						SCM:          consts.GitHubPlatform,
 					},
 					Version: nil,
 				},
@@ -246,6 +273,7 @@ func Test_handleImport(t *testing.T) {
 						Repository:   utils.GetPtr(""),
 						Path:         utils.GetPtr(""),
 						Type:         models.SourceTypeRemote,
+						SCM:          consts.GitHubPlatform,
 					},
 					Version: utils.GetPtr(""),
 				},
@@ -301,126 +329,10 @@ func Test_handleImport(t *testing.T) {
 			h := http.FileServer(http.Dir("testdata"))
 			ts := httptest.NewServer(h)
 			defer ts.Close()
-			GITHUB_BASE_URL = ts.URL
-
-			got, err := handleImport(tt.args.imports, tt.args.credentials, nil)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.want, got)
-			}
-		})
-	}
-}
-
-func Test_loadRemoteFile(t *testing.T) {
-	type args struct {
-		org         string
-		repo        string
-		version     string
-		path        string
-		credentials *models.Credentials
-		baseUrl     *string
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    []byte
-		wantErr bool
-	}{
-		{
-			name: "happy flow",
-			args: args{
-				org:     "org",
-				repo:    "repo",
-				version: "version",
-				path:    "file",
-			},
-			want: []byte("file data"),
-		},
-		{
-			name: "happy flow with credentials",
-			args: args{
-				org:     "org",
-				repo:    "repo",
-				version: "version",
-				path:    "file",
-				credentials: &models.Credentials{
-					Token: "token",
-				},
-			},
-			want: []byte("file data"),
-		},
-		{
-			name: "file does not exist",
-			args: args{
-				org:     "org",
-				repo:    "repo",
-				version: "version",
-				path:    "does-not-exist",
-			},
-			wantErr: true,
-		},
-		{
-			name: "empty args",
-			args: args{},
-			want: nil,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			h := http.FileServer(http.Dir("testdata"))
-			ts := httptest.NewServer(h)
-			defer ts.Close()
-			GITHUB_BASE_URL = ts.URL
+			fetcher.GITHUB_BASE_URL = ts.URL
 
-			got, err := loadRemoteFile(tt.args.org, tt.args.repo, tt.args.version, tt.args.path, tt.args.credentials, tt.args.baseUrl)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.want, got)
-			}
-		})
-	}
-}
+			got, err := handleImport(tt.args.imports, fetcher.NewDefaultFetcher(tt.args.credentials, nil, nil))
 
-func Test_loadLocalFile(t *testing.T) {
-	type args struct {
-		path string
-	}
-	tests := []struct {
-		name    string
-		args    args
-		want    []byte
-		wantErr bool
-	}{
-		{
-			name: "happy flow",
-			args: args{
-				path: "testdata/org/repo/version/file",
-			},
-			want: []byte("file data"),
-		},
-		{
-			name: "path does not exist",
-			args: args{
-				path: "testdata/org/repo/version/does-not-exist",
-			},
-			wantErr: true,
-		},
-		{
-			name: "empty args",
-			args: args{},
-			want: nil,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := loadLocalFile(tt.args.path)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
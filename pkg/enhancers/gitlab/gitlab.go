@@ -2,28 +2,26 @@ package gitlab
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/pkg/errors"
 
 	"github.com/argonsecurity/pipeline-parser/pkg/enhancers"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	gitlabParser "github.com/argonsecurity/pipeline-parser/pkg/parsers/gitlab"
+	gitlabJob "github.com/argonsecurity/pipeline-parser/pkg/parsers/gitlab/job"
 	"github.com/argonsecurity/pipeline-parser/pkg/utils"
 )
 
-var (
-	GITLAB_BASE_URL = "https://gitlab.com"
-)
-
 type GitLabEnhancer struct{}
 
-func (g *GitLabEnhancer) LoadImportedPipelines(data *models.Pipeline, credentials *models.Credentials, _, baseUrl *string) ([]*enhancers.ImportedPipeline, error) {
+func (g *GitLabEnhancer) LoadImportedPipelines(data *models.Pipeline, fetcher fetcher.Fetcher) ([]*enhancers.ImportedPipeline, error) {
 	var errs error
 	importedPipelines := []*enhancers.ImportedPipeline{}
 	if data.Imports != nil {
 		for _, importData := range data.Imports {
-			importedPipeline, err := handleImport(importData, credentials, baseUrl)
+			importedPipeline, err := handleImport(importData, fetcher)
 			if err != nil {
 				if errs == nil {
 					errs = errors.New("got error(s) importing pipeline(s):")
@@ -31,6 +29,14 @@ func (g *GitLabEnhancer) LoadImportedPipelines(data *models.Pipeline, credential
 				errs = errors.Wrap(errs, fmt.Sprintf("error importing pipeline: %s", err.Error()))
 			}
 
+			if importedPipeline == nil && importData != nil {
+				importedPipeline = &enhancers.ImportedPipeline{}
+			}
+			if importedPipeline != nil {
+				importedPipeline.Import = importData
+				importedPipeline.Error = err
+			}
+
 			// We append nil imported pipelines to maintain the order of the imported pipelines
 			importedPipelines = append(importedPipelines, importedPipeline)
 		}
@@ -38,23 +44,23 @@ func (g *GitLabEnhancer) LoadImportedPipelines(data *models.Pipeline, credential
 	return importedPipelines, errs
 }
 
-func handleImport(importData *models.Import, credentials *models.Credentials, baseUrl *string) (*enhancers.ImportedPipeline, error) {
+func handleImport(importData *models.Import, fetcher fetcher.Fetcher) (*enhancers.ImportedPipeline, error) {
 	if importData == nil || importData.Source == nil {
 		return nil, nil
 	}
 
 	if importData.Source.Type == models.SourceTypeRemote {
-		return handleRemoteImport(importData, credentials, baseUrl)
+		return handleRemoteImport(importData, fetcher)
 	}
 
 	if importData.Source.Type == models.SourceTypeLocal {
-		return handleLocalImport(importData)
+		return handleLocalImport(importData, fetcher)
 	}
 
 	return nil, nil
 }
 
-func handleRemoteImport(importData *models.Import, credentials *models.Credentials, baseUrl *string) (*enhancers.ImportedPipeline, error) {
+func handleRemoteImport(importData *models.Import, fetcher fetcher.Fetcher) (*enhancers.ImportedPipeline, error) {
 	if importData.Source.Type != models.SourceTypeRemote {
 		return nil, errors.New("invalid source type for remote import")
 	}
@@ -66,33 +72,19 @@ func handleRemoteImport(importData *models.Import, credentials *models.Credentia
 		return nil, errors.New("missing required fields for remote import")
 	}
 
-	if baseUrl == nil || *baseUrl == "" {
-		baseUrl = &GITLAB_BASE_URL
-	}
-
-	url := fmt.Sprintf("%s/%s/%s/-/raw/%s/%s",
-		*baseUrl,
-		*importData.Source.Organization,
-		*importData.Source.Repository,
-		*importData.Version,
-		*importData.Source.Path,
-	)
-	client := utils.GetHttpClient(credentials)
-	resp, err := client.R().Get(url)
+	buf, err := fetcher.Fetch(importData.Source, importData.Version)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.IsErrorState() {
-		return nil, errors.New(resp.Response.Status)
-	}
-
-	buf := resp.Bytes()
 	return &enhancers.ImportedPipeline{Data: buf}, nil
 }
 
-func handleLocalImport(importData *models.Import) (*enhancers.ImportedPipeline, error) {
-	buf, err := os.ReadFile(strings.TrimPrefix(*importData.Source.Path, "/"))
+// handleLocalImport fetches a local include, whose path is relative to the repository root, e.g. `/templates/build.yml`
+func handleLocalImport(importData *models.Import, fetcher fetcher.Fetcher) (*enhancers.ImportedPipeline, error) {
+	source := *importData.Source
+	source.Path = utils.GetPtr(strings.TrimPrefix(*importData.Source.Path, "/"))
+	buf, err := fetcher.Fetch(&source, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -114,9 +106,41 @@ func (g *GitLabEnhancer) Enhance(data *models.Pipeline, importedPipelines []*enh
 		}
 	}
 
+	resolveImportedExtends(data)
+	data.Stages = gitlabParser.UpdateStages(data.Stages, data.Jobs)
+
 	return data, nil
 }
 
+// resolveImportedExtends merges jobs with the parents they extend that are defined in included pipelines
+func resolveImportedExtends(data *models.Pipeline) {
+	gitlabJob.ResolveExternalExtends(data.Jobs, func(jobID string) *models.Job {
+		return findImportedJob(data.Imports, jobID)
+	})
+}
+
+// findImportedJob returns the definition of a job that GitLab uses when merging the included pipelines -
+// a later include overrides the jobs of the includes before it, and the jobs of a pipeline override the jobs it includes.
+func findImportedJob(imports []*models.Import, jobID string) *models.Job {
+	for i := len(imports) - 1; i >= 0; i-- {
+		importData := imports[i]
+		if importData == nil || importData.Pipeline == nil {
+			continue
+		}
+
+		for _, job := range importData.Pipeline.Jobs {
+			if job.ID != nil && *job.ID == jobID {
+				return job
+			}
+		}
+
+		if job := findImportedJob(importData.Pipeline.Imports, jobID); job != nil {
+			return job
+		}
+	}
+	return nil
+}
+
 func (g *GitLabEnhancer) InheritParentPipelineData(parent, child *models.Pipeline) *models.Pipeline {
 	return child
 }
@@ -8,8 +8,10 @@ import (
 
 	"github.com/argonsecurity/pipeline-parser/pkg/consts"
 	"github.com/argonsecurity/pipeline-parser/pkg/enhancers"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
 	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_handleLocalImport(t *testing.T) {
@@ -53,7 +55,7 @@ func Test_handleLocalImport(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := handleLocalImport(tt.args.importData)
+			got, err := handleLocalImport(tt.args.importData, fetcher.NewDefaultFetcher(nil, nil, nil))
 			if (err != nil) != tt.wantErr {
 				t.Errorf("handleLocalImport() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -129,10 +131,10 @@ func Test_handleRemoteImport(t *testing.T) {
 		h := http.FileServer(http.Dir("testdata"))
 		ts := httptest.NewServer(h)
 		defer ts.Close()
-		GITLAB_BASE_URL = ts.URL
+		fetcher.GITLAB_BASE_URL = ts.URL
 
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := handleRemoteImport(tt.args.importData, tt.args.credentials, nil)
+			got, err := handleRemoteImport(tt.args.importData, fetcher.NewDefaultFetcher(tt.args.credentials, nil, nil))
 			if (err != nil) != tt.wantErr {
 				t.Errorf("handleRemoteImport() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -211,10 +213,10 @@ func Test_handleImport(t *testing.T) {
 		h := http.FileServer(http.Dir("testdata"))
 		ts := httptest.NewServer(h)
 		defer ts.Close()
-		GITLAB_BASE_URL = ts.URL
+		fetcher.GITLAB_BASE_URL = ts.URL
 
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := handleImport(tt.args.importData, tt.args.credentials, nil)
+			got, err := handleImport(tt.args.importData, fetcher.NewDefaultFetcher(tt.args.credentials, nil, nil))
 			if (err != nil) != tt.wantErr {
 				t.Errorf("handleImport() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -255,6 +257,13 @@ func TestGitLabEnhancer_LoadImportedPipelines(t *testing.T) {
 			},
 			want: []*enhancers.ImportedPipeline{
 				{
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Type: models.SourceTypeLocal,
+							SCM:  consts.GitLabPlatform,
+							Path: utils.GetPtr("testdata/pipeline.yaml"),
+						},
+					},
 					Data: []byte("test data\n"),
 				},
 			},
@@ -280,6 +289,17 @@ func TestGitLabEnhancer_LoadImportedPipelines(t *testing.T) {
 			},
 			want: []*enhancers.ImportedPipeline{
 				{
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Type:         models.SourceTypeRemote,
+							SCM:          consts.GitLabPlatform,
+							Path:         utils.GetPtr("pipeline.yaml"),
+							Organization: utils.GetPtr("group"),
+							Repository:   utils.GetPtr("subgroup/project"),
+						},
+						Version:     utils.GetPtr("master"),
+						VersionType: models.BranchVersion,
+					},
 					Data: []byte("test data\n"),
 				},
 			},
@@ -323,12 +343,43 @@ func TestGitLabEnhancer_LoadImportedPipelines(t *testing.T) {
 			},
 			want: []*enhancers.ImportedPipeline{
 				{
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Type: models.SourceTypeLocal,
+							SCM:  consts.GitLabPlatform,
+							Path: utils.GetPtr("testdata/pipeline.yaml"),
+						},
+					},
 					Data: []byte("test data\n"),
 				},
 				{
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Type:         models.SourceTypeRemote,
+							SCM:          consts.GitLabPlatform,
+							Path:         utils.GetPtr("pipeline.yaml"),
+							Organization: utils.GetPtr("group"),
+							Repository:   utils.GetPtr("subgroup/project"),
+						},
+						Version:     utils.GetPtr("master"),
+						VersionType: models.BranchVersion,
+					},
 					Data: []byte("test data\n"),
 				},
-				nil,
+				{
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Type:         models.SourceTypeRemote,
+							SCM:          consts.GitLabPlatform,
+							Path:         utils.GetPtr("pipeline.yaml"),
+							Organization: utils.GetPtr("group"),
+							Repository:   utils.GetPtr("subgroup/project"),
+						},
+						Version:     utils.GetPtr("invalidbranch"),
+						VersionType: models.BranchVersion,
+					},
+					Error: assert.AnError,
+				},
 			},
 			wantErr: true,
 		},
@@ -351,7 +402,22 @@ func TestGitLabEnhancer_LoadImportedPipelines(t *testing.T) {
 					},
 				},
 			},
-			want:    []*enhancers.ImportedPipeline{nil},
+			want: []*enhancers.ImportedPipeline{
+				{
+					Import: &models.Import{
+						Source: &models.ImportSource{
+							Type:         models.SourceTypeRemote,
+							SCM:          consts.GitLabPlatform,
+							Path:         utils.GetPtr("pipeline.yaml"),
+							Organization: utils.GetPtr("group"),
+							Repository:   utils.GetPtr("subgroup/project"),
+						},
+						Version:     utils.GetPtr("invalidbranch"),
+						VersionType: models.BranchVersion,
+					},
+					Error: assert.AnError,
+				},
+			},
 			wantErr: true,
 		},
 	}
@@ -359,14 +425,19 @@ func TestGitLabEnhancer_LoadImportedPipelines(t *testing.T) {
 		h := http.FileServer(http.Dir("testdata"))
 		ts := httptest.NewServer(h)
 		defer ts.Close()
-		GITLAB_BASE_URL = ts.URL
+		fetcher.GITLAB_BASE_URL = ts.URL
 		t.Run(tt.name, func(t *testing.T) {
 			g := &GitLabEnhancer{}
-			got, err := g.LoadImportedPipelines(tt.args.data, tt.args.credentials, utils.GetPtr(""), utils.GetPtr(""))
+			got, err := g.LoadImportedPipelines(tt.args.data, fetcher.NewDefaultFetcher(tt.args.credentials, utils.GetPtr(""), utils.GetPtr("")))
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GitLabEnhancer.LoadImportedPipelines() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			for _, importedPipeline := range got {
+				if importedPipeline != nil && importedPipeline.Error != nil {
+					importedPipeline.Error = assert.AnError
+				}
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("GitLabEnhancer.LoadImportedPipelines() = %v, want %v", got, tt.want)
 			}
@@ -485,3 +556,154 @@ func TestGitLabEnhancer_Enhance(t *testing.T) {
 		})
 	}
 }
+
+func Test_resolveImportedExtends(t *testing.T) {
+	tests := []struct {
+		name string
+		data *models.Pipeline
+		want []*models.Job
+	}{
+		{
+			name: "job extends an imported template",
+			data: &models.Pipeline{
+				Jobs: []*models.Job{
+					{ID: utils.GetPtr(".local"), TimeoutMS: utils.GetPtr(1)},
+					{ID: utils.GetPtr("test"), Extends: []string{".local", ".remote"}},
+				},
+				Imports: []*models.Import{
+					{
+						Pipeline: &models.Pipeline{
+							Jobs: []*models.Job{
+								{ID: utils.GetPtr(".base"), Tags: []string{"docker"}},
+								{ID: utils.GetPtr(".remote"), Tags: []string{"docker"}, Extends: []string{".base"}},
+							},
+						},
+					},
+				},
+			},
+			want: []*models.Job{
+				{ID: utils.GetPtr(".local"), TimeoutMS: utils.GetPtr(1)},
+				{ID: utils.GetPtr("test"), TimeoutMS: utils.GetPtr(1), Tags: []string{"docker"}, Extends: []string{".local", ".base", ".remote"}},
+			},
+		},
+		{
+			name: "later parent overrides an earlier imported parent",
+			data: &models.Pipeline{
+				Jobs: []*models.Job{
+					{ID: utils.GetPtr(".local"), TimeoutMS: utils.GetPtr(1), Tags: []string{"local"}},
+					{ID: utils.GetPtr("test"), Extends: []string{".remote", ".local"}},
+				},
+				Imports: []*models.Import{
+					{
+						Pipeline: &models.Pipeline{
+							Jobs: []*models.Job{
+								{ID: utils.GetPtr(".remote"), TimeoutMS: utils.GetPtr(2), Tags: []string{"remote"}, ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("remote"))},
+							},
+						},
+					},
+				},
+			},
+			want: []*models.Job{
+				{ID: utils.GetPtr(".local"), TimeoutMS: utils.GetPtr(1), Tags: []string{"local"}},
+				{ID: utils.GetPtr("test"), TimeoutMS: utils.GetPtr(1), Tags: []string{"local"}, ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("remote")), Extends: []string{".remote", ".local"}},
+			},
+		},
+		{
+			name: "later imported parent overrides an earlier local parent",
+			data: &models.Pipeline{
+				Jobs: []*models.Job{
+					{ID: utils.GetPtr(".local"), TimeoutMS: utils.GetPtr(1), Tags: []string{"local"}},
+					{ID: utils.GetPtr("test"), Tags: []string{"test"}, Extends: []string{".local", ".remote"}},
+				},
+				Imports: []*models.Import{
+					{
+						Pipeline: &models.Pipeline{
+							Jobs: []*models.Job{
+								{ID: utils.GetPtr(".remote"), TimeoutMS: utils.GetPtr(2), Tags: []string{"remote"}},
+							},
+						},
+					},
+				},
+			},
+			want: []*models.Job{
+				{ID: utils.GetPtr(".local"), TimeoutMS: utils.GetPtr(1), Tags: []string{"local"}},
+				{ID: utils.GetPtr("test"), TimeoutMS: utils.GetPtr(2), Tags: []string{"test"}, Extends: []string{".local", ".remote"}},
+			},
+		},
+		{
+			name: "later include overrides a template of an earlier include",
+			data: &models.Pipeline{
+				Jobs: []*models.Job{
+					{ID: utils.GetPtr("test"), Extends: []string{".remote"}},
+				},
+				Imports: []*models.Import{
+					{
+						Pipeline: &models.Pipeline{
+							Jobs: []*models.Job{
+								{ID: utils.GetPtr(".remote"), Tags: []string{"first"}},
+							},
+						},
+					},
+					{
+						Pipeline: &models.Pipeline{
+							Jobs: []*models.Job{
+								{ID: utils.GetPtr(".remote"), Tags: []string{"second"}},
+							},
+						},
+					},
+				},
+			},
+			want: []*models.Job{
+				{ID: utils.GetPtr("test"), Tags: []string{"second"}, Extends: []string{".remote"}},
+			},
+		},
+		{
+			name: "included pipeline overrides the templates it includes",
+			data: &models.Pipeline{
+				Jobs: []*models.Job{
+					{ID: utils.GetPtr("test"), Extends: []string{".remote"}},
+				},
+				Imports: []*models.Import{
+					{
+						Pipeline: &models.Pipeline{
+							Jobs: []*models.Job{
+								{ID: utils.GetPtr(".remote"), Tags: []string{"included"}},
+							},
+							Imports: []*models.Import{
+								{
+									Pipeline: &models.Pipeline{
+										Jobs: []*models.Job{
+											{ID: utils.GetPtr(".remote"), Tags: []string{"nested"}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []*models.Job{
+				{ID: utils.GetPtr("test"), Tags: []string{"included"}, Extends: []string{".remote"}},
+			},
+		},
+		{
+			name: "imported template is missing",
+			data: &models.Pipeline{
+				Jobs: []*models.Job{
+					{ID: utils.GetPtr("test"), Extends: []string{".remote"}},
+				},
+			},
+			want: []*models.Job{
+				{ID: utils.GetPtr("test"), Extends: []string{".remote"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolveImportedExtends(tt.data)
+			if !reflect.DeepEqual(tt.data.Jobs, tt.want) {
+				t.Errorf("resolveImportedExtends() = %v, want %v", tt.data.Jobs, tt.want)
+			}
+		})
+	}
+}
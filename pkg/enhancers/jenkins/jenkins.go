@@ -0,0 +1,21 @@
+package jenkins
+
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/enhancers"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+type JenkinsEnhancer struct{}
+
+func (j *JenkinsEnhancer) LoadImportedPipelines(data *models.Pipeline, _ fetcher.Fetcher) ([]*enhancers.ImportedPipeline, error) {
+	return nil, nil
+}
+
+func (j *JenkinsEnhancer) Enhance(data *models.Pipeline, importedPipelines []*enhancers.ImportedPipeline) (*models.Pipeline, error) {
+	return data, nil
+}
+
+func (j *JenkinsEnhancer) InheritParentPipelineData(parent, child *models.Pipeline) *models.Pipeline {
+	return child
+}
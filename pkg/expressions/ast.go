@@ -0,0 +1,154 @@
+package expressions
+
+import (
+	"fmt"
+	"strings"
+)
+
+type LiteralKind string
+
+const (
+	StringLiteral  LiteralKind = "string"
+	NumberLiteral  LiteralKind = "number"
+	BooleanLiteral LiteralKind = "boolean"
+	NullLiteral    LiteralKind = "null"
+)
+
+// Node is a node of the syntax tree of an expression
+type Node interface {
+	Offset() int // The offset of the node in the expression
+	String() string
+}
+
+// Literal is a string, number, boolean or null value
+type Literal struct {
+	Kind     LiteralKind
+	Value    any // string, float64, bool or nil
+	Raw      string
+	Position int
+}
+
+// NamedValue is the name of a context, such as github, env or steps
+type NamedValue struct {
+	Name     string
+	Position int
+}
+
+// PropertyAccess is a property dereference, such as github.event
+type PropertyAccess struct {
+	Object   Node
+	Property string
+	Position int
+}
+
+// IndexAccess is an index dereference, such as needs['build'] or matrix[format('{0}', 'os')]
+type IndexAccess struct {
+	Object   Node
+	Index    Node
+	Position int
+}
+
+// FilterAccess is an object filter, such as github.event.commits.*.message or needs[*]
+type FilterAccess struct {
+	Object   Node
+	Position int
+}
+
+// FunctionCall is a call to one of the built-in functions
+type FunctionCall struct {
+	Name      string
+	Arguments []Node
+	Position  int
+}
+
+// UnaryExpression is a negation
+type UnaryExpression struct {
+	Operator TokenType
+	Operand  Node
+	Position int
+}
+
+// BinaryExpression is a comparison or a logical operation
+type BinaryExpression struct {
+	Operator TokenType
+	Left     Node
+	Right    Node
+	Position int
+}
+
+func (n *Literal) Offset() int          { return n.Position }
+func (n *NamedValue) Offset() int       { return n.Position }
+func (n *PropertyAccess) Offset() int   { return n.Position }
+func (n *IndexAccess) Offset() int      { return n.Position }
+func (n *FilterAccess) Offset() int     { return n.Position }
+func (n *FunctionCall) Offset() int     { return n.Position }
+func (n *UnaryExpression) Offset() int  { return n.Position }
+func (n *BinaryExpression) Offset() int { return n.Position }
+
+func (n *Literal) String() string {
+	switch n.Kind {
+	case StringLiteral:
+		return fmt.Sprintf("'%s'", strings.ReplaceAll(n.Value.(string), "'", "''"))
+	case NullLiteral:
+		return "null"
+	}
+	return n.Raw
+}
+
+func (n *NamedValue) String() string {
+	return n.Name
+}
+
+func (n *PropertyAccess) String() string {
+	return fmt.Sprintf("%s.%s", n.Object, n.Property)
+}
+
+func (n *IndexAccess) String() string {
+	return fmt.Sprintf("%s[%s]", n.Object, n.Index)
+}
+
+func (n *FilterAccess) String() string {
+	return fmt.Sprintf("%s.*", n.Object)
+}
+
+func (n *FunctionCall) String() string {
+	arguments := make([]string, len(n.Arguments))
+	for i, argument := range n.Arguments {
+		arguments[i] = argument.String()
+	}
+	return fmt.Sprintf("%s(%s)", n.Name, strings.Join(arguments, ", "))
+}
+
+func (n *UnaryExpression) String() string {
+	return fmt.Sprintf("%s%s", n.Operator, n.Operand)
+}
+
+func (n *BinaryExpression) String() string {
+	return fmt.Sprintf("(%s %s %s)", n.Left, n.Operator, n.Right)
+}
+
+// Walk visits a node and all of its descendants in depth-first order, as long as visit returns true
+func Walk(node Node, visit func(Node) bool) {
+	if node == nil || !visit(node) {
+		return
+	}
+
+	switch n := node.(type) {
+	case *PropertyAccess:
+		Walk(n.Object, visit)
+	case *IndexAccess:
+		Walk(n.Object, visit)
+		Walk(n.Index, visit)
+	case *FilterAccess:
+		Walk(n.Object, visit)
+	case *FunctionCall:
+		for _, argument := range n.Arguments {
+			Walk(argument, visit)
+		}
+	case *UnaryExpression:
+		Walk(n.Operand, visit)
+	case *BinaryExpression:
+		Walk(n.Left, visit)
+		Walk(n.Right, visit)
+	}
+}
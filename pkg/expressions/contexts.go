@@ -0,0 +1,98 @@
+package expressions
+
+import "strings"
+
+// ContextReference is a path into one of the contexts of the workflow, such as github.event.pull_request.title.
+// Filters and indexes that are not string or number literals are represented by a "*" path element.
+type ContextReference struct {
+	Context string
+	Path    []string
+	Node    Node // The outermost access node of the reference
+}
+
+func (r *ContextReference) String() string {
+	return strings.Join(append([]string{r.Context}, r.Path...), ".")
+}
+
+// ContextReferences returns the context paths an expression references, in the order they appear in the expression.
+// Only the longest access chain is returned for every reference - github.event.issue.title does not also yield github.event.
+func ContextReferences(node Node) []*ContextReference {
+	var references []*ContextReference
+	Walk(node, func(n Node) bool {
+		switch n.(type) {
+		case *NamedValue, *PropertyAccess, *IndexAccess, *FilterAccess:
+			reference, ok := getContextReference(n)
+			if !ok {
+				return true
+			}
+			references = append(references, reference)
+
+			// dynamic indexes may reference contexts themselves
+			for _, index := range getDynamicIndexes(n) {
+				references = append(references, ContextReferences(index)...)
+			}
+			return false
+		}
+		return true
+	})
+	return references
+}
+
+func getContextReference(node Node) (*ContextReference, bool) {
+	var path []string
+	current := node
+	for {
+		switch n := current.(type) {
+		case *NamedValue:
+			return &ContextReference{Context: n.Name, Path: reverse(path), Node: node}, true
+		case *PropertyAccess:
+			path = append(path, n.Property)
+			current = n.Object
+		case *IndexAccess:
+			path = append(path, getIndexPathElement(n.Index))
+			current = n.Object
+		case *FilterAccess:
+			path = append(path, "*")
+			current = n.Object
+		default:
+			return nil, false
+		}
+	}
+}
+
+func getDynamicIndexes(node Node) []Node {
+	var indexes []Node
+	for {
+		switch n := node.(type) {
+		case *PropertyAccess:
+			node = n.Object
+		case *FilterAccess:
+			node = n.Object
+		case *IndexAccess:
+			if _, ok := n.Index.(*Literal); !ok {
+				indexes = append([]Node{n.Index}, indexes...)
+			}
+			node = n.Object
+		default:
+			return indexes
+		}
+	}
+}
+
+func getIndexPathElement(index Node) string {
+	if literal, ok := index.(*Literal); ok && (literal.Kind == StringLiteral || literal.Kind == NumberLiteral) {
+		if literal.Kind == StringLiteral {
+			return literal.Value.(string)
+		}
+		return literal.Raw
+	}
+	return "*"
+}
+
+func reverse(path []string) []string {
+	reversed := make([]string, len(path))
+	for i, element := range path {
+		reversed[len(path)-1-i] = element
+	}
+	return reversed
+}
@@ -0,0 +1,50 @@
+package expressions
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestContextReferences(t *testing.T) {
+	testCases := []struct {
+		expression         string
+		expectedReferences []string
+	}{
+		{
+			expression:         "'literal' == true",
+			expectedReferences: []string{},
+		},
+		{
+			expression:         "github.event.pull_request.title",
+			expectedReferences: []string{"github.event.pull_request.title"},
+		},
+		{
+			expression:         "contains(github.event.issue.labels.*.name, 'bug') && !cancelled()",
+			expectedReferences: []string{"github.event.issue.labels.*.name"},
+		},
+		{
+			expression:         "needs['build'].outputs[matrix.os] || steps.test.outputs[0]",
+			expectedReferences: []string{"needs.build.outputs.*", "matrix.os", "steps.test.outputs.0"},
+		},
+		{
+			expression:         "fromJSON(steps.meta.outputs.json).tags[0] == env",
+			expectedReferences: []string{"steps.meta.outputs.json", "env"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.expression, func(t *testing.T) {
+			node, err := Parse(testCase.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			references := utils.Map(ContextReferences(node), func(reference *ContextReference) string {
+				return reference.String()
+			})
+			testutils.DeepCompare(t, testCase.expectedReferences, references)
+		})
+	}
+}
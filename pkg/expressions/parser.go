@@ -0,0 +1,268 @@
+package expressions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+)
+
+// functions maps the lower cased name of every built-in function to its minimum and maximum number of arguments,
+// where a maximum of -1 means the function accepts any number of arguments
+var functions = map[string][2]int{
+	"contains":   {2, 2},
+	"startswith": {2, 2},
+	"endswith":   {2, 2},
+	"format":     {1, -1},
+	"join":       {1, 2},
+	"tojson":     {1, 1},
+	"fromjson":   {1, 1},
+	"hashfiles":  {1, -1},
+	"success":    {0, 0},
+	"always":     {0, 0},
+	"cancelled":  {0, 0},
+	"failure":    {0, 0},
+}
+
+type parser struct {
+	expression string
+	tokens     []*Token
+	position   int
+	functions  map[string][2]int
+}
+
+// Parse parses a GitHub Actions expression (without the surrounding ${{ }}) into a syntax tree
+func Parse(expression string) (Node, error) {
+	return ParseWithFunctions(expression, functions)
+}
+
+// ParseWithFunctions parses an expression with the same syntax as GitHub Actions expressions, such as an Azure Pipelines
+// template expression, whose built-in functions map their lower cased names to their minimum and maximum number of arguments
+func ParseWithFunctions(expression string, functions map[string][2]int) (Node, error) {
+	tokens, err := Tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{expression: expression, tokens: tokens, functions: functions}
+	if p.peek().Type == EOFToken {
+		return nil, p.errorf(p.peek(), "empty expression")
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if token := p.peek(); token.Type != EOFToken {
+		return nil, p.errorf(token, "unexpected '%s'", token.Value)
+	}
+	return node, nil
+}
+
+func (p *parser) parseOr() (Node, error) {
+	return p.parseBinary(p.parseAnd, OrToken)
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	return p.parseBinary(p.parseEquality, AndToken)
+}
+
+func (p *parser) parseEquality() (Node, error) {
+	return p.parseBinary(p.parseComparison, EqualToken, NotEqualToken)
+}
+
+func (p *parser) parseComparison() (Node, error) {
+	return p.parseBinary(p.parseUnary, LessToken, LessEqualToken, GreaterToken, GreaterEqualToken)
+}
+
+// parseBinary parses a left associative chain of operations with the same precedence
+func (p *parser) parseBinary(parseOperand func() (Node, error), operators ...TokenType) (Node, error) {
+	left, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isNext(operators...) {
+		operator := p.next()
+		right, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryExpression{Operator: operator.Type, Left: left, Right: right, Position: operator.Offset}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if !p.isNext(NotToken) {
+		return p.parsePostfix()
+	}
+
+	operator := p.next()
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &UnaryExpression{Operator: operator.Type, Operand: operand, Position: operator.Offset}, nil
+}
+
+func (p *parser) parsePostfix() (Node, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isNext(DotToken, LeftBracketToken) {
+		token := p.next()
+		if token.Type == DotToken {
+			property := p.next()
+			switch property.Type {
+			case StarToken:
+				node = &FilterAccess{Object: node, Position: token.Offset}
+			case IdentifierToken:
+				node = &PropertyAccess{Object: node, Property: property.Value, Position: token.Offset}
+			default:
+				return nil, p.errorf(property, "expected a property name after '.'")
+			}
+			continue
+		}
+
+		if p.isNext(StarToken) {
+			p.next()
+			node = &FilterAccess{Object: node, Position: token.Offset}
+		} else {
+			index, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			node = &IndexAccess{Object: node, Index: index, Position: token.Offset}
+		}
+		if err := p.expect(RightBracketToken); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	token := p.next()
+	switch token.Type {
+	case StringToken:
+		return &Literal{Kind: StringLiteral, Value: token.Value, Raw: token.Value, Position: token.Offset}, nil
+	case NumberToken:
+		value, err := parseNumber(token.Value)
+		if err != nil {
+			return nil, p.errorf(token, "invalid number '%s'", token.Value)
+		}
+		return &Literal{Kind: NumberLiteral, Value: value, Raw: token.Value, Position: token.Offset}, nil
+	case BooleanToken:
+		return &Literal{Kind: BooleanLiteral, Value: token.Value == "true", Raw: token.Value, Position: token.Offset}, nil
+	case NullToken:
+		return &Literal{Kind: NullLiteral, Raw: token.Value, Position: token.Offset}, nil
+	case LeftParenToken:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(RightParenToken); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case IdentifierToken:
+		if p.isNext(LeftParenToken) {
+			return p.parseFunctionCall(token)
+		}
+		return &NamedValue{Name: token.Value, Position: token.Offset}, nil
+	case EOFToken:
+		return nil, p.errorf(token, "unexpected end of expression")
+	}
+	return nil, p.errorf(token, "unexpected '%s'", token.Value)
+}
+
+func (p *parser) parseFunctionCall(name *Token) (Node, error) {
+	arity, ok := p.functions[strings.ToLower(name.Value)]
+	if !ok {
+		return nil, p.errorf(name, "unknown function '%s'", name.Value)
+	}
+
+	p.next() // (
+	call := &FunctionCall{Name: name.Value, Position: name.Offset}
+	if !p.isNext(RightParenToken) {
+		for {
+			argument, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			call.Arguments = append(call.Arguments, argument)
+			if !p.isNext(CommaToken) {
+				break
+			}
+			p.next()
+		}
+	}
+	if err := p.expect(RightParenToken); err != nil {
+		return nil, err
+	}
+
+	if count := len(call.Arguments); count < arity[0] || arity[1] >= 0 && count > arity[1] {
+		return nil, p.errorf(name, "invalid number of arguments for function '%s': %d", name.Value, count)
+	}
+	return call, nil
+}
+
+func (p *parser) peek() *Token {
+	return p.tokens[p.position]
+}
+
+func (p *parser) next() *Token {
+	token := p.tokens[p.position]
+	if token.Type != EOFToken {
+		p.position++
+	}
+	return token
+}
+
+func (p *parser) isNext(types ...TokenType) bool {
+	next := p.peek().Type
+	for _, tokenType := range types {
+		if next == tokenType {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(tokenType TokenType) error {
+	if token := p.next(); token.Type != tokenType {
+		return p.errorf(token, "expected '%s'", tokenType)
+	}
+	return nil
+}
+
+func (p *parser) errorf(token *Token, format string, args ...any) error {
+	return consts.NewErrInvalidExpression(p.expression, fmt.Sprintf(format, args...), token.Offset)
+}
+
+func parseNumber(value string) (float64, error) {
+	sign := 1.0
+	unsigned := value
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		unsigned = value[1:]
+		if value[0] == '-' {
+			sign = -1
+		}
+	}
+
+	lower := strings.ToLower(unsigned)
+	if strings.HasPrefix(lower, "0x") {
+		number, err := strconv.ParseInt(lower[2:], 16, 64)
+		return sign * float64(number), err
+	}
+	if strings.HasPrefix(lower, "0o") {
+		number, err := strconv.ParseInt(lower[2:], 8, 64)
+		return sign * float64(number), err
+	}
+	number, err := strconv.ParseFloat(unsigned, 64)
+	return sign * number, err
+}
@@ -0,0 +1,181 @@
+package expressions
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expression    string
+		expectedNode  Node
+		expectedError error
+	}{
+		{
+			name:       "Property access",
+			expression: "github.event.pull_request.title",
+			expectedNode: &PropertyAccess{
+				Object: &PropertyAccess{
+					Object: &PropertyAccess{
+						Object:   &NamedValue{Name: "github", Position: 0},
+						Property: "event",
+						Position: 6,
+					},
+					Property: "pull_request",
+					Position: 12,
+				},
+				Property: "title",
+				Position: 25,
+			},
+		},
+		{
+			name:       "Operator precedence",
+			expression: "!a || b == 1 && c < 'x'",
+			expectedNode: &BinaryExpression{
+				Operator: OrToken,
+				Left: &UnaryExpression{
+					Operator: NotToken,
+					Operand:  &NamedValue{Name: "a", Position: 1},
+					Position: 0,
+				},
+				Right: &BinaryExpression{
+					Operator: AndToken,
+					Left: &BinaryExpression{
+						Operator: EqualToken,
+						Left:     &NamedValue{Name: "b", Position: 6},
+						Right:    &Literal{Kind: NumberLiteral, Value: 1.0, Raw: "1", Position: 11},
+						Position: 8,
+					},
+					Right: &BinaryExpression{
+						Operator: LessToken,
+						Left:     &NamedValue{Name: "c", Position: 16},
+						Right:    &Literal{Kind: StringLiteral, Value: "x", Raw: "x", Position: 20},
+						Position: 18,
+					},
+					Position: 13,
+				},
+				Position: 3,
+			},
+		},
+		{
+			name:       "Function call with index and filter",
+			expression: "contains(needs['build'].*.result, null)",
+			expectedNode: &FunctionCall{
+				Name: "contains",
+				Arguments: []Node{
+					&PropertyAccess{
+						Object: &FilterAccess{
+							Object: &IndexAccess{
+								Object:   &NamedValue{Name: "needs", Position: 9},
+								Index:    &Literal{Kind: StringLiteral, Value: "build", Raw: "build", Position: 15},
+								Position: 14,
+							},
+							Position: 23,
+						},
+						Property: "result",
+						Position: 25,
+					},
+					&Literal{Kind: NullLiteral, Raw: "null", Position: 34},
+				},
+				Position: 0,
+			},
+		},
+		{
+			name:       "Grouping and hex numbers",
+			expression: "(true) != (0xff)",
+			expectedNode: &BinaryExpression{
+				Operator: NotEqualToken,
+				Left:     &Literal{Kind: BooleanLiteral, Value: true, Raw: "true", Position: 1},
+				Right:    &Literal{Kind: NumberLiteral, Value: 255.0, Raw: "0xff", Position: 11},
+				Position: 7,
+			},
+		},
+		{
+			name:          "Empty expression",
+			expression:    "  ",
+			expectedError: consts.NewErrInvalidExpression("  ", "empty expression", 2),
+		},
+		{
+			name:          "Missing operand",
+			expression:    "a &&",
+			expectedError: consts.NewErrInvalidExpression("a &&", "unexpected end of expression", 4),
+		},
+		{
+			name:          "Unknown function",
+			expression:    "toUpper(a)",
+			expectedError: consts.NewErrInvalidExpression("toUpper(a)", "unknown function 'toUpper'", 0),
+		},
+		{
+			name:          "Invalid number of arguments",
+			expression:    "a && startsWith(a)",
+			expectedError: consts.NewErrInvalidExpression("a && startsWith(a)", "invalid number of arguments for function 'startsWith': 1", 5),
+		},
+		{
+			name:          "Unclosed index",
+			expression:    "a[0",
+			expectedError: consts.NewErrInvalidExpression("a[0", "expected ']'", 3),
+		},
+		{
+			name:          "Trailing tokens",
+			expression:    "a b",
+			expectedError: consts.NewErrInvalidExpression("a b", "unexpected 'b'", 2),
+		},
+		{
+			name:          "Invalid property name",
+			expression:    "a.'b'",
+			expectedError: consts.NewErrInvalidExpression("a.'b'", "expected a property name after '.'", 2),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			node, err := Parse(testCase.expression)
+
+			testutils.DeepCompare(t, testCase.expectedError, err)
+			testutils.DeepCompare(t, testCase.expectedNode, node)
+		})
+	}
+}
+
+func TestParseWithFunctions(t *testing.T) {
+	functions := map[string][2]int{"eq": {2, 2}}
+
+	node, err := ParseWithFunctions("eq(parameters.mode, 'local')", functions)
+	testutils.DeepCompare(t, nil, err)
+	testutils.DeepCompare(t, &FunctionCall{
+		Name: "eq",
+		Arguments: []Node{
+			&PropertyAccess{Object: &NamedValue{Name: "parameters", Position: 3}, Property: "mode", Position: 13},
+			&Literal{Kind: StringLiteral, Value: "local", Raw: "local", Position: 20},
+		},
+		Position: 0,
+	}, node)
+
+	_, err = ParseWithFunctions("contains(a, b)", functions)
+	testutils.DeepCompare(t, consts.NewErrInvalidExpression("contains(a, b)", "unknown function 'contains'", 0), err)
+}
+
+func TestNodeString(t *testing.T) {
+	testCases := []struct {
+		expression     string
+		expectedString string
+	}{
+		{expression: "github.event.issue.title", expectedString: "github.event.issue.title"},
+		{expression: "!contains( github.head_ref,'it''s' )", expectedString: "!contains(github.head_ref, 'it''s')"},
+		{expression: "a == b || c[*] && d['e'] != null", expectedString: "((a == b) || (c.* && (d['e'] != null)))"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.expression, func(t *testing.T) {
+			node, err := Parse(testCase.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.DeepCompare(t, testCase.expectedString, node.String())
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package expressions
+
+import (
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	expressionStart = "${{"
+	expressionEnd   = "}}"
+)
+
+// Expression is a ${{ }} expression embedded in a value of the pipeline
+type Expression struct {
+	Raw           string // The expression without the surrounding ${{ }}
+	Node          Node
+	Offset        int // The offset of the expression in the value
+	FileReference *models.FileReference
+}
+
+// ContextReferences returns the context paths the expression references
+func (e *Expression) ContextReferences() []*ContextReference {
+	return ContextReferences(e.Node)
+}
+
+// ParseTemplate parses all the ${{ }} expressions of a value, such as a run script or an input.
+// Every syntax error is a *consts.ErrInvalidExpression. As the layout of the value in its file is unknown, the
+// expressions and the errors are located at the value's file reference. Expressions with syntax errors are not returned.
+func ParseTemplate(value string, fileReference *models.FileReference) ([]*Expression, []error) {
+	return parseTemplate(value, referenceLocator(fileReference))
+}
+
+// ParseScalarTemplate parses all the ${{ }} expressions of a YAML scalar, like ParseTemplate.
+// The expressions and the syntax errors are located in source, the content of the scalar's file, by the scalar's style.
+func ParseScalarTemplate(node *yaml.Node, source []byte) ([]*Expression, []error) {
+	return parseTemplate(node.Value, scalarLocator(node, source))
+}
+
+// ParseCondition parses an if condition, which is an expression whether or not it is wrapped with ${{ }}.
+// A condition that embeds expressions within other text is parsed as a template.
+func ParseCondition(condition string, fileReference *models.FileReference) ([]*Expression, []error) {
+	return parseCondition(condition, referenceLocator(fileReference))
+}
+
+// ParseScalarCondition parses an if condition that is a YAML scalar, locating it in source like ParseScalarTemplate
+func ParseScalarCondition(node *yaml.Node, source []byte) ([]*Expression, []error) {
+	return parseCondition(node.Value, scalarLocator(node, source))
+}
+
+// locator translates a range of offsets in a value to a file reference
+type locator func(start, end int) *models.FileReference
+
+func parseTemplate(value string, locate locator) ([]*Expression, []error) {
+	var expressions []*Expression
+	var errs []error
+	for offset := 0; ; {
+		start := strings.Index(value[offset:], expressionStart)
+		if start < 0 {
+			break
+		}
+		start += offset
+
+		end := FindExpressionEnd(value, start+len(expressionStart))
+		if end < 0 {
+			err := consts.NewErrInvalidExpression(value[start:], "missing closing '}}'", 0)
+			errs = append(errs, locateError(err, start, locate))
+			break
+		}
+
+		rawStart := start + len(expressionStart)
+		raw := value[rawStart:end]
+		node, err := Parse(raw)
+		if err != nil {
+			errs = append(errs, locateError(err, rawStart, locate))
+		} else {
+			expressions = append(expressions, &Expression{
+				Raw:           strings.TrimSpace(raw),
+				Node:          node,
+				Offset:        start,
+				FileReference: locate(start, end+len(expressionEnd)),
+			})
+		}
+		offset = end + len(expressionEnd)
+	}
+	return expressions, errs
+}
+
+func parseCondition(condition string, locate locator) ([]*Expression, []error) {
+	trimmed := strings.TrimSpace(condition)
+	if strings.Contains(trimmed, expressionStart) {
+		return parseTemplate(condition, locate)
+	}
+
+	node, err := Parse(condition)
+	if err != nil {
+		return nil, []error{locateError(err, 0, locate)}
+	}
+	return []*Expression{{
+		Raw:           trimmed,
+		Node:          node,
+		FileReference: locate(0, len(condition)),
+	}}, nil
+}
+
+// FindExpressionEnd returns the offset of the }} that closes an expression, skipping over string literals
+func FindExpressionEnd(value string, offset int) int {
+	inString := false
+	for ; offset < len(value); offset++ {
+		switch {
+		case value[offset] == '\'':
+			inString = !inString // an escaped quote toggles the state twice
+		case !inString && strings.HasPrefix(value[offset:], expressionEnd):
+			return offset
+		}
+	}
+	return -1
+}
+
+// locateError sets the file reference of an expression error, given the offset of the expression in the value
+func locateError(err error, expressionOffset int, locate locator) error {
+	if expressionError, ok := err.(*consts.ErrInvalidExpression); ok {
+		offset := expressionOffset + expressionError.Offset
+		expressionError.FileReference = locate(offset, offset)
+	}
+	return err
+}
+
+// referenceLocator locates every range of a value at the value's file reference
+func referenceLocator(fileReference *models.FileReference) locator {
+	return func(start, end int) *models.FileReference {
+		return fileReference
+	}
+}
+
+// scalarLocator locates the ranges of a scalar's value in the scalar's source
+func scalarLocator(node *yaml.Node, source []byte) locator {
+	positions := getScalarPositions(node, source)
+	return func(start, end int) *models.FileReference {
+		return &models.FileReference{
+			StartRef: positions[start],
+			EndRef:   positions[end],
+		}
+	}
+}
+
+// getScalarPositions returns the position in source of every offset of a scalar's value, and of the value's end.
+// The value is matched against the text of the scalar in source, skipping over what the YAML parser strips from it -
+// the quotes, escape sequences, the indentation of block scalars and the line breaks that are folded into spaces.
+func getScalarPositions(node *yaml.Node, source []byte) []*models.FileLocation {
+	lines := strings.Split(string(source), "\n")
+	line, column := node.Line-1, node.Column-1
+	switch node.Style {
+	case yaml.DoubleQuotedStyle, yaml.SingleQuotedStyle:
+		column++ // the opening quote
+	case yaml.LiteralStyle, yaml.FoldedStyle:
+		line, column = line+1, 0 // the content starts on the line after the block indicator
+	}
+
+	value := node.Value
+	positions := make([]*models.FileLocation, len(value)+1)
+	for offset := 0; offset < len(value); {
+		if line >= len(lines) {
+			positions[offset] = &models.FileLocation{Line: line + 1, Column: column + 1}
+			offset++
+			continue
+		}
+
+		if column >= len(lines[line]) { // the line break
+			if value[offset] == '\n' || value[offset] == ' ' {
+				positions[offset] = &models.FileLocation{Line: line + 1, Column: column + 1}
+				offset++
+			}
+			line, column = line+1, 0
+			continue
+		}
+
+		char := lines[line][column]
+		if node.Style == yaml.DoubleQuotedStyle && char == '\\' {
+			if column == len(lines[line])-1 { // an escaped line break, which is removed from the value
+				line, column = line+1, 0
+				continue
+			}
+			positions[offset] = &models.FileLocation{Line: line + 1, Column: column + 1}
+			offset++
+			column += getEscapeLength(lines[line][column:])
+			continue
+		}
+		if char == value[offset] {
+			positions[offset] = &models.FileLocation{Line: line + 1, Column: column + 1}
+			offset++
+		}
+		column++
+	}
+	positions[len(value)] = &models.FileLocation{Line: line + 1, Column: column + 1}
+	return positions
+}
+
+// getEscapeLength returns the length of the escape sequence at the start of a line of a double quoted scalar
+func getEscapeLength(line string) int {
+	length := 2
+	switch line[1] {
+	case 'x':
+		length = 4
+	case 'u':
+		length = 6
+	case 'U':
+		length = 10
+	}
+	if length > len(line) {
+		return len(line)
+	}
+	return length
+}
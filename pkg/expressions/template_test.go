@@ -0,0 +1,243 @@
+package expressions
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type expectedExpression struct {
+	Raw           string
+	Offset        int
+	FileReference *models.FileReference
+}
+
+func TestParseTemplate(t *testing.T) {
+	fileReference := testutils.CreateFileReference(10, 14, 14, 14)
+
+	testCases := []struct {
+		name                string
+		value               string
+		expectedExpressions []expectedExpression
+		expectedErrors      int
+	}{
+		{
+			name:  "Value without expressions",
+			value: "echo hello",
+		},
+		{
+			name:  "Single line value",
+			value: "echo ${{ github.head_ref }} '${{ format('}}{0}', 'a') }}'",
+			expectedExpressions: []expectedExpression{
+				{Raw: "github.head_ref", Offset: 5, FileReference: fileReference},
+				{Raw: "format('}}{0}', 'a')", Offset: 29, FileReference: fileReference},
+			},
+		},
+		{
+			name:  "Block value with syntax errors",
+			value: "echo 1\necho ${{ github.sha == }}\necho ${{ github.ref }}\necho ${{ github.actor\n",
+			expectedExpressions: []expectedExpression{
+				{Raw: "github.ref", Offset: 38, FileReference: fileReference},
+			},
+			expectedErrors: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			expressions, errs := ParseTemplate(testCase.value, fileReference)
+
+			testutils.DeepCompare(t, testCase.expectedExpressions, toExpected(expressions))
+			assert.Len(t, errs, testCase.expectedErrors)
+			for _, err := range errs {
+				testutils.DeepCompare(t, fileReference, err.(*consts.ErrInvalidExpression).FileReference)
+			}
+		})
+	}
+}
+
+// scalarsSource has expressions in scalars of every style, to check their locations against their real positions
+const scalarsSource = `jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: |
+          echo 1
+          echo ${{ github.sha == }}
+      - run: |-
+          echo ${{ github.ref }}
+          echo ${{ github.actor ! }}
+      - run: >
+          echo ${{ github.ref }}
+          ${{ github.sha == }}
+      - run: "echo ${{ github.head_ref }} ${{ github.sha == }}"
+      - run: 'echo ''${{ github.ref }}'' ${{ github.sha == }}'
+      - if: github.ref ==
+        run: echo
+`
+
+func TestParseScalarTemplate(t *testing.T) {
+	scalars := findScalars(t, scalarsSource, "run")
+
+	testCases := []struct {
+		name                   string
+		node                   *yaml.Node
+		expectedExpressions    []expectedExpression
+		expectedErrorLocations []*models.FileReference
+	}{
+		{
+			name: "Literal block scalar",
+			node: scalars[0],
+			expectedErrorLocations: []*models.FileReference{
+				testutils.CreateFileReference(7, 34, 7, 34),
+			},
+		},
+		{
+			name: "Stripped literal block scalar",
+			node: scalars[1],
+			expectedExpressions: []expectedExpression{
+				{Raw: "github.ref", Offset: 5, FileReference: testutils.CreateFileReference(9, 16, 9, 33)},
+			},
+			expectedErrorLocations: []*models.FileReference{
+				testutils.CreateFileReference(10, 33, 10, 33),
+			},
+		},
+		{
+			name: "Folded block scalar",
+			node: scalars[2],
+			expectedExpressions: []expectedExpression{
+				{Raw: "github.ref", Offset: 5, FileReference: testutils.CreateFileReference(12, 16, 12, 33)},
+			},
+			expectedErrorLocations: []*models.FileReference{
+				testutils.CreateFileReference(13, 29, 13, 29),
+			},
+		},
+		{
+			name: "Double quoted scalar",
+			node: scalars[3],
+			expectedExpressions: []expectedExpression{
+				{Raw: "github.head_ref", Offset: 5, FileReference: testutils.CreateFileReference(14, 20, 14, 42)},
+			},
+			expectedErrorLocations: []*models.FileReference{
+				testutils.CreateFileReference(14, 61, 14, 61),
+			},
+		},
+		{
+			name: "Single quoted scalar",
+			node: scalars[4],
+			expectedExpressions: []expectedExpression{
+				{Raw: "github.ref", Offset: 6, FileReference: testutils.CreateFileReference(15, 22, 15, 39)},
+			},
+			expectedErrorLocations: []*models.FileReference{
+				testutils.CreateFileReference(15, 60, 15, 60),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			expressions, errs := ParseScalarTemplate(testCase.node, []byte(scalarsSource))
+
+			testutils.DeepCompare(t, testCase.expectedExpressions, toExpected(expressions))
+
+			var errorLocations []*models.FileReference
+			for _, err := range errs {
+				expressionError, ok := err.(*consts.ErrInvalidExpression)
+				if !ok {
+					t.Fatalf("unexpected error type: %T", err)
+				}
+				errorLocations = append(errorLocations, expressionError.FileReference)
+			}
+			testutils.DeepCompare(t, testCase.expectedErrorLocations, errorLocations)
+		})
+	}
+}
+
+func TestParseScalarCondition(t *testing.T) {
+	condition := findScalars(t, scalarsSource, "if")[0]
+
+	expressions, errs := ParseScalarCondition(condition, []byte(scalarsSource))
+
+	assert.Empty(t, expressions)
+	if assert.Len(t, errs, 1) {
+		testutils.DeepCompare(t, testutils.CreateFileReference(16, 26, 16, 26), errs[0].(*consts.ErrInvalidExpression).FileReference)
+	}
+}
+
+// findScalars returns the values of a key in a YAML document, in their order in the document
+func findScalars(t *testing.T, source string, key string) []*yaml.Node {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(source), &root); err != nil {
+		t.Fatal(err)
+	}
+
+	var scalars []*yaml.Node
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		for i, child := range node.Content {
+			if node.Kind == yaml.MappingNode && i%2 == 0 && child.Value == key {
+				scalars = append(scalars, node.Content[i+1])
+			}
+			walk(child)
+		}
+	}
+	walk(&root)
+	return scalars
+}
+
+func toExpected(expressions []*Expression) []expectedExpression {
+	var expected []expectedExpression
+	for _, expression := range expressions {
+		expected = append(expected, expectedExpression{Raw: expression.Raw, Offset: expression.Offset, FileReference: expression.FileReference})
+	}
+	return expected
+}
+
+func TestParseCondition(t *testing.T) {
+	fileReference := testutils.CreateFileReference(5, 9, 5, 40)
+
+	testCases := []struct {
+		name             string
+		condition        string
+		expectedRaw      []string
+		expectedErrorLoc *models.FileReference
+	}{
+		{
+			name:        "Bare condition",
+			condition:   "github.event_name == 'push'",
+			expectedRaw: []string{"github.event_name == 'push'"},
+		},
+		{
+			name:        "Wrapped condition",
+			condition:   "${{ success() }}",
+			expectedRaw: []string{"success()"},
+		},
+		{
+			name:             "Invalid condition",
+			condition:        "github.ref ==",
+			expectedErrorLoc: fileReference,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			expressions, errs := ParseCondition(testCase.condition, fileReference)
+
+			var raw []string
+			for _, expression := range expressions {
+				raw = append(raw, expression.Raw)
+			}
+			testutils.DeepCompare(t, testCase.expectedRaw, raw)
+
+			var errorLocation *models.FileReference
+			if len(errs) > 0 {
+				errorLocation = errs[0].(*consts.ErrInvalidExpression).FileReference
+			}
+			testutils.DeepCompare(t, testCase.expectedErrorLoc, errorLocation)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package expressions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+)
+
+type TokenType string
+
+const (
+	IdentifierToken   TokenType = "identifier"
+	StringToken       TokenType = "string"
+	NumberToken       TokenType = "number"
+	BooleanToken      TokenType = "boolean"
+	NullToken         TokenType = "null"
+	DotToken          TokenType = "."
+	CommaToken        TokenType = ","
+	StarToken         TokenType = "*"
+	LeftParenToken    TokenType = "("
+	RightParenToken   TokenType = ")"
+	LeftBracketToken  TokenType = "["
+	RightBracketToken TokenType = "]"
+	NotToken          TokenType = "!"
+	AndToken          TokenType = "&&"
+	OrToken           TokenType = "||"
+	EqualToken        TokenType = "=="
+	NotEqualToken     TokenType = "!="
+	LessToken         TokenType = "<"
+	LessEqualToken    TokenType = "<="
+	GreaterToken      TokenType = ">"
+	GreaterEqualToken TokenType = ">="
+	EOFToken          TokenType = "eof"
+)
+
+// operators are ordered so that two-character operators are matched before their one-character prefixes
+var operators = []TokenType{
+	AndToken, OrToken, EqualToken, NotEqualToken, LessEqualToken, GreaterEqualToken,
+	LessToken, GreaterToken, NotToken, DotToken, CommaToken, StarToken,
+	LeftParenToken, RightParenToken, LeftBracketToken, RightBracketToken,
+}
+
+type Token struct {
+	Type   TokenType
+	Value  string
+	Offset int // The offset of the token in the expression
+}
+
+// Tokenize splits a GitHub Actions expression (without the surrounding ${{ }}) into tokens.
+// The last token is always an EOF token.
+func Tokenize(expression string) ([]*Token, error) {
+	var tokens []*Token
+	for offset := 0; offset < len(expression); {
+		char := expression[offset]
+		switch {
+		case isWhitespace(char):
+			offset++
+		case char == '\'':
+			value, end, err := readString(expression, offset)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, &Token{Type: StringToken, Value: value, Offset: offset})
+			offset = end
+		case isDigit(char) || (char == '-' || char == '+' || char == '.') && offset+1 < len(expression) && isDigit(expression[offset+1]) && !isAfterValue(tokens):
+			end := readWhile(expression, offset+1, isNumberChar)
+			tokens = append(tokens, &Token{Type: NumberToken, Value: expression[offset:end], Offset: offset})
+			offset = end
+		case isIdentifierStart(char):
+			end := readWhile(expression, offset, isIdentifierChar)
+			value := expression[offset:end]
+			tokens = append(tokens, &Token{Type: getIdentifierTokenType(value, tokens), Value: value, Offset: offset})
+			offset = end
+		default:
+			operator := readOperator(expression, offset)
+			if operator == "" {
+				return nil, consts.NewErrInvalidExpression(expression, fmt.Sprintf("unexpected character '%c'", char), offset)
+			}
+			tokens = append(tokens, &Token{Type: operator, Value: string(operator), Offset: offset})
+			offset += len(operator)
+		}
+	}
+	return append(tokens, &Token{Type: EOFToken, Offset: len(expression)}), nil
+}
+
+// readString reads a single quoted string, where a quote is escaped by doubling it
+func readString(expression string, start int) (string, int, error) {
+	var value strings.Builder
+	for offset := start + 1; offset < len(expression); offset++ {
+		if expression[offset] != '\'' {
+			value.WriteByte(expression[offset])
+			continue
+		}
+		if offset+1 < len(expression) && expression[offset+1] == '\'' {
+			value.WriteByte('\'')
+			offset++
+			continue
+		}
+		return value.String(), offset + 1, nil
+	}
+	return "", 0, consts.NewErrInvalidExpression(expression, "unterminated string", start)
+}
+
+func readOperator(expression string, offset int) TokenType {
+	for _, operator := range operators {
+		if strings.HasPrefix(expression[offset:], string(operator)) {
+			return operator
+		}
+	}
+	return ""
+}
+
+func readWhile(expression string, offset int, predicate func(byte) bool) int {
+	for offset < len(expression) && predicate(expression[offset]) {
+		offset++
+	}
+	return offset
+}
+
+// getIdentifierTokenType returns the type of a word - keywords are literals, unless they are the name of a property
+func getIdentifierTokenType(value string, previous []*Token) TokenType {
+	if len(previous) > 0 && previous[len(previous)-1].Type == DotToken {
+		return IdentifierToken
+	}
+
+	switch value {
+	case "true", "false":
+		return BooleanToken
+	case "null":
+		return NullToken
+	}
+	return IdentifierToken
+}
+
+// isAfterValue returns whether the previous token ends a value, in which case a sign or a dot is not the start of a number
+func isAfterValue(tokens []*Token) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+
+	switch tokens[len(tokens)-1].Type {
+	case IdentifierToken, StringToken, NumberToken, BooleanToken, NullToken, RightParenToken, RightBracketToken, StarToken:
+		return true
+	}
+	return false
+}
+
+func isWhitespace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\n' || char == '\r'
+}
+
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
+
+func isNumberChar(char byte) bool {
+	return isDigit(char) || isIdentifierStart(char) || char == '.' || char == '-' || char == '+'
+}
+
+func isIdentifierStart(char byte) bool {
+	return char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char == '_'
+}
+
+func isIdentifierChar(char byte) bool {
+	return isIdentifierStart(char) || isDigit(char) || char == '-'
+}
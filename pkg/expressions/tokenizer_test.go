@@ -0,0 +1,91 @@
+package expressions
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+)
+
+func TestTokenize(t *testing.T) {
+	testCases := []struct {
+		name           string
+		expression     string
+		expectedTokens []*Token
+		expectedError  error
+	}{
+		{
+			name:           "Empty expression",
+			expression:     "",
+			expectedTokens: []*Token{{Type: EOFToken}},
+		},
+		{
+			name:       "Context access and comparison",
+			expression: "github.event_name == 'push'",
+			expectedTokens: []*Token{
+				{Type: IdentifierToken, Value: "github", Offset: 0},
+				{Type: DotToken, Value: ".", Offset: 6},
+				{Type: IdentifierToken, Value: "event_name", Offset: 7},
+				{Type: EqualToken, Value: "==", Offset: 18},
+				{Type: StringToken, Value: "push", Offset: 21},
+				{Type: EOFToken, Offset: 27},
+			},
+		},
+		{
+			name:       "Literals and operators",
+			expression: "!true || null != -1.5 && 0xff >= 2e3",
+			expectedTokens: []*Token{
+				{Type: NotToken, Value: "!", Offset: 0},
+				{Type: BooleanToken, Value: "true", Offset: 1},
+				{Type: OrToken, Value: "||", Offset: 6},
+				{Type: NullToken, Value: "null", Offset: 9},
+				{Type: NotEqualToken, Value: "!=", Offset: 14},
+				{Type: NumberToken, Value: "-1.5", Offset: 17},
+				{Type: AndToken, Value: "&&", Offset: 22},
+				{Type: NumberToken, Value: "0xff", Offset: 25},
+				{Type: GreaterEqualToken, Value: ">=", Offset: 30},
+				{Type: NumberToken, Value: "2e3", Offset: 33},
+				{Type: EOFToken, Offset: 36},
+			},
+		},
+		{
+			name:       "Escaped quotes, filters and keyword properties",
+			expression: "format('it''s', a.*.null[0])",
+			expectedTokens: []*Token{
+				{Type: IdentifierToken, Value: "format", Offset: 0},
+				{Type: LeftParenToken, Value: "(", Offset: 6},
+				{Type: StringToken, Value: "it's", Offset: 7},
+				{Type: CommaToken, Value: ",", Offset: 14},
+				{Type: IdentifierToken, Value: "a", Offset: 16},
+				{Type: DotToken, Value: ".", Offset: 17},
+				{Type: StarToken, Value: "*", Offset: 18},
+				{Type: DotToken, Value: ".", Offset: 19},
+				{Type: IdentifierToken, Value: "null", Offset: 20},
+				{Type: LeftBracketToken, Value: "[", Offset: 24},
+				{Type: NumberToken, Value: "0", Offset: 25},
+				{Type: RightBracketToken, Value: "]", Offset: 26},
+				{Type: RightParenToken, Value: ")", Offset: 27},
+				{Type: EOFToken, Offset: 28},
+			},
+		},
+		{
+			name:          "Unterminated string",
+			expression:    "a == 'b",
+			expectedError: consts.NewErrInvalidExpression("a == 'b", "unterminated string", 5),
+		},
+		{
+			name:          "Unexpected character",
+			expression:    "a = b",
+			expectedError: consts.NewErrInvalidExpression("a = b", "unexpected character '='", 2),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tokens, err := Tokenize(testCase.expression)
+
+			testutils.DeepCompare(t, testCase.expectedError, err)
+			testutils.DeepCompare(t, testCase.expectedTokens, tokens)
+		})
+	}
+}
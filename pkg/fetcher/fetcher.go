@@ -0,0 +1,68 @@
+package fetcher
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+// Fetcher fetches the content of an imported pipeline file
+type Fetcher interface {
+	Fetch(source *models.ImportSource, version *string) ([]byte, error)
+}
+
+// SourceFetcher fetches local sources and remote sources with different fetchers
+type SourceFetcher struct {
+	Local  Fetcher
+	Remote Fetcher
+}
+
+func (s *SourceFetcher) Fetch(source *models.ImportSource, version *string) ([]byte, error) {
+	switch {
+	case source == nil:
+		return nil, nil
+	case source.Type == models.SourceTypeLocal && s.Local != nil:
+		return s.Local.Fetch(source, version)
+	case source.Type == models.SourceTypeRemote && s.Remote != nil:
+		return s.Remote.Fetch(source, version)
+	}
+	return nil, nil
+}
+
+// NewDefaultFetcher returns a fetcher that reads local sources relative to the working directory and remote sources from the SCM api
+func NewDefaultFetcher(credentials *models.Credentials, organization, baseUrl *string) Fetcher {
+	return &SourceFetcher{
+		Local: &LocalFetcher{},
+		Remote: &HTTPFetcher{
+			Credentials:  credentials,
+			Organization: organization,
+			BaseUrl:      baseUrl,
+		},
+	}
+}
+
+// Key returns the key of a source, e.g. `org/repo/path@version` for remote sources and `path` for local sources
+func Key(source *models.ImportSource, version *string) string {
+	if source == nil {
+		return ""
+	}
+
+	var parts []string
+	if source.Type == models.SourceTypeRemote {
+		for _, part := range []*string{source.Organization, source.Repository} {
+			if part != nil && *part != "" {
+				parts = append(parts, *part)
+			}
+		}
+	}
+	if source.Path != nil {
+		parts = append(parts, *source.Path)
+	}
+
+	key := strings.Join(parts, "/")
+	if source.Type == models.SourceTypeRemote && version != nil && *version != "" {
+		key = fmt.Sprintf("%s@%s", key, *version)
+	}
+	return key
+}
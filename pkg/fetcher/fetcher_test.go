@@ -0,0 +1,118 @@
+package fetcher
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  *models.ImportSource
+		version *string
+		want    string
+	}{
+		{
+			name: "nil source",
+			want: "",
+		},
+		{
+			name: "local source",
+			source: &models.ImportSource{
+				Path: utils.GetPtr("templates/build.yml"),
+				Type: models.SourceTypeLocal,
+			},
+			version: utils.GetPtr("v1"),
+			want:    "templates/build.yml",
+		},
+		{
+			name: "remote source with version",
+			source: &models.ImportSource{
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("templates/build.yml"),
+				Type:         models.SourceTypeRemote,
+			},
+			version: utils.GetPtr("v1"),
+			want:    "org/repo/templates/build.yml@v1",
+		},
+		{
+			name: "remote source without version",
+			source: &models.ImportSource{
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("templates/build.yml"),
+				Type:         models.SourceTypeRemote,
+			},
+			want: "org/repo/templates/build.yml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Key(tt.source, tt.version))
+		})
+	}
+}
+
+func TestSourceFetcher(t *testing.T) {
+	fetcher := &SourceFetcher{
+		Local:  MemoryFetcher{"build.yml": []byte("local")},
+		Remote: MemoryFetcher{"org/repo/build.yml@v1": []byte("remote")},
+	}
+
+	tests := []struct {
+		name    string
+		source  *models.ImportSource
+		version *string
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "nil source",
+		},
+		{
+			name: "local source",
+			source: &models.ImportSource{
+				Path: utils.GetPtr("build.yml"),
+				Type: models.SourceTypeLocal,
+			},
+			want: []byte("local"),
+		},
+		{
+			name: "remote source",
+			source: &models.ImportSource{
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("build.yml"),
+				Type:         models.SourceTypeRemote,
+			},
+			version: utils.GetPtr("v1"),
+			want:    []byte("remote"),
+		},
+		{
+			name: "remote source - file not found",
+			source: &models.ImportSource{
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("build.yml"),
+				Type:         models.SourceTypeRemote,
+			},
+			version: utils.GetPtr("v2"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fetcher.Fetch(tt.source, tt.version)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
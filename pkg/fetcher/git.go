@@ -0,0 +1,66 @@
+package fetcher
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+const defaultRevision = "HEAD"
+
+// GitFetcher reads files from git checkouts at the requested version, without accessing the network.
+// Local sources are read from the checkout at Root, remote sources from the checkout at <Root>/<organization>/<repository>.
+// A version that is not a local branch, tag or commit is looked up in the origin remote branches.
+type GitFetcher struct {
+	Root string
+}
+
+func (g *GitFetcher) Fetch(source *models.ImportSource, version *string) ([]byte, error) {
+	if source == nil || source.Path == nil || *source.Path == "" {
+		return nil, nil
+	}
+
+	directory := g.Root
+	if source.Type == models.SourceTypeRemote {
+		if source.Organization == nil || source.Repository == nil {
+			return nil, consts.NewErrFetchImport(Key(source, version), "remote sources require an organization and a repository")
+		}
+		var ok bool
+		if directory, ok = utils.JoinUnderRoot(g.Root, *source.Organization, *source.Repository); !ok {
+			return nil, consts.NewErrFetchImport(Key(source, version), "the repository is outside of the root directory")
+		}
+	}
+
+	revision := defaultRevision
+	if version != nil && *version != "" {
+		revision = *version
+	}
+	// the version is read from the imported pipeline, so it must not be parsed as an option of git
+	if strings.HasPrefix(revision, "-") {
+		return nil, consts.NewErrFetchImport(Key(source, version), "invalid version")
+	}
+	filePath := path.Clean("/" + *source.Path)[1:]
+
+	buf, err := gitShow(directory, revision, filePath)
+	if err != nil && revision != defaultRevision {
+		buf, err = gitShow(directory, "origin/"+revision, filePath)
+	}
+	if err != nil {
+		return nil, consts.NewErrFetchImport(Key(source, version), err.Error())
+	}
+	return buf, nil
+}
+
+func gitShow(directory, revision, path string) ([]byte, error) {
+	cmd := exec.Command("git", "-C", directory, "show", "--end-of-options", fmt.Sprintf("%s:%s", revision, path))
+	buf, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return nil, fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return buf, err
+}
@@ -0,0 +1,140 @@
+package fetcher
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func createGitRepository(t *testing.T, directory string, files map[string]string, tag string) {
+	t.Helper()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", directory, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		t.Fatal(err)
+	}
+	run("init", "-q")
+	for name, content := range files {
+		path := filepath.Join(directory, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run("add", "-A")
+	run("commit", "-q", "-m", "init")
+	if tag != "" {
+		run("tag", tag)
+	}
+}
+
+func TestGitFetcher(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	createGitRepository(t, root, map[string]string{"templates/build.yml": "local"}, "")
+	createGitRepository(t, filepath.Join(root, "org", "repo"), map[string]string{"build.yml": "v1"}, "v1")
+	if err := os.WriteFile(filepath.Join(root, "org", "repo", "build.yml"), []byte("uncommitted"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		source  *models.ImportSource
+		version *string
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "local source at HEAD",
+			source: &models.ImportSource{
+				Path: utils.GetPtr("/templates/build.yml"),
+				Type: models.SourceTypeLocal,
+			},
+			want: []byte("local"),
+		},
+		{
+			name: "remote source at a tag",
+			source: &models.ImportSource{
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("build.yml"),
+				Type:         models.SourceTypeRemote,
+			},
+			version: utils.GetPtr("v1"),
+			want:    []byte("v1"),
+		},
+		{
+			name: "unknown version",
+			source: &models.ImportSource{
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("build.yml"),
+				Type:         models.SourceTypeRemote,
+			},
+			version: utils.GetPtr("v2"),
+			wantErr: true,
+		},
+		{
+			name: "version that is a git option",
+			source: &models.ImportSource{
+				Path: utils.GetPtr("templates/build.yml"),
+				Type: models.SourceTypeLocal,
+			},
+			version: utils.GetPtr("--output=" + filepath.Join(root, "injected")),
+			wantErr: true,
+		},
+		{
+			name: "path outside of the repository",
+			source: &models.ImportSource{
+				Path: utils.GetPtr("../../templates/build.yml"),
+				Type: models.SourceTypeLocal,
+			},
+			want: []byte("local"),
+		},
+		{
+			name: "repository outside of the root",
+			source: &models.ImportSource{
+				Organization: utils.GetPtr(".."),
+				Repository:   utils.GetPtr(".."),
+				Path:         utils.GetPtr("build.yml"),
+				Type:         models.SourceTypeRemote,
+			},
+			wantErr: true,
+		},
+		{
+			name: "remote source without repository",
+			source: &models.ImportSource{
+				Path: utils.GetPtr("build.yml"),
+				Type: models.SourceTypeRemote,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&GitFetcher{Root: root}).Fetch(tt.source, tt.version)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	assert.NoFileExists(t, filepath.Join(root, "injected"))
+}
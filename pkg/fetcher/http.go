@@ -0,0 +1,145 @@
+package fetcher
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+	"github.com/imroc/req/v3"
+)
+
+var (
+	GITHUB_BASE_URL     = "https://raw.githubusercontent.com"
+	GITLAB_BASE_URL     = "https://gitlab.com"
+	AZURE_SAAS_BASE_URL = "https://dev.azure.com/"
+	ITEMS_API           = "{PROJECT}/_apis/git/repositories/{REPOSITORY}/items?path={PATH}"
+	VERSION_QUERY       = "&versionDescriptor.versionType=tag&version="
+)
+
+// HTTPFetcher fetches remote sources from the api of their SCM.
+// Azure sources are expected to hold the project of the repository in their organization.
+type HTTPFetcher struct {
+	Credentials  *models.Credentials
+	Organization *string
+	BaseUrl      *string
+}
+
+func (h *HTTPFetcher) Fetch(source *models.ImportSource, version *string) ([]byte, error) {
+	if source == nil {
+		return nil, nil
+	}
+
+	if source.Type != models.SourceTypeRemote {
+		return nil, consts.NewErrFetchImport(Key(source, version), "only remote sources can be fetched over http")
+	}
+
+	switch source.SCM {
+	case consts.GitHubPlatform:
+		return h.fetchGitHubFile(source, version)
+	case consts.GitLabPlatform:
+		return h.fetchGitLabFile(source, version)
+	case consts.AzurePlatform:
+		return h.fetchAzureFile(source, version)
+	}
+	return nil, consts.NewErrFetchImport(Key(source, version), fmt.Sprintf("unsupported scm: %s", source.SCM))
+}
+
+func (h *HTTPFetcher) fetchGitHubFile(source *models.ImportSource, version *string) ([]byte, error) {
+	org, repo, path, ref := getSourceParts(source, version)
+	if org == "" || repo == "" || path == "" {
+		return nil, nil
+	}
+
+	if ref == "" {
+		ref = "main"
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", GITHUB_BASE_URL, org, repo, ref, path)
+	if h.BaseUrl != nil && *h.BaseUrl != "" {
+		url = fmt.Sprintf("%s/raw/%s/%s/%s/%s", *h.BaseUrl, org, repo, ref, path)
+	}
+
+	return get(utils.GetHttpClient(h.Credentials), url, Key(source, version))
+}
+
+func (h *HTTPFetcher) fetchGitLabFile(source *models.ImportSource, version *string) ([]byte, error) {
+	org, repo, path, ref := getSourceParts(source, version)
+	if org == "" || repo == "" || path == "" || ref == "" {
+		return nil, consts.NewErrFetchImport(Key(source, version), "missing required fields for remote import")
+	}
+
+	baseUrl := GITLAB_BASE_URL
+	if h.BaseUrl != nil && *h.BaseUrl != "" {
+		baseUrl = *h.BaseUrl
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/-/raw/%s/%s", baseUrl, org, repo, ref, path)
+	return get(utils.GetHttpClient(h.Credentials), url, Key(source, version))
+}
+
+func (h *HTTPFetcher) fetchAzureFile(source *models.ImportSource, version *string) ([]byte, error) {
+	project, repo, path, ref := getSourceParts(source, version)
+	// templates of other organizations are not supported, so they are skipped when the organization is unknown
+	if project == "" || repo == "" || path == "" || h.Organization == nil || *h.Organization == "" {
+		return nil, nil
+	}
+
+	baseUrl := AZURE_SAAS_BASE_URL
+	if h.BaseUrl != nil && *h.BaseUrl != "" {
+		baseUrl = *h.BaseUrl
+	}
+	url := GenerateAzureRequestUrl(project, repo, path, ref, *h.Organization, baseUrl)
+
+	return get(utils.GetHttpClientWithBasicAuth(h.Credentials), url, Key(source, version))
+}
+
+// GenerateAzureRequestUrl returns the url of a file in the items api of azure repos
+func GenerateAzureRequestUrl(proj, repo, path, version, organization, baseUrl string) string {
+	url := baseUrl
+	if !strings.HasSuffix(url, "/") {
+		url = url + "/"
+	}
+	if !strings.Contains(url, organization) {
+		url = url + organization + "/"
+	}
+	url = url + ITEMS_API
+	url = strings.Replace(url, "{PROJECT}", proj, 1)
+	url = strings.Replace(url, "{REPOSITORY}", repo, 1)
+	url = strings.Replace(url, "{PATH}", path, 1)
+	if version != "" {
+		url = url + VERSION_QUERY + version
+	}
+	return url
+}
+
+func get(client *req.Client, url, key string) ([]byte, error) {
+	resp, err := client.R().Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsErrorState() {
+		return nil, consts.NewErrFetchImport(key, resp.Response.Status)
+	}
+
+	return resp.Bytes(), nil
+}
+
+func getSourceParts(source *models.ImportSource, version *string) (string, string, string, string) {
+	var org, repo, path, ref string
+	if source.Organization != nil {
+		org = *source.Organization
+	}
+	if source.Repository != nil {
+		repo = *source.Repository
+	}
+	if source.Path != nil {
+		path = *source.Path
+	}
+	if version != nil {
+		ref = *version
+	}
+	return org, repo, path, ref
+}
@@ -0,0 +1,170 @@
+package fetcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPFetcher(t *testing.T) {
+	ts := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer ts.Close()
+	GITHUB_BASE_URL = ts.URL
+	GITLAB_BASE_URL = ts.URL
+
+	tests := []struct {
+		name        string
+		credentials *models.Credentials
+		source      *models.ImportSource
+		version     *string
+		want        []byte
+		wantErr     bool
+	}{
+		{
+			name: "github - happy flow",
+			source: &models.ImportSource{
+				SCM:          consts.GitHubPlatform,
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("file"),
+				Type:         models.SourceTypeRemote,
+			},
+			version: utils.GetPtr("version"),
+			want:    []byte("file data"),
+		},
+		{
+			name:        "github - with credentials",
+			credentials: &models.Credentials{Token: "token"},
+			source: &models.ImportSource{
+				SCM:          consts.GitHubPlatform,
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("file"),
+				Type:         models.SourceTypeRemote,
+			},
+			version: utils.GetPtr("version"),
+			want:    []byte("file data"),
+		},
+		{
+			name: "github - file does not exist",
+			source: &models.ImportSource{
+				SCM:          consts.GitHubPlatform,
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("does-not-exist"),
+				Type:         models.SourceTypeRemote,
+			},
+			version: utils.GetPtr("version"),
+			wantErr: true,
+		},
+		{
+			name: "github - empty source",
+			source: &models.ImportSource{
+				SCM:  consts.GitHubPlatform,
+				Type: models.SourceTypeRemote,
+			},
+		},
+		{
+			name: "gitlab - happy flow",
+			source: &models.ImportSource{
+				SCM:          consts.GitLabPlatform,
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("file"),
+				Type:         models.SourceTypeRemote,
+			},
+			version: utils.GetPtr("version"),
+			want:    []byte("file data"),
+		},
+		{
+			name: "gitlab - missing version",
+			source: &models.ImportSource{
+				SCM:          consts.GitLabPlatform,
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("file"),
+				Type:         models.SourceTypeRemote,
+			},
+			wantErr: true,
+		},
+		{
+			name: "local source",
+			source: &models.ImportSource{
+				Path: utils.GetPtr("file"),
+				Type: models.SourceTypeLocal,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported scm",
+			source: &models.ImportSource{
+				SCM:          consts.BitbucketPlatform,
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("file"),
+				Type:         models.SourceTypeRemote,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&HTTPFetcher{Credentials: tt.credentials}).Fetch(tt.source, tt.version)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGenerateAzureRequestUrl(t *testing.T) {
+	type args struct {
+		proj         string
+		repo         string
+		path         string
+		version      string
+		organization string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "happy flow - w/o version",
+			args: args{
+				proj:         "proj",
+				repo:         "repo",
+				path:         "file",
+				version:      "",
+				organization: "azure-org",
+			},
+			want: "https://dev.azure.com/azure-org/proj/_apis/git/repositories/repo/items?path=file",
+		},
+		{
+			name: "happy flow - w/ string",
+			args: args{
+				proj:         "proj",
+				repo:         "repo",
+				path:         "file",
+				version:      "refs/head/3",
+				organization: "azure-org",
+			},
+			want: "https://dev.azure.com/azure-org/proj/_apis/git/repositories/repo/items?path=file&versionDescriptor.versionType=tag&version=refs/head/3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GenerateAzureRequestUrl(tt.args.proj, tt.args.repo, tt.args.path, tt.args.version, tt.args.organization, "https://dev.azure.com/")
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package fetcher
+
+import (
+	"os"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+// LocalFetcher reads files from a local directory.
+// Local sources are read relative to Root, or to the working directory if Root is empty.
+// Remote sources are read from a mirror of the repository at <Root>/<organization>/<repository>, regardless of their version.
+type LocalFetcher struct {
+	Root string
+}
+
+func (l *LocalFetcher) Fetch(source *models.ImportSource, version *string) ([]byte, error) {
+	if source == nil || source.Path == nil || *source.Path == "" {
+		return nil, nil
+	}
+
+	path := *source.Path
+	if source.Type == models.SourceTypeRemote {
+		if l.Root == "" || source.Organization == nil || source.Repository == nil {
+			return nil, consts.NewErrFetchImport(Key(source, version), "remote sources require a root directory, an organization and a repository")
+		}
+		repository, ok := utils.JoinUnderRoot(l.Root, *source.Organization, *source.Repository)
+		if !ok {
+			return nil, consts.NewErrFetchImport(Key(source, version), "the repository is outside of the root directory")
+		}
+		if path, ok = utils.JoinUnderRoot(repository, path); !ok {
+			return nil, consts.NewErrFetchImport(Key(source, version), "the path is outside of the repository")
+		}
+	} else if l.Root != "" {
+		var ok bool
+		if path, ok = utils.JoinUnderRoot(l.Root, path); !ok {
+			return nil, consts.NewErrFetchImport(Key(source, version), "the path is outside of the root directory")
+		}
+	}
+
+	return os.ReadFile(path)
+}
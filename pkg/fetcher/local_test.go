@@ -0,0 +1,115 @@
+package fetcher
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalFetcher(t *testing.T) {
+	tests := []struct {
+		name    string
+		root    string
+		source  *models.ImportSource
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "local source relative to the working directory",
+			source: &models.ImportSource{
+				Path: utils.GetPtr("testdata/org/repo/version/file"),
+				Type: models.SourceTypeLocal,
+			},
+			want: []byte("file data"),
+		},
+		{
+			name: "local source relative to the root",
+			root: "testdata",
+			source: &models.ImportSource{
+				Path: utils.GetPtr("local.yml"),
+				Type: models.SourceTypeLocal,
+			},
+			want: []byte("local data"),
+		},
+		{
+			name: "remote source from a mirror",
+			root: "testdata",
+			source: &models.ImportSource{
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("version/file"),
+				Type:         models.SourceTypeRemote,
+			},
+			want: []byte("file data"),
+		},
+		{
+			name: "remote source without root",
+			source: &models.ImportSource{
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("version/file"),
+				Type:         models.SourceTypeRemote,
+			},
+			wantErr: true,
+		},
+		{
+			name: "local source outside of the root",
+			root: "testdata",
+			source: &models.ImportSource{
+				Path: utils.GetPtr("../local_test.go"),
+				Type: models.SourceTypeLocal,
+			},
+			wantErr: true,
+		},
+		{
+			name: "remote source outside of the root",
+			root: "testdata",
+			source: &models.ImportSource{
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("../.."),
+				Path:         utils.GetPtr("local_test.go"),
+				Type:         models.SourceTypeRemote,
+			},
+			wantErr: true,
+		},
+		{
+			name: "remote path outside of the repository",
+			root: "testdata",
+			source: &models.ImportSource{
+				Organization: utils.GetPtr("org"),
+				Repository:   utils.GetPtr("repo"),
+				Path:         utils.GetPtr("../../local.yml"),
+				Type:         models.SourceTypeRemote,
+			},
+			wantErr: true,
+		},
+		{
+			name: "file does not exist",
+			source: &models.ImportSource{
+				Path: utils.GetPtr("testdata/does-not-exist"),
+				Type: models.SourceTypeLocal,
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty path",
+			source: &models.ImportSource{
+				Path: utils.GetPtr(""),
+				Type: models.SourceTypeLocal,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&LocalFetcher{Root: tt.root}).Fetch(tt.source, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
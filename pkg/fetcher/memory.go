@@ -0,0 +1,18 @@
+package fetcher
+
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+// MemoryFetcher fetches files from a map of file contents, keyed by the Key of their source
+type MemoryFetcher map[string][]byte
+
+func (m MemoryFetcher) Fetch(source *models.ImportSource, version *string) ([]byte, error) {
+	key := Key(source, version)
+	buf, ok := m[key]
+	if !ok {
+		return nil, consts.NewErrFetchImport(key, "file not found")
+	}
+	return buf, nil
+}
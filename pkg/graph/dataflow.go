@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+// DataFlow is an artifact or cache produced by one job and consumed by another
+type DataFlow struct {
+	Producer string              `json:"producer,omitempty"`
+	Consumer string              `json:"consumer,omitempty"`
+	Type     models.ArtifactType `json:"type,omitempty"`
+	Name     *string             `json:"name,omitempty"`
+	Produced *models.Artifact    `json:"produced,omitempty"`
+	Consumed *models.Artifact    `json:"consumed,omitempty"`
+}
+
+// DataFlows returns every artifact and cache that flows from one job to another.
+// A consumed artifact that names its producing job is matched against that job only, other file artifacts
+// are matched against the jobs that run before the consumer, and caches are matched against all the other jobs,
+// as any of them may have saved the cache the consumer restores.
+func (g *Graph) DataFlows() []*DataFlow {
+	var flows []*DataFlow
+	for _, consumerID := range g.sortedNodeIDs() {
+		consumer := g.Nodes[consumerID]
+		ancestors := g.getAncestors(consumerID)
+		seen := map[*models.Artifact]bool{}
+
+		for _, consumed := range consumer.Job.Consumes {
+			if consumed == nil {
+				continue
+			}
+
+			for _, producerID := range g.getCandidateProducers(consumerID, consumed, ancestors) {
+				for _, produced := range g.Nodes[producerID].Job.Produces {
+					if !isArtifactMatch(produced, consumed) || seen[produced] {
+						continue
+					}
+					seen[produced] = true
+
+					flows = append(flows, &DataFlow{
+						Producer: producerID,
+						Consumer: consumerID,
+						Type:     produced.Type,
+						Name:     produced.Name,
+						Produced: produced,
+						Consumed: consumed,
+					})
+				}
+			}
+		}
+	}
+	sortDataFlows(flows)
+	return flows
+}
+
+func (g *Graph) getCandidateProducers(consumerID string, consumed *models.Artifact, ancestors map[string]bool) []string {
+	if consumed.JobID != nil {
+		if _, ok := g.Nodes[*consumed.JobID]; ok && *consumed.JobID != consumerID {
+			return []string{*consumed.JobID}
+		}
+		return nil
+	}
+
+	var producers []string
+	for _, nodeID := range g.sortedNodeIDs() {
+		if nodeID == consumerID {
+			continue
+		}
+		if consumed.Type == models.CacheArtifactType || ancestors[nodeID] {
+			producers = append(producers, nodeID)
+		}
+	}
+	return producers
+}
+
+// getAncestors returns all the jobs a job depends on, directly or transitively
+func (g *Graph) getAncestors(nodeID string) map[string]bool {
+	ancestors := map[string]bool{}
+	queue := []string{nodeID}
+	for len(queue) > 0 {
+		node, ok := g.Nodes[queue[0]]
+		queue = queue[1:]
+		if !ok {
+			continue
+		}
+		for _, dependency := range node.Dependencies {
+			if !ancestors[dependency] {
+				ancestors[dependency] = true
+				queue = append(queue, dependency)
+			}
+		}
+	}
+	delete(ancestors, nodeID)
+	return ancestors
+}
+
+func isArtifactMatch(produced, consumed *models.Artifact) bool {
+	if produced == nil || produced.Type != consumed.Type {
+		return false
+	}
+	if consumed.Name == nil || produced.Name == nil {
+		return true
+	}
+	return *produced.Name == *consumed.Name
+}
+
+// sortDataFlows orders data flows by consumer and then by producer
+func sortDataFlows(flows []*DataFlow) {
+	sort.SliceStable(flows, func(i, j int) bool {
+		if flows[i].Consumer != flows[j].Consumer {
+			return flows[i].Consumer < flows[j].Consumer
+		}
+		return flows[i].Producer < flows[j].Producer
+	})
+}
@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestDataFlows(t *testing.T) {
+	buildArtifact := &models.Artifact{Type: models.FileArtifactType, Name: utils.GetPtr("dist"), Paths: []string{"dist/"}}
+	reportArtifact := &models.Artifact{Type: models.FileArtifactType, Name: utils.GetPtr("junit")}
+	cache := &models.Artifact{Type: models.CacheArtifactType, Name: utils.GetPtr("node")}
+	otherCache := &models.Artifact{Type: models.CacheArtifactType, Name: utils.GetPtr("pip")}
+	allArtifacts := &models.Artifact{Type: models.FileArtifactType}
+	namedArtifact := &models.Artifact{Type: models.FileArtifactType, Name: utils.GetPtr("dist")}
+	jobArtifacts := &models.Artifact{Type: models.FileArtifactType, JobID: utils.GetPtr("build")}
+	missingJobArtifacts := &models.Artifact{Type: models.FileArtifactType, JobID: utils.GetPtr("missing")}
+
+	withArtifacts := func(job *models.Job, produces, consumes []*models.Artifact) *models.Job {
+		job.Produces = produces
+		job.Consumes = consumes
+		return job
+	}
+
+	testCases := []struct {
+		name          string
+		pipeline      *models.Pipeline
+		expectedFlows []*DataFlow
+	}{
+		{
+			name:          "Pipeline without artifacts",
+			pipeline:      &models.Pipeline{Jobs: []*models.Job{createJob("build", 0), createJob("test", 0, "build")}},
+			expectedFlows: nil,
+		},
+		{
+			name: "Unnamed artifacts are consumed from all the previous jobs",
+			pipeline: &models.Pipeline{
+				Jobs: []*models.Job{
+					withArtifacts(createJob("build", 0), []*models.Artifact{buildArtifact}, nil),
+					withArtifacts(createJob("test", 0, "build"), []*models.Artifact{reportArtifact}, []*models.Artifact{allArtifacts}),
+					withArtifacts(createJob("deploy", 0, "test"), nil, []*models.Artifact{allArtifacts}),
+					withArtifacts(createJob("lint", 0), nil, []*models.Artifact{allArtifacts}),
+				},
+			},
+			expectedFlows: []*DataFlow{
+				{Producer: "build", Consumer: "deploy", Type: models.FileArtifactType, Name: utils.GetPtr("dist"), Produced: buildArtifact, Consumed: allArtifacts},
+				{Producer: "test", Consumer: "deploy", Type: models.FileArtifactType, Name: utils.GetPtr("junit"), Produced: reportArtifact, Consumed: allArtifacts},
+				{Producer: "build", Consumer: "test", Type: models.FileArtifactType, Name: utils.GetPtr("dist"), Produced: buildArtifact, Consumed: allArtifacts},
+			},
+		},
+		{
+			name: "Named and job artifacts",
+			pipeline: &models.Pipeline{
+				Jobs: []*models.Job{
+					withArtifacts(createJob("build", 0), []*models.Artifact{buildArtifact, reportArtifact}, nil),
+					withArtifacts(createJob("test", 0, "build"), nil, []*models.Artifact{namedArtifact}),
+					withArtifacts(createJob("deploy", 0), nil, []*models.Artifact{jobArtifacts, missingJobArtifacts}),
+				},
+			},
+			expectedFlows: []*DataFlow{
+				{Producer: "build", Consumer: "deploy", Type: models.FileArtifactType, Name: utils.GetPtr("dist"), Produced: buildArtifact, Consumed: jobArtifacts},
+				{Producer: "build", Consumer: "deploy", Type: models.FileArtifactType, Name: utils.GetPtr("junit"), Produced: reportArtifact, Consumed: jobArtifacts},
+				{Producer: "build", Consumer: "test", Type: models.FileArtifactType, Name: utils.GetPtr("dist"), Produced: buildArtifact, Consumed: namedArtifact},
+			},
+		},
+		{
+			name: "Caches are shared regardless of the job order",
+			pipeline: &models.Pipeline{
+				Jobs: []*models.Job{
+					withArtifacts(createJob("build", 0), []*models.Artifact{cache}, []*models.Artifact{cache}),
+					withArtifacts(createJob("test", 0), []*models.Artifact{otherCache}, []*models.Artifact{cache}),
+				},
+			},
+			expectedFlows: []*DataFlow{
+				{Producer: "build", Consumer: "test", Type: models.CacheArtifactType, Name: utils.GetPtr("node"), Produced: cache, Consumed: cache},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			flows := Build(testCase.pipeline).DataFlows()
+
+			testutils.DeepCompare(t, testCase.expectedFlows, flows)
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+type DiagnosticType string
+
+const (
+	CycleDiagnostic      DiagnosticType = "cycle"
+	MissingJobDiagnostic DiagnosticType = "missing_job"
+)
+
+type Diagnostic struct {
+	Type          DiagnosticType        `json:"type,omitempty"`
+	Message       string                `json:"message,omitempty"`
+	JobIDs        []string              `json:"job_ids,omitempty"`
+	MissingJobID  *string               `json:"missing_job_id,omitempty"`
+	FileReference *models.FileReference `json:"file_reference,omitempty"`
+}
+
+type Node struct {
+	ID           string      `json:"id,omitempty"`
+	Job          *models.Job `json:"-"`
+	Dependencies []string    `json:"dependencies,omitempty"`
+	Dependents   []string    `json:"dependents,omitempty"`
+}
+
+// Graph is the execution graph of the jobs of a pipeline, where every edge points from a job to the jobs it depends on
+type Graph struct {
+	Nodes       map[string]*Node `json:"nodes,omitempty"`
+	Diagnostics []*Diagnostic    `json:"diagnostics,omitempty"`
+}
+
+// Build creates the job graph of a pipeline.
+// A job depends on the jobs it explicitly references and on the jobs of the stages its stage depends on.
+// Jobs that ignore the stage order (GitLab needs) depend only on their explicit dependencies, so a job with an empty
+// needs starts immediately. In GitLab, hidden (template) jobs are not part of the graph.
+func Build(pipeline *models.Pipeline) *Graph {
+	g := &Graph{
+		Nodes: map[string]*Node{},
+	}
+	if pipeline == nil {
+		return g
+	}
+
+	for _, job := range pipeline.Jobs {
+		if jobID := getJobID(job); jobID != "" && !isHiddenJob(pipeline.Platform, jobID) {
+			g.Nodes[jobID] = &Node{ID: jobID, Job: job}
+		}
+	}
+
+	stageDependencies := getStageDependencies(pipeline.Stages)
+	for _, nodeID := range g.sortedNodeIDs() {
+		node := g.Nodes[nodeID]
+		dependencies := g.getExplicitDependencies(node)
+		if !node.Job.IgnoresStageOrder {
+			for _, dependency := range stageDependencies[node.ID] {
+				if _, ok := g.Nodes[dependency]; ok {
+					dependencies = append(dependencies, dependency)
+				}
+			}
+		}
+
+		for _, dependency := range dependencies {
+			if !utils.SliceContains(node.Dependencies, dependency) {
+				node.Dependencies = append(node.Dependencies, dependency)
+			}
+		}
+		sort.Strings(node.Dependencies)
+	}
+
+	for _, node := range g.Nodes {
+		for _, dependency := range node.Dependencies {
+			if dependencyNode, ok := g.Nodes[dependency]; ok {
+				dependencyNode.Dependents = append(dependencyNode.Dependents, node.ID)
+			}
+		}
+	}
+	for _, node := range g.Nodes {
+		sort.Strings(node.Dependents)
+	}
+
+	g.detectCycles()
+	return g
+}
+
+func (g *Graph) getExplicitDependencies(node *Node) []string {
+	var dependencies []string
+	for _, dependency := range node.Job.Dependencies {
+		if dependency == nil || dependency.JobID == nil || dependency.Pipeline != nil || dependency.ArtifactsOnly {
+			continue
+		}
+
+		dependencyID := *dependency.JobID
+		if _, ok := g.Nodes[dependencyID]; !ok {
+			g.Diagnostics = append(g.Diagnostics, &Diagnostic{
+				Type:          MissingJobDiagnostic,
+				Message:       fmt.Sprintf("job %s depends on job %s which does not exist", node.ID, dependencyID),
+				JobIDs:        []string{node.ID},
+				MissingJobID:  utils.GetPtr(dependencyID),
+				FileReference: node.Job.FileReference,
+			})
+		}
+		dependencies = append(dependencies, dependencyID)
+	}
+	return dependencies
+}
+
+// getStageDependencies maps every job to the jobs of the stages its stage depends on.
+// Stages without jobs are skipped over, so their dependencies are inherited by the stages that depend on them.
+func getStageDependencies(stages []*models.Stage) map[string][]string {
+	stagesByID := map[string]*models.Stage{}
+	for _, stage := range stages {
+		if stage != nil && stage.ID != nil {
+			stagesByID[*stage.ID] = stage
+		}
+	}
+
+	var resolveStageJobs func(stageID string, visited map[string]bool) []string
+	resolveStageJobs = func(stageID string, visited map[string]bool) []string {
+		stage, ok := stagesByID[stageID]
+		if !ok || visited[stageID] {
+			return nil
+		}
+		visited[stageID] = true
+
+		if len(stage.Jobs) > 0 {
+			return stage.Jobs
+		}
+
+		var jobs []string
+		for _, dependency := range stage.Dependencies {
+			jobs = append(jobs, resolveStageJobs(dependency, visited)...)
+		}
+		return jobs
+	}
+
+	jobDependencies := map[string][]string{}
+	for _, stage := range stages {
+		if stage == nil {
+			continue
+		}
+
+		var dependencies []string
+		for _, dependency := range stage.Dependencies {
+			dependencies = append(dependencies, resolveStageJobs(dependency, map[string]bool{})...)
+		}
+
+		for _, jobID := range stage.Jobs {
+			jobDependencies[jobID] = append(jobDependencies[jobID], dependencies...)
+		}
+	}
+	return jobDependencies
+}
+
+// detectCycles reports every strongly connected component of the graph that contains a cycle (Tarjan's algorithm)
+func (g *Graph) detectCycles() {
+	index := 0
+	indices := map[string]int{}
+	lowLinks := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+
+	var strongConnect func(nodeID string)
+	strongConnect = func(nodeID string) {
+		indices[nodeID] = index
+		lowLinks[nodeID] = index
+		index++
+		stack = append(stack, nodeID)
+		onStack[nodeID] = true
+
+		for _, dependency := range g.Nodes[nodeID].Dependencies {
+			if _, ok := g.Nodes[dependency]; !ok {
+				continue
+			}
+			if _, visited := indices[dependency]; !visited {
+				strongConnect(dependency)
+				lowLinks[nodeID] = min(lowLinks[nodeID], lowLinks[dependency])
+			} else if onStack[dependency] {
+				lowLinks[nodeID] = min(lowLinks[nodeID], indices[dependency])
+			}
+		}
+
+		if lowLinks[nodeID] != indices[nodeID] {
+			return
+		}
+
+		var component []string
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+			if last == nodeID {
+				break
+			}
+		}
+
+		if len(component) > 1 || utils.SliceContains(g.Nodes[nodeID].Dependencies, nodeID) {
+			sort.Strings(component)
+			g.Diagnostics = append(g.Diagnostics, &Diagnostic{
+				Type:          CycleDiagnostic,
+				Message:       fmt.Sprintf("jobs %s have a circular dependency", strings.Join(component, ", ")),
+				JobIDs:        component,
+				FileReference: g.Nodes[component[0]].Job.FileReference,
+			})
+		}
+	}
+
+	for _, nodeID := range g.sortedNodeIDs() {
+		if _, visited := indices[nodeID]; !visited {
+			strongConnect(nodeID)
+		}
+	}
+}
+
+// Layers returns the jobs grouped by execution order - every job depends only on jobs of previous layers.
+// Jobs that can never run (see Unreachable) are not part of any layer.
+func (g *Graph) Layers() [][]string {
+	inDegree := map[string]int{}
+	for _, node := range g.Nodes {
+		inDegree[node.ID] = len(node.Dependencies)
+	}
+
+	var layers [][]string
+	var current []string
+	for _, nodeID := range g.sortedNodeIDs() {
+		if inDegree[nodeID] == 0 {
+			current = append(current, nodeID)
+		}
+	}
+
+	for len(current) > 0 {
+		layers = append(layers, current)
+		var next []string
+		for _, nodeID := range current {
+			for _, dependent := range g.Nodes[nodeID].Dependents {
+				inDegree[dependent]--
+				if inDegree[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		sort.Strings(next)
+		current = next
+	}
+	return layers
+}
+
+// Unreachable returns the jobs that can never run, because they are part of a cycle
+// or depend (directly or transitively) on a cycle or on a job that does not exist
+func (g *Graph) Unreachable() []string {
+	scheduled := map[string]bool{}
+	for _, layer := range g.Layers() {
+		for _, nodeID := range layer {
+			scheduled[nodeID] = true
+		}
+	}
+
+	return utils.Filter(g.sortedNodeIDs(), func(nodeID string) bool {
+		return !scheduled[nodeID]
+	})
+}
+
+// CriticalPath returns the longest chain of dependent jobs, weighted by the jobs' timeout, and its total length in milliseconds
+func (g *Graph) CriticalPath() ([]string, int) {
+	distances := map[string]int{}
+	previous := map[string]string{}
+
+	var end string
+	longest := -1
+	for _, layer := range g.Layers() {
+		for _, nodeID := range layer {
+			node := g.Nodes[nodeID]
+			distance := 0
+			for _, dependency := range node.Dependencies {
+				if _, ok := previous[nodeID]; !ok || distances[dependency] > distance {
+					distance = distances[dependency]
+					previous[nodeID] = dependency
+				}
+			}
+
+			distances[nodeID] = distance + getJobTimeout(node.Job)
+			if distances[nodeID] > longest {
+				longest = distances[nodeID]
+				end = nodeID
+			}
+		}
+	}
+
+	if end == "" {
+		return nil, 0
+	}
+
+	path := []string{}
+	for nodeID := end; nodeID != ""; nodeID = previous[nodeID] {
+		path = append([]string{nodeID}, path...)
+	}
+	return path, longest
+}
+
+func (g *Graph) sortedNodeIDs() []string {
+	nodeIDs := utils.GetMapKeys(g.Nodes)
+	sort.Strings(nodeIDs)
+	return nodeIDs
+}
+
+func getJobID(job *models.Job) string {
+	if job == nil {
+		return ""
+	}
+	if job.ID != nil && *job.ID != "" {
+		return *job.ID
+	}
+	if job.Name != nil {
+		return *job.Name
+	}
+	return ""
+}
+
+func isHiddenJob(platform models.Platform, jobID string) bool {
+	return platform == consts.GitLabPlatform && strings.HasPrefix(jobID, ".")
+}
+
+func getJobTimeout(job *models.Job) int {
+	if job == nil || job.TimeoutMS == nil {
+		return 0
+	}
+	return *job.TimeoutMS
+}
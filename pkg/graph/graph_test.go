@@ -0,0 +1,305 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func createJob(id string, timeoutMS int, dependencies ...string) *models.Job {
+	job := &models.Job{
+		ID:            utils.GetPtr(id),
+		FileReference: testutils.CreateFileReference(1, 1, 1, 1),
+	}
+	if timeoutMS > 0 {
+		job.TimeoutMS = utils.GetPtr(timeoutMS)
+	}
+	for _, dependency := range dependencies {
+		job.Dependencies = append(job.Dependencies, &models.JobDependency{JobID: utils.GetPtr(dependency)})
+	}
+	return job
+}
+
+func TestBuild(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		pipeline             *models.Pipeline
+		expectedDependencies map[string][]string
+		expectedDiagnostics  []*Diagnostic
+	}{
+		{
+			name:                 "Pipeline is nil",
+			pipeline:             nil,
+			expectedDependencies: map[string][]string{},
+		},
+		{
+			name: "Explicit dependencies",
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Jobs: []*models.Job{
+					createJob("build", 0),
+					createJob("test", 0, "build"),
+					createJob("deploy", 0, "build", "test"),
+				},
+			},
+			expectedDependencies: map[string][]string{
+				"build":  nil,
+				"test":   {"build"},
+				"deploy": {"build", "test"},
+			},
+		},
+		{
+			name: "GitLab stages are skipped over when empty and replaced by needs",
+			pipeline: &models.Pipeline{
+				Platform: consts.GitLabPlatform,
+				Jobs: []*models.Job{
+					createJob("compile", 0),
+					createJob("unit", 0),
+					createJob("lint", 0),
+					{
+						ID:                utils.GetPtr("deploy"),
+						Dependencies:      []*models.JobDependency{{JobID: utils.GetPtr("unit")}},
+						IgnoresStageOrder: true,
+					},
+				},
+				Stages: []*models.Stage{
+					{ID: utils.GetPtr("build"), Jobs: []string{"compile"}},
+					{ID: utils.GetPtr("empty"), Dependencies: []string{"build"}},
+					{ID: utils.GetPtr("test"), Dependencies: []string{"empty"}, Jobs: []string{"lint", "unit"}},
+					{ID: utils.GetPtr("deploy"), Dependencies: []string{"test"}, Jobs: []string{"deploy"}},
+				},
+			},
+			expectedDependencies: map[string][]string{
+				"compile": nil,
+				"unit":    {"compile"},
+				"lint":    {"compile"},
+				"deploy":  {"unit"},
+			},
+		},
+		{
+			name: "GitLab dependencies do not replace the stage ordering and hidden jobs are skipped",
+			pipeline: &models.Pipeline{
+				Platform: consts.GitLabPlatform,
+				Jobs: []*models.Job{
+					createJob(".template", 0),
+					createJob("compile", 0),
+					createJob("lint", 0),
+					{
+						ID:           utils.GetPtr("unit"),
+						Dependencies: []*models.JobDependency{{JobID: utils.GetPtr("lint"), ArtifactsOnly: true}},
+					},
+				},
+				Stages: []*models.Stage{
+					{ID: utils.GetPtr("build"), Jobs: []string{".template", "compile"}},
+					{ID: utils.GetPtr("test"), Dependencies: []string{"build"}, Jobs: []string{"lint", "unit"}},
+				},
+			},
+			expectedDependencies: map[string][]string{
+				"compile": nil,
+				"lint":    {"compile"},
+				"unit":    {"compile"},
+			},
+		},
+		{
+			name: "GitLab jobs with empty needs or only pipeline needs start immediately",
+			pipeline: &models.Pipeline{
+				Platform: consts.GitLabPlatform,
+				Jobs: []*models.Job{
+					createJob("compile", 0),
+					{ID: utils.GetPtr("deploy"), IgnoresStageOrder: true},
+					{
+						ID:                utils.GetPtr("downstream"),
+						Dependencies:      []*models.JobDependency{{JobID: utils.GetPtr("package"), Pipeline: utils.GetPtr("other/project")}},
+						IgnoresStageOrder: true,
+					},
+				},
+				Stages: []*models.Stage{
+					{ID: utils.GetPtr("build"), Jobs: []string{"compile"}},
+					{ID: utils.GetPtr("deploy"), Dependencies: []string{"build"}, Jobs: []string{"deploy", "downstream"}},
+				},
+			},
+			expectedDependencies: map[string][]string{
+				"compile":    nil,
+				"deploy":     nil,
+				"downstream": nil,
+			},
+		},
+		{
+			name: "Azure stage dependencies are added to job dependencies",
+			pipeline: &models.Pipeline{
+				Platform: consts.AzurePlatform,
+				Jobs: []*models.Job{
+					createJob("compile", 0),
+					createJob("package", 0, "publish"),
+					createJob("publish", 0),
+				},
+				Stages: []*models.Stage{
+					{ID: utils.GetPtr("build"), Jobs: []string{"compile"}},
+					{ID: utils.GetPtr("release"), Dependencies: []string{"build"}, Jobs: []string{"package", "publish"}},
+				},
+			},
+			expectedDependencies: map[string][]string{
+				"compile": nil,
+				"package": {"compile", "publish"},
+				"publish": {"compile"},
+			},
+		},
+		{
+			name: "Missing jobs and cycles",
+			pipeline: &models.Pipeline{
+				Jobs: []*models.Job{
+					createJob("a", 0, "b"),
+					createJob("b", 0, "a"),
+					createJob("c", 0, "missing"),
+					createJob("d", 0, "d"),
+				},
+			},
+			expectedDependencies: map[string][]string{
+				"a": {"b"},
+				"b": {"a"},
+				"c": {"missing"},
+				"d": {"d"},
+			},
+			expectedDiagnostics: []*Diagnostic{
+				{
+					Type:          MissingJobDiagnostic,
+					Message:       "job c depends on job missing which does not exist",
+					JobIDs:        []string{"c"},
+					MissingJobID:  utils.GetPtr("missing"),
+					FileReference: testutils.CreateFileReference(1, 1, 1, 1),
+				},
+				{
+					Type:          CycleDiagnostic,
+					Message:       "jobs a, b have a circular dependency",
+					JobIDs:        []string{"a", "b"},
+					FileReference: testutils.CreateFileReference(1, 1, 1, 1),
+				},
+				{
+					Type:          CycleDiagnostic,
+					Message:       "jobs d have a circular dependency",
+					JobIDs:        []string{"d"},
+					FileReference: testutils.CreateFileReference(1, 1, 1, 1),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := Build(testCase.pipeline)
+
+			dependencies := map[string][]string{}
+			for nodeID, node := range got.Nodes {
+				dependencies[nodeID] = node.Dependencies
+			}
+
+			testutils.DeepCompare(t, testCase.expectedDependencies, dependencies)
+			testutils.DeepCompare(t, testCase.expectedDiagnostics, got.Diagnostics)
+		})
+	}
+}
+
+func TestLayers(t *testing.T) {
+	testCases := []struct {
+		name                string
+		pipeline            *models.Pipeline
+		expectedLayers      [][]string
+		expectedUnreachable []string
+	}{
+		{
+			name:                "Empty pipeline",
+			pipeline:            &models.Pipeline{},
+			expectedLayers:      nil,
+			expectedUnreachable: []string{},
+		},
+		{
+			name: "Diamond",
+			pipeline: &models.Pipeline{
+				Jobs: []*models.Job{
+					createJob("build", 0),
+					createJob("unit", 0, "build"),
+					createJob("e2e", 0, "build"),
+					createJob("deploy", 0, "unit", "e2e"),
+				},
+			},
+			expectedLayers:      [][]string{{"build"}, {"e2e", "unit"}, {"deploy"}},
+			expectedUnreachable: []string{},
+		},
+		{
+			name: "Jobs depending on cycles and missing jobs are unreachable",
+			pipeline: &models.Pipeline{
+				Jobs: []*models.Job{
+					createJob("build", 0),
+					createJob("a", 0, "b"),
+					createJob("b", 0, "a"),
+					createJob("c", 0, "a", "build"),
+					createJob("d", 0, "missing"),
+				},
+			},
+			expectedLayers:      [][]string{{"build"}},
+			expectedUnreachable: []string{"a", "b", "c", "d"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := Build(testCase.pipeline)
+
+			testutils.DeepCompare(t, testCase.expectedLayers, g.Layers())
+			testutils.DeepCompare(t, testCase.expectedUnreachable, g.Unreachable())
+		})
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	testCases := []struct {
+		name           string
+		pipeline       *models.Pipeline
+		expectedPath   []string
+		expectedLength int
+	}{
+		{
+			name:           "Empty pipeline",
+			pipeline:       &models.Pipeline{},
+			expectedPath:   nil,
+			expectedLength: 0,
+		},
+		{
+			name: "Longest path by timeout",
+			pipeline: &models.Pipeline{
+				Jobs: []*models.Job{
+					createJob("build", 10),
+					createJob("unit", 5, "build"),
+					createJob("e2e", 30, "build"),
+					createJob("deploy", 1, "unit", "e2e"),
+					createJob("lint", 20),
+				},
+			},
+			expectedPath:   []string{"build", "e2e", "deploy"},
+			expectedLength: 41,
+		},
+		{
+			name: "Jobs without timeout",
+			pipeline: &models.Pipeline{
+				Jobs: []*models.Job{
+					createJob("build", 0),
+					createJob("test", 0, "build"),
+				},
+			},
+			expectedPath:   []string{"build"},
+			expectedLength: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path, length := Build(testCase.pipeline).CriticalPath()
+
+			testutils.DeepCompare(t, testCase.expectedPath, path)
+			testutils.DeepCompare(t, testCase.expectedLength, length)
+		})
+	}
+}
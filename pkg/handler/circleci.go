@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/enhancers"
+	circleciEnhancer "github.com/argonsecurity/pipeline-parser/pkg/enhancers/circleci"
+	"github.com/argonsecurity/pipeline-parser/pkg/loaders"
+	circleciLoader "github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci"
+	circleciModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/parsers"
+	circleciParser "github.com/argonsecurity/pipeline-parser/pkg/parsers/circleci"
+)
+
+type CircleCIHandler struct{}
+
+func (g *CircleCIHandler) GetPlatform() models.Platform {
+	return consts.CircleCIPlatform
+}
+
+func (g *CircleCIHandler) GetLoader() loaders.Loader[circleciModels.Config] {
+	return &circleciLoader.CircleCILoader{}
+}
+
+func (g *CircleCIHandler) GetParser() parsers.Parser[circleciModels.Config] {
+	return &circleciParser.CircleCIParser{}
+}
+
+func (g *CircleCIHandler) GetEnhancer() enhancers.Enhancer {
+	return &circleciEnhancer.CircleCIEnhancer{}
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/enhancers"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+func newImportDiagnostic(severity models.DiagnosticSeverity, code models.DiagnosticCode, message string, importedPipeline *enhancers.ImportedPipeline) *models.Diagnostic {
+	diagnostic := &models.Diagnostic{
+		Severity:      severity,
+		Code:          code,
+		Message:       message,
+		FileReference: getImportFileReference(importedPipeline),
+	}
+	if importedPipeline.Import != nil {
+		diagnostic.Source = importedPipeline.Import.Source
+	}
+	return diagnostic
+}
+
+// liftImportDiagnostic moves a diagnostic of an imported pipeline to the pipeline that imports it.
+// The file reference of the diagnostic points into the imported file, so it is replaced with the location of the import,
+// and a diagnostic without a source gets the source of the imported pipeline.
+func liftImportDiagnostic(diagnostic *models.Diagnostic, importedPipeline *enhancers.ImportedPipeline) *models.Diagnostic {
+	lifted := *diagnostic
+	lifted.FileReference = getImportFileReference(importedPipeline)
+	if lifted.Source == nil && importedPipeline.Import != nil {
+		lifted.Source = importedPipeline.Import.Source
+	}
+	return &lifted
+}
+
+func getImportFileReference(importedPipeline *enhancers.ImportedPipeline) *models.FileReference {
+	if importedPipeline.Import != nil && importedPipeline.Import.FileReference != nil {
+		return importedPipeline.Import.FileReference
+	}
+	return importedPipeline.OriginFileReference
+}
+
+func hasImportErrors(importedPipelines []*enhancers.ImportedPipeline) bool {
+	for _, importedPipeline := range importedPipelines {
+		if importedPipeline != nil && importedPipeline.Error != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func getFatalDiagnostic(pipeline *models.Pipeline) *models.Diagnostic {
+	for _, diagnostic := range pipeline.Diagnostics {
+		if diagnostic.Severity == models.ErrorSeverity {
+			return diagnostic
+		}
+	}
+	return nil
+}
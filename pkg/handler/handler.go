@@ -2,13 +2,17 @@ package handler
 
 import (
 	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/detector"
 	"github.com/argonsecurity/pipeline-parser/pkg/enhancers"
 	generalEnhancer "github.com/argonsecurity/pipeline-parser/pkg/enhancers/general"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
 	"github.com/argonsecurity/pipeline-parser/pkg/loaders"
 	azureModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/azure/models"
 	bitbucketModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/bitbucket/models"
+	circleciModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci/models"
 	githubModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/github/models"
 	gitlabModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/gitlab/models"
+	jenkinsModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins/models"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
 	"github.com/argonsecurity/pipeline-parser/pkg/parsers"
 )
@@ -20,23 +24,46 @@ type Handler[T any] interface {
 	GetEnhancer() enhancers.Enhancer
 }
 
-func Handle(data []byte, platform models.Platform, credentials *models.Credentials, organization, baseUrl *string) (*models.Pipeline, error) {
+// Handle parses the pipeline of the given platform.
+// Imported pipelines are fetched over http from the SCM api, unless another fetcher is passed with WithFetcher.
+// Imports that fail to be fetched or parsed are reported in the diagnostics of the pipeline, or fail the parsing with WithFailOnError.
+func Handle(data []byte, platform models.Platform, credentials *models.Credentials, organization, baseUrl *string, opts ...Option) (*models.Pipeline, error) {
 	var pipeline *models.Pipeline
 	var err error
 
+	options := &handleOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.fetcher == nil {
+		options.fetcher = fetcher.NewDefaultFetcher(credentials, organization, baseUrl)
+	}
+
 	if len(data) == 0 {
 		return nil, consts.NewErrEmptyData()
 	}
 
+	if platform == consts.AutoPlatform {
+		detection, err := detector.Detect("", data)
+		if err != nil {
+			return nil, err
+		}
+		platform = detection.Platform
+	}
+
 	switch platform {
 	case consts.GitHubPlatform:
-		pipeline, err = handle[githubModels.Workflow](data, &GitHubHandler{}, credentials, organization, baseUrl, nil)
+		pipeline, err = handle[githubModels.Workflow](data, &GitHubHandler{}, options.fetcher, nil, nil)
 	case consts.GitLabPlatform:
-		pipeline, err = handle[gitlabModels.GitlabCIConfiguration](data, &GitLabHandler{}, credentials, organization, baseUrl, nil)
+		pipeline, err = handle[gitlabModels.GitlabCIConfiguration](data, &GitLabHandler{}, options.fetcher, nil, nil)
 	case consts.AzurePlatform:
-		pipeline, err = handle[azureModels.Pipeline](data, &AzureHandler{}, credentials, organization, baseUrl, nil)
+		pipeline, err = handle[azureModels.Pipeline](data, &AzureHandler{}, options.fetcher, nil, nil)
 	case consts.BitbucketPlatform:
-		pipeline, err = handle[bitbucketModels.Pipeline](data, &BitbucketHandler{}, credentials, organization, baseUrl, nil)
+		pipeline, err = handle[bitbucketModels.Pipeline](data, &BitbucketHandler{}, options.fetcher, nil, nil)
+	case consts.JenkinsPlatform:
+		pipeline, err = handle[jenkinsModels.Pipeline](data, &JenkinsHandler{}, options.fetcher, nil, nil)
+	case consts.CircleCIPlatform:
+		pipeline, err = handle[circleciModels.Config](data, &CircleCIHandler{}, options.fetcher, nil, nil)
 	default:
 		return nil, consts.NewErrInvalidPlatform(platform)
 	}
@@ -49,11 +76,18 @@ func Handle(data []byte, platform models.Platform, credentials *models.Credentia
 		return nil, err
 	}
 
+	if options.failOnError {
+		if diagnostic := getFatalDiagnostic(pipeline); diagnostic != nil {
+			return nil, consts.NewErrFatalDiagnostic(diagnostic)
+		}
+	}
+
 	return pipeline, nil
 }
 
-func handle[T any](data []byte, handler Handler[T], credentials *models.Credentials, organization, baseUrl *string, parentPipeline *models.Pipeline) (*models.Pipeline, error) {
-	pipeline, err := handler.GetLoader().Load(data)
+// handle loads and parses a pipeline and the pipelines it imports, where imported is the import of an imported pipeline
+func handle[T any](data []byte, handler Handler[T], fetcher fetcher.Fetcher, parentPipeline *models.Pipeline, imported *models.Import) (*models.Pipeline, error) {
+	pipeline, err := load(data, handler.GetLoader(), imported)
 	if err != nil {
 		return nil, err
 	}
@@ -67,17 +101,70 @@ func handle[T any](data []byte, handler Handler[T], credentials *models.Credenti
 
 	parsedPipeline = enhancer.InheritParentPipelineData(parentPipeline, parsedPipeline)
 
-	importedPipelines, _ := enhancer.LoadImportedPipelines(parsedPipeline, credentials, organization, baseUrl)
+	importedPipelines, err := enhancer.LoadImportedPipelines(parsedPipeline, fetcher)
+	if err != nil && !hasImportErrors(importedPipelines) {
+		parsedPipeline.Diagnostics = append(parsedPipeline.Diagnostics, &models.Diagnostic{
+			Severity: models.ErrorSeverity,
+			Code:     models.ImportFetchFailedCode,
+			Message:  err.Error(),
+		})
+	}
 
 	for _, importedPipeline := range importedPipelines {
 		if importedPipeline == nil {
 			continue
 		}
-		parsedImportedPipeline, _ := handle(importedPipeline.Data, handler, credentials, organization, baseUrl, parsedPipeline)
+
+		if importedPipeline.Error != nil {
+			parsedPipeline.Diagnostics = append(parsedPipeline.Diagnostics, newImportDiagnostic(models.ErrorSeverity, models.ImportFetchFailedCode, importedPipeline.Error.Error(), importedPipeline))
+			continue
+		}
+
+		if len(importedPipeline.Data) == 0 {
+			parsedPipeline.Diagnostics = append(parsedPipeline.Diagnostics, newImportDiagnostic(models.WarningSeverity, models.ImportNotResolvedCode, "imported pipeline is not supported and was not fetched", importedPipeline))
+			continue
+		}
+
+		parsedImportedPipeline, err := handle(importedPipeline.Data, handler, fetcher, parsedPipeline, importedPipeline.Import)
+		if err != nil {
+			parsedPipeline.Diagnostics = append(parsedPipeline.Diagnostics, newImportDiagnostic(models.ErrorSeverity, models.ImportParseFailedCode, err.Error(), importedPipeline))
+			continue
+		}
+
+		// diagnostics of imported pipelines are reported on the root pipeline, at the import they come from
+		for _, diagnostic := range parsedImportedPipeline.Diagnostics {
+			parsedPipeline.Diagnostics = append(parsedPipeline.Diagnostics, liftImportDiagnostic(diagnostic, importedPipeline))
+		}
+		parsedImportedPipeline.Diagnostics = nil
 		importedPipeline.Pipeline = parsedImportedPipeline
 	}
 
-	enhancedPipeline, _ := handler.GetEnhancer().Enhance(parsedPipeline, importedPipelines)
+	enhancedPipeline, err := handler.GetEnhancer().Enhance(parsedPipeline, importedPipelines)
+	if enhancedPipeline == nil {
+		enhancedPipeline = parsedPipeline
+	}
+	if err != nil {
+		enhancedPipeline.Diagnostics = append(enhancedPipeline.Diagnostics, &models.Diagnostic{
+			Severity: models.ErrorSeverity,
+			Code:     models.EnhanceFailedCode,
+			Message:  err.Error(),
+		})
+	}
 
 	return generalEnhancer.Enhance(enhancedPipeline, handler.GetPlatform())
 }
+
+// load loads a pipeline, expanding its template expressions if the platform supports them.
+// Imported pipelines are expanded with the parameters they are imported with, and the root pipeline with its parameter defaults.
+func load[T any](data []byte, loader loaders.Loader[T], imported *models.Import) (*T, error) {
+	templateLoader, ok := loader.(loaders.TemplateLoader[T])
+	if !ok {
+		return loader.Load(data)
+	}
+
+	var parameters map[string]any
+	if imported != nil {
+		parameters = imported.Parameters
+	}
+	return templateLoader.LoadTemplate(data, parameters)
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleDiagnostics(t *testing.T) {
+	testCases := []struct {
+		name                string
+		data                string
+		fetcher             fetcher.Fetcher
+		opts                []Option
+		expectedDiagnostics []*models.Diagnostic
+		expectedError       bool
+	}{
+		{
+			name:    "Imports are resolved",
+			data:    "include: /build.yml\n",
+			fetcher: fetcher.MemoryFetcher{"build.yml": []byte("build:\n  script: make\n")},
+		},
+		{
+			name:    "Import fetch failed",
+			data:    "include: /build.yml\n",
+			fetcher: fetcher.MemoryFetcher{},
+			expectedDiagnostics: []*models.Diagnostic{
+				{
+					Severity:      models.ErrorSeverity,
+					Code:          models.ImportFetchFailedCode,
+					Message:       "failed fetching build.yml: file not found",
+					FileReference: testutils.CreateFileReference(1, 10, 1, 20),
+					Source: &models.ImportSource{
+						SCM:  consts.GitLabPlatform,
+						Path: utils.GetPtr("/build.yml"),
+						Type: models.SourceTypeLocal,
+					},
+				},
+			},
+		},
+		{
+			name:    "Import parse failed",
+			data:    "include: /build.yml\n",
+			fetcher: fetcher.MemoryFetcher{"build.yml": []byte("build: [\n")},
+			expectedDiagnostics: []*models.Diagnostic{
+				{
+					Severity:      models.ErrorSeverity,
+					Code:          models.ImportParseFailedCode,
+					Message:       "yaml: line 1: did not find expected node content",
+					FileReference: testutils.CreateFileReference(1, 10, 1, 20),
+					Source: &models.ImportSource{
+						SCM:  consts.GitLabPlatform,
+						Path: utils.GetPtr("/build.yml"),
+						Type: models.SourceTypeLocal,
+					},
+				},
+			},
+		},
+		{
+			name: "Diagnostics of nested imports are reported on the root pipeline at the root import",
+			data: "include: /build.yml\n",
+			fetcher: fetcher.MemoryFetcher{
+				"build.yml": []byte("build:\n  script: make\ninclude: /test.yml\n"),
+			},
+			expectedDiagnostics: []*models.Diagnostic{
+				{
+					Severity:      models.ErrorSeverity,
+					Code:          models.ImportFetchFailedCode,
+					Message:       "failed fetching test.yml: file not found",
+					FileReference: testutils.CreateFileReference(1, 10, 1, 20),
+					Source: &models.ImportSource{
+						SCM:  consts.GitLabPlatform,
+						Path: utils.GetPtr("/test.yml"),
+						Type: models.SourceTypeLocal,
+					},
+				},
+			},
+		},
+		{
+			name:          "Error diagnostic is fatal",
+			data:          "include: /build.yml\n",
+			fetcher:       fetcher.MemoryFetcher{},
+			opts:          []Option{WithFailOnError()},
+			expectedError: true,
+		},
+		{
+			name:    "Fail on error without error diagnostics",
+			data:    "include: /build.yml\n",
+			fetcher: fetcher.MemoryFetcher{"build.yml": []byte("build:\n  script: make\n")},
+			opts:    []Option{WithFailOnError()},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			opts := append([]Option{WithFetcher(testCase.fetcher)}, testCase.opts...)
+			pipeline, err := Handle([]byte(testCase.data), consts.GitLabPlatform, nil, nil, nil, opts...)
+
+			if testCase.expectedError {
+				assert.Error(t, err)
+				assert.IsType(t, &consts.ErrFatalDiagnostic{}, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			testutils.DeepCompare(t, testCase.expectedDiagnostics, pipeline.Diagnostics)
+		})
+	}
+}
+
+func TestHandleAzureRootTemplateExpressions(t *testing.T) {
+	data := `parameters:
+  - name: configuration
+    default: Release
+  - name: runTests
+    type: boolean
+    default: false
+  - name: extraSteps
+    type: stepList
+    default:
+      - script: echo extra
+steps:
+  - script: make ${{ parameters.configuration }}
+  - ${{ if parameters.runTests }}:
+      - script: make test
+  - ${{ parameters.extraSteps }}
+`
+	pipeline, err := Handle([]byte(data), consts.AzurePlatform, nil, nil, nil, WithFetcher(fetcher.MemoryFetcher{}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var scripts []string
+	for _, job := range pipeline.Jobs {
+		for _, step := range job.Steps {
+			if step.Shell != nil && step.Shell.Script != nil {
+				scripts = append(scripts, *step.Shell.Script)
+			}
+		}
+	}
+	assert.Equal(t, []string{"make Release", "echo extra"}, scripts)
+}
+
+func TestHandleRegistryPasswordsAreNotOutput(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		platform models.Platform
+	}{
+		{
+			name: "GitHub container and service credentials",
+			data: `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    container:
+      image: registry.example.com/team/app:1.0
+      credentials:
+        username: user
+        password: hunter2
+    services:
+      postgres:
+        image: registry.example.com/team/postgres:14
+        credentials:
+          username: user
+          password: hunter2
+    steps:
+      - run: npm test
+`,
+			platform: consts.GitHubPlatform,
+		},
+		{
+			name: "Bitbucket service credentials",
+			data: `pipelines:
+  default:
+    - step:
+        services:
+          - postgres
+        script:
+          - npm test
+definitions:
+  services:
+    postgres:
+      image:
+        name: registry.example.com/team/postgres:14
+        username: user
+        password: hunter2
+`,
+			platform: consts.BitbucketPlatform,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			pipeline, err := Handle([]byte(testCase.data), testCase.platform, nil, nil, nil, WithFetcher(fetcher.MemoryFetcher{}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			output, err := json.Marshal(pipeline)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.NotContains(t, string(output), "hunter2")
+			assert.Contains(t, string(output), "registry.example.com")
+		})
+	}
+}
+
+func TestHandleGitLabStagesOfImportedExtends(t *testing.T) {
+	data := `include: /templates.yml
+stages:
+  - build
+  - deploy
+compile:
+  stage: build
+  script: make
+prod:
+  extends: .deploy
+unknown:
+  extends: .missing
+`
+	templates := fetcher.MemoryFetcher{"templates.yml": []byte(".deploy:\n  stage: deploy\n  script: ./deploy.sh\n")}
+
+	pipeline, err := Handle([]byte(data), consts.GitLabPlatform, nil, nil, nil, WithFetcher(templates))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.DeepCompare(t, []*models.Stage{
+		{ID: utils.GetPtr("build"), Name: utils.GetPtr("build"), Order: 0, Jobs: []string{"compile"}},
+		{ID: utils.GetPtr("deploy"), Name: utils.GetPtr("deploy"), Order: 1, Dependencies: []string{"build"}, Jobs: []string{"prod"}},
+		{ID: utils.GetPtr("test"), Name: utils.GetPtr("test"), Order: 2, Dependencies: []string{"deploy"}, Jobs: []string{"unknown"}},
+	}, pipeline.Stages)
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/enhancers"
+	jenkinsEnhancer "github.com/argonsecurity/pipeline-parser/pkg/enhancers/jenkins"
+	"github.com/argonsecurity/pipeline-parser/pkg/loaders"
+	jenkinsLoader "github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins"
+	jenkinsModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/parsers"
+	jenkinsParser "github.com/argonsecurity/pipeline-parser/pkg/parsers/jenkins"
+)
+
+type JenkinsHandler struct{}
+
+func (g *JenkinsHandler) GetPlatform() models.Platform {
+	return consts.JenkinsPlatform
+}
+
+func (g *JenkinsHandler) GetLoader() loaders.Loader[jenkinsModels.Pipeline] {
+	return &jenkinsLoader.JenkinsLoader{}
+}
+
+func (g *JenkinsHandler) GetParser() parsers.Parser[jenkinsModels.Pipeline] {
+	return &jenkinsParser.JenkinsParser{}
+}
+
+func (g *JenkinsHandler) GetEnhancer() enhancers.Enhancer {
+	return &jenkinsEnhancer.JenkinsEnhancer{}
+}
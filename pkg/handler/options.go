@@ -0,0 +1,24 @@
+package handler
+
+import "github.com/argonsecurity/pipeline-parser/pkg/fetcher"
+
+type handleOptions struct {
+	fetcher     fetcher.Fetcher
+	failOnError bool
+}
+
+type Option func(*handleOptions)
+
+// WithFetcher sets the fetcher of the imported pipelines, e.g. to parse pipelines offline
+func WithFetcher(fetcher fetcher.Fetcher) Option {
+	return func(options *handleOptions) {
+		options.fetcher = fetcher
+	}
+}
+
+// WithFailOnError makes Handle return an error if the pipeline has an error diagnostic, e.g. an import that failed to be fetched
+func WithFailOnError() Option {
+	return func(options *handleOptions) {
+		options.failOnError = true
+	}
+}
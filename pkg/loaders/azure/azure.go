@@ -12,3 +12,17 @@ func (g *AzureLoader) Load(data []byte) (*models.Pipeline, error) {
 	err := yaml.Unmarshal(data, pipeline)
 	return pipeline, err
 }
+
+// LoadTemplate loads a pipeline or an imported template, expanding its template expressions with the given parameters
+// and the defaults of the parameters it declares
+func (g *AzureLoader) LoadTemplate(data []byte, parameters map[string]any) (*models.Pipeline, error) {
+	pipeline := &models.Pipeline{}
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil || document.Kind == 0 {
+		return pipeline, err
+	}
+
+	expandTemplate(&document, parameters)
+	err := document.Decode(pipeline)
+	return pipeline, err
+}
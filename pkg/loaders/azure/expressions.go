@@ -0,0 +1,511 @@
+package azure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/expressions"
+)
+
+// templateFunctions maps the lower cased name of every function of template expressions to its minimum and maximum
+// number of arguments, where a maximum of -1 means the function accepts any number of arguments
+var templateFunctions = map[string][2]int{
+	"and":           {2, -1},
+	"or":            {2, -1},
+	"not":           {1, 1},
+	"xor":           {2, 2},
+	"eq":            {2, 2},
+	"ne":            {2, 2},
+	"gt":            {2, 2},
+	"ge":            {2, 2},
+	"lt":            {2, 2},
+	"le":            {2, 2},
+	"in":            {1, -1},
+	"notin":         {1, -1},
+	"contains":      {2, 2},
+	"containsvalue": {2, 2},
+	"startswith":    {2, 2},
+	"endswith":      {2, 2},
+	"coalesce":      {1, -1},
+	"format":        {1, -1},
+	"lower":         {1, 1},
+	"upper":         {1, 1},
+	"length":        {1, 1},
+	"replace":       {3, 3},
+	"join":          {2, 2},
+	"split":         {2, 2},
+	"iif":           {3, 3},
+	"converttojson": {1, 1},
+}
+
+// errUnresolved is returned for expressions whose value is only known at runtime, or by the pipeline that imports the template
+var errUnresolved = errors.New("expression cannot be evaluated at compile time")
+
+// templateContext holds the values template expressions can reference
+type templateContext struct {
+	parameters map[string]any
+	variables  variableValues
+	locals     map[string]any // The variables of each loops
+}
+
+// variableValues are the variables of a template, where a missing variable is defined at runtime
+type variableValues map[string]any
+
+func (c *templateContext) withLocal(name string, value any) *templateContext {
+	locals := map[string]any{name: value}
+	for key, localValue := range c.locals {
+		if key != name {
+			locals[key] = localValue
+		}
+	}
+	return &templateContext{parameters: c.parameters, variables: c.variables, locals: locals}
+}
+
+// evaluateExpression evaluates a template expression, without the surrounding ${{ }}
+func evaluateExpression(expression string, context *templateContext) (any, error) {
+	node, err := expressions.ParseWithFunctions(expression, templateFunctions)
+	if err != nil {
+		return nil, err
+	}
+	return evaluate(node, context)
+}
+
+func evaluate(node expressions.Node, context *templateContext) (any, error) {
+	switch node := node.(type) {
+	case *expressions.Literal:
+		return node.Value, nil
+	case *expressions.NamedValue:
+		return evaluateNamedValue(node.Name, context)
+	case *expressions.PropertyAccess:
+		object, err := evaluate(node.Object, context)
+		if err != nil {
+			return nil, err
+		}
+		return getProperty(object, node.Property)
+	case *expressions.IndexAccess:
+		object, err := evaluate(node.Object, context)
+		if err != nil {
+			return nil, err
+		}
+		index, err := evaluate(node.Index, context)
+		if err != nil {
+			return nil, err
+		}
+		return getIndex(object, index)
+	case *expressions.FunctionCall:
+		return evaluateFunction(node, context)
+	case *expressions.UnaryExpression:
+		operand, err := evaluate(node.Operand, context)
+		if err != nil {
+			return nil, err
+		}
+		return !toBool(operand), nil
+	case *expressions.BinaryExpression:
+		return evaluateBinaryExpression(node, context)
+	}
+	return nil, errUnresolved
+}
+
+func evaluateNamedValue(name string, context *templateContext) (any, error) {
+	if value, ok := context.locals[name]; ok {
+		return value, nil
+	}
+
+	switch name {
+	case "parameters":
+		return context.parameters, nil
+	case "variables":
+		return context.variables, nil
+	}
+	return nil, errUnresolved
+}
+
+func getProperty(object any, name string) (any, error) {
+	switch object := object.(type) {
+	case variableValues:
+		value, ok := lookup(object, name)
+		if !ok {
+			return nil, errUnresolved
+		}
+		return checkResolved(value)
+	case map[string]any:
+		value, _ := lookup(object, name)
+		return checkResolved(value)
+	}
+	return nil, nil
+}
+
+func getIndex(object any, index any) (any, error) {
+	if items, ok := object.([]any); ok {
+		number, ok := toNumber(index)
+		if !ok || number < 0 || number >= float64(len(items)) || number != math.Trunc(number) {
+			return nil, nil
+		}
+		return checkResolved(items[int(number)])
+	}
+	return getProperty(object, toString(index))
+}
+
+// lookup returns the value of a key, which is matched case insensitively when there is no exact match
+func lookup[T ~map[string]any](values T, name string) (any, bool) {
+	if value, ok := values[name]; ok {
+		return value, true
+	}
+	for key, value := range values {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// checkResolved returns an error for values that embed expressions of the importing pipeline, which are not evaluated
+func checkResolved(value any) (any, error) {
+	if value, ok := value.(string); ok && strings.Contains(value, expressionStart) {
+		return nil, errUnresolved
+	}
+	return value, nil
+}
+
+func evaluateBinaryExpression(node *expressions.BinaryExpression, context *templateContext) (any, error) {
+	left, err := evaluate(node.Left, context)
+	if err != nil {
+		return nil, err
+	}
+
+	switch node.Operator {
+	case expressions.AndToken:
+		if !toBool(left) {
+			return left, nil
+		}
+		return evaluate(node.Right, context)
+	case expressions.OrToken:
+		if toBool(left) {
+			return left, nil
+		}
+		return evaluate(node.Right, context)
+	}
+
+	right, err := evaluate(node.Right, context)
+	if err != nil {
+		return nil, err
+	}
+
+	switch node.Operator {
+	case expressions.EqualToken:
+		return equals(left, right), nil
+	case expressions.NotEqualToken:
+		return !equals(left, right), nil
+	}
+	return compareWith(left, right, node.Operator)
+}
+
+func evaluateFunction(call *expressions.FunctionCall, context *templateContext) (any, error) {
+	name := strings.ToLower(call.Name)
+
+	// the logical functions only evaluate the arguments they need
+	switch name {
+	case "and", "or":
+		for _, argument := range call.Arguments {
+			value, err := evaluate(argument, context)
+			if err != nil {
+				return nil, err
+			}
+			if toBool(value) != (name == "and") {
+				return name == "or", nil
+			}
+		}
+		return name == "and", nil
+	case "iif":
+		condition, err := evaluate(call.Arguments[0], context)
+		if err != nil {
+			return nil, err
+		}
+		if toBool(condition) {
+			return evaluate(call.Arguments[1], context)
+		}
+		return evaluate(call.Arguments[2], context)
+	}
+
+	var arguments []any
+	for _, argument := range call.Arguments {
+		value, err := evaluate(argument, context)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, value)
+	}
+
+	switch name {
+	case "not":
+		return !toBool(arguments[0]), nil
+	case "xor":
+		return toBool(arguments[0]) != toBool(arguments[1]), nil
+	case "eq":
+		return equals(arguments[0], arguments[1]), nil
+	case "ne":
+		return !equals(arguments[0], arguments[1]), nil
+	case "gt":
+		return compareWith(arguments[0], arguments[1], expressions.GreaterToken)
+	case "ge":
+		return compareWith(arguments[0], arguments[1], expressions.GreaterEqualToken)
+	case "lt":
+		return compareWith(arguments[0], arguments[1], expressions.LessToken)
+	case "le":
+		return compareWith(arguments[0], arguments[1], expressions.LessEqualToken)
+	case "in", "notin":
+		found := false
+		for _, value := range arguments[1:] {
+			if equals(arguments[0], value) {
+				found = true
+			}
+		}
+		return found == (name == "in"), nil
+	case "contains":
+		return strings.Contains(strings.ToLower(toString(arguments[0])), strings.ToLower(toString(arguments[1]))), nil
+	case "containsvalue":
+		return containsValue(arguments[0], arguments[1]), nil
+	case "startswith":
+		return strings.HasPrefix(strings.ToLower(toString(arguments[0])), strings.ToLower(toString(arguments[1]))), nil
+	case "endswith":
+		return strings.HasSuffix(strings.ToLower(toString(arguments[0])), strings.ToLower(toString(arguments[1]))), nil
+	case "coalesce":
+		for _, value := range arguments {
+			if value != nil && value != "" {
+				return value, nil
+			}
+		}
+		return nil, nil
+	case "format":
+		return format(toString(arguments[0]), arguments[1:])
+	case "lower":
+		return strings.ToLower(toString(arguments[0])), nil
+	case "upper":
+		return strings.ToUpper(toString(arguments[0])), nil
+	case "length":
+		return length(arguments[0]), nil
+	case "replace":
+		return strings.ReplaceAll(toString(arguments[0]), toString(arguments[1]), toString(arguments[2])), nil
+	case "join":
+		return join(toString(arguments[0]), arguments[1]), nil
+	case "split":
+		var items []any
+		for _, item := range strings.Split(toString(arguments[0]), toString(arguments[1])) {
+			items = append(items, item)
+		}
+		return items, nil
+	case "converttojson":
+		data, err := json.MarshalIndent(arguments[0], "", "  ")
+		return string(data), err
+	}
+	return nil, errUnresolved
+}
+
+// toBool converts a value to a boolean - null, false, 0 and the empty string are false, and everything else is true
+func toBool(value any) bool {
+	switch value := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	case string:
+		return value != ""
+	}
+	if isNumber(value) {
+		number, _ := toNumber(value)
+		return number != 0
+	}
+	return true
+}
+
+func isNumber(value any) bool {
+	switch value.(type) {
+	case int, int64, float64:
+		return true
+	}
+	return false
+}
+
+func toNumber(value any) (float64, bool) {
+	switch value := value.(type) {
+	case nil:
+		return 0, true
+	case bool:
+		if value {
+			return 1, true
+		}
+		return 0, true
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case float64:
+		return value, true
+	case string:
+		trimmed := strings.TrimSpace(value)
+		if trimmed == "" {
+			return 0, true
+		}
+		number, err := strconv.ParseFloat(trimmed, 64)
+		return number, err == nil
+	}
+	return 0, false
+}
+
+func toString(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case bool:
+		if value {
+			return "True"
+		}
+		return "False"
+	case int:
+		return strconv.Itoa(value)
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// equals compares two values after converting the right value to the type of the left one, where strings are compared case insensitively
+func equals(left, right any) bool {
+	switch left := left.(type) {
+	case nil:
+		return right == nil
+	case string:
+		return strings.EqualFold(left, toString(right))
+	case bool:
+		return left == toBool(right)
+	}
+	if isNumber(left) {
+		leftNumber, _ := toNumber(left)
+		rightNumber, ok := toNumber(right)
+		return ok && leftNumber == rightNumber
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+func compareWith(left, right any, operator expressions.TokenType) (bool, error) {
+	comparison, err := compare(left, right)
+	if err != nil {
+		return false, err
+	}
+
+	switch operator {
+	case expressions.GreaterToken:
+		return comparison > 0, nil
+	case expressions.GreaterEqualToken:
+		return comparison >= 0, nil
+	case expressions.LessToken:
+		return comparison < 0, nil
+	}
+	return comparison <= 0, nil
+}
+
+// compare orders two values after converting the right value to the type of the left one
+func compare(left, right any) (int, error) {
+	if left, ok := left.(string); ok {
+		return strings.Compare(strings.ToLower(left), strings.ToLower(toString(right))), nil
+	}
+
+	leftNumber, ok := toNumber(left)
+	if !ok {
+		return 0, errUnresolved
+	}
+	rightNumber, ok := toNumber(right)
+	if !ok {
+		return 0, errUnresolved
+	}
+	switch {
+	case leftNumber < rightNumber:
+		return -1, nil
+	case leftNumber > rightNumber:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func containsValue(collection, value any) bool {
+	switch collection := collection.(type) {
+	case []any:
+		for _, item := range collection {
+			if equals(item, value) {
+				return true
+			}
+		}
+	case map[string]any:
+		for _, item := range collection {
+			if equals(item, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// format replaces the {N} placeholders of a format string with the arguments, where {{ and }} are escaped braces
+func format(formatString string, arguments []any) (string, error) {
+	var builder strings.Builder
+	for i := 0; i < len(formatString); i++ {
+		char := formatString[i]
+		switch {
+		case strings.HasPrefix(formatString[i:], "{{"), strings.HasPrefix(formatString[i:], "}}"):
+			builder.WriteByte(char)
+			i++
+		case char == '{':
+			end := strings.IndexByte(formatString[i:], '}')
+			if end < 0 {
+				return "", errUnresolved
+			}
+			index, err := strconv.Atoi(formatString[i+1 : i+end])
+			if err != nil || index < 0 || index >= len(arguments) {
+				return "", errUnresolved
+			}
+			builder.WriteString(toString(arguments[index]))
+			i += end
+		default:
+			builder.WriteByte(char)
+		}
+	}
+	return builder.String(), nil
+}
+
+func length(value any) int {
+	switch value := value.(type) {
+	case string:
+		return len([]rune(value))
+	case []any:
+		return len(value)
+	case map[string]any:
+		return len(value)
+	}
+	return 0
+}
+
+func join(separator string, collection any) string {
+	items, ok := collection.([]any)
+	if !ok {
+		return toString(collection)
+	}
+
+	var values []string
+	for _, item := range items {
+		values = append(values, toString(item))
+	}
+	return strings.Join(values, separator)
+}
@@ -0,0 +1,88 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+)
+
+func TestEvaluateExpression(t *testing.T) {
+	context := &templateContext{
+		parameters: map[string]any{
+			"mode":         "Local",
+			"count":        3,
+			"enabled":      true,
+			"environments": []any{"dev", "prod"},
+			"settings":     map[string]any{"region": "eu", "tags": []any{"a", "b"}},
+			"inherited":    "${{ parameters.mode }}",
+		},
+		variables: variableValues{"toolsVersion": "8.0"},
+		locals:    map[string]any{"environment": "dev"},
+	}
+
+	testCases := []struct {
+		name          string
+		expression    string
+		expectedValue any
+		expectedError error
+	}{
+		{name: "Parameter", expression: "parameters.mode", expectedValue: "Local"},
+		{name: "Parameter index", expression: "parameters['count']", expectedValue: 3},
+		{name: "Case insensitive parameter", expression: "parameters.Enabled", expectedValue: true},
+		{name: "Undefined parameter", expression: "parameters.missing", expectedValue: nil},
+		{name: "Nested property", expression: "parameters.settings.tags[1]", expectedValue: "b"},
+		{name: "Variable", expression: "variables.toolsVersion", expectedValue: "8.0"},
+		{name: "Runtime variable", expression: "variables['Build.SourceBranch']", expectedError: errUnresolved},
+		{name: "Loop variable", expression: "environment", expectedValue: "dev"},
+		{name: "Unknown name", expression: "dependencies.build.outputs", expectedError: errUnresolved},
+		{name: "Unevaluated parameter of the importing pipeline", expression: "parameters.inherited", expectedError: errUnresolved},
+		{name: "Case insensitive equality", expression: "eq(parameters.mode, 'local')", expectedValue: true},
+		{name: "Equality converts to the left type", expression: "eq(parameters.count, '3')", expectedValue: true},
+		{name: "Boolean equality", expression: "ne(parameters.enabled, true)", expectedValue: false},
+		{name: "Comparison", expression: "and(gt(parameters.count, 2), le(parameters.count, 3))", expectedValue: true},
+		{name: "Logical functions", expression: "or(not(parameters.enabled), xor(true, false))", expectedValue: true},
+		{name: "Short circuit", expression: "and(false, variables.runtime)", expectedValue: false},
+		{name: "In", expression: "in(parameters.mode, 'remote', 'local')", expectedValue: true},
+		{name: "Not in", expression: "notIn(environment, 'dev', 'prod')", expectedValue: false},
+		{name: "Contains value", expression: "containsValue(parameters.environments, 'PROD')", expectedValue: true},
+		{name: "String functions", expression: "and(contains('main-branch', 'BRANCH'), startsWith('main', 'ma'), endsWith('main', 'in'))", expectedValue: true},
+		{name: "Coalesce", expression: "coalesce(parameters.missing, '', 'fallback')", expectedValue: "fallback"},
+		{name: "Format", expression: "format('{0}-{1} {{x}}', environment, parameters.count)", expectedValue: "dev-3 {x}"},
+		{name: "Case conversion", expression: "upper(lower(parameters.mode))", expectedValue: "LOCAL"},
+		{name: "Length", expression: "length(parameters.environments)", expectedValue: 2},
+		{name: "Replace", expression: "replace('a-b-c', '-', '.')", expectedValue: "a.b.c"},
+		{name: "Join", expression: "join(',', parameters.environments)", expectedValue: "dev,prod"},
+		{name: "Split", expression: "split('a;b', ';')", expectedValue: []any{"a", "b"}},
+		{name: "Iif", expression: "iif(parameters.enabled, 'on', variables.runtime)", expectedValue: "on"},
+		{name: "Convert to JSON", expression: "convertToJson(parameters.environments)", expectedValue: "[\n  \"dev\",\n  \"prod\"\n]"},
+		{name: "Number literal", expression: "eq(1.5, '1.5')", expectedValue: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			value, err := evaluateExpression(testCase.expression, context)
+
+			testutils.DeepCompare(t, testCase.expectedError, err)
+			testutils.DeepCompare(t, testCase.expectedValue, value)
+		})
+	}
+}
+
+func TestToBool(t *testing.T) {
+	testCases := []struct {
+		value    any
+		expected bool
+	}{
+		{value: nil, expected: false},
+		{value: "", expected: false},
+		{value: "false", expected: true},
+		{value: 0, expected: false},
+		{value: 0.5, expected: true},
+		{value: []any{}, expected: true},
+		{value: map[string]any{}, expected: true},
+	}
+
+	for _, testCase := range testCases {
+		testutils.DeepCompare(t, testCase.expected, toBool(testCase.value))
+	}
+}
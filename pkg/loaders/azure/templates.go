@@ -0,0 +1,463 @@
+package azure
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/expressions"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	expressionStart = "${{"
+	expressionEnd   = "}}"
+)
+
+type directiveType string
+
+const (
+	ifDirective     directiveType = "if"
+	elseIfDirective directiveType = "elseif"
+	elseDirective   directiveType = "else"
+	eachDirective   directiveType = "each"
+)
+
+var eachDirectiveRegex = regexp.MustCompile(`^each\s+([A-Za-z_][A-Za-z0-9_]*)\s+in\s+(.+)$`)
+
+// directive is a mapping key that inserts its value into the parent mapping or sequence, such as ${{ if eq(a, b) }}
+type directive struct {
+	Type       directiveType
+	Expression string // The condition of an if or elseif directive, or the collection of an each directive
+	Variable   string // The loop variable of an each directive
+}
+
+// conditionalChain tracks the if, elseif and else directives of a mapping or a sequence
+type conditionalChain struct {
+	active     bool // The previous directive is part of the chain
+	matched    bool // One of the conditions of the chain was met
+	unresolved bool // One of the conditions of the chain cannot be evaluated at compile time
+}
+
+// expandTemplate expands the compile-time expressions of a template - its parameters, variables, conditional insertions
+// and each loops - given the parameters it is imported with. Values are inserted at the location of their expressions,
+// and expressions that cannot be evaluated at compile time are left as they are.
+func expandTemplate(document *yaml.Node, parameters map[string]any) {
+	root := document
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return
+	}
+
+	context := &templateContext{
+		parameters: getTemplateParameters(root, parameters),
+		variables:  getTemplateVariables(root),
+	}
+	expandNode(root, context)
+}
+
+// getTemplateParameters returns the default values of the parameters a template declares, overridden by the parameters it is imported with
+func getTemplateParameters(root *yaml.Node, parameters map[string]any) map[string]any {
+	values := map[string]any{}
+	if declarations := getMapValue(root, "parameters"); declarations != nil {
+		switch declarations.Kind {
+		case yaml.SequenceNode:
+			for _, declaration := range declarations.Content {
+				name := getMapValue(declaration, "name")
+				if name == nil {
+					continue
+				}
+				values[name.Value] = decodeNode(getMapValue(declaration, "default"))
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(declarations.Content); i += 2 {
+				values[declarations.Content[i].Value] = decodeNode(declarations.Content[i+1])
+			}
+		}
+	}
+
+	for name, value := range parameters {
+		for declaredName := range values {
+			if strings.EqualFold(declaredName, name) {
+				delete(values, declaredName)
+			}
+		}
+		values[name] = value
+	}
+	return values
+}
+
+// getTemplateVariables returns the variables a template defines with values that are known at compile time
+func getTemplateVariables(root *yaml.Node) variableValues {
+	values := variableValues{}
+	variables := getMapValue(root, "variables")
+	if variables == nil {
+		return values
+	}
+
+	addVariable := func(name string, value *yaml.Node) {
+		if value != nil && value.Kind == yaml.ScalarNode && !strings.Contains(value.Value, expressionStart) {
+			values[name] = value.Value
+		}
+	}
+
+	switch variables.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(variables.Content); i += 2 {
+			addVariable(variables.Content[i].Value, variables.Content[i+1])
+		}
+	case yaml.SequenceNode:
+		for _, variable := range variables.Content {
+			if name := getMapValue(variable, "name"); name != nil {
+				addVariable(name.Value, getMapValue(variable, "value"))
+			}
+		}
+	}
+	return values
+}
+
+// expandNode expands the expressions of a node and returns the node to replace it with
+func expandNode(node *yaml.Node, context *templateContext) *yaml.Node {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return expandScalar(node, context)
+	case yaml.MappingNode:
+		node.Content = expandMapping(node.Content, context)
+	case yaml.SequenceNode:
+		node.Content = expandSequence(node.Content, context)
+	}
+	return node
+}
+
+func expandScalar(node *yaml.Node, context *templateContext) *yaml.Node {
+	if expression, ok := getWholeExpression(node.Value); ok {
+		value, err := evaluateExpression(expression, context)
+		if err != nil {
+			return node
+		}
+		return createNode(value, node)
+	}
+
+	if value := interpolate(node.Value, context); value != node.Value {
+		expanded := *node
+		expanded.Value = value
+		expanded.Tag = consts.StringTag
+		return &expanded
+	}
+	return node
+}
+
+func expandMapping(content []*yaml.Node, context *templateContext) []*yaml.Node {
+	var expanded []*yaml.Node
+	var chain conditionalChain
+	for i := 0; i+1 < len(content); i += 2 {
+		key, value := content[i], content[i+1]
+		directive := parseDirective(key)
+		if directive == nil {
+			chain = conditionalChain{}
+			expanded = append(expanded, expandKey(key, context), expandNode(value, context))
+			continue
+		}
+
+		bodies, contexts, ok := expandDirective(directive, value, &chain, context)
+		if !ok {
+			expanded = append(expanded, key, expandNode(value, context))
+			continue
+		}
+		for i, body := range bodies {
+			if body = expandNode(body, contexts[i]); body.Kind == yaml.MappingNode {
+				expanded = append(expanded, body.Content...)
+			}
+		}
+	}
+	return expanded
+}
+
+func expandSequence(content []*yaml.Node, context *templateContext) []*yaml.Node {
+	var expanded []*yaml.Node
+	var chain conditionalChain
+	for _, item := range content {
+		if isDirectiveMapping(item) {
+			expanded = append(expanded, expandSequenceDirectives(item, &chain, context)...)
+			continue
+		}
+		chain = conditionalChain{}
+
+		expression, ok := getWholeExpression(item.Value)
+		if item.Kind != yaml.ScalarNode || !ok {
+			expanded = append(expanded, expandNode(item, context))
+			continue
+		}
+
+		// an expression item is replaced with the items of a sequence, and removed when it has no value
+		value, err := evaluateExpression(expression, context)
+		if err != nil {
+			expanded = append(expanded, item)
+			continue
+		}
+		switch items := value.(type) {
+		case nil:
+		case []any:
+			for _, itemValue := range items {
+				expanded = append(expanded, createNode(itemValue, item))
+			}
+		default:
+			expanded = append(expanded, createNode(items, item))
+		}
+	}
+	return expanded
+}
+
+// expandSequenceDirectives expands a sequence item whose keys are all directives into the items it inserts
+func expandSequenceDirectives(item *yaml.Node, chain *conditionalChain, context *templateContext) []*yaml.Node {
+	var expanded []*yaml.Node
+	var unresolved []*yaml.Node
+	for i := 0; i+1 < len(item.Content); i += 2 {
+		key, value := item.Content[i], item.Content[i+1]
+		bodies, contexts, ok := expandDirective(parseDirective(key), value, chain, context)
+		if !ok {
+			unresolved = append(unresolved, key, expandNode(value, context))
+			continue
+		}
+		for i, body := range bodies {
+			body = expandNode(body, contexts[i])
+			switch {
+			case body.Kind == yaml.SequenceNode:
+				expanded = append(expanded, body.Content...)
+			case body.Kind == yaml.MappingNode, body.Kind == yaml.ScalarNode && body.Tag != consts.NullTag:
+				expanded = append(expanded, body)
+			}
+		}
+	}
+
+	if len(unresolved) > 0 {
+		item.Content = unresolved
+		expanded = append(expanded, item)
+	}
+	return expanded
+}
+
+// expandDirective returns the nodes a directive inserts, each with the context to expand it with.
+// It returns false if the directive cannot be evaluated at compile time.
+func expandDirective(directive *directive, value *yaml.Node, chain *conditionalChain, context *templateContext) ([]*yaml.Node, []*templateContext, bool) {
+	if directive.Type != eachDirective {
+		insert, ok := chain.next(directive, context)
+		if !ok || !insert {
+			return nil, nil, ok
+		}
+		return []*yaml.Node{value}, []*templateContext{context}, true
+	}
+
+	*chain = conditionalChain{}
+	collection, err := evaluateExpression(directive.Expression, context)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	var items []any
+	switch collection := collection.(type) {
+	case nil:
+	case []any:
+		items = collection
+	case map[string]any:
+		keys := make([]string, 0, len(collection))
+		for key := range collection {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			items = append(items, map[string]any{"key": key, "value": collection[key]})
+		}
+	default:
+		return nil, nil, false
+	}
+
+	var bodies []*yaml.Node
+	var contexts []*templateContext
+	for _, item := range items {
+		bodies = append(bodies, copyNode(value))
+		contexts = append(contexts, context.withLocal(directive.Variable, item))
+	}
+	return bodies, contexts, true
+}
+
+// next returns whether the value of a conditional directive is inserted, or false if its condition cannot be evaluated
+func (c *conditionalChain) next(directive *directive, context *templateContext) (bool, bool) {
+	if directive.Type == ifDirective {
+		*c = conditionalChain{active: true}
+	} else if !c.active || c.unresolved {
+		return false, false
+	}
+
+	if directive.Type == elseDirective {
+		c.active = false
+		return !c.matched, true
+	}
+	if c.matched {
+		return false, true
+	}
+
+	value, err := evaluateExpression(directive.Expression, context)
+	if err != nil {
+		c.unresolved = true
+		return false, false
+	}
+	c.matched = toBool(value)
+	return c.matched, true
+}
+
+func expandKey(key *yaml.Node, context *templateContext) *yaml.Node {
+	if key.Kind != yaml.ScalarNode {
+		return key
+	}
+
+	value := interpolate(key.Value, context)
+	if expression, ok := getWholeExpression(key.Value); ok {
+		if evaluated, err := evaluateExpression(expression, context); err == nil {
+			value = toString(evaluated)
+		}
+	}
+	if value == key.Value {
+		return key
+	}
+	expanded := *key
+	expanded.Value = value
+	expanded.Tag = consts.StringTag
+	return &expanded
+}
+
+// interpolate replaces the expressions embedded in a string with their values
+func interpolate(value string, context *templateContext) string {
+	var builder strings.Builder
+	offset := 0
+	for {
+		start := strings.Index(value[offset:], expressionStart)
+		if start < 0 {
+			break
+		}
+		start += offset
+
+		end := expressions.FindExpressionEnd(value, start+len(expressionStart))
+		if end < 0 {
+			break
+		}
+		builder.WriteString(value[offset:start])
+		if evaluated, err := evaluateExpression(value[start+len(expressionStart):end], context); err == nil {
+			builder.WriteString(toString(evaluated))
+		} else {
+			builder.WriteString(value[start : end+len(expressionEnd)])
+		}
+		offset = end + len(expressionEnd)
+	}
+	builder.WriteString(value[offset:])
+	return builder.String()
+}
+
+// getWholeExpression returns the expression of a value that is a single ${{ }} expression
+func getWholeExpression(value string) (string, bool) {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, expressionStart) {
+		return "", false
+	}
+	end := expressions.FindExpressionEnd(trimmed, len(expressionStart))
+	if end != len(trimmed)-len(expressionEnd) {
+		return "", false
+	}
+	return trimmed[len(expressionStart):end], true
+}
+
+func parseDirective(key *yaml.Node) *directive {
+	if key.Kind != yaml.ScalarNode {
+		return nil
+	}
+	expression, ok := getWholeExpression(key.Value)
+	if !ok {
+		return nil
+	}
+
+	expression = strings.TrimSpace(expression)
+	word, rest, _ := strings.Cut(expression, " ")
+	switch directiveType(word) {
+	case ifDirective, elseIfDirective:
+		return &directive{Type: directiveType(word), Expression: rest}
+	case elseDirective:
+		if strings.TrimSpace(rest) == "" {
+			return &directive{Type: elseDirective}
+		}
+	case eachDirective:
+		if match := eachDirectiveRegex.FindStringSubmatch(expression); match != nil {
+			return &directive{Type: eachDirective, Variable: match[1], Expression: match[2]}
+		}
+	}
+	return nil
+}
+
+func isDirectiveMapping(node *yaml.Node) bool {
+	if node.Kind != yaml.MappingNode || len(node.Content) == 0 {
+		return false
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if parseDirective(node.Content[i]) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// createNode creates the node of an expression value, at the location of the expression
+func createNode(value any, location *yaml.Node) *yaml.Node {
+	if number, ok := value.(float64); ok && number == math.Trunc(number) && math.Abs(number) < math.MaxInt32 {
+		value = int(number)
+	}
+
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return location
+	}
+	setLocation(&node, location.Line, location.Column)
+	return &node
+}
+
+func setLocation(node *yaml.Node, line, column int) {
+	node.Line = line
+	node.Column = column
+	for _, child := range node.Content {
+		setLocation(child, line, column)
+	}
+}
+
+func copyNode(node *yaml.Node) *yaml.Node {
+	copied := *node
+	copied.Content = nil
+	for _, child := range node.Content {
+		copied.Content = append(copied.Content, copyNode(child))
+	}
+	return &copied
+}
+
+func getMapValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func decodeNode(node *yaml.Node) any {
+	if node == nil {
+		return nil
+	}
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,193 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	testCases := []struct {
+		name         string
+		template     string
+		parameters   map[string]any
+		expectedYaml string
+	}{
+		{
+			name: "Parameter defaults and imported parameters",
+			template: `
+parameters:
+  - name: configuration
+    default: Release
+  - name: image
+    default: ubuntu-latest
+  - name: steps
+    type: stepList
+    default: []
+pool:
+  vmImage: ${{ parameters.image }}
+steps:
+  - script: make ${{ parameters.configuration }} TARGET=${{ parameters.target }}
+  - ${{ parameters.steps }}
+`,
+			parameters: map[string]any{
+				"Image": "windows-latest",
+				"steps": []any{map[string]any{"script": "echo one"}, map[string]any{"script": "echo two"}},
+			},
+			expectedYaml: `
+parameters:
+  - name: configuration
+    default: Release
+  - name: image
+    default: ubuntu-latest
+  - name: steps
+    type: stepList
+    default: []
+pool:
+  vmImage: windows-latest
+steps:
+  - script: make Release TARGET=
+  - script: echo one
+  - script: echo two
+`,
+		},
+		{
+			name: "Legacy parameters and variables",
+			template: `
+parameters:
+  name: world
+variables:
+  greeting: hello
+steps:
+  - script: echo ${{ variables.greeting }} ${{ parameters.name }} ${{ variables['Build.SourceBranch'] }}
+`,
+			expectedYaml: `
+parameters:
+  name: world
+variables:
+  greeting: hello
+steps:
+  - script: echo hello world ${{ variables['Build.SourceBranch'] }}
+`,
+		},
+		{
+			name: "Conditional insertion",
+			template: `
+steps:
+  - ${{ if eq(parameters.mode, 'debug') }}:
+      - script: echo debug
+  - ${{ elseif eq(parameters.mode, 'release') }}:
+      - script: echo release
+  - ${{ else }}:
+      - script: echo other
+  - script: echo always
+    ${{ if parameters.verbose }}:
+      env:
+        VERBOSE: true
+    ${{ else }}:
+      displayName: quiet
+`,
+			parameters: map[string]any{"mode": "Release", "verbose": true},
+			expectedYaml: `
+steps:
+  - script: echo release
+  - script: echo always
+    env:
+      VERBOSE: true
+`,
+		},
+		{
+			name: "Each loops",
+			template: `
+jobs:
+  - ${{ each environment in parameters.environments }}:
+      - job: deploy_${{ environment }}
+        variables:
+          ${{ each setting in parameters.settings }}:
+            ${{ setting.key }}_${{ environment }}: ${{ setting.value }}
+`,
+			parameters: map[string]any{
+				"environments": []any{"dev", "prod"},
+				"settings":     map[string]any{"retries": 3, "region": "eu"},
+			},
+			expectedYaml: `
+jobs:
+  - job: deploy_dev
+    variables:
+      region_dev: eu
+      retries_dev: 3
+  - job: deploy_prod
+    variables:
+      region_prod: eu
+      retries_prod: 3
+`,
+		},
+		{
+			name: "Runtime expressions are left as they are",
+			template: `
+steps:
+  - ${{ if eq(variables['Build.Reason'], 'PullRequest') }}:
+      - script: echo ${{ parameters.name }}
+  - ${{ else }}:
+      - script: echo push
+  - ${{ each step in dependencies.steps }}:
+      - ${{ step }}
+  - script: echo $(Build.BuildId)
+`,
+			parameters: map[string]any{"name": "test"},
+			expectedYaml: `
+steps:
+  - ${{ if eq(variables['Build.Reason'], 'PullRequest') }}:
+      - script: echo test
+  - ${{ else }}:
+      - script: echo push
+  - ${{ each step in dependencies.steps }}:
+      - ${{ step }}
+  - script: echo $(Build.BuildId)
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var document yaml.Node
+			if err := yaml.Unmarshal([]byte(testCase.template), &document); err != nil {
+				t.Fatal(err)
+			}
+			expandTemplate(&document, testCase.parameters)
+
+			var expanded, expected any
+			if err := document.Decode(&expanded); err != nil {
+				t.Fatal(err)
+			}
+			if err := yaml.Unmarshal([]byte(testCase.expectedYaml), &expected); err != nil {
+				t.Fatal(err)
+			}
+			testutils.DeepCompare(t, expected, expanded)
+		})
+	}
+}
+
+func TestExpandTemplateLocation(t *testing.T) {
+	template := `steps:
+  - ${{ each name in parameters.names }}:
+      - script: echo ${{ name }}
+  - ${{ parameters.step }}
+`
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(template), &document); err != nil {
+		t.Fatal(err)
+	}
+	expandTemplate(&document, map[string]any{
+		"names": []any{"a", "b"},
+		"step":  map[string]any{"script": "echo c"},
+	})
+
+	var locations [][2]int
+	for _, step := range document.Content[0].Content[1].Content {
+		script := step.Content[1]
+		locations = append(locations, [2]int{script.Line, script.Column})
+	}
+	testutils.DeepCompare(t, [][2]int{{3, 17}, {3, 17}, {4, 5}}, locations)
+}
@@ -80,6 +80,84 @@ func TestLoad(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name:     "parallel steps with fail-fast",
+			filename: "../../../test/fixtures/bitbucket/parallel-fail-fast.yml",
+			expectedPipeline: &bbModels.Pipeline{
+				Image: &bbModels.Image{
+					ImageData: &bbModels.ImageData{
+						Name: utils.GetPtr("node:16"),
+					},
+				},
+				Pipelines: &bbModels.BuildPipelines{
+					Default: []*bbModels.Step{
+						{
+							Step: &bbModels.ExecutionUnitRef{
+								ExecutionUnit: &bbModels.ExecutionUnit{
+									Name: utils.GetPtr("Build"),
+									Script: []*bbModels.Script{
+										{
+											String:        utils.GetPtr("npm install"),
+											FileReference: testutils.CreateFileReference(8, 13, 8, 24),
+										},
+									},
+								},
+								FileReference: testutils.CreateFileReference(5, 7, 8, 24),
+							},
+						},
+						{
+							FailFast: utils.GetPtr(true),
+							Parallel: []*bbModels.ParallelSteps{
+								{
+									Step: &bbModels.ExecutionUnitRef{
+										ExecutionUnit: &bbModels.ExecutionUnit{
+											Name: utils.GetPtr("Unit tests"),
+											Script: []*bbModels.Script{
+												{
+													String:        utils.GetPtr("npm test"),
+													FileReference: testutils.CreateFileReference(15, 19, 15, 27),
+												},
+											},
+										},
+										FileReference: testutils.CreateFileReference(12, 13, 15, 27),
+									},
+								},
+								{
+									Step: &bbModels.ExecutionUnitRef{
+										ExecutionUnit: &bbModels.ExecutionUnit{
+											Name: utils.GetPtr("Lint"),
+											Script: []*bbModels.Script{
+												{
+													String:        utils.GetPtr("npx eslint ."),
+													FileReference: testutils.CreateFileReference(19, 19, 19, 31),
+												},
+											},
+										},
+										FileReference: testutils.CreateFileReference(16, 13, 19, 31),
+									},
+								},
+							},
+						},
+						{
+							Step: &bbModels.ExecutionUnitRef{
+								ExecutionUnit: &bbModels.ExecutionUnit{
+									Name:    utils.GetPtr("Deploy"),
+									Trigger: utils.GetPtr(bbModels.MANUAL),
+									Script: []*bbModels.Script{
+										{
+											String:        utils.GetPtr("npm run deploy"),
+											FileReference: testutils.CreateFileReference(24, 13, 24, 27),
+										},
+									},
+								},
+								FileReference: testutils.CreateFileReference(20, 7, 24, 27),
+							},
+						},
+					},
+				},
+			},
+			expectedError: nil,
+		},
 		{
 			name:     "sync steps",
 			filename: "../../../test/fixtures/bitbucket/sync-steps.yml",
@@ -283,6 +361,7 @@ func TestLoad(t *testing.T) {
 								},
 								FileReference: testutils.CreateFileReference(16, 9, 18, 26),
 							},
+							FileReference: testutils.CreateFileReference(14, 7, 18, 26),
 						},
 					},
 				},
@@ -423,7 +502,8 @@ func TestLoad(t *testing.T) {
 					},
 					Services: map[string]*bbModels.Service{
 						"docker": {
-							Memory: utils.GetPtr(int64(2048)),
+							Memory:        utils.GetPtr(int64(2048)),
+							FileReference: testutils.CreateFileReference(6, 7, 6, 19),
 						},
 					},
 					Steps: []*bbModels.Step{
@@ -518,3 +598,49 @@ func TestLoad(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadParallelFailFastValues(t *testing.T) {
+	testCases := []struct {
+		name             string
+		failFast         string
+		expectedFailFast *bool
+		expectError      bool
+	}{
+		{
+			name:             "Capitalized boolean",
+			failFast:         "True",
+			expectedFailFast: utils.GetPtr(true),
+		},
+		{
+			name:        "String value",
+			failFast:    "yes",
+			expectError: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			data := `pipelines:
+  default:
+    - parallel:
+        fail-fast: ` + testCase.failFast + `
+        steps:
+          - step:
+              script:
+                - npm test
+`
+			loader := &BitbucketLoader{}
+			pipeline, err := loader.Load([]byte(data))
+			if testCase.expectError {
+				if err == nil {
+					t.Errorf("Expected an error for fail-fast: %s", testCase.failFast)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutils.DeepCompare(t, testCase.expectedFailFast, pipeline.Pipelines.Default[0].FailFast)
+		})
+	}
+}
@@ -1,7 +1,27 @@
 package models
 
+import (
+	loadersUtils "github.com/argonsecurity/pipeline-parser/pkg/loaders/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
 type Service struct {
-	Image     *Image                  `yaml:"image"`
-	Memory    *int64                  `yaml:"memory,omitempty"`    // Memory limit for the service container, in megabytes
-	Variables *EnvironmentVariablesRef `yaml:"variables,omitempty"` // Environment variables passed to the service container
+	Image         *Image                   `yaml:"image"`
+	Memory        *int64                   `yaml:"memory,omitempty"`    // Memory limit for the service container, in megabytes
+	Type          *string                  `yaml:"type,omitempty"`      // The type of the service, e.g. docker for the docker daemon service
+	Variables     *EnvironmentVariablesRef `yaml:"variables,omitempty"` // Environment variables passed to the service container
+	FileReference *models.FileReference    `yaml:"-"`
+}
+
+func (s *Service) UnmarshalYAML(node *yaml.Node) error {
+	type service Service
+	var decoded service
+	if err := node.Decode(&decoded); err != nil {
+		return err
+	}
+
+	*s = Service(decoded)
+	s.FileReference = loadersUtils.GetFileReference(node)
+	return nil
 }
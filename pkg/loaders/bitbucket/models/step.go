@@ -1,6 +1,7 @@
 package models
 
 import (
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
 	loadersUtils "github.com/argonsecurity/pipeline-parser/pkg/loaders/utils"
 	"gopkg.in/yaml.v3"
 )
@@ -10,6 +11,7 @@ type StepMap map[string][]*Step
 type Step struct {
 	Step      *ExecutionUnitRef     `yaml:"step,omitempty"`
 	Parallel  []*ParallelSteps      `yaml:"parallel"`
+	FailFast  *bool                 `yaml:"fail-fast"` // Whether the parallel steps are stopped when one of them fails
 	Variables []*CustomStepVariable `yaml:"variables"` // List of variables for the custom pipeline
 }
 
@@ -39,6 +41,9 @@ func (s *Step) UnmarshalYAML(node *yaml.Node) error {
 	return loadersUtils.IterateOnMap(node, func(key string, value *yaml.Node) error {
 		switch key {
 		case "parallel":
+			if value.Kind == yaml.MappingNode && hasKey(value, "steps") {
+				return s.parseParallelGroup(value)
+			}
 			var parallel []*ParallelSteps
 			if err := loadersUtils.ParseSequenceOrOne(value, &parallel); err != nil {
 				return err
@@ -67,6 +72,39 @@ func (s *Step) UnmarshalYAML(node *yaml.Node) error {
 	}, "Step")
 }
 
+// parseParallelGroup parses the parallel steps with options, e.g. `parallel: {fail-fast: true, steps: [...]}`
+func (s *Step) parseParallelGroup(node *yaml.Node) error {
+	return loadersUtils.IterateOnMap(node, func(key string, value *yaml.Node) error {
+		switch key {
+		case "fail-fast":
+			if value.Tag != consts.BooleanTag {
+				return consts.NewErrInvalidYamlTag(value.Tag, "FailFast")
+			}
+			var failFast bool
+			if err := value.Decode(&failFast); err != nil {
+				return err
+			}
+			s.FailFast = &failFast
+		case "steps":
+			var parallel []*ParallelSteps
+			if err := loadersUtils.ParseSequenceOrOne(value, &parallel); err != nil {
+				return err
+			}
+			s.Parallel = parallel
+		}
+		return nil
+	}, "Parallel")
+}
+
+func hasKey(node *yaml.Node, key string) bool {
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return true
+		}
+	}
+	return false
+}
+
 func (sm *StepMap) UnmarshalYAML(node *yaml.Node) error {
 	var stepMap = make(map[string][]*Step)
 	if err := loadersUtils.IterateOnMap(node, func(key string, value *yaml.Node) error {
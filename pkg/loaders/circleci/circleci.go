@@ -0,0 +1,14 @@
+package circleci
+
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci/models"
+	"gopkg.in/yaml.v3"
+)
+
+type CircleCILoader struct{}
+
+func (c *CircleCILoader) Load(data []byte) (*models.Config, error) {
+	config := &models.Config{}
+	err := yaml.Unmarshal(data, config)
+	return config, err
+}
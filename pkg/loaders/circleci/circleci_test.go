@@ -0,0 +1,145 @@
+package circleci
+
+import (
+	"testing"
+
+	circleciModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci/models"
+	commonModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/common/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestLoad(t *testing.T) {
+	testCases := []struct {
+		name           string
+		data           string
+		expectedConfig *circleciModels.Config
+		expectedError  bool
+	}{
+		{
+			name: "Config with orbs, jobs and workflows",
+			data: `version: 2.1
+orbs:
+  node: circleci/node@5.1.0
+jobs:
+  build:
+    executor:
+      name: node/default
+      tag: "18.16"
+    machine: true
+    steps:
+      - checkout
+      - node/install-packages:
+          cache-path: ~/.npm
+      - run: npm test
+workflows:
+  version: 2
+  main:
+    jobs:
+      - build:
+          name: build-18
+          requires:
+            - lint: [success, failed]
+          context: [aws, docker]
+          filters:
+            tags:
+              ignore: /.*/
+`,
+			expectedConfig: &circleciModels.Config{
+				Version: utils.GetPtr("2.1"),
+				Orbs: &circleciModels.Orbs{
+					"node": {
+						Name:          "node",
+						Reference:     utils.GetPtr("circleci/node"),
+						Version:       utils.GetPtr("5.1.0"),
+						FileReference: testutils.CreateFileReference(3, 3, 3, 22),
+					},
+				},
+				Jobs: &circleciModels.Jobs{
+					"build": {
+						Executor: circleciModels.Executor{
+							Machine: &circleciModels.Machine{
+								FileReference: testutils.CreateFileReference(9, 14, 9, 18),
+							},
+						},
+						ExecutorRef: &circleciModels.ExecutorRef{
+							Name:          "node/default",
+							Parameters:    map[string]any{"tag": "18.16"},
+							FileReference: testutils.CreateFileReference(7, 7, 8, 17),
+						},
+						Steps: []*circleciModels.Step{
+							{
+								Name:          "checkout",
+								FileReference: testutils.CreateFileReference(11, 9, 11, 17),
+							},
+							{
+								Name: "node/install-packages",
+								Parameters: &commonModels.Map{
+									Values: []*commonModels.MapEntry{
+										{
+											Key:           "cache-path",
+											Value:         "~/.npm",
+											FileReference: testutils.CreateFileReference(13, 11, 13, 17),
+										},
+									},
+									FileReference: testutils.CreateFileReference(11, 7, 13, 17),
+								},
+								FileReference: testutils.CreateFileReference(12, 9, 13, 29),
+							},
+							{
+								Name: "run",
+								Run: &circleciModels.Run{
+									Command:       "npm test",
+									FileReference: testutils.CreateFileReference(14, 9, 14, 17),
+								},
+								FileReference: testutils.CreateFileReference(14, 9, 14, 22),
+							},
+						},
+						FileReference: testutils.CreateFileReference(5, 3, 14, 17),
+					},
+				},
+				Workflows: &circleciModels.Workflows{
+					"main": {
+						Jobs: []*circleciModels.WorkflowJob{
+							{
+								JobName:  "build",
+								Name:     utils.GetPtr("build-18"),
+								Requires: circleciModels.Requires{"lint"},
+								Context:  circleciModels.StringList{"aws", "docker"},
+								Filters: &circleciModels.Filters{
+									Tags: &circleciModels.Filter{
+										Ignore: circleciModels.StringList{"/.*/"},
+									},
+								},
+								FileReference: testutils.CreateFileReference(19, 9, 26, 27),
+							},
+						},
+						FileReference: testutils.CreateFileReference(17, 3, 26, 27),
+					},
+				},
+			},
+		},
+		{
+			name:          "Invalid yaml",
+			data:          "jobs: [",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			loader := &CircleCILoader{}
+			got, err := loader.Load([]byte(testCase.data))
+
+			if testCase.expectedError {
+				if err == nil {
+					t.Errorf("expected an error, got nil")
+				}
+				return
+			}
+
+			testutils.DeepCompare(t, nil, err)
+			testutils.DeepCompare(t, testCase.expectedConfig, got)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	loadersUtils "github.com/argonsecurity/pipeline-parser/pkg/loaders/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+type EnvironmentVariablesRef struct {
+	models.EnvironmentVariables
+	FileReference *models.FileReference
+}
+
+func (e *EnvironmentVariablesRef) UnmarshalYAML(node *yaml.Node) error {
+	var env models.EnvironmentVariables
+	if err := node.Decode(&env); err != nil {
+		return err
+	}
+
+	e.EnvironmentVariables = env
+	e.FileReference = loadersUtils.GetFileReference(node)
+	return nil
+}
+
+// StringList is a yaml tag that is either a single string or a list of strings
+type StringList []string
+
+func (s *StringList) UnmarshalYAML(node *yaml.Node) error {
+	var values []string
+	if err := loadersUtils.ParseSequenceOrOne(node, &values); err != nil {
+		return err
+	}
+	*s = values
+	return nil
+}
@@ -0,0 +1,10 @@
+package models
+
+type Config struct {
+	Version   *string    `yaml:"version"`
+	Orbs      *Orbs      `yaml:"orbs,omitempty"`
+	Executors *Executors `yaml:"executors,omitempty"`
+	Commands  *Commands  `yaml:"commands,omitempty"`
+	Jobs      *Jobs      `yaml:"jobs,omitempty"`
+	Workflows *Workflows `yaml:"workflows,omitempty"`
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	loadersUtils "github.com/argonsecurity/pipeline-parser/pkg/loaders/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+type Executors map[string]*Executor
+
+// Executor is the environment a job runs in. The same keys may be set directly on a job.
+type Executor struct {
+	Docker           []*DockerImage           `yaml:"docker,omitempty"`
+	Machine          *Machine                 `yaml:"machine,omitempty"`
+	Macos            *Macos                   `yaml:"macos,omitempty"`
+	ResourceClass    *string                  `yaml:"resource_class,omitempty"`
+	Shell            *string                  `yaml:"shell,omitempty"`
+	WorkingDirectory *string                  `yaml:"working_directory,omitempty"`
+	Environment      *EnvironmentVariablesRef `yaml:"environment,omitempty"`
+	FileReference    *models.FileReference    `yaml:"-"`
+}
+
+type DockerImage struct {
+	Image         string                   `yaml:"image"`
+	Name          *string                  `yaml:"name,omitempty"`
+	User          *string                  `yaml:"user,omitempty"`
+	Environment   *EnvironmentVariablesRef `yaml:"environment,omitempty"`
+	Auth          *DockerAuth              `yaml:"auth,omitempty"`
+	FileReference *models.FileReference
+}
+
+type DockerAuth struct {
+	Username *string `yaml:"username,omitempty"`
+	Password *string `yaml:"password,omitempty"`
+}
+
+type Machine struct {
+	Image         *string `yaml:"image,omitempty"`
+	FileReference *models.FileReference
+}
+
+type Macos struct {
+	Xcode *string `yaml:"xcode,omitempty"`
+}
+
+// ExecutorRef references an executor by name, e.g. `executor: node/default` or `executor: { name: node/default }`
+type ExecutorRef struct {
+	Name          string
+	Parameters    map[string]any
+	FileReference *models.FileReference
+}
+
+func (e *Executors) UnmarshalYAML(node *yaml.Node) error {
+	executors := Executors{}
+	if err := loadersUtils.IterateOnMap(node, func(key string, value *yaml.Node) error {
+		var executor Executor
+		if err := value.Decode(&executor); err != nil {
+			return err
+		}
+		executor.FileReference = loadersUtils.GetFileReference(value)
+		executors[key] = &executor
+		return nil
+	}, "Executors"); err != nil {
+		return err
+	}
+
+	*e = executors
+	return nil
+}
+
+func (d *DockerImage) UnmarshalYAML(node *yaml.Node) error {
+	type dockerImage DockerImage
+	var image dockerImage
+	if err := node.Decode(&image); err != nil {
+		return err
+	}
+
+	*d = DockerImage(image)
+	d.FileReference = loadersUtils.GetFileReference(node)
+	return nil
+}
+
+func (m *Machine) UnmarshalYAML(node *yaml.Node) error {
+	m.FileReference = loadersUtils.GetFileReference(node)
+	if node.Tag == consts.BooleanTag {
+		return nil
+	}
+
+	return loadersUtils.IterateOnMap(node, func(key string, value *yaml.Node) error {
+		if key == "image" {
+			m.Image = &value.Value
+		}
+		return nil
+	}, "Machine")
+}
+
+func (e *ExecutorRef) UnmarshalYAML(node *yaml.Node) error {
+	e.FileReference = loadersUtils.GetFileReference(node)
+	if node.Tag == consts.StringTag {
+		e.Name = node.Value
+		return nil
+	}
+
+	parameters := map[string]any{}
+	if err := loadersUtils.IterateOnMap(node, func(key string, value *yaml.Node) error {
+		if key == "name" {
+			e.Name = value.Value
+			return nil
+		}
+		parameters[key] = loadersUtils.GetNodeValue(value)
+		return nil
+	}, "ExecutorRef"); err != nil {
+		return err
+	}
+
+	if len(parameters) > 0 {
+		e.Parameters = parameters
+	}
+	return nil
+}
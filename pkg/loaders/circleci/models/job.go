@@ -0,0 +1,61 @@
+package models
+
+import (
+	loadersUtils "github.com/argonsecurity/pipeline-parser/pkg/loaders/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+type Jobs map[string]*Job
+
+type Job struct {
+	Executor      `yaml:",inline"`
+	ExecutorRef   *ExecutorRef `yaml:"executor,omitempty"`
+	Steps         []*Step      `yaml:"steps,omitempty"`
+	Parallelism   *int         `yaml:"parallelism,omitempty"`
+	FileReference *models.FileReference
+}
+
+type Commands map[string]*Command
+
+type Command struct {
+	Description   *string `yaml:"description,omitempty"`
+	Steps         []*Step `yaml:"steps,omitempty"`
+	FileReference *models.FileReference
+}
+
+func (j *Jobs) UnmarshalYAML(node *yaml.Node) error {
+	jobs := Jobs{}
+	if err := loadersUtils.IterateOnMap(node, func(key string, value *yaml.Node) error {
+		var job Job
+		if err := value.Decode(&job); err != nil {
+			return err
+		}
+		job.FileReference = loadersUtils.GetFileReference(value)
+		jobs[key] = &job
+		return nil
+	}, "Jobs"); err != nil {
+		return err
+	}
+
+	*j = jobs
+	return nil
+}
+
+func (c *Commands) UnmarshalYAML(node *yaml.Node) error {
+	commands := Commands{}
+	if err := loadersUtils.IterateOnMap(node, func(key string, value *yaml.Node) error {
+		var command Command
+		if err := value.Decode(&command); err != nil {
+			return err
+		}
+		command.FileReference = loadersUtils.GetFileReference(value)
+		commands[key] = &command
+		return nil
+	}, "Commands"); err != nil {
+		return err
+	}
+
+	*c = commands
+	return nil
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	loadersUtils "github.com/argonsecurity/pipeline-parser/pkg/loaders/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+type Orbs map[string]*Orb
+
+type Orb struct {
+	Name          string
+	Reference     *string // The orb in the registry, e.g. circleci/node. nil for inline orbs
+	Version       *string
+	FileReference *models.FileReference
+}
+
+func (o *Orbs) UnmarshalYAML(node *yaml.Node) error {
+	orbs := Orbs{}
+	if err := loadersUtils.IterateOnMap(node, func(key string, value *yaml.Node) error {
+		orb := &Orb{
+			Name:          key,
+			FileReference: loadersUtils.GetFileReference(value),
+		}
+
+		if value.Tag == consts.StringTag {
+			reference, version, _ := strings.Cut(value.Value, "@")
+			orb.Reference = &reference
+			if version != "" {
+				orb.Version = &version
+			}
+		}
+
+		orbs[key] = orb
+		return nil
+	}, "Orbs"); err != nil {
+		return err
+	}
+
+	*o = orbs
+	return nil
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	commonModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/common/models"
+	loadersUtils "github.com/argonsecurity/pipeline-parser/pkg/loaders/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+const runStep = "run"
+
+// Step is either a run step, a built-in step (e.g. checkout), a command defined in the config or an orb command
+type Step struct {
+	Name          string
+	Run           *Run
+	Parameters    *commonModels.Map
+	FileReference *models.FileReference
+}
+
+type Run struct {
+	Name             *string                  `yaml:"name,omitempty"`
+	Command          string                   `yaml:"command"`
+	Shell            *string                  `yaml:"shell,omitempty"`
+	WorkingDirectory *string                  `yaml:"working_directory,omitempty"`
+	Environment      *EnvironmentVariablesRef `yaml:"environment,omitempty"`
+	When             *string                  `yaml:"when,omitempty"`
+	FileReference    *models.FileReference
+}
+
+func (s *Step) UnmarshalYAML(node *yaml.Node) error {
+	s.FileReference = loadersUtils.GetFileReference(node)
+	if node.Tag == consts.StringTag {
+		s.Name = node.Value
+		return nil
+	}
+
+	return loadersUtils.IterateOnMap(node, func(key string, value *yaml.Node) error {
+		s.Name = key
+		if key == runStep {
+			var run Run
+			if err := value.Decode(&run); err != nil {
+				return err
+			}
+			s.Run = &run
+			return nil
+		}
+
+		if value.Tag == consts.MapTag {
+			var parameters commonModels.Map
+			if err := value.Decode(&parameters); err != nil {
+				return err
+			}
+			s.Parameters = &parameters
+		}
+		return nil
+	}, "Step")
+}
+
+func (r *Run) UnmarshalYAML(node *yaml.Node) error {
+	if node.Tag == consts.StringTag {
+		r.Command = node.Value
+		r.FileReference = loadersUtils.GetFileReference(node)
+		return nil
+	}
+
+	type run Run
+	var parsedRun run
+	if err := node.Decode(&parsedRun); err != nil {
+		return err
+	}
+
+	*r = Run(parsedRun)
+	r.FileReference = loadersUtils.GetFileReference(node)
+	return nil
+}
@@ -0,0 +1,150 @@
+package models
+
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	loadersUtils "github.com/argonsecurity/pipeline-parser/pkg/loaders/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+type Workflows map[string]*Workflow
+
+type Workflow struct {
+	Jobs          []*WorkflowJob     `yaml:"jobs,omitempty"`
+	Triggers      []*WorkflowTrigger `yaml:"triggers,omitempty"`
+	FileReference *models.FileReference
+}
+
+// WorkflowJob is a job invocation in a workflow, e.g. `- test: { requires: [build] }`
+type WorkflowJob struct {
+	JobName       string
+	Name          *string    `yaml:"name,omitempty"` // Overrides the job name in the workflow
+	Requires      Requires   `yaml:"requires,omitempty"`
+	Context       StringList `yaml:"context,omitempty"`
+	Filters       *Filters   `yaml:"filters,omitempty"`
+	Type          *string    `yaml:"type,omitempty"`
+	Parameters    map[string]any
+	FileReference *models.FileReference
+}
+
+type WorkflowTrigger struct {
+	Schedule      *Schedule `yaml:"schedule,omitempty"`
+	FileReference *models.FileReference
+}
+
+type Schedule struct {
+	Cron    string   `yaml:"cron"`
+	Filters *Filters `yaml:"filters,omitempty"`
+}
+
+type Filters struct {
+	Branches *Filter `yaml:"branches,omitempty"`
+	Tags     *Filter `yaml:"tags,omitempty"`
+}
+
+type Filter struct {
+	Only   StringList `yaml:"only,omitempty"`
+	Ignore StringList `yaml:"ignore,omitempty"`
+}
+
+// Requires is the list of jobs a workflow job waits for, e.g. `[build]` or `[build: [success, failed]]`
+type Requires []string
+
+var workflowJobKeys = []string{"name", "requires", "context", "filters", "type", "matrix", "pre-steps", "post-steps"}
+
+func (w *Workflows) UnmarshalYAML(node *yaml.Node) error {
+	workflows := Workflows{}
+	if err := loadersUtils.IterateOnMap(node, func(key string, value *yaml.Node) error {
+		if key == "version" { // Workflows of config version 2.0 have a version key
+			return nil
+		}
+
+		var workflow Workflow
+		if err := value.Decode(&workflow); err != nil {
+			return err
+		}
+		workflow.FileReference = loadersUtils.GetFileReference(value)
+		workflows[key] = &workflow
+		return nil
+	}, "Workflows"); err != nil {
+		return err
+	}
+
+	*w = workflows
+	return nil
+}
+
+func (j *WorkflowJob) UnmarshalYAML(node *yaml.Node) error {
+	j.FileReference = loadersUtils.GetFileReference(node)
+	if node.Tag == consts.StringTag {
+		j.JobName = node.Value
+		return nil
+	}
+
+	return loadersUtils.IterateOnMap(node, func(key string, value *yaml.Node) error {
+		j.JobName = key
+		if value.Tag != consts.MapTag {
+			return nil
+		}
+
+		type workflowJob WorkflowJob
+		var job workflowJob
+		if err := value.Decode(&job); err != nil {
+			return err
+		}
+		j.Name = job.Name
+		j.Requires = job.Requires
+		j.Context = job.Context
+		j.Filters = job.Filters
+		j.Type = job.Type
+
+		parameters := map[string]any{}
+		if err := loadersUtils.IterateOnMap(value, func(parameterKey string, parameterValue *yaml.Node) error {
+			if !utils.SliceContains(workflowJobKeys, parameterKey) {
+				parameters[parameterKey] = loadersUtils.GetNodeValue(parameterValue)
+			}
+			return nil
+		}, "WorkflowJob"); err != nil {
+			return err
+		}
+		if len(parameters) > 0 {
+			j.Parameters = parameters
+		}
+		return nil
+	}, "WorkflowJob")
+}
+
+func (r *Requires) UnmarshalYAML(node *yaml.Node) error {
+	if node.Tag == consts.StringTag {
+		*r = Requires{node.Value}
+		return nil
+	}
+
+	if node.Tag != consts.SequenceTag {
+		return consts.NewErrInvalidYamlTag(node.Tag, "Requires")
+	}
+
+	requires := Requires{}
+	for _, requirement := range node.Content {
+		if requirement.Tag == consts.MapTag && len(requirement.Content) > 0 {
+			requires = append(requires, requirement.Content[0].Value)
+		} else {
+			requires = append(requires, requirement.Value)
+		}
+	}
+	*r = requires
+	return nil
+}
+
+func (t *WorkflowTrigger) UnmarshalYAML(node *yaml.Node) error {
+	type workflowTrigger WorkflowTrigger
+	var trigger workflowTrigger
+	if err := node.Decode(&trigger); err != nil {
+		return err
+	}
+
+	*t = WorkflowTrigger(trigger)
+	t.FileReference = loadersUtils.GetFileReference(node)
+	return nil
+}
@@ -363,6 +363,7 @@ func TestLoad(t *testing.T) {
 								Pages:              "read",
 								RepositoryProjects: "read",
 								SecurityEvents:     "read",
+								FileReference:      testutils.CreateFileReference(10, 18, 10, 26),
 							},
 							FileReference: testutils.CreateFileReference(8, 3, 10, 26),
 						},
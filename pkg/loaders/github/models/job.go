@@ -85,19 +85,44 @@ func (c *Concurrency) UnmarshalYAML(node *yaml.Node) error {
 	return nil
 }
 
+type JobOutput struct {
+	Name          string
+	Value         string
+	FileReference *models.FileReference
+}
+
+type JobOutputs []*JobOutput
+
+func (o *JobOutputs) UnmarshalYAML(node *yaml.Node) error {
+	if node.Tag != consts.MapTag {
+		return consts.NewErrInvalidYamlTag(node.Tag, "outputs")
+	}
+
+	for i := 0; i < len(node.Content); i += 2 {
+		keyNode := node.Content[i]
+		valueNode := node.Content[i+1]
+		*o = append(*o, &JobOutput{
+			Name:          keyNode.Value,
+			Value:         valueNode.Value,
+			FileReference: loadersUtils.GetMapKeyFileReference(keyNode, valueNode),
+		})
+	}
+	return nil
+}
+
 // Job is a normal CI job
 type Job struct {
 	ID              *string                  `yaml:"id"`
 	Concurrency     *Concurrency             `yaml:"concurrency,omitempty"`
-	Container       interface{}              `yaml:"container,omitempty"`
+	Container       *Container               `yaml:"container,omitempty"`
 	ContinueOnError *string                  `yaml:"continue-on-error,omitempty"`
 	Defaults        *Defaults                `yaml:"defaults,omitempty"`
 	Env             *EnvironmentVariablesRef `yaml:"env,omitempty"`
-	Environment     interface{}              `yaml:"environment,omitempty"`
+	Environment     *Environment             `yaml:"environment,omitempty"`
 	If              string                   `yaml:"if,omitempty"`
 	Name            string                   `yaml:"name,omitempty"`
 	Needs           *Needs                   `yaml:"needs,omitempty"`
-	Outputs         map[string]string        `yaml:"outputs,omitempty"`
+	Outputs         *JobOutputs              `yaml:"outputs,omitempty"`
 	Permissions     *PermissionsEvent        `yaml:"permissions,omitempty"`
 	RunsOn          *RunsOn                  `yaml:"runs-on"`
 	Services        map[string]*Container    `yaml:"services,omitempty"`
@@ -57,6 +57,7 @@ func (p *PermissionsEvent) UnmarshalYAML(node *yaml.Node) error {
 		case writeAll:
 			*p = *createFullPermissions("write")
 		}
+		p.FileReference = loadersUtils.GetFileReference(node)
 		return nil
 	}
 
@@ -81,6 +81,10 @@ func (on *On) UnmarshalYAML(node *yaml.Node) error {
 		return nil
 	}
 
+	if node.Tag == consts.StringTag { // format - "on: push"
+		return on.unmarshalString(node.Value, &yaml.Node{}, on.FileReference)
+	}
+
 	for i := 0; i < len(node.Content); i += 2 {
 		if err := on.unmarshalNode(node.Content[i], node.Content[i+1]); err != nil {
 			return err
@@ -105,34 +109,34 @@ func (on *On) unmarshalString(key string, val *yaml.Node, fileReference *models.
 		err = val.Decode(&on.Schedule.Crons)
 	case "push":
 		on.Push = &Ref{FileReference: fileReference}
-		if !val.IsZero() {
+		if !isEmptyNode(val) {
 			err = val.Decode(&on.Push)
 		}
 	case "pull_request":
 		on.PullRequest = &Ref{FileReference: fileReference}
-		if !val.IsZero() {
+		if !isEmptyNode(val) {
 			err = val.Decode(&on.PullRequest)
 		}
 	case "pull_request_target":
 		on.PullRequestTarget = &Ref{FileReference: fileReference}
-		if !val.IsZero() {
+		if !isEmptyNode(val) {
 			err = val.Decode(&on.PullRequestTarget)
 		}
 	case "workflow_call":
 		on.WorkflowCall = &WorkflowCall{FileReference: fileReference}
-		if !val.IsZero() {
+		if !isEmptyNode(val) {
 			err = val.Decode(&on.WorkflowCall)
 		}
 	case "workflow_run":
 		on.WorkflowRun = &WorkflowRun{FileReference: fileReference}
-		if !val.IsZero() {
+		if !isEmptyNode(val) {
 			if err = val.Decode(&on.WorkflowRun); err == nil {
 				err = val.Decode(&(on.WorkflowRun.Ref))
 			}
 		}
 	case "workflow_dispatch":
 		on.WorkflowDispatch = &WorkflowDispatch{FileReference: fileReference}
-		if !val.IsZero() {
+		if !isEmptyNode(val) {
 			err = val.Decode(&on.WorkflowDispatch)
 		}
 	default:
@@ -147,3 +151,8 @@ func (on *On) unmarshalString(key string, val *yaml.Node, fileReference *models.
 	}
 	return err
 }
+
+// isEmptyNode returns true for an event without configuration, e.g. "push:"
+func isEmptyNode(node *yaml.Node) bool {
+	return node.IsZero() || node.Tag == consts.NullTag
+}
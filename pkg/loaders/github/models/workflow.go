@@ -1,16 +1,36 @@
 package models
 
 import (
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	loadersUtils "github.com/argonsecurity/pipeline-parser/pkg/loaders/utils"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"gopkg.in/yaml.v3"
 )
 
 type Container struct {
-	Credentials *Credentials  `yaml:"credentials,omitempty"`
-	Env         interface{}   `yaml:"env,omitempty"`
-	Image       string        `yaml:"image"`
-	Options     string        `yaml:"options,omitempty"`
-	Ports       []interface{} `yaml:"ports,omitempty"`
-	Volumes     []string      `yaml:"volumes,omitempty"`
+	Credentials   *Credentials             `yaml:"credentials,omitempty"`
+	Env           *EnvironmentVariablesRef `yaml:"env,omitempty"`
+	Image         string                   `yaml:"image"`
+	Options       string                   `yaml:"options,omitempty"`
+	Ports         []interface{}            `yaml:"ports,omitempty"`
+	Volumes       []string                 `yaml:"volumes,omitempty"`
+	FileReference *models.FileReference    `yaml:"-"`
+}
+
+func (c *Container) UnmarshalYAML(node *yaml.Node) error {
+	if node.Tag == consts.StringTag { // format - "container: image:tag"
+		*c = Container{Image: node.Value}
+	} else {
+		type container Container
+		var decoded container
+		if err := node.Decode(&decoded); err != nil {
+			return err
+		}
+		*c = Container(decoded)
+	}
+
+	c.FileReference = loadersUtils.GetFileReference(node)
+	return nil
 }
 
 type Credentials struct {
@@ -23,8 +43,25 @@ type Defaults struct {
 }
 
 type Environment struct {
-	Name string `yaml:"name"`
-	Url  string `yaml:"url,omitempty"`
+	Name          string                `yaml:"name"`
+	Url           string                `yaml:"url,omitempty"`
+	FileReference *models.FileReference `yaml:"-"`
+}
+
+func (e *Environment) UnmarshalYAML(node *yaml.Node) error {
+	if node.Tag == consts.StringTag { // format - "environment: production"
+		*e = Environment{Name: node.Value}
+	} else {
+		type environment Environment
+		var decoded environment
+		if err := node.Decode(&decoded); err != nil {
+			return err
+		}
+		*e = Environment(decoded)
+	}
+
+	e.FileReference = loadersUtils.GetFileReference(node)
+	return nil
 }
 
 type Ref struct {
@@ -85,6 +85,7 @@ func TestLoad(t *testing.T) {
 							DependencyScanning: "dependency.json",
 							LicenseScanning:    "license.json",
 						},
+						FileReference: testutils.CreateFileReference(29, 5, 34, 43),
 					},
 				},
 			},
@@ -126,6 +127,7 @@ func TestLoad(t *testing.T) {
 								"build",
 								".gradle",
 							},
+							FileReference: testutils.CreateFileReference(26, 3, 31, 16),
 						},
 						FileReference: testutils.CreateFileReference(23, 1, 31, 16),
 					},
@@ -170,10 +172,11 @@ func TestLoad(t *testing.T) {
 						Dependencies: []string{
 							"build",
 						},
-						Environment: map[string]string{
-							"name": "$TF_STATE_NAME",
+						Environment: &job.Environment{
+							Name:          "$TF_STATE_NAME",
+							FileReference: testutils.CreateFileReference(27, 3, 28, 25),
 						},
-						FileReference: testutils.CreateFileReference(27, 1, 32, 10),
+						FileReference: testutils.CreateFileReference(23, 1, 28, 25),
 					},
 				},
 			},
@@ -195,7 +198,12 @@ func TestLoad(t *testing.T) {
 							FileReference: testutils.CreateFileReference(202, 3, 202, 25),
 						},
 						Stage:    "build",
-						Services: []any{"docker:20.10.12-dind"},
+						Services: []*common.Service{
+							{
+								Name:          "docker:20.10.12-dind",
+								FileReference: testutils.CreateFileReference(205, 7, 205, 27),
+							},
+						},
 						Variables: &common.EnvironmentVariablesRef{
 							Variables: &common.Variables{
 								"DOCKER_BUILDKIT": "1",
@@ -1,14 +1,21 @@
 package models
 
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/loaders/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
 type Artifacts struct {
-	Exclude   []string `yaml:"exclude,omitempty"`
-	ExpireIn  string   `yaml:"expire_in,omitempty"`
-	ExposeAs  string   `yaml:"expose_as,omitempty"`
-	Name      string   `yaml:"name,omitempty"`
-	Paths     []string `yaml:"paths,omitempty"`
-	Reports   *Reports `yaml:"reports,omitempty"`
-	Untracked bool     `yaml:"untracked,omitempty"`
-	When      string   `yaml:"when,omitempty"`
+	Exclude       []string              `yaml:"exclude,omitempty"`
+	ExpireIn      string                `yaml:"expire_in,omitempty"`
+	ExposeAs      string                `yaml:"expose_as,omitempty"`
+	Name          string                `yaml:"name,omitempty"`
+	Paths         []string              `yaml:"paths,omitempty"`
+	Reports       *Reports              `yaml:"reports,omitempty"`
+	Untracked     bool                  `yaml:"untracked,omitempty"`
+	When          string                `yaml:"when,omitempty"`
+	FileReference *models.FileReference `yaml:"-"`
 }
 
 type Reports struct {
@@ -31,6 +38,17 @@ type Reports struct {
 	Terraform          any `yaml:"terraform,omitempty"`
 }
 
+func (a *Artifacts) UnmarshalYAML(node *yaml.Node) error {
+	type artifacts Artifacts
+	var decoded artifacts
+	if err := node.Decode(&decoded); err != nil {
+		return err
+	}
+	*a = Artifacts(decoded)
+	a.FileReference = utils.GetFileReference(node)
+	return nil
+}
+
 type CoverageReport struct {
 	CoverageFormat any    `yaml:"coverage_format,omitempty"`
 	Path           string `yaml:"path,omitempty"`
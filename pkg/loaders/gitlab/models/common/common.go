@@ -1,9 +1,27 @@
 package common
 
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/loaders/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
 type Cache struct {
-	When      string   `yaml:"when,omitempty"`
-	Key       any      `yaml:"key,omitempty"`
-	Paths     []string `yaml:"paths,omitempty"`
-	Policy    string   `yaml:"policy,omitempty"`
-	Untracked bool     `yaml:"untracked,omitempty"`
+	When          string                `yaml:"when,omitempty"`
+	Key           any                   `yaml:"key,omitempty"`
+	Paths         []string              `yaml:"paths,omitempty"`
+	Policy        string                `yaml:"policy,omitempty"`
+	Untracked     bool                  `yaml:"untracked,omitempty"`
+	FileReference *models.FileReference `yaml:"-"`
+}
+
+func (c *Cache) UnmarshalYAML(node *yaml.Node) error {
+	type cache Cache
+	var decoded cache
+	if err := node.Decode(&decoded); err != nil {
+		return err
+	}
+	*c = Cache(decoded)
+	c.FileReference = utils.GetFileReference(node)
+	return nil
 }
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/loaders/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+type Service struct {
+	Name          string                   `yaml:"name"`
+	Alias         string                   `yaml:"alias"`
+	Entrypoint    []string                 `yaml:"entrypoint"`
+	Command       []string                 `yaml:"command"`
+	Variables     *EnvironmentVariablesRef `yaml:"variables"`
+	FileReference *models.FileReference
+}
+
+func (s *Service) UnmarshalYAML(node *yaml.Node) error {
+	s.FileReference = utils.GetFileReference(node)
+
+	if node.Tag == consts.StringTag { // format - "- image:tag"
+		s.Name = node.Value
+		return nil
+	}
+
+	return utils.IterateOnMap(node, func(key string, value *yaml.Node) error {
+		switch key {
+		case "name":
+			s.Name = value.Value
+		case "alias":
+			s.Alias = value.Value
+		case "entrypoint":
+			entrypoint, err := utils.ParseYamlStringSequenceToSlice(value, "Service.entrypoint")
+			if err != nil {
+				return err
+			}
+			s.Entrypoint = entrypoint
+		case "command":
+			command, err := utils.ParseYamlStringSequenceToSlice(value, "Service.command")
+			if err != nil {
+				return err
+			}
+			s.Command = command
+		case "variables":
+			return value.Decode(&s.Variables)
+		}
+		return nil
+	}, "Service")
+}
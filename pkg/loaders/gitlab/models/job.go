@@ -18,7 +18,7 @@ type Job struct {
 	Cache         *common.Cache                   `yaml:"cache"`
 	Coverage      string                          `yaml:"coverage"`
 	Dependencies  []string                        `yaml:"dependencies"`
-	Environment   any                             `yaml:"environment"` // TODO: implement
+	Environment   *job.Environment                `yaml:"environment"`
 	Extends       any                             `yaml:"extends"`
 	Image         *common.Image                   `yaml:"image"`
 	Inherit       *job.Inherit                    `yaml:"inherit"`
@@ -31,7 +31,7 @@ type Job struct {
 	Rules         *common.Rules                   `yaml:"rules"`
 	Script        *common.Script                  `yaml:"script"`
 	Secrets       *Secrets                        `yaml:"secrets"`
-	Services      []any                           `yaml:"services"` // TODO: implement
+	Services      []*common.Service               `yaml:"services"`
 	Stage         string                          `yaml:"stage"`
 	StartIn       string                          `yaml:"start_in"`
 	Tags          []string                        `yaml:"tags"`
@@ -0,0 +1,44 @@
+package job
+
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/loaders/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+type Environment struct {
+	Name           string
+	Url            string
+	OnStop         string
+	Action         string
+	AutoStopIn     string
+	DeploymentTier string
+	FileReference  *models.FileReference
+}
+
+func (e *Environment) UnmarshalYAML(node *yaml.Node) error {
+	e.FileReference = utils.GetFileReference(node)
+	if node.Tag == consts.StringTag {
+		e.Name = node.Value
+		return nil
+	}
+
+	return utils.IterateOnMap(node, func(key string, value *yaml.Node) error {
+		switch key {
+		case "name":
+			e.Name = value.Value
+		case "url":
+			e.Url = value.Value
+		case "on_stop":
+			e.OnStop = value.Value
+		case "action":
+			e.Action = value.Value
+		case "auto_stop_in":
+			e.AutoStopIn = value.Value
+		case "deployment_tier":
+			e.DeploymentTier = value.Value
+		}
+		return nil
+	}, "Environment")
+}
@@ -21,11 +21,12 @@ func (n *Needs) UnmarshalYAML(node *yaml.Node) error {
 		if item.Tag == consts.StringTag {
 			needs = append(needs,
 				&NeedsItem{
-					Job: item.Value,
+					Artifacts: true,
+					Job:       item.Value,
 				},
 			)
 		} else if item.Tag == consts.MapTag {
-			needsItem := &NeedsItem{}
+			needsItem := &NeedsItem{Artifacts: true} // artifacts are downloaded unless disabled
 			if err := item.Decode(&needsItem); err != nil {
 				return err
 			}
@@ -12,8 +12,8 @@ type GitlabCIConfiguration struct {
 	Image        *common.Image  `yaml:"image"`
 	Include      *common.Include       `yaml:"include"`
 
-	Pages    any   `yaml:"pages"`
-	Services []any `yaml:"services"`
+	Pages    any               `yaml:"pages"`
+	Services []*common.Service `yaml:"services"`
 
 	// Groups jobs into stages. All jobs in one stage must complete before next stage is executed. Defaults to ['build', 'test', 'deploy'].
 	Stages    []string                        `yaml:"stages"`
@@ -23,16 +23,16 @@ type GitlabCIConfiguration struct {
 }
 
 type Default struct {
-	AfterScript   []*common.Script `yaml:"after_script"`
-	Artifacts     *Artifacts       `yaml:"artifacts"`
-	BeforeScript  []*common.Script `yaml:"before_script"`
-	Cache         *common.Cache    `yaml:"cache"`
-	Image         *common.Image    `yaml:"image"`
-	Interruptible bool             `yaml:"interruptible"`
-	Retry         *common.Retry    `yaml:"retry"`
-	Services      []any            `yaml:"services"`
-	Tags          []string         `yaml:"tags"`
-	Timeout       string           `yaml:"timeout"`
+	AfterScript   []*common.Script  `yaml:"after_script"`
+	Artifacts     *Artifacts        `yaml:"artifacts"`
+	BeforeScript  []*common.Script  `yaml:"before_script"`
+	Cache         *common.Cache     `yaml:"cache"`
+	Image         *common.Image     `yaml:"image"`
+	Interruptible bool              `yaml:"interruptible"`
+	Retry         *common.Retry     `yaml:"retry"`
+	Services      []*common.Service `yaml:"services"`
+	Tags          []string          `yaml:"tags"`
+	Timeout       string            `yaml:"timeout"`
 }
 
 type Workflow struct {
@@ -0,0 +1,293 @@
+package jenkins
+
+import (
+	"strconv"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins/models"
+	commonModels "github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+var (
+	// when options that change the evaluation order of the conditions and are not conditions by themselves
+	whenOptions = []string{"beforeAgent", "beforeInput", "beforeOptions"}
+)
+
+type JenkinsLoader struct{}
+
+func (j *JenkinsLoader) Load(data []byte) (*models.Pipeline, error) {
+	statements, err := parseStatements(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range statements {
+		if s.Name == "pipeline" && s.HasBlock {
+			return loadPipeline(s), nil
+		}
+	}
+	return nil, consts.NewErrInvalidJenkinsfile("declarative pipeline block not found")
+}
+
+func loadPipeline(s *statement) *models.Pipeline {
+	pipeline := &models.Pipeline{FileReference: s.FileReference}
+	for _, child := range s.Block {
+		switch child.Name {
+		case "agent":
+			pipeline.Agent = loadAgent(child)
+		case "environment":
+			pipeline.Environment = loadEnvironment(child)
+		case "options":
+			pipeline.Options = loadOptions(child)
+		case "stages":
+			pipeline.Stages = loadStages(child)
+		}
+	}
+	return pipeline
+}
+
+func loadStages(s *statement) []*models.Stage {
+	var stages []*models.Stage
+	for _, child := range s.Block {
+		if child.Name == "stage" {
+			stages = append(stages, loadStage(child))
+		}
+	}
+	return stages
+}
+
+func loadStage(s *statement) *models.Stage {
+	stage := &models.Stage{FileReference: s.FileReference}
+	if name := getArgument(s, "name", 0); name != nil {
+		stage.Name = name.Value
+	}
+
+	for _, child := range s.Block {
+		switch child.Name {
+		case "agent":
+			stage.Agent = loadAgent(child)
+		case "environment":
+			stage.Environment = loadEnvironment(child)
+		case "when":
+			stage.When = loadWhen(child)
+		case "options":
+			stage.Options = loadOptions(child)
+		case "steps":
+			stage.Steps = loadSteps(child.Block)
+		case "stages":
+			stage.Stages = loadStages(child)
+		case "parallel":
+			stage.Parallel = loadStages(child)
+		}
+	}
+	return stage
+}
+
+func loadSteps(statements []*statement) []*models.Step {
+	var steps []*models.Step
+	for _, s := range statements {
+		if s.IsAssignment {
+			continue
+		}
+
+		step := &models.Step{
+			Name:          s.Name,
+			Arguments:     loadArguments(s.Arguments),
+			Steps:         loadSteps(s.Block),
+			FileReference: s.FileReference,
+		}
+		if s.HasBlock && step.Steps == nil {
+			step.Steps = []*models.Step{}
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+func loadAgent(s *statement) *models.Agent {
+	agent := &models.Agent{FileReference: s.FileReference}
+	if len(s.Arguments) > 0 {
+		switch s.Arguments[0].Value {
+		case "any":
+			agent.Any = true
+		case "none":
+			agent.None = true
+		}
+		if label := getArgument(s, "label", -1); label != nil {
+			agent.Label = &label.Value
+		}
+		return agent
+	}
+
+	for _, child := range s.Block {
+		switch child.Name {
+		case "label":
+			agent.Label = getArgumentValue(child, "label", 0)
+		case "node":
+			agent.Label = getArgumentValue(child, "label", 0)
+			for _, nodeChild := range child.Block {
+				if nodeChild.Name == "label" {
+					agent.Label = getArgumentValue(nodeChild, "label", 0)
+				}
+			}
+		case "docker":
+			agent.Docker = loadDockerAgent(child)
+		case "dockerfile":
+			agent.Dockerfile = loadDockerfileAgent(child)
+		}
+	}
+	return agent
+}
+
+func loadDockerAgent(s *statement) *models.DockerAgent {
+	docker := &models.DockerAgent{
+		Image:                 getArgumentValue(s, "image", 0),
+		Args:                  getArgumentValue(s, "args", -1),
+		RegistryURL:           getArgumentValue(s, "registryUrl", -1),
+		RegistryCredentialsID: getArgumentValue(s, "registryCredentialsId", -1),
+	}
+
+	for _, child := range s.Block {
+		value := getArgumentValue(child, "", 0)
+		switch child.Name {
+		case "image":
+			docker.Image = value
+		case "args":
+			docker.Args = value
+		case "registryUrl":
+			docker.RegistryURL = value
+		case "registryCredentialsId":
+			docker.RegistryCredentialsID = value
+		}
+	}
+	return docker
+}
+
+func loadDockerfileAgent(s *statement) *models.DockerfileAgent {
+	dockerfile := &models.DockerfileAgent{
+		Filename: getArgumentValue(s, "filename", -1),
+		Dir:      getArgumentValue(s, "dir", -1),
+	}
+
+	for _, child := range s.Block {
+		value := getArgumentValue(child, "", 0)
+		switch child.Name {
+		case "filename":
+			dockerfile.Filename = value
+		case "dir":
+			dockerfile.Dir = value
+		}
+	}
+	return dockerfile
+}
+
+func loadEnvironment(s *statement) *models.Environment {
+	environment := &models.Environment{
+		EnvironmentVariables: commonModels.EnvironmentVariables{},
+		FileReference:        s.FileReference,
+	}
+
+	for _, child := range s.Block {
+		if child.IsAssignment && len(child.Arguments) > 0 {
+			environment.EnvironmentVariables[child.Name] = child.Arguments[0].Value
+		}
+	}
+	return environment
+}
+
+func loadOptions(s *statement) *models.Options {
+	options := &models.Options{FileReference: s.FileReference}
+	for _, child := range s.Block {
+		if child.Name == "timeout" {
+			options.Timeout = loadTimeout(child)
+		}
+	}
+	return options
+}
+
+func loadTimeout(s *statement) *models.Timeout {
+	timeout := &models.Timeout{
+		Unit:          "MINUTES",
+		FileReference: s.FileReference,
+	}
+
+	if time := getArgument(s, "time", 0); time != nil {
+		if value, err := strconv.Atoi(time.Value); err == nil {
+			timeout.Time = value
+		}
+	}
+	if unit := getArgument(s, "unit", -1); unit != nil {
+		timeout.Unit = unit.Value
+	}
+	return timeout
+}
+
+func loadWhen(s *statement) *models.When {
+	return &models.When{
+		Conditions:    loadWhenConditions(s.Block),
+		FileReference: s.FileReference,
+	}
+}
+
+func loadWhenConditions(statements []*statement) []*models.WhenCondition {
+	var conditions []*models.WhenCondition
+	for _, s := range statements {
+		if utils.SliceContains(whenOptions, s.Name) || s.IsAssignment {
+			continue
+		}
+
+		condition := &models.WhenCondition{
+			Type:          s.Name,
+			Statement:     s.Source,
+			Arguments:     loadArguments(s.Arguments),
+			FileReference: s.FileReference,
+		}
+
+		switch s.Name {
+		case "expression":
+			condition.Expression = &s.BlockContent
+		case "not", "allOf", "anyOf":
+			condition.Conditions = loadWhenConditions(s.Block)
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions
+}
+
+func loadArguments(arguments []*argument) []*models.Argument {
+	if len(arguments) == 0 {
+		return nil
+	}
+	return utils.Map(arguments, func(arg *argument) *models.Argument {
+		return &models.Argument{
+			Name:     arg.Name,
+			Value:    arg.Value,
+			IsString: arg.IsString,
+		}
+	})
+}
+
+// getArgument returns the argument with the given name, or the unnamed argument in the given position
+func getArgument(s *statement, name string, position int) *argument {
+	var unnamed []*argument
+	for _, arg := range s.Arguments {
+		if arg.Name == nil {
+			unnamed = append(unnamed, arg)
+		} else if *arg.Name == name {
+			return arg
+		}
+	}
+
+	if position >= 0 && position < len(unnamed) {
+		return unnamed[position]
+	}
+	return nil
+}
+
+func getArgumentValue(s *statement, name string, position int) *string {
+	if arg := getArgument(s, name, position); arg != nil {
+		return &arg.Value
+	}
+	return nil
+}
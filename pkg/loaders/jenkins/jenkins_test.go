@@ -0,0 +1,136 @@
+package jenkins
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins/models"
+	commonModels "github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestLoad(t *testing.T) {
+	testCases := []struct {
+		name             string
+		data             string
+		expectedPipeline *models.Pipeline
+		expectedError    error
+	}{
+		{
+			name: "Nested stages",
+			data: `@Library('shared') _
+pipeline {
+  agent none
+  stages {
+    stage('CI') {
+      agent { docker { image 'node:18'; registryCredentialsId 'hub' } }
+      environment { CI = 'true' }
+      options { timeout(30) }
+      stages {
+        stage('Test') {
+          when {
+            beforeAgent true
+            not { branch 'main' }
+            expression { return params.RUN_TESTS }
+          }
+          steps {
+            script { npm.test() }
+          }
+        }
+      }
+    }
+  }
+}`,
+			expectedPipeline: &models.Pipeline{
+				Agent: &models.Agent{
+					None:          true,
+					FileReference: testutils.CreateFileReference(3, 3, 3, 13),
+				},
+				Stages: []*models.Stage{
+					{
+						Name: "CI",
+						Agent: &models.Agent{
+							Docker: &models.DockerAgent{
+								Image:                 utils.GetPtr("node:18"),
+								RegistryCredentialsID: utils.GetPtr("hub"),
+							},
+							FileReference: testutils.CreateFileReference(6, 7, 6, 72),
+						},
+						Environment: &models.Environment{
+							EnvironmentVariables: commonModels.EnvironmentVariables{"CI": "true"},
+							FileReference:        testutils.CreateFileReference(7, 7, 7, 34),
+						},
+						Options: &models.Options{
+							Timeout: &models.Timeout{
+								Time:          30,
+								Unit:          "MINUTES",
+								FileReference: testutils.CreateFileReference(8, 17, 8, 28),
+							},
+							FileReference: testutils.CreateFileReference(8, 7, 8, 30),
+						},
+						Stages: []*models.Stage{
+							{
+								Name: "Test",
+								When: &models.When{
+									Conditions: []*models.WhenCondition{
+										{
+											Type:      "not",
+											Statement: "not { branch 'main' }",
+											Conditions: []*models.WhenCondition{
+												{
+													Type:          "branch",
+													Statement:     "branch 'main'",
+													Arguments:     []*models.Argument{{Value: "main", IsString: true}},
+													FileReference: testutils.CreateFileReference(13, 19, 13, 32),
+												},
+											},
+											FileReference: testutils.CreateFileReference(13, 13, 13, 34),
+										},
+										{
+											Type:          "expression",
+											Statement:     "expression { return params.RUN_TESTS }",
+											Expression:    utils.GetPtr("return params.RUN_TESTS"),
+											FileReference: testutils.CreateFileReference(14, 13, 14, 51),
+										},
+									},
+									FileReference: testutils.CreateFileReference(11, 11, 15, 12),
+								},
+								Steps: []*models.Step{
+									{
+										Name: "script",
+										Steps: []*models.Step{
+											{
+												Name:          "npm.test",
+												FileReference: testutils.CreateFileReference(17, 22, 17, 32),
+											},
+										},
+										FileReference: testutils.CreateFileReference(17, 13, 17, 34),
+									},
+								},
+								FileReference: testutils.CreateFileReference(10, 9, 19, 10),
+							},
+						},
+						FileReference: testutils.CreateFileReference(5, 5, 21, 6),
+					},
+				},
+				FileReference: testutils.CreateFileReference(2, 1, 23, 2),
+			},
+		},
+		{
+			name:          "Scripted pipeline",
+			data:          "node {\n  sh 'make'\n}",
+			expectedError: consts.NewErrInvalidJenkinsfile("declarative pipeline block not found"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			loader := &JenkinsLoader{}
+			got, err := loader.Load([]byte(testCase.data))
+
+			testutils.DeepCompare(t, testCase.expectedError, err)
+			testutils.DeepCompare(t, testCase.expectedPipeline, got)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package jenkins
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+type tokenType int
+
+const (
+	identifierToken tokenType = iota
+	stringToken
+	numberToken
+	symbolToken
+	newLineToken
+)
+
+type token struct {
+	Type     tokenType
+	Value    string // the unquoted value for strings, the raw text otherwise
+	Start    int    // byte offset of the first character
+	End      int    // byte offset after the last character
+	StartRef *models.FileLocation
+	EndRef   *models.FileLocation // the location right after the last character
+}
+
+type lexer struct {
+	data   string
+	offset int
+	line   int
+	col    int
+	tokens []*token
+}
+
+// tokenize splits a Jenkinsfile into the tokens that are required to parse the declarative pipeline syntax.
+// Comments are dropped, strings are unquoted and everything else that is not an identifier or a number is a symbol.
+func tokenize(data string) ([]*token, error) {
+	l := &lexer{data: data, line: 1, col: 1}
+	for l.offset < len(l.data) {
+		if err := l.next(); err != nil {
+			return nil, err
+		}
+	}
+	return l.tokens, nil
+}
+
+func (l *lexer) next() error {
+	c := l.data[l.offset]
+	switch {
+	case c == '\n':
+		l.emit(newLineToken, l.offset, l.offset+1, "\n")
+	case c == ' ' || c == '\t' || c == '\r':
+		l.advance(1)
+	case strings.HasPrefix(l.data[l.offset:], "//"):
+		end := strings.IndexByte(l.data[l.offset:], '\n')
+		if end == -1 {
+			end = len(l.data) - l.offset
+		}
+		l.advance(end)
+	case strings.HasPrefix(l.data[l.offset:], "/*"):
+		end := strings.Index(l.data[l.offset+2:], "*/")
+		if end == -1 {
+			return l.error("unterminated comment")
+		}
+		l.advance(end + 4)
+	case c == '\'' || c == '"':
+		return l.readString()
+	case isIdentifierStart(c):
+		end := l.offset + 1
+		for end < len(l.data) && isIdentifierPart(l.data[end]) {
+			end++
+		}
+		l.emit(identifierToken, l.offset, end, l.data[l.offset:end])
+	case c >= '0' && c <= '9':
+		end := l.offset + 1
+		for end < len(l.data) && (l.data[end] >= '0' && l.data[end] <= '9' || l.data[end] == '.') {
+			end++
+		}
+		l.emit(numberToken, l.offset, end, l.data[l.offset:end])
+	default:
+		l.emit(symbolToken, l.offset, l.offset+1, string(c))
+	}
+	return nil
+}
+
+func (l *lexer) readString() error {
+	quote := l.data[l.offset : l.offset+1]
+	if tripleQuote := strings.Repeat(quote, 3); strings.HasPrefix(l.data[l.offset:], tripleQuote) {
+		end := strings.Index(l.data[l.offset+3:], tripleQuote)
+		if end == -1 {
+			return l.error("unterminated string")
+		}
+		l.emit(stringToken, l.offset, l.offset+end+6, l.data[l.offset+3:l.offset+3+end])
+		return nil
+	}
+
+	builder := strings.Builder{}
+	for end := l.offset + 1; end < len(l.data); end++ {
+		switch l.data[end] {
+		case '\\':
+			if end+1 < len(l.data) {
+				end++
+				builder.WriteByte(unescape(l.data[end]))
+			}
+		case '$':
+			builder.WriteByte('$')
+			if quote == `"` && end+1 < len(l.data) && l.data[end+1] == '{' {
+				// interpolated expressions may contain quotes, e.g. "${env.TAG ?: "latest"}"
+				closing := strings.IndexByte(l.data[end:], '}')
+				if closing == -1 {
+					return l.error("unterminated string")
+				}
+				builder.WriteString(l.data[end+1 : end+closing+1])
+				end += closing
+			}
+		case '\n':
+			return l.error("unterminated string")
+		case quote[0]:
+			l.emit(stringToken, l.offset, end+1, builder.String())
+			return nil
+		default:
+			builder.WriteByte(l.data[end])
+		}
+	}
+	return l.error("unterminated string")
+}
+
+func (l *lexer) emit(tokenType tokenType, start, end int, value string) {
+	t := &token{
+		Type:     tokenType,
+		Value:    value,
+		Start:    start,
+		End:      end,
+		StartRef: &models.FileLocation{Line: l.line, Column: l.col},
+	}
+	l.advance(end - start)
+	t.EndRef = &models.FileLocation{Line: l.line, Column: l.col}
+	l.tokens = append(l.tokens, t)
+}
+
+func (l *lexer) advance(length int) {
+	for _, c := range l.data[l.offset : l.offset+length] {
+		if c == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+	}
+	l.offset += length
+}
+
+func (l *lexer) error(message string) error {
+	return consts.NewErrInvalidJenkinsfile(fmt.Sprintf("%s at line %d, column %d", message, l.line, l.col))
+}
+
+func (t *token) is(tokenType tokenType, value string) bool {
+	return t != nil && t.Type == tokenType && t.Value == value
+}
+
+func isIdentifierStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == '$' || c == '@'
+}
+
+func isIdentifierPart(c byte) bool {
+	return isIdentifierStart(c) || c >= '0' && c <= '9' || c == '.'
+}
+
+func unescape(c byte) byte {
+	switch c {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	}
+	return c
+}
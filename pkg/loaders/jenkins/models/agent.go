@@ -0,0 +1,24 @@
+package models
+
+import "github.com/argonsecurity/pipeline-parser/pkg/models"
+
+type Agent struct {
+	Any           bool
+	None          bool
+	Label         *string
+	Docker        *DockerAgent
+	Dockerfile    *DockerfileAgent
+	FileReference *models.FileReference
+}
+
+type DockerAgent struct {
+	Image                 *string
+	Args                  *string
+	RegistryURL           *string
+	RegistryCredentialsID *string
+}
+
+type DockerfileAgent struct {
+	Filename *string
+	Dir      *string
+}
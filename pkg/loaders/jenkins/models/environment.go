@@ -0,0 +1,8 @@
+package models
+
+import "github.com/argonsecurity/pipeline-parser/pkg/models"
+
+type Environment struct {
+	models.EnvironmentVariables
+	FileReference *models.FileReference
+}
@@ -0,0 +1,14 @@
+package models
+
+import "github.com/argonsecurity/pipeline-parser/pkg/models"
+
+type Options struct {
+	Timeout       *Timeout
+	FileReference *models.FileReference
+}
+
+type Timeout struct {
+	Time          int
+	Unit          string
+	FileReference *models.FileReference
+}
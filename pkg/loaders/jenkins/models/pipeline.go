@@ -0,0 +1,11 @@
+package models
+
+import "github.com/argonsecurity/pipeline-parser/pkg/models"
+
+type Pipeline struct {
+	Agent         *Agent
+	Environment   *Environment
+	Options       *Options
+	Stages        []*Stage
+	FileReference *models.FileReference
+}
@@ -0,0 +1,15 @@
+package models
+
+import "github.com/argonsecurity/pipeline-parser/pkg/models"
+
+type Stage struct {
+	Name          string
+	Agent         *Agent
+	Environment   *Environment
+	When          *When
+	Options       *Options
+	Steps         []*Step
+	Stages        []*Stage // Sequential nested stages
+	Parallel      []*Stage // Nested stages that run in parallel
+	FileReference *models.FileReference
+}
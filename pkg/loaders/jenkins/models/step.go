@@ -0,0 +1,16 @@
+package models
+
+import "github.com/argonsecurity/pipeline-parser/pkg/models"
+
+type Step struct {
+	Name          string
+	Arguments     []*Argument
+	Steps         []*Step // Steps that are nested in the step's closure, e.g. dir('app') { sh 'make' }. Not nil for every step with a closure
+	FileReference *models.FileReference
+}
+
+type Argument struct {
+	Name     *string
+	Value    string
+	IsString bool // Whether the value is a string literal, otherwise it is a Groovy expression
+}
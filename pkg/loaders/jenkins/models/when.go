@@ -0,0 +1,17 @@
+package models
+
+import "github.com/argonsecurity/pipeline-parser/pkg/models"
+
+type When struct {
+	Conditions    []*WhenCondition
+	FileReference *models.FileReference
+}
+
+type WhenCondition struct {
+	Type          string // The condition name, e.g. branch, environment, expression, not, allOf
+	Statement     string // The source code of the condition
+	Arguments     []*Argument
+	Expression    *string          // The Groovy code of an expression condition
+	Conditions    []*WhenCondition // The nested conditions of not, allOf and anyOf
+	FileReference *models.FileReference
+}
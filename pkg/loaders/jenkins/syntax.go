@@ -0,0 +1,286 @@
+package jenkins
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+// statement is a Groovy command - a name followed by optional arguments and an optional closure,
+// e.g. `sh 'make'`, `timeout(time: 1, unit: 'HOURS')`, `stage('Build') { ... }` or `FOO = 'bar'`
+type statement struct {
+	Name          string
+	Arguments     []*argument
+	IsAssignment  bool
+	Block         []*statement
+	HasBlock      bool
+	BlockContent  string // the source code inside the closure braces
+	Source        string // the source code of the whole statement
+	FileReference *models.FileReference
+}
+
+type argument struct {
+	Name     *string
+	Value    string // the unquoted value of a string literal, the source code of any other expression
+	IsString bool
+}
+
+type syntaxParser struct {
+	data     string
+	tokens   []*token
+	position int
+}
+
+// parseStatements parses the Jenkinsfile into a tree of statements.
+// Code that is not a command (e.g. expressions inside script blocks) is skipped.
+func parseStatements(data string) ([]*statement, error) {
+	tokens, err := tokenize(data)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &syntaxParser{data: data, tokens: tokens}
+	return p.parseBlock(nil)
+}
+
+func (p *syntaxParser) parseBlock(openBrace *token) ([]*statement, error) {
+	var statements []*statement
+	for {
+		p.skipSeparators()
+		t := p.peek()
+		if t == nil {
+			if openBrace != nil {
+				return nil, p.error(openBrace, "missing closing brace")
+			}
+			return statements, nil
+		}
+
+		if t.is(symbolToken, "}") {
+			if openBrace == nil {
+				return nil, p.error(t, "unexpected closing brace")
+			}
+			return statements, nil
+		}
+
+		if t.Type != identifierToken {
+			if err := p.skipExpression(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		s, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, s)
+	}
+}
+
+func (p *syntaxParser) parseStatement() (*statement, error) {
+	nameToken := p.next()
+	s := &statement{Name: nameToken.Value}
+	last := nameToken
+
+	switch t := p.peek(); {
+	case t == nil:
+	case t.is(symbolToken, "=") && !p.peekAt(1).is(symbolToken, "="):
+		p.next()
+		s.IsAssignment = true
+		tokens, err := p.readUntilEndOfStatement()
+		if err != nil {
+			return nil, err
+		}
+		if len(tokens) > 0 {
+			s.Arguments = []*argument{p.parseArgumentValue(tokens)}
+			last = tokens[len(tokens)-1]
+		}
+	case t.is(symbolToken, "("):
+		tokens, err := p.readGroup()
+		if err != nil {
+			return nil, err
+		}
+		s.Arguments = p.parseArguments(tokens[1 : len(tokens)-1])
+		last = tokens[len(tokens)-1]
+	case !t.is(symbolToken, "{"):
+		tokens, err := p.readUntilEndOfStatement()
+		if err != nil {
+			return nil, err
+		}
+		if len(tokens) > 0 {
+			s.Arguments = p.parseArguments(tokens)
+			last = tokens[len(tokens)-1]
+		}
+	}
+
+	if t := p.peek(); t != nil && t.is(symbolToken, "{") && !s.IsAssignment {
+		openBrace := p.next()
+		block, err := p.parseBlock(openBrace)
+		if err != nil {
+			return nil, err
+		}
+		closeBrace := p.next()
+		s.Block = block
+		s.HasBlock = true
+		s.BlockContent = strings.TrimSpace(p.data[openBrace.End:closeBrace.Start])
+		last = closeBrace
+	}
+
+	s.Source = p.data[nameToken.Start:last.End]
+	s.FileReference = &models.FileReference{
+		StartRef: nameToken.StartRef,
+		EndRef:   last.EndRef,
+	}
+	return s, nil
+}
+
+// parseArguments splits the tokens of an arguments list by top level commas, e.g. `time: 1, unit: 'HOURS'`
+func (p *syntaxParser) parseArguments(tokens []*token) []*argument {
+	var arguments []*argument
+	depth := 0
+	start := 0
+	for i, t := range tokens {
+		switch {
+		case t.Type != symbolToken:
+		case t.Value == "(" || t.Value == "[" || t.Value == "{":
+			depth++
+		case t.Value == ")" || t.Value == "]" || t.Value == "}":
+			depth--
+		case t.Value == "," && depth == 0:
+			if i > start {
+				arguments = append(arguments, p.parseArgument(tokens[start:i]))
+			}
+			start = i + 1
+		}
+	}
+
+	if start < len(tokens) {
+		arguments = append(arguments, p.parseArgument(tokens[start:]))
+	}
+	return arguments
+}
+
+func (p *syntaxParser) parseArgument(tokens []*token) *argument {
+	if len(tokens) > 2 && (tokens[0].Type == identifierToken || tokens[0].Type == stringToken) && tokens[1].is(symbolToken, ":") {
+		arg := p.parseArgumentValue(tokens[2:])
+		arg.Name = &tokens[0].Value
+		return arg
+	}
+	return p.parseArgumentValue(tokens)
+}
+
+func (p *syntaxParser) parseArgumentValue(tokens []*token) *argument {
+	if len(tokens) == 1 && tokens[0].Type == stringToken {
+		return &argument{Value: tokens[0].Value, IsString: true}
+	}
+	return &argument{Value: p.data[tokens[0].Start:tokens[len(tokens)-1].End]}
+}
+
+// readUntilEndOfStatement reads the tokens until the end of the line, a semicolon, or a closure that is not nested in brackets.
+// Lines that end with a comma or an operator continue on the next line.
+func (p *syntaxParser) readUntilEndOfStatement() ([]*token, error) {
+	var tokens []*token
+	for t := p.peek(); t != nil; t = p.peek() {
+		switch {
+		case t.is(symbolToken, "(") || t.is(symbolToken, "["):
+			group, err := p.readGroup()
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, group...)
+			continue
+		case t.Type == newLineToken:
+			if len(tokens) > 0 && isContinuationToken(tokens[len(tokens)-1]) {
+				p.next()
+				continue
+			}
+			return tokens, nil
+		case t.is(symbolToken, ";") || t.is(symbolToken, "{") || t.is(symbolToken, "}") ||
+			t.is(symbolToken, ")") || t.is(symbolToken, "]"):
+			return tokens, nil
+		}
+		tokens = append(tokens, p.next())
+	}
+	return tokens, nil
+}
+
+// readGroup reads a balanced group of brackets, including the brackets themselves
+func (p *syntaxParser) readGroup() ([]*token, error) {
+	open := p.peek()
+	var closing []string
+	var tokens []*token
+	for t := p.peek(); t != nil; t = p.peek() {
+		tokens = append(tokens, p.next())
+		if t.Type != symbolToken {
+			continue
+		}
+
+		switch t.Value {
+		case "(":
+			closing = append(closing, ")")
+		case "[":
+			closing = append(closing, "]")
+		case "{":
+			closing = append(closing, "}")
+		case ")", "]", "}":
+			if closing[len(closing)-1] != t.Value {
+				return nil, p.error(t, fmt.Sprintf("unexpected %s", t.Value))
+			}
+			closing = closing[:len(closing)-1]
+			if len(closing) == 0 {
+				return tokens, nil
+			}
+		}
+	}
+	return nil, p.error(open, fmt.Sprintf("missing closing %s", closing[len(closing)-1]))
+}
+
+// skipExpression skips code that is not a command, e.g. `[a, b].each { ... }` or `return x`
+func (p *syntaxParser) skipExpression() error {
+	for t := p.peek(); t != nil; t = p.peek() {
+		switch {
+		case t.Type == newLineToken || t.is(symbolToken, ";") || t.is(symbolToken, "}"):
+			return nil
+		case t.is(symbolToken, "(") || t.is(symbolToken, "[") || t.is(symbolToken, "{"):
+			if _, err := p.readGroup(); err != nil {
+				return err
+			}
+		default:
+			p.next()
+		}
+	}
+	return nil
+}
+
+func (p *syntaxParser) skipSeparators() {
+	for t := p.peek(); t != nil && (t.Type == newLineToken || t.is(symbolToken, ";")); t = p.peek() {
+		p.next()
+	}
+}
+
+func (p *syntaxParser) peek() *token {
+	return p.peekAt(0)
+}
+
+func (p *syntaxParser) peekAt(offset int) *token {
+	if p.position+offset >= len(p.tokens) {
+		return nil
+	}
+	return p.tokens[p.position+offset]
+}
+
+func (p *syntaxParser) next() *token {
+	t := p.tokens[p.position]
+	p.position++
+	return t
+}
+
+func (p *syntaxParser) error(t *token, message string) error {
+	return consts.NewErrInvalidJenkinsfile(fmt.Sprintf("%s at line %d, column %d", message, t.StartRef.Line, t.StartRef.Column))
+}
+
+func isContinuationToken(t *token) bool {
+	return t.Type == symbolToken && strings.Contains(",+-*/&|?:=.<>!", t.Value)
+}
@@ -0,0 +1,105 @@
+package jenkins
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseStatements(t *testing.T) {
+	testCases := []struct {
+		name               string
+		data               string
+		expectedStatements []*statement
+		expectedError      error
+	}{
+		{
+			name:               "Empty file",
+			data:               "",
+			expectedStatements: nil,
+		},
+		{
+			name: "Command with string argument",
+			data: "sh 'make' // build",
+			expectedStatements: []*statement{
+				{
+					Name:          "sh",
+					Arguments:     []*argument{{Value: "make", IsString: true}},
+					Source:        "sh 'make'",
+					FileReference: testutils.CreateFileReference(1, 1, 1, 10),
+				},
+			},
+		},
+		{
+			name: "Named arguments across lines",
+			data: "timeout time: 1,\n  unit: \"HOURS\"",
+			expectedStatements: []*statement{
+				{
+					Name: "timeout",
+					Arguments: []*argument{
+						{Name: utils.GetPtr("time"), Value: "1"},
+						{Name: utils.GetPtr("unit"), Value: "HOURS", IsString: true},
+					},
+					Source:        "timeout time: 1,\n  unit: \"HOURS\"",
+					FileReference: testutils.CreateFileReference(1, 1, 2, 16),
+				},
+			},
+		},
+		{
+			name: "Assignment",
+			data: `FOO = credentials("token")`,
+			expectedStatements: []*statement{
+				{
+					Name:          "FOO",
+					Arguments:     []*argument{{Value: `credentials("token")`}},
+					IsAssignment:  true,
+					Source:        `FOO = credentials("token")`,
+					FileReference: testutils.CreateFileReference(1, 1, 1, 27),
+				},
+			},
+		},
+		{
+			name: "Closure with nested commands and skipped expressions",
+			data: "stage('Build') {\n  /* comment */\n  [1, 2].each { println it }\n  sh \"\"\"\n    make ${env.TARGET ?: \"all\"}\n  \"\"\"\n}",
+			expectedStatements: []*statement{
+				{
+					Name:      "stage",
+					Arguments: []*argument{{Value: "Build", IsString: true}},
+					Block: []*statement{
+						{
+							Name:          "sh",
+							Arguments:     []*argument{{Value: "\n    make ${env.TARGET ?: \"all\"}\n  ", IsString: true}},
+							Source:        "sh \"\"\"\n    make ${env.TARGET ?: \"all\"}\n  \"\"\"",
+							FileReference: testutils.CreateFileReference(4, 3, 6, 6),
+						},
+					},
+					HasBlock:      true,
+					BlockContent:  "/* comment */\n  [1, 2].each { println it }\n  sh \"\"\"\n    make ${env.TARGET ?: \"all\"}\n  \"\"\"",
+					Source:        "stage('Build') {\n  /* comment */\n  [1, 2].each { println it }\n  sh \"\"\"\n    make ${env.TARGET ?: \"all\"}\n  \"\"\"\n}",
+					FileReference: testutils.CreateFileReference(1, 1, 7, 2),
+				},
+			},
+		},
+		{
+			name:          "Missing closing brace",
+			data:          "pipeline {\n  agent any\n",
+			expectedError: consts.NewErrInvalidJenkinsfile("missing closing brace at line 1, column 10"),
+		},
+		{
+			name:          "Unterminated string",
+			data:          "sh 'make",
+			expectedError: consts.NewErrInvalidJenkinsfile("unterminated string at line 1, column 4"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := parseStatements(testCase.data)
+
+			testutils.DeepCompare(t, testCase.expectedError, err)
+			testutils.DeepCompare(t, testCase.expectedStatements, got)
+		})
+	}
+}
@@ -3,3 +3,8 @@ package loaders
 type Loader[T any] interface {
 	Load(data []byte) (*T, error)
 }
+
+// TemplateLoader is a loader that expands the compile-time expressions of a pipeline and of the templates it imports
+type TemplateLoader[T any] interface {
+	LoadTemplate(data []byte, parameters map[string]any) (*T, error)
+}
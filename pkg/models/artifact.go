@@ -0,0 +1,19 @@
+package models
+
+const (
+	FileArtifactType  ArtifactType = "artifact"
+	CacheArtifactType ArtifactType = "cache"
+)
+
+type ArtifactType string
+
+// Artifact is a set of files that a job or a step produces or consumes, either as a pipeline artifact or as a cache.
+// A consumed artifact without a name or a job ID stands for all the artifacts of the jobs that ran before it.
+type Artifact struct {
+	Type          ArtifactType   `json:"type,omitempty"`
+	Name          *string        `json:"name,omitempty"`
+	Paths         []string       `json:"paths,omitempty"`
+	ExpireIn      *string        `json:"expire_in,omitempty"`
+	JobID         *string        `json:"job_id,omitempty"` // The job that produced a consumed artifact
+	FileReference *FileReference `json:"file_reference,omitempty"`
+}
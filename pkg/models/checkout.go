@@ -0,0 +1,8 @@
+package models
+
+// CheckoutSettings are the settings of the repository checkout of a job or step
+type CheckoutSettings struct {
+	Enabled *bool `json:"enabled,omitempty"`
+	Depth   *int  `json:"depth,omitempty"` // The number of commits to fetch, nil for a full clone
+	LFS     *bool `json:"lfs,omitempty"`   // Whether the Git LFS files are downloaded
+}
@@ -0,0 +1,27 @@
+package models
+
+type DiagnosticSeverity string
+
+const (
+	ErrorSeverity   DiagnosticSeverity = "error"
+	WarningSeverity DiagnosticSeverity = "warning"
+	InfoSeverity    DiagnosticSeverity = "info"
+)
+
+type DiagnosticCode string
+
+const (
+	ImportFetchFailedCode DiagnosticCode = "import_fetch_failed"
+	ImportParseFailedCode DiagnosticCode = "import_parse_failed"
+	ImportNotResolvedCode DiagnosticCode = "import_not_resolved"
+	EnhanceFailedCode     DiagnosticCode = "enhance_failed"
+)
+
+// Diagnostic is a problem that occurred while parsing the pipeline or one of its imported pipelines
+type Diagnostic struct {
+	Severity      DiagnosticSeverity `json:"severity,omitempty"`
+	Code          DiagnosticCode     `json:"code,omitempty"`
+	Message       string             `json:"message,omitempty"`
+	FileReference *FileReference     `json:"file_reference,omitempty"`
+	Source        *ImportSource      `json:"source,omitempty"`
+}
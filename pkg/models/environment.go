@@ -0,0 +1,13 @@
+package models
+
+// Environment is the deployment environment a job targets
+type Environment struct {
+	Name          *string        `json:"name,omitempty"`
+	URL           *string        `json:"url,omitempty"`
+	Action        *string        `json:"action,omitempty"`
+	OnStop        *string        `json:"on_stop,omitempty"`
+	Tier          *string        `json:"tier,omitempty"`
+	ResourceName  *string        `json:"resource_name,omitempty"`
+	ResourceType  *string        `json:"resource_type,omitempty"`
+	FileReference *FileReference `json:"file_reference,omitempty"`
+}
@@ -17,11 +17,17 @@ type Job struct {
 	ID                   *string                  `json:"id,omitempty"`
 	Name                 *string                  `json:"name,omitempty"`
 	Steps                []*Step                  `json:"steps,omitempty"`
+	StepGroups           []*StepGroup             `json:"step_groups,omitempty"`
 	ContinueOnError      *string                  `json:"continue_on_error,omitempty"`
 	PreSteps             []*Step                  `json:"pre_steps,omitempty"`
 	PostSteps            []*Step                  `json:"post_steps,omitempty"`
 	EnvironmentVariables *EnvironmentVariablesRef `json:"environment_variables,omitempty"`
 	Runner               *Runner                  `json:"runner,omitempty"`
+	Services             []*Service               `json:"services,omitempty"`
+	Environment          *Environment             `json:"environment,omitempty"`
+	Produces             []*Artifact              `json:"produces,omitempty"`
+	Consumes             []*Artifact              `json:"consumes,omitempty"`
+	Outputs              []*Output                `json:"outputs,omitempty"`
 	Conditions           []*Condition             `json:"conditions,omitempty"`
 	ConcurrencyGroup     *ConcurrencyGroup        `json:"concurrency_group,omitempty"`
 	Inputs               []*Parameter             `json:"inputs,omitempty"`
@@ -29,10 +35,22 @@ type Job struct {
 	Tags                 []string                 `json:"tags,omitempty"`
 	TokenPermissions     *TokenPermissions        `json:"token_permissions,omitempty"`
 	Dependencies         []*JobDependency         `json:"dependencies,omitempty"`
+	IgnoresStageOrder    bool                     `json:"ignores_stage_order,omitempty"` // Whether the job waits only for its dependencies instead of the previous stages, e.g. GitLab jobs with needs
 	Metadata             Metadata                 `json:"metadata,omitempty"`
 	Matrix               *Matrix                  `json:"matrix,omitempty"`
 	FileReference        *FileReference           `json:"file_reference,omitempty"`
 	Imports              *Import                  `json:"imports,omitempty"`
+	Extends              []string                 `json:"extends,omitempty"`
+}
+
+// StepGroup is a group of the steps of a job. The groups of a job run one after the other,
+// and the steps of a parallel group run at the same time.
+type StepGroup struct {
+	Steps         []int          `json:"steps"` // The indexes of the steps of the group in the steps of the job
+	Parallel      bool           `json:"parallel,omitempty"`
+	FailFast      *bool          `json:"fail_fast,omitempty"` // Whether the steps of a parallel group are stopped when one of them fails
+	Manual        bool           `json:"manual,omitempty"`    // Whether the group waits for someone to start it
+	FileReference *FileReference `json:"file_reference,omitempty"`
 }
 
 type Matrix struct {
@@ -46,4 +64,5 @@ type JobDependency struct {
 	JobID            *string           `json:"job_id,omitempty"`
 	ConcurrencyGroup *ConcurrencyGroup `json:"concurrency_group,omitempty"`
 	Pipeline         *string           `json:"pipeline,omitempty"`
+	ArtifactsOnly    bool              `json:"artifacts_only,omitempty"` // The job only downloads the artifacts of the dependency and does not wait for it
 }
@@ -0,0 +1,8 @@
+package models
+
+// Output is a named value a job or a step exposes to the jobs and steps that run after it
+type Output struct {
+	Name          *string        `json:"name,omitempty"`
+	Value         *string        `json:"value,omitempty"`
+	FileReference *FileReference `json:"file_reference,omitempty"`
+}
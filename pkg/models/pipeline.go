@@ -1,14 +1,16 @@
 package models
 
 type Pipeline struct {
-	Id         *string      `json:"id,omitempty"`
-	Name       *string      `json:"name,omitempty"`
-	Triggers   *Triggers    `json:"triggers,omitempty"`
-	Jobs       []*Job       `json:"jobs,omitempty"`
-	Imports    []*Import    `json:"imports,omitempty"`
-	Parameters []*Parameter `json:"parameters,omitempty"`
-	Defaults   *Defaults    `json:"defaults,omitempty"`
-	Platform   Platform     `json:"platform,omitempty"`
+	Id          *string       `json:"id,omitempty"`
+	Name        *string       `json:"name,omitempty"`
+	Triggers    *Triggers     `json:"triggers,omitempty"`
+	Jobs        []*Job        `json:"jobs,omitempty"`
+	Stages      []*Stage      `json:"stages,omitempty"`
+	Imports     []*Import     `json:"imports,omitempty"`
+	Parameters  []*Parameter  `json:"parameters,omitempty"`
+	Defaults    *Defaults     `json:"defaults,omitempty"`
+	Platform    Platform      `json:"platform,omitempty"`
+	Diagnostics []*Diagnostic `json:"diagnostics,omitempty"`
 }
 
 type Scans struct {
@@ -29,10 +31,12 @@ type Defaults struct {
 	EnvironmentVariables *EnvironmentVariablesRef `json:"environment_variables,omitempty"`
 	Scans                *Scans                   `json:"scans,omitempty"`
 	Runner               *Runner                  `json:"runner,omitempty"`
+	Services             []*Service               `json:"services,omitempty"`
 	Conditions           []*Condition             `json:"conditions,omitempty"`
 	ContinueOnError      *bool                    `json:"continue_on_error,omitempty"`
 	TokenPermissions     *TokenPermissions        `json:"token_permissions,omitempty"`
 	Settings             *map[string]any          `json:"settings,omitempty"`
+	Checkout             *CheckoutSettings        `json:"checkout,omitempty"`
 	FileReference        *FileReference           `json:"file_reference,omitempty"`
 	PostSteps            []*Step                  `json:"post_steps,omitempty"`
 	PreSteps             []*Step                  `json:"pre_steps,omitempty"`
@@ -26,6 +26,7 @@ type Runner struct {
 	OS             *string         `json:"os,omitempty"`
 	Arch           *string         `json:"arch,omitempty"`
 	SelfHosted     *bool           `json:"self_hosted,omitempty"`
+	ResourceClass  *string         `json:"resource_class,omitempty"` // The size of the machine the runner runs on
 	DockerMetadata *DockerMetadata `json:"docker_metadata,omitempty"`
 	FileReference  *FileReference  `json:"file_reference,omitempty"`
 }
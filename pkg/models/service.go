@@ -0,0 +1,12 @@
+package models
+
+// Service is a container that runs alongside a job, e.g. a database used by the job's tests
+type Service struct {
+	Name                 *string                  `json:"name,omitempty"`
+	DockerMetadata       *DockerMetadata          `json:"docker_metadata,omitempty"`
+	Ports                []string                 `json:"ports,omitempty"`
+	Volumes              []string                 `json:"volumes,omitempty"`
+	Options              *string                  `json:"options,omitempty"`
+	EnvironmentVariables *EnvironmentVariablesRef `json:"environment_variables,omitempty"`
+	FileReference        *FileReference           `json:"file_reference,omitempty"`
+}
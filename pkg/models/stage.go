@@ -0,0 +1,13 @@
+package models
+
+// Stage is a named group of jobs that runs in order with the other stages of the pipeline
+type Stage struct {
+	ID                   *string                  `json:"id,omitempty"`
+	Name                 *string                  `json:"name,omitempty"`
+	Order                int                      `json:"order"`
+	Conditions           []*Condition             `json:"conditions,omitempty"`
+	Dependencies         []string                 `json:"dependencies,omitempty"`
+	EnvironmentVariables *EnvironmentVariablesRef `json:"environment_variables,omitempty"`
+	Jobs                 []string                 `json:"jobs,omitempty"`
+	FileReference        *FileReference           `json:"file_reference,omitempty"`
+}
@@ -34,11 +34,15 @@ type Step struct {
 	EnvironmentVariables *EnvironmentVariablesRef `json:"environment_variables,omitempty"`
 	WorkingDirectory     *string                  `json:"working_directory,omitempty"`
 	Timeout              *int                     `json:"timeout,omitempty"`
+	Checkout             *CheckoutSettings        `json:"checkout,omitempty"`
 	Conditions           *[]Condition             `json:"conditions,omitempty"`
 	Shell                *Shell                   `json:"shell,omitempty"`
 	Task                 *Task                    `json:"task,omitempty"`
 	Metadata             Metadata                 `json:"metadata,omitempty"`
 	AfterScript          *Shell                   `json:"after_script,omitempty"`
+	Produces             []*Artifact              `json:"produces,omitempty"`
+	Consumes             []*Artifact              `json:"consumes,omitempty"`
+	Outputs              []*Output                `json:"outputs,omitempty"`
 	FileReference        *FileReference           `json:"file_reference,omitempty"`
 	Imports              *Import                  `json:"imports,omitempty"`
 }
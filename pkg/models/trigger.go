@@ -23,6 +23,7 @@ type Trigger struct {
 	Event         EventType      `json:"event,omitempty"`
 	Disabled      *bool          `json:"disabled,omitempty"`
 	Schedules     *[]string      `json:"schedules,omitempty"`
+	Jobs          []string       `json:"jobs,omitempty"` // The IDs of the jobs the trigger runs, all of the jobs when empty
 	FileReference *FileReference `json:"file_reference,omitempty"`
 }
 
@@ -26,6 +26,7 @@ func (g *AzureParser) Parse(azurePipeline *azureModels.Pipeline) (*models.Pipeli
 
 	if azurePipeline.Stages != nil {
 		jobs = append(jobs, parseStages(azurePipeline.Stages)...)
+		pipeline.Stages = parsePipelineStages(azurePipeline.Stages)
 	}
 
 	if azurePipeline.Jobs != nil {
@@ -1,6 +1,7 @@
 package azure
 
 import (
+	"sort"
 	"strings"
 
 	azureModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/azure/models"
@@ -47,13 +48,18 @@ func parseExtendParameters(params map[string]any, rootFileRef *models.FileRefere
 		return nil, nil
 	}
 
-	for key, param := range params {
+	// iterate the parameters in a stable order, to keep the order of the imports deterministic
+	keys := utils.GetMapKeys(params)
+	sort.Strings(keys)
+	for _, key := range keys {
+		param := params[key]
 		var items []any
 		if utils.IsArray(param) {
 			items = append(items, param.([]any)...)
 		} else {
 			items = append(items, param)
 		}
+		var values []any
 		for _, item := range items {
 			value, ok := tryToParseTemplate(item)
 			if ok {
@@ -72,10 +78,20 @@ func parseExtendParameters(params map[string]any, rootFileRef *models.FileRefere
 				imports = append(imports, paramImports...)
 				continue
 			}
-			if parameters == nil {
-				parameters = make(map[string]any)
-			}
-			parameters[key] = item
+			values = append(values, item)
+		}
+
+		if len(values) == 0 {
+			continue
+		}
+		if parameters == nil {
+			parameters = make(map[string]any)
+		}
+		// the items of a list parameter that are not templates are kept as a list
+		if utils.IsArray(param) {
+			parameters[key] = values
+		} else {
+			parameters[key] = values[0]
 		}
 	}
 
@@ -84,7 +100,10 @@ func parseExtendParameters(params map[string]any, rootFileRef *models.FileRefere
 
 func tryToParseTemplate(input any) (azureModels.Template, bool) {
 	var azureTemplate azureModels.Template
-	return azureTemplate, mapstructure.Decode(input, &azureTemplate) == nil
+	if err := mapstructure.Decode(input, &azureTemplate); err != nil {
+		return azureTemplate, false
+	}
+	return azureTemplate, azureTemplate.Template != ""
 }
 
 func calculateSourceType(alias string) models.SourceType {
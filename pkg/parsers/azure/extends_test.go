@@ -92,6 +92,49 @@ func TestParseExtends(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Extends with list and object parameters",
+			extends: &azureModels.Extends{
+				Template: azureModels.Template{
+					Template: "template1",
+					Parameters: map[string]any{
+						"environments": []any{"dev", "prod"},
+						"settings": map[string]any{
+							"retries": 3,
+						},
+						"steps": []any{
+							map[string]any{"script": "echo build"},
+							map[string]any{"template": "template2"},
+						},
+					},
+				},
+			},
+			expectedImports: []*models.Import{
+				{
+					Parameters: map[string]any{
+						"environments": []any{"dev", "prod"},
+						"settings": map[string]any{
+							"retries": 3,
+						},
+						"steps": []any{
+							map[string]any{"script": "echo build"},
+						},
+					},
+					Source: &models.ImportSource{
+						Path:            utils.GetPtr("template1"),
+						RepositoryAlias: utils.GetPtr(""),
+						Type:            models.SourceTypeLocal,
+					},
+				},
+				{
+					Source: &models.ImportSource{
+						Path:            utils.GetPtr("template2"),
+						RepositoryAlias: utils.GetPtr(""),
+						Type:            models.SourceTypeLocal,
+					},
+				},
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
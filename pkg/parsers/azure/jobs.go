@@ -2,6 +2,7 @@ package azure
 
 import (
 	"strconv"
+	"strings"
 
 	azureModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/azure/models"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
@@ -77,11 +78,33 @@ func parseDeploymentJob(job *azureModels.DeploymentJob) *models.Job {
 	parsedJob := parseBaseJob(&job.BaseJob)
 
 	parsedJob.ID = &job.Deployment
+	parsedJob.Environment = parseDeploymentEnvironment(job.Environment)
 	parsedJob.FileReference = job.FileReference
 
 	return parsedJob
 }
 
+// parseDeploymentEnvironment parses the environment of a deployment job, where "environment.resource" is the shorthand of a resource in the environment
+func parseDeploymentEnvironment(environment *azureModels.DeploymentEnvironmentRef) *models.Environment {
+	if environment == nil || environment.DeploymentEnvironment == nil || environment.DeploymentEnvironment.Name == "" {
+		return nil
+	}
+
+	name, resourceName := environment.DeploymentEnvironment.Name, environment.DeploymentEnvironment.ResourceName
+	if resourceName == "" {
+		if parts := strings.SplitN(name, ".", 2); len(parts) == 2 {
+			name, resourceName = parts[0], parts[1]
+		}
+	}
+
+	return &models.Environment{
+		Name:          &name,
+		ResourceName:  utils.GetPtrOrNil(resourceName),
+		ResourceType:  utils.GetPtrOrNil(environment.DeploymentEnvironment.ResourceType),
+		FileReference: environment.FileReference,
+	}
+}
+
 func parseBaseJob(job *azureModels.BaseJob) *models.Job {
 	if job == nil {
 		return nil
@@ -386,6 +386,12 @@ func TestParseDeploymentJob(t *testing.T) {
 						},
 					},
 				},
+				Environment: &azureModels.DeploymentEnvironmentRef{
+					DeploymentEnvironment: &azureModels.DeploymentEnvironment{
+						Name: "production.web",
+					},
+					FileReference: testutils.CreateFileReference(4, 5, 4, 32),
+				},
 				FileReference: testutils.CreateFileReference(1, 2, 3, 4),
 			},
 			expectedJob: &models.Job{
@@ -395,6 +401,11 @@ func TestParseDeploymentJob(t *testing.T) {
 				TimeoutMS:       utils.GetPtr(6000000),
 				Conditions:      []*models.Condition{{Statement: "job-1-condition"}},
 				Dependencies:    []*models.JobDependency{{JobID: utils.GetPtr("job-2")}},
+				Environment: &models.Environment{
+					Name:          utils.GetPtr("production"),
+					ResourceName:  utils.GetPtr("web"),
+					FileReference: testutils.CreateFileReference(4, 5, 4, 32),
+				},
 				Runner: &models.Runner{
 					OS: utils.GetPtr("linux"),
 					DockerMetadata: &models.DockerMetadata{
@@ -434,6 +445,60 @@ func TestParseDeploymentJob(t *testing.T) {
 	}
 }
 
+func TestParseDeploymentEnvironment(t *testing.T) {
+	testCases := []struct {
+		name                string
+		environment         *azureModels.DeploymentEnvironmentRef
+		expectedEnvironment *models.Environment
+	}{
+		{
+			name:                "Environment is nil",
+			environment:         nil,
+			expectedEnvironment: nil,
+		},
+		{
+			name:                "Environment without name",
+			environment:         &azureModels.DeploymentEnvironmentRef{DeploymentEnvironment: &azureModels.DeploymentEnvironment{}},
+			expectedEnvironment: nil,
+		},
+		{
+			name: "Environment name",
+			environment: &azureModels.DeploymentEnvironmentRef{
+				DeploymentEnvironment: &azureModels.DeploymentEnvironment{Name: "staging"},
+				FileReference:         testutils.CreateFileReference(1, 2, 1, 20),
+			},
+			expectedEnvironment: &models.Environment{
+				Name:          utils.GetPtr("staging"),
+				FileReference: testutils.CreateFileReference(1, 2, 1, 20),
+			},
+		},
+		{
+			name: "Environment with resource",
+			environment: &azureModels.DeploymentEnvironmentRef{
+				DeploymentEnvironment: &azureModels.DeploymentEnvironment{
+					Name:         "production",
+					ResourceName: "cluster.namespace",
+					ResourceType: "Kubernetes",
+				},
+				FileReference: testutils.CreateFileReference(1, 2, 4, 25),
+			},
+			expectedEnvironment: &models.Environment{
+				Name:          utils.GetPtr("production"),
+				ResourceName:  utils.GetPtr("cluster.namespace"),
+				ResourceType:  utils.GetPtr("Kubernetes"),
+				FileReference: testutils.CreateFileReference(1, 2, 4, 25),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseDeploymentEnvironment(testCase.environment)
+			testutils.DeepCompare(t, testCase.expectedEnvironment, got)
+		})
+	}
+}
+
 func TestParseBaseJob(t *testing.T) {
 	testCases := []struct {
 		name        string
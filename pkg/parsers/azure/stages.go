@@ -1,8 +1,11 @@
 package azure
 
 import (
+	"sort"
+
 	azureModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/azure/models"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
 )
 
 func parseStages(stages *azureModels.Stages) []*models.Job {
@@ -69,3 +72,93 @@ func parseTemplateStage(stage *azureModels.TemplateStage) *models.Job {
 		FileReference: stage.FileReference,
 	}
 }
+
+func parsePipelineStages(stages *azureModels.Stages) []*models.Stage {
+	if stages == nil || (stages.Stages == nil && stages.TemplateStages == nil) {
+		return nil
+	}
+
+	var parsedStages []*models.Stage
+
+	for _, stage := range stages.Stages {
+		parsedStages = append(parsedStages, parseStageModel(stage))
+	}
+
+	for _, stage := range stages.TemplateStages {
+		if stage.Template.Template != "" {
+			parsedStages = append(parsedStages, parseTemplateStageModel(stage))
+		}
+	}
+
+	// Stages and template stages are loaded separately, so the original order is restored by their location in the file
+	sort.SliceStable(parsedStages, func(i, j int) bool {
+		return getStartLine(parsedStages[i].FileReference) < getStartLine(parsedStages[j].FileReference)
+	})
+
+	for i, stage := range parsedStages {
+		stage.Order = i
+		if stage.Dependencies == nil && i > 0 {
+			// A stage without dependsOn depends on the stage that precedes it
+			stage.Dependencies = []string{*parsedStages[i-1].ID}
+		}
+	}
+
+	return parsedStages
+}
+
+func parseStageModel(stage *azureModels.Stage) *models.Stage {
+	parsedStage := &models.Stage{
+		ID:            &stage.Stage,
+		Name:          utils.GetPtrOrNil(stage.DisplayName),
+		Jobs:          getJobIDs(stage.Jobs),
+		FileReference: stage.FileReference,
+	}
+
+	if stage.Condition != "" {
+		parsedStage.Conditions = []*models.Condition{{Statement: stage.Condition}}
+	}
+
+	if stage.DependsOn != nil {
+		// An explicit empty dependsOn is kept as an empty (non nil) list, as it removes the implicit dependency
+		parsedStage.Dependencies = append([]string{}, *stage.DependsOn...)
+	}
+
+	if stage.Variables != nil {
+		parsedStage.EnvironmentVariables = parseVariables(stage.Variables)
+	}
+
+	return parsedStage
+}
+
+func parseTemplateStageModel(stage *azureModels.TemplateStage) *models.Stage {
+	return &models.Stage{
+		ID:            &stage.Template.Template,
+		Jobs:          []string{stage.Template.Template},
+		FileReference: stage.FileReference,
+	}
+}
+
+func getJobIDs(jobs *azureModels.Jobs) []string {
+	if jobs == nil {
+		return nil
+	}
+
+	var jobIDs []string
+	for _, job := range jobs.CIJobs {
+		jobIDs = append(jobIDs, job.Job)
+	}
+	for _, job := range jobs.DeploymentJobs {
+		jobIDs = append(jobIDs, job.Deployment)
+	}
+	for _, job := range jobs.TemplateJobs {
+		jobIDs = append(jobIDs, job.Template.Template)
+	}
+	return jobIDs
+}
+
+func getStartLine(fileReference *models.FileReference) int {
+	if fileReference == nil || fileReference.StartRef == nil {
+		return 0
+	}
+	return fileReference.StartRef.Line
+}
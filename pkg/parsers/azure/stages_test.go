@@ -190,3 +190,96 @@ func TestParseStage(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePipelineStages(t *testing.T) {
+	testCases := []struct {
+		name           string
+		stages         *azureModels.Stages
+		expectedStages []*models.Stage
+	}{
+		{
+			name:           "Stages is nil",
+			stages:         nil,
+			expectedStages: nil,
+		},
+		{
+			name:           "Stages is empty",
+			stages:         &azureModels.Stages{},
+			expectedStages: nil,
+		},
+		{
+			name: "Stages with data",
+			stages: &azureModels.Stages{
+				Stages: []*azureModels.Stage{
+					{
+						Stage:       "build",
+						DisplayName: "Build",
+						Condition:   "succeeded()",
+						Jobs: &azureModels.Jobs{
+							CIJobs: []*azureModels.CIJob{
+								{Job: "compile"},
+							},
+							DeploymentJobs: []*azureModels.DeploymentJob{
+								{Deployment: "publish"},
+							},
+						},
+						FileReference: testutils.CreateFileReference(1, 1, 4, 4),
+					},
+					{
+						Stage:         "deploy",
+						DependsOn:     &azureModels.DependsOn{"build", "test"},
+						FileReference: testutils.CreateFileReference(9, 1, 12, 4),
+					},
+					{
+						Stage:         "cleanup",
+						DependsOn:     &azureModels.DependsOn{},
+						FileReference: testutils.CreateFileReference(13, 1, 14, 4),
+					},
+				},
+				TemplateStages: []*azureModels.TemplateStage{
+					{
+						Template:      azureModels.Template{Template: "stages/test.yml"},
+						FileReference: testutils.CreateFileReference(5, 1, 8, 4),
+					},
+				},
+			},
+			expectedStages: []*models.Stage{
+				{
+					ID:            utils.GetPtr("build"),
+					Name:          utils.GetPtr("Build"),
+					Order:         0,
+					Conditions:    []*models.Condition{{Statement: "succeeded()"}},
+					Jobs:          []string{"compile", "publish"},
+					FileReference: testutils.CreateFileReference(1, 1, 4, 4),
+				},
+				{
+					ID:            utils.GetPtr("stages/test.yml"),
+					Order:         1,
+					Dependencies:  []string{"build"},
+					Jobs:          []string{"stages/test.yml"},
+					FileReference: testutils.CreateFileReference(5, 1, 8, 4),
+				},
+				{
+					ID:            utils.GetPtr("deploy"),
+					Order:         2,
+					Dependencies:  []string{"build", "test"},
+					FileReference: testutils.CreateFileReference(9, 1, 12, 4),
+				},
+				{
+					ID:            utils.GetPtr("cleanup"),
+					Order:         3,
+					Dependencies:  []string{},
+					FileReference: testutils.CreateFileReference(13, 1, 14, 4),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parsePipelineStages(testCase.stages)
+
+			testutils.DeepCompare(t, testCase.expectedStages, got)
+		})
+	}
+}
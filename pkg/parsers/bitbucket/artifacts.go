@@ -0,0 +1,71 @@
+package bitbucket
+
+import (
+	bitbucketModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/bitbucket/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+// parseStepArtifacts returns the artifacts and caches a step produces and consumes.
+// Steps download the artifacts of all the previous steps unless the download is disabled, and both restore and save their caches.
+func parseStepArtifacts(executionUnitRef *bitbucketModels.ExecutionUnitRef, definitions *bitbucketModels.Definitions) ([]*models.Artifact, []*models.Artifact) {
+	var produces, consumes []*models.Artifact
+	executionUnit := executionUnitRef.ExecutionUnit
+
+	download := true
+	if executionUnit.Artifacts != nil {
+		paths := executionUnit.Artifacts.Paths
+		if executionUnit.Artifacts.SharedStepFiles != nil {
+			paths = executionUnit.Artifacts.SharedStepFiles.Paths
+			download = executionUnit.Artifacts.SharedStepFiles.Download == nil || *executionUnit.Artifacts.SharedStepFiles.Download
+		}
+		if len(paths) > 0 {
+			produces = append(produces, &models.Artifact{
+				Type:          models.FileArtifactType,
+				Paths:         parsePaths(paths),
+				FileReference: executionUnitRef.FileReference,
+			})
+		}
+	}
+	if download {
+		consumes = append(consumes, &models.Artifact{Type: models.FileArtifactType})
+	}
+
+	for _, name := range executionUnit.Caches {
+		if name == nil {
+			continue
+		}
+		cache := &models.Artifact{
+			Type:          models.CacheArtifactType,
+			Name:          name,
+			FileReference: executionUnitRef.FileReference,
+		}
+		if definitions != nil && definitions.Caches != nil {
+			if path := (*definitions.Caches)[*name]; path != nil {
+				cache.Paths = []string{*path}
+			}
+		}
+		produces = append(produces, cache)
+		consumes = append(consumes, cache)
+	}
+	return produces, consumes
+}
+
+func removeArtifactDownloads(artifacts []*models.Artifact) []*models.Artifact {
+	var caches []*models.Artifact
+	for _, artifact := range artifacts {
+		if artifact.Type != models.FileArtifactType {
+			caches = append(caches, artifact)
+		}
+	}
+	return caches
+}
+
+func parsePaths(paths []*string) []string {
+	var parsedPaths []string
+	for _, path := range paths {
+		if path != nil {
+			parsedPaths = append(parsedPaths, *path)
+		}
+	}
+	return parsedPaths
+}
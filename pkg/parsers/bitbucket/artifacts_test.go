@@ -0,0 +1,101 @@
+package bitbucket
+
+import (
+	"testing"
+
+	bitbucketModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/bitbucket/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseStepArtifacts(t *testing.T) {
+	fileReference := testutils.CreateFileReference(1, 2, 3, 4)
+	testCases := []struct {
+		name             string
+		executionUnit    *bitbucketModels.ExecutionUnit
+		definitions      *bitbucketModels.Definitions
+		expectedProduces []*models.Artifact
+		expectedConsumes []*models.Artifact
+	}{
+		{
+			name:          "Step without artifacts downloads the previous artifacts",
+			executionUnit: &bitbucketModels.ExecutionUnit{},
+			expectedConsumes: []*models.Artifact{
+				{Type: models.FileArtifactType},
+			},
+		},
+		{
+			name: "Step with artifact paths",
+			executionUnit: &bitbucketModels.ExecutionUnit{
+				Artifacts: &bitbucketModels.Artifacts{
+					Paths: []*string{utils.GetPtr("dist/**"), nil, utils.GetPtr("reports/*.txt")},
+				},
+			},
+			expectedProduces: []*models.Artifact{
+				{
+					Type:          models.FileArtifactType,
+					Paths:         []string{"dist/**", "reports/*.txt"},
+					FileReference: fileReference,
+				},
+			},
+			expectedConsumes: []*models.Artifact{
+				{Type: models.FileArtifactType},
+			},
+		},
+		{
+			name: "Step with shared files that does not download artifacts",
+			executionUnit: &bitbucketModels.ExecutionUnit{
+				Artifacts: &bitbucketModels.Artifacts{
+					SharedStepFiles: &bitbucketModels.SharedStepFiles{
+						Download: utils.GetPtr(false),
+						Paths:    []*string{utils.GetPtr("build/")},
+					},
+				},
+			},
+			expectedProduces: []*models.Artifact{
+				{
+					Type:          models.FileArtifactType,
+					Paths:         []string{"build/"},
+					FileReference: fileReference,
+				},
+			},
+		},
+		{
+			name: "Step with predefined and custom caches",
+			executionUnit: &bitbucketModels.ExecutionUnit{
+				Artifacts: &bitbucketModels.Artifacts{
+					SharedStepFiles: &bitbucketModels.SharedStepFiles{
+						Download: utils.GetPtr(false),
+					},
+				},
+				Caches: []*string{utils.GetPtr("node"), utils.GetPtr("bundler")},
+			},
+			definitions: &bitbucketModels.Definitions{
+				Caches: &bitbucketModels.Caches{
+					"bundler": utils.GetPtr("vendor/bundle"),
+				},
+			},
+			expectedProduces: []*models.Artifact{
+				{Type: models.CacheArtifactType, Name: utils.GetPtr("node"), FileReference: fileReference},
+				{Type: models.CacheArtifactType, Name: utils.GetPtr("bundler"), Paths: []string{"vendor/bundle"}, FileReference: fileReference},
+			},
+			expectedConsumes: []*models.Artifact{
+				{Type: models.CacheArtifactType, Name: utils.GetPtr("node"), FileReference: fileReference},
+				{Type: models.CacheArtifactType, Name: utils.GetPtr("bundler"), Paths: []string{"vendor/bundle"}, FileReference: fileReference},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			produces, consumes := parseStepArtifacts(&bitbucketModels.ExecutionUnitRef{
+				ExecutionUnit: testCase.executionUnit,
+				FileReference: fileReference,
+			}, testCase.definitions)
+
+			testutils.DeepCompare(t, testCase.expectedProduces, produces)
+			testutils.DeepCompare(t, testCase.expectedConsumes, consumes)
+		})
+	}
+}
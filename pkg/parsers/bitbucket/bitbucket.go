@@ -15,6 +15,8 @@ func (g *BitbucketParser) Parse(bitbucketPipeline *bitbucketModels.Pipeline) (*m
 	var pipeline models.Pipeline
 
 	pipeline.Defaults = parsePipelineDefaults(bitbucketPipeline)
+	pipeline.Triggers = parsePipelineTriggers(bitbucketPipeline)
+	pipeline.Parameters = parsePipelineParameters(bitbucketPipeline)
 	pipeline.Jobs = parseJobs(bitbucketPipeline)
 
 	return &pipeline, nil
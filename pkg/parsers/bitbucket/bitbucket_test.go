@@ -27,6 +27,7 @@ func TestParse(t *testing.T) {
 				Pipelines: &bbModels.BuildPipelines{
 					Default: []*bbModels.Step{
 						{
+							FailFast: utils.GetPtr(true),
 							Parallel: []*bbModels.ParallelSteps{
 								{
 									Step: &bbModels.ExecutionUnitRef{
@@ -76,12 +77,23 @@ func TestParse(t *testing.T) {
 				},
 			},
 			expectedPipeline: &models.Pipeline{
+				Triggers: &models.Triggers{
+					Triggers: []*models.Trigger{
+						{
+							Event:         models.PushEvent,
+							Jobs:          []string{"job-default"},
+							FileReference: testutils.CreateFileReference(7, 13, 19, 21),
+						},
+					},
+					FileReference: testutils.CreateFileReference(7, 13, 19, 21),
+				},
 				Defaults: &models.Defaults{},
 				Jobs: []*models.Job{
 					{
 						FileReference: testutils.CreateFileReference(7, 13, 19, 21),
 						ID:            utils.GetPtr("job-default"),
 						Name:          utils.GetPtr("default"),
+						TimeoutMS:     utils.GetPtr(7200000),
 						Steps: []*models.Step{
 							{
 								Type: "shell",
@@ -91,6 +103,20 @@ func TestParse(t *testing.T) {
 									Script:        utils.GetPtr("npm install\nnpm test"),
 									FileReference: testutils.CreateFileReference(11, 17, 12, 25),
 								},
+								Produces: []*models.Artifact{
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("node"),
+										FileReference: testutils.CreateFileReference(7, 13, 12, 25),
+									},
+								},
+								Consumes: []*models.Artifact{
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("node"),
+										FileReference: testutils.CreateFileReference(7, 13, 12, 25),
+									},
+								},
 								FileReference: testutils.CreateFileReference(7, 13, 12, 25),
 							},
 							{
@@ -101,6 +127,52 @@ func TestParse(t *testing.T) {
 									Script:        utils.GetPtr("npm install eslint\nnpx eslint ."),
 									FileReference: testutils.CreateFileReference(16, 17, 17, 29),
 								},
+								Produces: []*models.Artifact{
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("node"),
+										FileReference: testutils.CreateFileReference(14, 13, 19, 21),
+									},
+								},
+								Consumes: []*models.Artifact{
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("node"),
+										FileReference: testutils.CreateFileReference(14, 13, 19, 21),
+									},
+								},
+								FileReference: testutils.CreateFileReference(14, 13, 19, 21),
+							},
+						},
+						StepGroups: []*models.StepGroup{
+							{
+								Steps:         []int{0, 1},
+								Parallel:      true,
+								FailFast:      utils.GetPtr(true),
+								FileReference: testutils.CreateFileReference(7, 13, 19, 21),
+							},
+						},
+						Produces: []*models.Artifact{
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("node"),
+								FileReference: testutils.CreateFileReference(7, 13, 12, 25),
+							},
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("node"),
+								FileReference: testutils.CreateFileReference(14, 13, 19, 21),
+							},
+						},
+						Consumes: []*models.Artifact{
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("node"),
+								FileReference: testutils.CreateFileReference(7, 13, 12, 25),
+							},
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("node"),
 								FileReference: testutils.CreateFileReference(14, 13, 19, 21),
 							},
 						},
@@ -135,7 +207,8 @@ func TestParse(t *testing.T) {
 							{
 								Step: &bbModels.ExecutionUnitRef{
 									ExecutionUnit: &bbModels.ExecutionUnit{
-										Name: utils.GetPtr("Deploy"),
+										Name:    utils.GetPtr("Deploy"),
+										Trigger: utils.GetPtr(bbModels.MANUAL),
 										Script: []*bbModels.Script{
 											{
 												String:        utils.GetPtr("deploy.sh"),
@@ -167,12 +240,30 @@ func TestParse(t *testing.T) {
 				},
 			},
 			expectedPipeline: &models.Pipeline{
+				Triggers: &models.Triggers{
+					Triggers: []*models.Trigger{
+						{
+							Event:         models.PullRequestEvent,
+							Branches:      &models.Filter{AllowList: []string{"**"}},
+							Jobs:          []string{"job-pull-requests-**"},
+							FileReference: testutils.CreateFileReference(7, 13, 12, 25),
+						},
+						{
+							Event:         models.PullRequestEvent,
+							Branches:      &models.Filter{AllowList: []string{"master"}},
+							Jobs:          []string{"job-pull-requests-master"},
+							FileReference: testutils.CreateFileReference(12, 17, 12, 25),
+						},
+					},
+					FileReference: testutils.CreateFileReference(7, 13, 12, 25),
+				},
 				Defaults: &models.Defaults{},
 				Jobs: []*models.Job{
 					{
 						FileReference: testutils.CreateFileReference(7, 13, 12, 25),
-						ID:            utils.GetPtr("job-**"),
+						ID:            utils.GetPtr("job-pull-requests-**"),
 						Name:          utils.GetPtr("**"),
+						TimeoutMS:     utils.GetPtr(7200000),
 						Steps: []*models.Step{
 							{
 								Type: "shell",
@@ -192,14 +283,28 @@ func TestParse(t *testing.T) {
 									Script:        utils.GetPtr("deploy.sh"),
 									FileReference: testutils.CreateFileReference(11, 17, 11, 28),
 								},
+								Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
+								FileReference: testutils.CreateFileReference(7, 13, 12, 25),
+							},
+						},
+						StepGroups: []*models.StepGroup{
+							{
+								Steps:         []int{0},
+								FileReference: testutils.CreateFileReference(7, 13, 12, 25),
+							},
+							{
+								Steps:         []int{1},
+								Manual:        true,
 								FileReference: testutils.CreateFileReference(7, 13, 12, 25),
 							},
 						},
+						Consumes: []*models.Artifact{{Type: models.FileArtifactType}},
 					},
 					{
 						FileReference: testutils.CreateFileReference(12, 17, 12, 25),
-						ID:            utils.GetPtr("job-master"),
+						ID:            utils.GetPtr("job-pull-requests-master"),
 						Name:          utils.GetPtr("master"),
+						TimeoutMS:     utils.GetPtr(7200000),
 						Steps: []*models.Step{
 							{
 								Type: "shell",
@@ -212,6 +317,12 @@ func TestParse(t *testing.T) {
 								FileReference: testutils.CreateFileReference(12, 17, 12, 25),
 							},
 						},
+						StepGroups: []*models.StepGroup{
+							{
+								Steps:         []int{0},
+								FileReference: testutils.CreateFileReference(12, 17, 12, 25),
+							},
+						},
 					},
 				},
 			},
@@ -291,11 +402,22 @@ func TestParse(t *testing.T) {
 				},
 			},
 			expectedPipeline: &models.Pipeline{
+				Triggers: &models.Triggers{
+					Triggers: []*models.Trigger{
+						{
+							Event:         models.ManualEvent,
+							Jobs:          []string{"job-custom-install"},
+							FileReference: testutils.CreateFileReference(8, 13, 12, 25),
+						},
+					},
+					FileReference: testutils.CreateFileReference(8, 13, 12, 25),
+				},
 				Defaults: &models.Defaults{
 					Runner: &models.Runner{
 						DockerMetadata: &models.DockerMetadata{
 							Image: utils.GetPtr("node:8"),
 						},
+						ResourceClass: utils.GetPtr("2x"),
 					},
 					Settings: &map[string]any{
 						"docker":   utils.GetPtr(true),
@@ -306,8 +428,9 @@ func TestParse(t *testing.T) {
 				Jobs: []*models.Job{
 					{
 						FileReference: testutils.CreateFileReference(8, 13, 12, 25),
-						ID:            utils.GetPtr("job-install"),
+						ID:            utils.GetPtr("job-custom-install"),
 						Name:          utils.GetPtr("install"),
+						TimeoutMS:     utils.GetPtr(3600000),
 						Steps: []*models.Step{
 							{
 								Name: utils.GetPtr("Build and Test"),
@@ -315,6 +438,8 @@ func TestParse(t *testing.T) {
 								Task: &models.Task{
 									Name:        utils.GetPtr("npm install"),
 									VersionType: "none",
+									Type:        models.CITaskType,
+									Inputs:      []*models.Parameter{{Name: utils.GetPtr("NPM_TOKEN"), Value: "secret"}},
 								},
 								EnvironmentVariables: &models.EnvironmentVariablesRef{
 									EnvironmentVariables: models.EnvironmentVariables{
@@ -325,6 +450,12 @@ func TestParse(t *testing.T) {
 								FileReference: testutils.CreateAliasFileReference(8, 13, 12, 25, true),
 							},
 						},
+						StepGroups: []*models.StepGroup{
+							{
+								Steps:         []int{0},
+								FileReference: testutils.CreateFileReference(8, 13, 12, 25),
+							},
+						},
 					},
 				},
 			},
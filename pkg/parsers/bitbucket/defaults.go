@@ -12,6 +12,7 @@ func parsePipelineDefaults(pipeline *bitbucketModels.Pipeline) *models.Defaults
 
 	var defaults models.Defaults
 	defaults.Runner = parseRunner(pipeline)
+	defaults.Checkout = parseCheckoutSettings(pipeline.Clone)
 
 	if pipeline.Options != nil {
 		defaults.Settings = &map[string]any{
@@ -29,14 +30,18 @@ func parseRunner(pipeline *bitbucketModels.Pipeline) *models.Runner {
 		return nil
 	}
 
+	var runner models.Runner
 	if pipeline.Image != nil && pipeline.Image.ImageData != nil {
-		runner := &models.Runner{
-			DockerMetadata: &models.DockerMetadata{
-				Image: pipeline.Image.ImageData.Name,
-			},
+		runner.DockerMetadata = &models.DockerMetadata{
+			Image: pipeline.Image.ImageData.Name,
 		}
+	}
+	if pipeline.Options != nil {
+		runner.ResourceClass = parseResourceClass(pipeline.Options.Size)
+	}
 
-		return runner
+	if runner == (models.Runner{}) {
+		return nil
 	}
-	return nil
+	return &runner
 }
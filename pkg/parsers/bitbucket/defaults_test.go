@@ -52,6 +52,10 @@ func TestDefaultsParse(t *testing.T) {
 						Name: utils.GetPtr("node:10.15.3"),
 					},
 				},
+				Clone: &bbModels.Clone{
+					Depth: "full",
+					LFS:   utils.GetPtr(true),
+				},
 				Options: &bbModels.GlobalSettings{
 					Docker:  utils.GetPtr(true),
 					MaxTime: utils.GetPtr(int64(60)),
@@ -63,6 +67,10 @@ func TestDefaultsParse(t *testing.T) {
 					DockerMetadata: &models.DockerMetadata{
 						Image: utils.GetPtr("node:10.15.3"),
 					},
+					ResourceClass: utils.GetPtr("1x"),
+				},
+				Checkout: &models.CheckoutSettings{
+					LFS: utils.GetPtr(true),
 				},
 				Settings: &map[string]any{
 					"docker":   utils.GetPtr(true),
@@ -112,6 +120,17 @@ func TestRunnerParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "size is defined",
+			bitbucketPipeline: &bbModels.Pipeline{
+				Options: &bbModels.GlobalSettings{
+					Size: utils.GetPtr(bbModels.X2),
+				},
+			},
+			expectedRunner: &models.Runner{
+				ResourceClass: utils.GetPtr("2x"),
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -5,9 +5,20 @@ import (
 
 	bitbucketModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/bitbucket/models"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	parserUtils "github.com/argonsecurity/pipeline-parser/pkg/parsers/utils"
 	"github.com/argonsecurity/pipeline-parser/pkg/utils"
 )
 
+// The sections of the pipelines, which are part of the IDs of their jobs
+const (
+	defaultSection      = "default"
+	pullRequestsSection = "pull-requests"
+	branchesSection     = "branches"
+	tagsSection         = "tags"
+	bookmarksSection    = "bookmarks"
+	customSection       = "custom"
+)
+
 func parseJobs(pipeline *bitbucketModels.Pipeline) []*models.Job {
 	if pipeline == nil {
 		return nil
@@ -17,96 +28,203 @@ func parseJobs(pipeline *bitbucketModels.Pipeline) []*models.Job {
 
 	if pipeline.Pipelines != nil {
 		if pipeline.Pipelines.Default != nil {
-			defaultJob := parseJob("default", pipeline.Pipelines.Default)
+			defaultJob := parseJob(defaultSection, "default", pipeline.Pipelines.Default, pipeline.Definitions)
 			jobs = append(jobs, defaultJob)
 		}
 
 		if pipeline.Pipelines.PullRequests != nil {
-			jobs = append(jobs, parseStepMapToJob(pipeline.Pipelines.PullRequests)...)
+			jobs = append(jobs, parseStepMapToJob(pullRequestsSection, pipeline.Pipelines.PullRequests, pipeline.Definitions)...)
 		}
 
 		if pipeline.Pipelines.Branches != nil {
-			jobs = append(jobs, parseStepMapToJob(pipeline.Pipelines.Branches)...)
+			jobs = append(jobs, parseStepMapToJob(branchesSection, pipeline.Pipelines.Branches, pipeline.Definitions)...)
 		}
 
 		if pipeline.Pipelines.Tags != nil {
-			jobs = append(jobs, parseStepMapToJob(pipeline.Pipelines.Tags)...)
+			jobs = append(jobs, parseStepMapToJob(tagsSection, pipeline.Pipelines.Tags, pipeline.Definitions)...)
 		}
 
 		if pipeline.Pipelines.Bookmarks != nil {
-			jobs = append(jobs, parseStepMapToJob(pipeline.Pipelines.Bookmarks)...)
+			jobs = append(jobs, parseStepMapToJob(bookmarksSection, pipeline.Pipelines.Bookmarks, pipeline.Definitions)...)
 		}
 
 		if pipeline.Pipelines.Custom != nil {
-			jobs = append(jobs, parseStepMapToJob(pipeline.Pipelines.Custom)...)
+			jobs = append(jobs, parseStepMapToJob(customSection, pipeline.Pipelines.Custom, pipeline.Definitions)...)
 		}
 	}
 
+	for _, job := range jobs {
+		job.TimeoutMS = parseJobTimeout(pipeline.Options)
+	}
+
 	return jobs
 }
 
-func parseStepMapToJob(jobMap *bitbucketModels.StepMap) []*models.Job {
+func parseStepMapToJob(section string, jobMap *bitbucketModels.StepMap, definitions *bitbucketModels.Definitions) []*models.Job {
 	var jobs []*models.Job
-	for jobName, steps := range *jobMap {
-		job := parseJob(jobName, steps)
+	for _, jobName := range getSortedPipelineNames(jobMap) {
+		job := parseJob(section, jobName, (*jobMap)[jobName], definitions)
 		jobs = append(jobs, job)
 	}
 	return jobs
 }
 
-func parseJob(jobName string, steps []*bitbucketModels.Step) *models.Job {
-	job := createJob(jobName)
-	job.Steps = parseStepArray(steps, job)
+func parseJob(section, jobName string, steps []*bitbucketModels.Step, definitions *bitbucketModels.Definitions) *models.Job {
+	job := createJob(section, jobName)
+	job.Steps, job.StepGroups = parseStepArray(steps, definitions)
+	job.Services = parseJobServices(steps, definitions)
+	job.Environment = parseJobEnvironment(steps)
+	job.Produces, job.Consumes = parserUtils.CollectStepsArtifacts(job.Steps)
 	job.FileReference = generateJobFileReference(job)
 	return job
 }
 
 func generateJobFileReference(job *models.Job) *models.FileReference {
-	if job.Steps != nil || len(job.Steps) > 0 {
-		return &models.FileReference{
-			StartRef: job.Steps[0].FileReference.StartRef,
-			EndRef:   job.Steps[len(job.Steps)-1].FileReference.EndRef,
+	if len(job.Steps) == 0 {
+		return nil
+	}
+
+	// the task steps of pipes end inside the step they run in, so the job ends at the step that ends last
+	endRef := job.Steps[0].FileReference.EndRef
+	for _, step := range job.Steps[1:] {
+		if stepEndRef := step.FileReference.EndRef; isAfter(stepEndRef, endRef) {
+			endRef = stepEndRef
 		}
 	}
-	return nil
+	return &models.FileReference{
+		StartRef: job.Steps[0].FileReference.StartRef,
+		EndRef:   endRef,
+	}
 }
 
-func parseStepArray(jobSteps []*bitbucketModels.Step, job *models.Job) []*models.Step {
+// parseStepArray parses the steps of a pipeline, with a step group for each of its steps and parallel steps, in the order they run
+func parseStepArray(jobSteps []*bitbucketModels.Step, definitions *bitbucketModels.Definitions) ([]*models.Step, []*models.StepGroup) {
 	var steps []*models.Step
-	for _, step := range jobSteps {
-		steps = append(steps, parseStep(step)...)
+	var stepGroups []*models.StepGroup
+	for i, step := range jobSteps {
+		parsedSteps := parseStep(step, definitions)
+		if i == 0 {
+			// the first steps of a job have no previous steps to download artifacts from
+			for _, parsedStep := range parsedSteps {
+				if parsedStep != nil {
+					parsedStep.Consumes = removeArtifactDownloads(parsedStep.Consumes)
+				}
+			}
+		}
+		if stepGroup := parseStepGroup(step, parsedSteps, len(steps)); stepGroup != nil {
+			stepGroups = append(stepGroups, stepGroup)
+		}
+		steps = append(steps, parsedSteps...)
 	}
-	return steps
+	return steps, stepGroups
+}
+
+// parseStepGroup creates the group of the steps parsed from a step, whose indexes in the job steps start at offset
+func parseStepGroup(step *bitbucketModels.Step, parsedSteps []*models.Step, offset int) *models.StepGroup {
+	if len(parsedSteps) == 0 {
+		return nil
+	}
+
+	stepGroup := &models.StepGroup{
+		Parallel: step.Parallel != nil,
+	}
+	for i, parsedStep := range parsedSteps {
+		stepGroup.Steps = append(stepGroup.Steps, offset+i)
+		if parsedStep == nil || parsedStep.FileReference == nil {
+			continue
+		}
+		if stepGroup.FileReference == nil {
+			stepGroup.FileReference = &models.FileReference{StartRef: parsedStep.FileReference.StartRef}
+		}
+		if endRef := parsedStep.FileReference.EndRef; stepGroup.FileReference.EndRef == nil || isAfter(endRef, stepGroup.FileReference.EndRef) {
+			stepGroup.FileReference.EndRef = endRef
+		}
+	}
+
+	if stepGroup.Parallel {
+		stepGroup.FailFast = step.FailFast
+	}
+
+	// a manual step, or the first step of manual parallel steps, waits for someone to run it
+	for _, executionUnitRef := range getExecutionUnits(step) {
+		if executionUnitRef.ExecutionUnit.Trigger != nil && *executionUnitRef.ExecutionUnit.Trigger == bitbucketModels.MANUAL {
+			stepGroup.Manual = true
+		}
+	}
+	return stepGroup
+}
+
+func getExecutionUnits(step *bitbucketModels.Step) []*bitbucketModels.ExecutionUnitRef {
+	var executionUnits []*bitbucketModels.ExecutionUnitRef
+	if step.Step != nil && step.Step.ExecutionUnit != nil {
+		executionUnits = append(executionUnits, step.Step)
+	}
+	for _, parallelStep := range step.Parallel {
+		if parallelStep != nil && parallelStep.Step != nil && parallelStep.Step.ExecutionUnit != nil {
+			executionUnits = append(executionUnits, parallelStep.Step)
+		}
+	}
+	return executionUnits
+}
+
+func isAfter(ref, other *models.FileLocation) bool {
+	return ref.Line > other.Line || (ref.Line == other.Line && ref.Column > other.Column)
 }
 
-func createJob(jobName string) *models.Job {
+func createJob(section, jobName string) *models.Job {
 	var job models.Job
-	id := fmt.Sprintf("job-%s", jobName)
-	job.ID = &id
+	job.ID = utils.GetPtr(getJobID(section, jobName))
 	job.Name = &jobName
 	return &job
 }
 
-func parseStep(step *bitbucketModels.Step) []*models.Step {
+// getJobID returns the ID of the job of a pipeline, which includes its section as the same name can be used in several sections
+func getJobID(section, jobName string) string {
+	if section == defaultSection {
+		return fmt.Sprintf("job-%s", section)
+	}
+	return fmt.Sprintf("job-%s-%s", section, jobName)
+}
+
+func parseStep(step *bitbucketModels.Step, definitions *bitbucketModels.Definitions) []*models.Step {
 	if step == nil {
 		return nil
 	}
 
 	var steps []*models.Step
 	if step.Step != nil {
-		steps = append(steps, parseExecutionUnitToStep(step.Step))
+		steps = append(steps, parseExecutionUnitToSteps(step.Step, definitions)...)
 	}
 
 	if step.Parallel != nil {
 		for _, parallelStep := range step.Parallel {
-			steps = append(steps, parseExecutionUnitToStep(parallelStep.Step))
+			steps = append(steps, parseExecutionUnitToSteps(parallelStep.Step, definitions)...)
 		}
 	}
 
 	return steps
 }
 
-func parseExecutionUnitToStep(executionUnitRef *bitbucketModels.ExecutionUnitRef) *models.Step {
+// parseExecutionUnitToSteps parses a step to a task step for each of its pipes, in addition to the step itself.
+// A step that runs only pipes is the task step of its first pipe.
+func parseExecutionUnitToSteps(executionUnitRef *bitbucketModels.ExecutionUnitRef, definitions *bitbucketModels.Definitions) []*models.Step {
+	step := parseExecutionUnitToStep(executionUnitRef, definitions)
+	if step == nil {
+		return []*models.Step{nil}
+	}
+
+	steps := []*models.Step{step}
+	pipes := getPipes(executionUnitRef.ExecutionUnit.Script)
+	if step.Task != nil {
+		pipes = pipes[1:]
+	}
+	for _, pipe := range pipes {
+		steps = append(steps, parsePipeStep(step.Name, pipe))
+	}
+	return steps
+}
+
+func parseExecutionUnitToStep(executionUnitRef *bitbucketModels.ExecutionUnitRef, definitions *bitbucketModels.Definitions) *models.Step {
 	if executionUnitRef == nil {
 		return nil
 	}
@@ -114,22 +232,18 @@ func parseExecutionUnitToStep(executionUnitRef *bitbucketModels.ExecutionUnitRef
 	var step models.Step
 	step.Name = executionUnitRef.ExecutionUnit.Name
 	step.FileReference = executionUnitRef.FileReference
-	if executionUnitRef.ExecutionUnit.MaxTime != nil {
-		var timeout int = int(*executionUnitRef.ExecutionUnit.MaxTime)
-		step.Timeout = &timeout
-	}
+	step.Timeout = parseTimeout(executionUnitRef.ExecutionUnit.MaxTime)
+	step.Checkout = parseCheckoutSettings(executionUnitRef.ExecutionUnit.Clone)
 	step.Shell = parseScriptToShell(executionUnitRef.ExecutionUnit.Script)
-	step.Task = parseScriptToTask(executionUnitRef.ExecutionUnit.Script)
-	step.Type = getStepType(&step)
-	step.Runner = parseStepRunner(executionUnitRef.ExecutionUnit)
-	var scripts = executionUnitRef.ExecutionUnit.Script
-	if step.Task != nil { // script env vars belong to tasks
-		for _, script := range scripts {
-			if script.PipeToExecute != nil {
-				step.EnvironmentVariables = parseEnvironmentVariables(step.EnvironmentVariables, script.PipeToExecute.Variables)
-			}
+	if step.Shell == nil { // the pipes of a step with commands are parsed to their own task steps
+		step.Task = parseScriptToTask(executionUnitRef.ExecutionUnit.Script)
+		if pipes := getPipes(executionUnitRef.ExecutionUnit.Script); len(pipes) > 0 {
+			step.EnvironmentVariables = parseEnvironmentVariables(step.EnvironmentVariables, pipes[0].Variables)
 		}
 	}
+	step.Type = getStepType(&step)
+	step.Runner = parseStepRunner(executionUnitRef.ExecutionUnit)
+	step.Produces, step.Consumes = parseStepArtifacts(executionUnitRef, definitions)
 	var afterScripts = executionUnitRef.ExecutionUnit.AfterScript
 	step.AfterScript = parseScriptToShell(afterScripts)
 	if step.AfterScript != nil {
@@ -208,27 +322,13 @@ func parseScriptToShell(scripts []*bitbucketModels.Script) *models.Shell {
 	return &shell
 }
 
+// parseScriptToTask returns the task of the first pipe of a script
 func parseScriptToTask(scripts []*bitbucketModels.Script) *models.Task {
-	if scripts == nil {
+	pipes := getPipes(scripts)
+	if len(pipes) == 0 {
 		return nil
 	}
-
-	var task models.Task
-	var scriptString string
-	for _, script := range scripts {
-		if script != nil {
-			if (script.PipeToExecute) != nil {
-				scriptString += addScriptLine(scriptString, *script.PipeToExecute.Pipe.String)
-			}
-		}
-	}
-
-	if scriptString == "" {
-		return nil
-	}
-	task.Name = &scriptString
-	task.VersionType = models.None // not supported
-	return &task
+	return parsePipeToTask(pipes[0])
 }
 
 func addScriptLine(scriptString, script string) string {
@@ -243,14 +343,17 @@ func parseStepRunner(executionUnit *bitbucketModels.ExecutionUnit) *models.Runne
 		return nil
 	}
 
+	var runner models.Runner
 	if executionUnit.Image != nil && executionUnit.Image.ImageData != nil {
-		runner := &models.Runner{
-			DockerMetadata: &models.DockerMetadata{
-				Image: executionUnit.Image.ImageData.Name,
-			},
+		runner.DockerMetadata = &models.DockerMetadata{
+			Image: executionUnit.Image.ImageData.Name,
 		}
+	}
+	parseRunsOn(&runner, executionUnit.RunsOn)
+	runner.ResourceClass = parseResourceClass(executionUnit.Size)
 
-		return runner
+	if runner == (models.Runner{}) {
+		return nil
 	}
-	return nil
+	return &runner
 }
@@ -57,6 +57,7 @@ func TestJobsParse(t *testing.T) {
 					FileReference: testutils.CreateFileReference(5, 6, 7, 8),
 					ID:            utils.GetPtr("job-default"),
 					Name:          utils.GetPtr("default"),
+					TimeoutMS:     utils.GetPtr(7200000),
 					Steps: []*models.Step{
 						{
 							Type: "shell",
@@ -68,6 +69,12 @@ func TestJobsParse(t *testing.T) {
 							FileReference: testutils.CreateFileReference(5, 6, 7, 8),
 						},
 					},
+					StepGroups: []*models.StepGroup{
+						{
+							Steps:         []int{0},
+							FileReference: testutils.CreateFileReference(5, 6, 7, 8),
+						},
+					},
 				},
 			},
 		},
@@ -101,8 +108,9 @@ func TestJobsParse(t *testing.T) {
 			expectedJobs: []*models.Job{
 				{
 					FileReference: testutils.CreateFileReference(5, 6, 7, 8),
-					ID:            utils.GetPtr("job-*"),
+					ID:            utils.GetPtr("job-pull-requests-*"),
 					Name:          utils.GetPtr("*"),
+					TimeoutMS:     utils.GetPtr(7200000),
 					Steps: []*models.Step{
 						{
 							Type: "shell",
@@ -114,6 +122,12 @@ func TestJobsParse(t *testing.T) {
 							FileReference: testutils.CreateFileReference(5, 6, 7, 8),
 						},
 					},
+					StepGroups: []*models.StepGroup{
+						{
+							Steps:         []int{0},
+							FileReference: testutils.CreateFileReference(5, 6, 7, 8),
+						},
+					},
 				},
 			},
 		},
@@ -154,14 +168,17 @@ func TestJobsParse(t *testing.T) {
 			expectedJobs: []*models.Job{
 				{
 					FileReference: testutils.CreateFileReference(5, 6, 7, 8),
-					ID:            utils.GetPtr("job-master"),
+					ID:            utils.GetPtr("job-branches-master"),
 					Name:          utils.GetPtr("master"),
+					TimeoutMS:     utils.GetPtr(7200000),
 					Steps: []*models.Step{
 						{
 							Type: "task",
 							Task: &models.Task{
 								Name:        utils.GetPtr("echo 'hello world'"),
 								VersionType: "none",
+								Type:        models.CITaskType,
+								Inputs:      []*models.Parameter{{Name: utils.GetPtr("key"), Value: "value"}},
 							},
 							EnvironmentVariables: &models.EnvironmentVariablesRef{
 								EnvironmentVariables: map[string]any{
@@ -172,6 +189,12 @@ func TestJobsParse(t *testing.T) {
 							FileReference: testutils.CreateFileReference(5, 6, 7, 8),
 						},
 					},
+					StepGroups: []*models.StepGroup{
+						{
+							Steps:         []int{0},
+							FileReference: testutils.CreateFileReference(5, 6, 7, 8),
+						},
+					},
 				},
 			},
 		},
@@ -194,13 +217,20 @@ func TestJobsParse(t *testing.T) {
 			expectedJobs: []*models.Job{
 				{
 					FileReference: testutils.CreateFileReference(5, 6, 7, 8),
-					ID:            utils.GetPtr("job-test:1.2.3"),
+					ID:            utils.GetPtr("job-tags-test:1.2.3"),
 					Name:          utils.GetPtr("test:1.2.3"),
+					TimeoutMS:     utils.GetPtr(7200000),
 					Steps: []*models.Step{
 						{
 							FileReference: testutils.CreateFileReference(5, 6, 7, 8),
 						},
 					},
+					StepGroups: []*models.StepGroup{
+						{
+							Steps:         []int{0},
+							FileReference: testutils.CreateFileReference(5, 6, 7, 8),
+						},
+					},
 				},
 			},
 		},
@@ -223,13 +253,20 @@ func TestJobsParse(t *testing.T) {
 			expectedJobs: []*models.Job{
 				{
 					FileReference: testutils.CreateFileReference(5, 6, 7, 8),
-					ID:            utils.GetPtr("job-on-push"),
+					ID:            utils.GetPtr("job-custom-on-push"),
 					Name:          utils.GetPtr("on-push"),
+					TimeoutMS:     utils.GetPtr(7200000),
 					Steps: []*models.Step{
 						{
 							FileReference: testutils.CreateFileReference(5, 6, 7, 8),
 						},
 					},
+					StepGroups: []*models.StepGroup{
+						{
+							Steps:         []int{0},
+							FileReference: testutils.CreateFileReference(5, 6, 7, 8),
+						},
+					},
 				},
 			},
 		},
@@ -277,6 +314,7 @@ func TestStepParse(t *testing.T) {
 						Script:        utils.GetPtr("echo 'hello world'"),
 						FileReference: testutils.CreateFileReference(1, 2, 3, 4),
 					},
+					Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 					FileReference: testutils.CreateFileReference(5, 6, 7, 8),
 				},
 			},
@@ -321,6 +359,7 @@ func TestStepParse(t *testing.T) {
 						Script:        utils.GetPtr("echo 'hello world'"),
 						FileReference: testutils.CreateFileReference(1, 2, 3, 4),
 					},
+					Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 					FileReference: testutils.CreateFileReference(5, 6, 7, 8),
 				},
 				{
@@ -330,6 +369,7 @@ func TestStepParse(t *testing.T) {
 						Script:        utils.GetPtr("echo 'goodbye world'"),
 						FileReference: testutils.CreateFileReference(4, 3, 2, 1),
 					},
+					Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 					FileReference: testutils.CreateFileReference(8, 7, 6, 5),
 				},
 			},
@@ -338,12 +378,85 @@ func TestStepParse(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			step := parseStep(testCase.bitbucketStep)
+			step := parseStep(testCase.bitbucketStep, nil)
 			testutils.DeepCompare(t, testCase.expectedStep, step)
 		})
 	}
 }
 
+func TestStepGroupParse(t *testing.T) {
+	testCases := []struct {
+		name              string
+		bitbucketStep     *bitbucketModels.Step
+		parsedSteps       []*models.Step
+		offset            int
+		expectedStepGroup *models.StepGroup
+	}{
+		{
+			name:              "No parsed steps",
+			bitbucketStep:     &bitbucketModels.Step{},
+			expectedStepGroup: nil,
+		},
+		{
+			name: "Manual step with pipe steps",
+			bitbucketStep: &bitbucketModels.Step{
+				Step: &bitbucketModels.ExecutionUnitRef{
+					ExecutionUnit: &bitbucketModels.ExecutionUnit{
+						Trigger: utils.GetPtr(bitbucketModels.MANUAL),
+					},
+				},
+			},
+			parsedSteps: []*models.Step{
+				{FileReference: testutils.CreateFileReference(5, 7, 12, 20)},
+				{FileReference: testutils.CreateFileReference(10, 19, 12, 20)},
+			},
+			offset: 2,
+			expectedStepGroup: &models.StepGroup{
+				Steps:         []int{2, 3},
+				Manual:        true,
+				FileReference: testutils.CreateFileReference(5, 7, 12, 20),
+			},
+		},
+		{
+			name: "Parallel steps with fail-fast",
+			bitbucketStep: &bitbucketModels.Step{
+				FailFast: utils.GetPtr(true),
+				Parallel: []*bitbucketModels.ParallelSteps{
+					{
+						Step: &bitbucketModels.ExecutionUnitRef{
+							ExecutionUnit: &bitbucketModels.ExecutionUnit{
+								Trigger: utils.GetPtr(bitbucketModels.AUTOMATIC),
+							},
+						},
+					},
+					{
+						Step: &bitbucketModels.ExecutionUnitRef{
+							ExecutionUnit: &bitbucketModels.ExecutionUnit{},
+						},
+					},
+				},
+			},
+			parsedSteps: []*models.Step{
+				{FileReference: testutils.CreateFileReference(6, 11, 9, 20)},
+				{FileReference: testutils.CreateFileReference(10, 11, 13, 30)},
+			},
+			expectedStepGroup: &models.StepGroup{
+				Steps:         []int{0, 1},
+				Parallel:      true,
+				FailFast:      utils.GetPtr(true),
+				FileReference: testutils.CreateFileReference(6, 11, 13, 30),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			stepGroup := parseStepGroup(testCase.bitbucketStep, testCase.parsedSteps, testCase.offset)
+			testutils.DeepCompare(t, testCase.expectedStepGroup, stepGroup)
+		})
+	}
+}
+
 func TestStepRunnerParse(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -375,6 +488,18 @@ func TestStepRunnerParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "self-hosted runner with size",
+			bitbucketExeUnit: &bitbucketModels.ExecutionUnit{
+				RunsOn: []*string{utils.GetPtr("self.hosted"), utils.GetPtr("linux")},
+				Size:   utils.GetPtr(bitbucketModels.X2),
+			},
+			expectedRunner: &models.Runner{
+				Labels:        &[]string{"self.hosted", "linux"},
+				SelfHosted:    utils.GetPtr(true),
+				ResourceClass: utils.GetPtr("2x"),
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -445,6 +570,7 @@ func TestScriptParse(t *testing.T) {
 			expectedTask: &models.Task{
 				Name:        utils.GetPtr("echo 'hello world'"),
 				VersionType: "none",
+				Type:        models.CITaskType,
 			},
 		},
 	}
@@ -493,6 +619,7 @@ func TestExecutionUnitParse(t *testing.T) {
 					Script:        utils.GetPtr("echo 'hello world'"),
 					FileReference: testutils.CreateFileReference(1, 2, 3, 4),
 				},
+				Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 				FileReference: testutils.CreateFileReference(5, 6, 7, 8),
 			},
 		},
@@ -516,6 +643,7 @@ func TestExecutionUnitParse(t *testing.T) {
 						Image: utils.GetPtr("test"),
 					},
 				},
+				Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 				FileReference: testutils.CreateFileReference(5, 6, 7, 8),
 			},
 		},
@@ -551,6 +679,11 @@ func TestExecutionUnitParse(t *testing.T) {
 				Task: &models.Task{
 					Name:        utils.GetPtr("echo 'hello world'"),
 					VersionType: "none",
+					Type:        models.CITaskType,
+					Inputs: []*models.Parameter{
+						{Name: utils.GetPtr("key"), Value: "value"},
+						{Name: utils.GetPtr("key2"), Value: "value2"},
+					},
 				},
 				EnvironmentVariables: &models.EnvironmentVariablesRef{
 					EnvironmentVariables: models.EnvironmentVariables{
@@ -559,6 +692,7 @@ func TestExecutionUnitParse(t *testing.T) {
 					},
 					FileReference: testutils.CreateFileReference(5, 6, 7, 8),
 				},
+				Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 				FileReference: testutils.CreateFileReference(9, 10, 11, 12),
 			},
 		},
@@ -566,7 +700,7 @@ func TestExecutionUnitParse(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			step := parseExecutionUnitToStep(testCase.bitbucketExeUnit)
+			step := parseExecutionUnitToStep(testCase.bitbucketExeUnit, nil)
 			testutils.DeepCompare(t, testCase.expectedStep, step)
 		})
 	}
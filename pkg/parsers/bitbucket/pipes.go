@@ -0,0 +1,95 @@
+package bitbucket
+
+import (
+	"sort"
+	"strings"
+
+	bitbucketModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/bitbucket/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	parserUtils "github.com/argonsecurity/pipeline-parser/pkg/parsers/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+const (
+	dockerPipePrefix = "docker://"
+	digestSeparator  = "@"
+	tagSeparator     = ":"
+)
+
+// getPipes returns the pipes of a script, in the order they run
+func getPipes(scripts []*bitbucketModels.Script) []*bitbucketModels.PipeToExecute {
+	var pipes []*bitbucketModels.PipeToExecute
+	for _, script := range scripts {
+		if script != nil && script.PipeToExecute != nil && script.PipeToExecute.Pipe != nil && script.PipeToExecute.Pipe.String != nil {
+			pipes = append(pipes, script.PipeToExecute)
+		}
+	}
+	return pipes
+}
+
+// parsePipeToTask parses a pipe, such as `atlassian/aws-s3-deploy:1.1.0` or `docker://acme/pipe:2.0.0`, to a task with the pipe variables as its inputs
+func parsePipeToTask(pipe *bitbucketModels.PipeToExecute) *models.Task {
+	name, version, versionType, taskType := parsePipeHeader(*pipe.Pipe.String)
+	task := &models.Task{
+		Name:        &name,
+		Version:     utils.GetPtrOrNil(version),
+		VersionType: versionType,
+		Type:        taskType,
+	}
+
+	if pipe.Variables != nil && len(pipe.Variables.EnvironmentVariables) > 0 {
+		names := utils.GetMapKeys(pipe.Variables.EnvironmentVariables)
+		sort.Strings(names)
+		for _, variableName := range names {
+			task.Inputs = append(task.Inputs, &models.Parameter{
+				Name:  utils.GetPtr(variableName),
+				Value: pipe.Variables.EnvironmentVariables[variableName],
+			})
+		}
+	}
+	return task
+}
+
+// parsePipeHeader splits a pipe to its image name and version.
+// Pipes are docker images, either from Docker Hub, e.g. `atlassian/aws-s3-deploy:1.1.0`, or from any registry with the docker:// prefix.
+func parsePipeHeader(header string) (string, string, models.VersionType, models.TaskType) {
+	taskType := models.CITaskType
+	if strings.HasPrefix(header, dockerPipePrefix) {
+		header = strings.TrimPrefix(header, dockerPipePrefix)
+		taskType = models.DockerTaskType
+	}
+
+	// an image digest is immutable, like a commit SHA
+	if index := strings.Index(header, digestSeparator); index != -1 {
+		return header[:index], header[index+1:], models.CommitSHA, taskType
+	}
+
+	// the tag separator is after the last slash, the colon of a registry port is not a tag separator
+	if index := strings.LastIndex(header, tagSeparator); index != -1 && index > strings.LastIndex(header, "/") {
+		version := header[index+1:]
+		return header[:index], version, parserUtils.DetectVersionType(version), taskType
+	}
+
+	return header, "", models.None, taskType
+}
+
+// parsePipeStep creates a task step for a pipe that runs in the same step as other pipes or commands
+func parsePipeStep(name *string, pipe *bitbucketModels.PipeToExecute) *models.Step {
+	step := &models.Step{
+		Name:          name,
+		Type:          models.TaskStepType,
+		Task:          parsePipeToTask(pipe),
+		FileReference: pipe.Pipe.FileReference,
+	}
+
+	if pipe.Variables != nil {
+		step.EnvironmentVariables = parseEnvironmentVariables(nil, pipe.Variables)
+		if step.FileReference != nil && pipe.Variables.FileReference != nil {
+			step.FileReference = &models.FileReference{
+				StartRef: step.FileReference.StartRef,
+				EndRef:   pipe.Variables.FileReference.EndRef,
+			}
+		}
+	}
+	return step
+}
@@ -0,0 +1,147 @@
+package bitbucket
+
+import (
+	"testing"
+
+	bitbucketModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/bitbucket/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePipeHeader(t *testing.T) {
+	testCases := []struct {
+		header              string
+		expectedName        string
+		expectedVersion     string
+		expectedVersionType models.VersionType
+		expectedTaskType    models.TaskType
+	}{
+		{
+			header:              "atlassian/aws-s3-deploy:1.1.0",
+			expectedName:        "atlassian/aws-s3-deploy",
+			expectedVersion:     "1.1.0",
+			expectedVersionType: models.TagVersion,
+			expectedTaskType:    models.CITaskType,
+		},
+		{
+			header:              "atlassian/aws-s3-deploy",
+			expectedName:        "atlassian/aws-s3-deploy",
+			expectedVersionType: models.None,
+			expectedTaskType:    models.CITaskType,
+		},
+		{
+			header:              "docker://acme/pipe:latest",
+			expectedName:        "acme/pipe",
+			expectedVersion:     "latest",
+			expectedVersionType: models.Latest,
+			expectedTaskType:    models.DockerTaskType,
+		},
+		{
+			header:              "docker://registry.acme.com:5000/pipes/deploy",
+			expectedName:        "registry.acme.com:5000/pipes/deploy",
+			expectedVersionType: models.None,
+			expectedTaskType:    models.DockerTaskType,
+		},
+		{
+			header:              "docker://acme/pipe@sha256:2f1e5c1c7cbe2a1c2b5d8f3e0f2d0a4c",
+			expectedName:        "acme/pipe",
+			expectedVersion:     "sha256:2f1e5c1c7cbe2a1c2b5d8f3e0f2d0a4c",
+			expectedVersionType: models.CommitSHA,
+			expectedTaskType:    models.DockerTaskType,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.header, func(t *testing.T) {
+			name, version, versionType, taskType := parsePipeHeader(testCase.header)
+
+			assert.Equal(t, testCase.expectedName, name)
+			assert.Equal(t, testCase.expectedVersion, version)
+			assert.Equal(t, testCase.expectedVersionType, versionType)
+			assert.Equal(t, testCase.expectedTaskType, taskType)
+		})
+	}
+}
+
+func TestExecutionUnitToStepsParse(t *testing.T) {
+	testCases := []struct {
+		name             string
+		bitbucketExeUnit *bitbucketModels.ExecutionUnitRef
+		expectedSteps    []*models.Step
+	}{
+		{
+			name:             "Step is nil",
+			bitbucketExeUnit: nil,
+			expectedSteps:    []*models.Step{nil},
+		},
+		{
+			name: "Step with commands and pipes",
+			bitbucketExeUnit: &bitbucketModels.ExecutionUnitRef{
+				ExecutionUnit: &bitbucketModels.ExecutionUnit{
+					Name: utils.GetPtr("deploy"),
+					Script: []*bitbucketModels.Script{
+						{
+							String:        utils.GetPtr("make build"),
+							FileReference: testutils.CreateFileReference(4, 13, 4, 23),
+						},
+						{
+							PipeToExecute: &bitbucketModels.PipeToExecute{
+								Pipe: &bitbucketModels.Pipe{
+									String:        utils.GetPtr("atlassian/aws-s3-deploy:1.1.0"),
+									FileReference: testutils.CreateFileReference(5, 19, 5, 48),
+								},
+								Variables: &bitbucketModels.EnvironmentVariablesRef{
+									EnvironmentVariables: models.EnvironmentVariables{
+										"S3_BUCKET": "bucket",
+									},
+									FileReference: testutils.CreateFileReference(6, 15, 7, 34),
+								},
+							},
+						},
+					},
+				},
+				FileReference: testutils.CreateFileReference(1, 7, 7, 34),
+			},
+			expectedSteps: []*models.Step{
+				{
+					Name: utils.GetPtr("deploy"),
+					Type: models.ShellStepType,
+					Shell: &models.Shell{
+						Type:          utils.GetPtr("shell"),
+						Script:        utils.GetPtr("make build"),
+						FileReference: testutils.CreateFileReference(4, 13, 4, 23),
+					},
+					Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
+					FileReference: testutils.CreateFileReference(1, 7, 7, 34),
+				},
+				{
+					Name: utils.GetPtr("deploy"),
+					Type: models.TaskStepType,
+					Task: &models.Task{
+						Name:        utils.GetPtr("atlassian/aws-s3-deploy"),
+						Version:     utils.GetPtr("1.1.0"),
+						VersionType: models.TagVersion,
+						Type:        models.CITaskType,
+						Inputs:      []*models.Parameter{{Name: utils.GetPtr("S3_BUCKET"), Value: "bucket"}},
+					},
+					EnvironmentVariables: &models.EnvironmentVariablesRef{
+						EnvironmentVariables: models.EnvironmentVariables{
+							"S3_BUCKET": "bucket",
+						},
+						FileReference: testutils.CreateFileReference(6, 15, 7, 34),
+					},
+					FileReference: testutils.CreateFileReference(5, 19, 7, 34),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			steps := parseExecutionUnitToSteps(testCase.bitbucketExeUnit, nil)
+			testutils.DeepCompare(t, testCase.expectedSteps, steps)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package bitbucket
+
+import (
+	"regexp"
+
+	bitbucketModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/bitbucket/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	parsersUtils "github.com/argonsecurity/pipeline-parser/pkg/parsers/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+var bitbucketVariableRegex = regexp.MustCompile(`^\$\{?(\w+)\}?$`)
+
+// parseJobServices returns the services enabled by the steps of a job, in the order they are first enabled.
+// Services without a definition, like the built-in docker service, only have a name.
+func parseJobServices(steps []*bitbucketModels.Step, definitions *bitbucketModels.Definitions) []*models.Service {
+	var names []string
+	for _, step := range steps {
+		if step == nil {
+			continue
+		}
+
+		executionUnits := []*bitbucketModels.ExecutionUnitRef{step.Step}
+		for _, parallelStep := range step.Parallel {
+			executionUnits = append(executionUnits, parallelStep.Step)
+		}
+
+		for _, executionUnit := range executionUnits {
+			if executionUnit == nil || executionUnit.ExecutionUnit == nil {
+				continue
+			}
+			for _, name := range executionUnit.ExecutionUnit.Services {
+				if name != nil && !utils.SliceContains(names, *name) {
+					names = append(names, *name)
+				}
+			}
+		}
+	}
+
+	var services []*models.Service
+	for _, name := range names {
+		var definition *bitbucketModels.Service
+		if definitions != nil {
+			definition = definitions.Services[name]
+		}
+		services = append(services, parseService(name, definition))
+	}
+	return services
+}
+
+func parseService(name string, service *bitbucketModels.Service) *models.Service {
+	parsedService := &models.Service{
+		Name: utils.GetPtr(name),
+	}
+	if service == nil {
+		return parsedService
+	}
+
+	parsedService.EnvironmentVariables = parseEnvironmentVariables(nil, service.Variables)
+	parsedService.FileReference = service.FileReference
+	if service.Image != nil && service.Image.ImageData != nil && service.Image.ImageData.Name != nil {
+		parsedService.DockerMetadata = parsersUtils.ParseDockerMetadata(*service.Image.ImageData.Name)
+		if parsedService.DockerMetadata != nil {
+			parsedService.DockerMetadata.RegistryCredentialsID = getVariableName(service.Image.ImageData.Password)
+		}
+	}
+	return parsedService
+}
+
+// getVariableName returns the name of the secured variable a value references, e.g. X for $X or ${X}.
+// Values that are not a variable reference return nil, so literal passwords never reach the output.
+func getVariableName(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	if match := bitbucketVariableRegex.FindStringSubmatch(*value); match != nil {
+		return &match[1]
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package bitbucket
+
+import (
+	"testing"
+
+	bitbucketModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/bitbucket/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseJobServices(t *testing.T) {
+	testCases := []struct {
+		name             string
+		steps            []*bitbucketModels.Step
+		definitions      *bitbucketModels.Definitions
+		expectedServices []*models.Service
+	}{
+		{
+			name:             "Steps are nil",
+			steps:            nil,
+			expectedServices: nil,
+		},
+		{
+			name: "Services of steps and parallel steps",
+			steps: []*bitbucketModels.Step{
+				{
+					Step: &bitbucketModels.ExecutionUnitRef{
+						ExecutionUnit: &bitbucketModels.ExecutionUnit{
+							Services: []*string{utils.GetPtr("docker"), utils.GetPtr("postgres")},
+						},
+					},
+				},
+				{
+					Parallel: []*bitbucketModels.ParallelSteps{
+						{
+							Step: &bitbucketModels.ExecutionUnitRef{
+								ExecutionUnit: &bitbucketModels.ExecutionUnit{
+									Services: []*string{utils.GetPtr("postgres"), utils.GetPtr("redis")},
+								},
+							},
+						},
+					},
+				},
+			},
+			definitions: &bitbucketModels.Definitions{
+				Services: map[string]*bitbucketModels.Service{
+					"postgres": {
+						Image: &bitbucketModels.Image{
+							ImageData: &bitbucketModels.ImageData{
+								Name:     utils.GetPtr("registry.example.com/team/postgres:14"),
+								Username: utils.GetPtr("$REGISTRY_USER"),
+								Password: utils.GetPtr("$REGISTRY_PASSWORD"),
+							},
+						},
+						Variables: &bitbucketModels.EnvironmentVariablesRef{
+							EnvironmentVariables: models.EnvironmentVariables{
+								"POSTGRES_DB": "test",
+							},
+							FileReference: testutils.CreateFileReference(9, 7, 9, 24),
+						},
+						FileReference: testutils.CreateFileReference(5, 7, 9, 24),
+					},
+					"redis": {
+						Image: &bitbucketModels.Image{
+							ImageData: &bitbucketModels.ImageData{
+								Name: utils.GetPtr("redis"),
+							},
+						},
+						FileReference: testutils.CreateFileReference(11, 7, 11, 19),
+					},
+				},
+			},
+			expectedServices: []*models.Service{
+				{
+					Name: utils.GetPtr("docker"),
+				},
+				{
+					Name: utils.GetPtr("postgres"),
+					DockerMetadata: &models.DockerMetadata{
+						Image:                 utils.GetPtr("team/postgres"),
+						Label:                 utils.GetPtr("14"),
+						RegistryURL:           utils.GetPtr("registry.example.com"),
+						RegistryCredentialsID: utils.GetPtr("REGISTRY_PASSWORD"),
+					},
+					EnvironmentVariables: &models.EnvironmentVariablesRef{
+						EnvironmentVariables: models.EnvironmentVariables{
+							"POSTGRES_DB": "test",
+						},
+						FileReference: testutils.CreateFileReference(9, 7, 9, 24),
+					},
+					FileReference: testutils.CreateFileReference(5, 7, 9, 24),
+				},
+				{
+					Name: utils.GetPtr("redis"),
+					DockerMetadata: &models.DockerMetadata{
+						Image: utils.GetPtr("redis"),
+					},
+					FileReference: testutils.CreateFileReference(11, 7, 11, 19),
+				},
+			},
+		},
+		{
+			name: "Service with a literal password",
+			steps: []*bitbucketModels.Step{
+				{
+					Step: &bitbucketModels.ExecutionUnitRef{
+						ExecutionUnit: &bitbucketModels.ExecutionUnit{
+							Services: []*string{utils.GetPtr("postgres")},
+						},
+					},
+				},
+			},
+			definitions: &bitbucketModels.Definitions{
+				Services: map[string]*bitbucketModels.Service{
+					"postgres": {
+						Image: &bitbucketModels.Image{
+							ImageData: &bitbucketModels.ImageData{
+								Name:     utils.GetPtr("registry.example.com/team/postgres:14"),
+								Username: utils.GetPtr("user"),
+								Password: utils.GetPtr("hunter2"),
+							},
+						},
+					},
+				},
+			},
+			expectedServices: []*models.Service{
+				{
+					Name: utils.GetPtr("postgres"),
+					DockerMetadata: &models.DockerMetadata{
+						Image:       utils.GetPtr("team/postgres"),
+						Label:       utils.GetPtr("14"),
+						RegistryURL: utils.GetPtr("registry.example.com"),
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseJobServices(testCase.steps, testCase.definitions)
+
+			testutils.DeepCompare(t, testCase.expectedServices, got)
+		})
+	}
+}
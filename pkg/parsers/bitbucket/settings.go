@@ -0,0 +1,94 @@
+package bitbucket
+
+import (
+	"strings"
+
+	bitbucketModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/bitbucket/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	selfHostedLabel = "self.hosted"
+)
+
+var (
+	defaultTimeoutMS int = 120 * 60 * 1000
+)
+
+// parseTimeout converts a max-time in minutes to milliseconds
+func parseTimeout(maxTime *int64) *int {
+	if maxTime == nil {
+		return nil
+	}
+	return utils.GetPtr(int(*maxTime) * 60 * 1000)
+}
+
+// parseJobTimeout returns the max-time of the pipeline options, which the steps of a pipeline time out after unless they set their own
+func parseJobTimeout(options *bitbucketModels.GlobalSettings) *int {
+	if options != nil && options.MaxTime != nil {
+		return parseTimeout(options.MaxTime)
+	}
+	return &defaultTimeoutMS
+}
+
+func parseCheckoutSettings(clone *bitbucketModels.Clone) *models.CheckoutSettings {
+	if clone == nil {
+		return nil
+	}
+
+	checkout := &models.CheckoutSettings{
+		Enabled: clone.Enabled,
+		LFS:     clone.LFS,
+	}
+	// the depth is either a number of commits or "full"
+	if depth, ok := clone.Depth.(int); ok {
+		checkout.Depth = &depth
+	}
+	return checkout
+}
+
+func parseResourceClass(size *bitbucketModels.Size) *string {
+	if size == nil {
+		return nil
+	}
+	return utils.GetPtr(string(*size))
+}
+
+func parseRunsOn(runner *models.Runner, runsOn []*string) {
+	var labels []string
+	for _, label := range runsOn {
+		if label != nil {
+			labels = append(labels, *label)
+		}
+	}
+	if len(labels) == 0 {
+		return
+	}
+
+	runner.Labels = &labels
+	// only self-hosted runners are selected by labels, and they must have the self.hosted label
+	runner.SelfHosted = utils.GetPtr(slices.ContainsFunc(labels, func(label string) bool {
+		return strings.EqualFold(label, selfHostedLabel)
+	}))
+}
+
+// parseJobEnvironment returns the environment of the last deployment step of a pipeline, the furthest environment the pipeline deploys to
+func parseJobEnvironment(steps []*bitbucketModels.Step) *models.Environment {
+	var environment *models.Environment
+	for _, step := range steps {
+		if step == nil {
+			continue
+		}
+		for _, executionUnitRef := range getExecutionUnits(step) {
+			if deployment := executionUnitRef.ExecutionUnit.Deployment; deployment != nil && *deployment != "" {
+				environment = &models.Environment{
+					Name:          deployment,
+					FileReference: executionUnitRef.FileReference,
+				}
+			}
+		}
+	}
+	return environment
+}
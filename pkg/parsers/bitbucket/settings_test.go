@@ -0,0 +1,147 @@
+package bitbucket
+
+import (
+	"testing"
+
+	bitbucketModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/bitbucket/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobTimeoutParse(t *testing.T) {
+	testCases := []struct {
+		name            string
+		options         *bitbucketModels.GlobalSettings
+		expectedTimeout int
+	}{
+		{
+			name:            "Options are nil",
+			options:         nil,
+			expectedTimeout: 120 * 60 * 1000,
+		},
+		{
+			name:            "Options without max-time",
+			options:         &bitbucketModels.GlobalSettings{Docker: utils.GetPtr(true)},
+			expectedTimeout: 120 * 60 * 1000,
+		},
+		{
+			name:            "Options with max-time",
+			options:         &bitbucketModels.GlobalSettings{MaxTime: utils.GetPtr(int64(30))},
+			expectedTimeout: 30 * 60 * 1000,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			timeout := parseJobTimeout(testCase.options)
+			assert.Equal(t, testCase.expectedTimeout, *timeout)
+		})
+	}
+}
+
+func TestCheckoutSettingsParse(t *testing.T) {
+	testCases := []struct {
+		name             string
+		clone            *bitbucketModels.Clone
+		expectedCheckout *models.CheckoutSettings
+	}{
+		{
+			name:             "Clone is nil",
+			clone:            nil,
+			expectedCheckout: nil,
+		},
+		{
+			name: "Clone with depth",
+			clone: &bitbucketModels.Clone{
+				Depth: 1,
+				LFS:   utils.GetPtr(true),
+			},
+			expectedCheckout: &models.CheckoutSettings{
+				Depth: utils.GetPtr(1),
+				LFS:   utils.GetPtr(true),
+			},
+		},
+		{
+			name: "Full clone",
+			clone: &bitbucketModels.Clone{
+				Depth: "full",
+			},
+			expectedCheckout: &models.CheckoutSettings{},
+		},
+		{
+			name: "Clone is disabled",
+			clone: &bitbucketModels.Clone{
+				Enabled: utils.GetPtr(false),
+			},
+			expectedCheckout: &models.CheckoutSettings{
+				Enabled: utils.GetPtr(false),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			checkout := parseCheckoutSettings(testCase.clone)
+			testutils.DeepCompare(t, testCase.expectedCheckout, checkout)
+		})
+	}
+}
+
+func TestJobEnvironmentParse(t *testing.T) {
+	testCases := []struct {
+		name                string
+		steps               []*bitbucketModels.Step
+		expectedEnvironment *models.Environment
+	}{
+		{
+			name:                "No steps",
+			steps:               nil,
+			expectedEnvironment: nil,
+		},
+		{
+			name: "No deployment steps",
+			steps: []*bitbucketModels.Step{
+				{
+					Step: &bitbucketModels.ExecutionUnitRef{
+						ExecutionUnit: &bitbucketModels.ExecutionUnit{Name: utils.GetPtr("build")},
+					},
+				},
+			},
+			expectedEnvironment: nil,
+		},
+		{
+			name: "Deployment steps",
+			steps: []*bitbucketModels.Step{
+				{
+					Step: &bitbucketModels.ExecutionUnitRef{
+						ExecutionUnit: &bitbucketModels.ExecutionUnit{Deployment: utils.GetPtr("staging")},
+						FileReference: testutils.CreateFileReference(5, 7, 9, 20),
+					},
+				},
+				{
+					Parallel: []*bitbucketModels.ParallelSteps{
+						{
+							Step: &bitbucketModels.ExecutionUnitRef{
+								ExecutionUnit: &bitbucketModels.ExecutionUnit{Deployment: utils.GetPtr("production")},
+								FileReference: testutils.CreateFileReference(12, 11, 16, 20),
+							},
+						},
+					},
+				},
+			},
+			expectedEnvironment: &models.Environment{
+				Name:          utils.GetPtr("production"),
+				FileReference: testutils.CreateFileReference(12, 11, 16, 20),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			environment := parseJobEnvironment(testCase.steps)
+			testutils.DeepCompare(t, testCase.expectedEnvironment, environment)
+		})
+	}
+}
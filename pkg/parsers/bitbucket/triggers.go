@@ -0,0 +1,168 @@
+package bitbucket
+
+import (
+	"sort"
+
+	bitbucketModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/bitbucket/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+// parsePipelineTriggers creates a trigger for each pipeline, with the glob pattern of its name as a branch or tag filter
+func parsePipelineTriggers(pipeline *bitbucketModels.Pipeline) *models.Triggers {
+	if pipeline == nil || pipeline.Pipelines == nil {
+		return nil
+	}
+
+	buildPipelines := pipeline.Pipelines
+	var triggers []*models.Trigger
+	if buildPipelines.Default != nil {
+		trigger := parseTrigger(defaultSection, "default", buildPipelines.Default, models.PushEvent)
+		// the default pipeline runs on pushes to the branches without a pipeline of their own
+		if branches := getSortedPipelineNames(buildPipelines.Branches); len(branches) > 0 {
+			trigger.Branches = &models.Filter{DenyList: branches}
+		}
+		triggers = append(triggers, trigger)
+	}
+
+	triggers = append(triggers, parseStepMapTriggers(pullRequestsSection, buildPipelines.PullRequests, models.PullRequestEvent, setBranchesFilter)...)
+	triggers = append(triggers, parseStepMapTriggers(branchesSection, buildPipelines.Branches, models.PushEvent, setBranchesFilter)...)
+	triggers = append(triggers, parseStepMapTriggers(tagsSection, buildPipelines.Tags, models.PushEvent, setTagsFilter)...)
+	// bookmarks are the branches of Mercurial repositories
+	triggers = append(triggers, parseStepMapTriggers(bookmarksSection, buildPipelines.Bookmarks, models.PushEvent, setBranchesFilter)...)
+	triggers = append(triggers, parseStepMapTriggers(customSection, buildPipelines.Custom, models.ManualEvent, nil)...)
+
+	if len(triggers) == 0 {
+		return nil
+	}
+
+	var fileReferences []*models.FileReference
+	for _, trigger := range triggers {
+		fileReferences = append(fileReferences, trigger.FileReference)
+	}
+	return &models.Triggers{
+		Triggers:      triggers,
+		FileReference: mergeFileReferences(fileReferences),
+	}
+}
+
+func parseStepMapTriggers(section string, stepMap *bitbucketModels.StepMap, event models.EventType, setFilter func(trigger *models.Trigger, pattern string)) []*models.Trigger {
+	var triggers []*models.Trigger
+	for _, name := range getSortedPipelineNames(stepMap) {
+		trigger := parseTrigger(section, name, (*stepMap)[name], event)
+		if setFilter != nil {
+			setFilter(trigger, name)
+		}
+		triggers = append(triggers, trigger)
+	}
+	return triggers
+}
+
+func parseTrigger(section, name string, steps []*bitbucketModels.Step, event models.EventType) *models.Trigger {
+	trigger := &models.Trigger{
+		Event:         event,
+		Jobs:          []string{getJobID(section, name)},
+		FileReference: getStepsFileReference(steps),
+	}
+	for _, parameter := range parseCustomVariables(steps) {
+		trigger.Parameters = append(trigger.Parameters, *parameter)
+	}
+	return trigger
+}
+
+func setBranchesFilter(trigger *models.Trigger, pattern string) {
+	trigger.Branches = &models.Filter{AllowList: []string{pattern}}
+}
+
+func setTagsFilter(trigger *models.Trigger, pattern string) {
+	trigger.Tags = &models.Filter{AllowList: []string{pattern}}
+}
+
+// parsePipelineParameters returns the variables of all the custom pipelines, which are prompted for when they are run
+func parsePipelineParameters(pipeline *bitbucketModels.Pipeline) []*models.Parameter {
+	if pipeline == nil || pipeline.Pipelines == nil {
+		return nil
+	}
+
+	var parameters []*models.Parameter
+	for _, name := range getSortedPipelineNames(pipeline.Pipelines.Custom) {
+		parameters = append(parameters, parseCustomVariables((*pipeline.Pipelines.Custom)[name])...)
+	}
+	return parameters
+}
+
+func parseCustomVariables(steps []*bitbucketModels.Step) []*models.Parameter {
+	var parameters []*models.Parameter
+	for _, step := range steps {
+		if step == nil {
+			continue
+		}
+		for _, variable := range step.Variables {
+			if variable == nil || variable.Name == nil {
+				continue
+			}
+			parameter := &models.Parameter{
+				Name:          variable.Name,
+				FileReference: variable.FileReference,
+			}
+			if variable.Default != nil {
+				parameter.Default = *variable.Default
+			}
+			for _, value := range variable.AllowedValues {
+				if value != nil {
+					parameter.Options = append(parameter.Options, *value)
+				}
+			}
+			parameters = append(parameters, parameter)
+		}
+	}
+	return parameters
+}
+
+func getSortedPipelineNames(stepMap *bitbucketModels.StepMap) []string {
+	if stepMap == nil {
+		return nil
+	}
+	names := utils.GetMapKeys(*stepMap)
+	sort.Strings(names)
+	return names
+}
+
+// getStepsFileReference returns the span of the steps of a pipeline, including its variables
+func getStepsFileReference(steps []*bitbucketModels.Step) *models.FileReference {
+	var fileReferences []*models.FileReference
+	for _, step := range steps {
+		if step == nil {
+			continue
+		}
+		for _, executionUnitRef := range getExecutionUnits(step) {
+			fileReferences = append(fileReferences, executionUnitRef.FileReference)
+		}
+		for _, variable := range step.Variables {
+			if variable != nil {
+				fileReferences = append(fileReferences, variable.FileReference)
+			}
+		}
+	}
+	return mergeFileReferences(fileReferences)
+}
+
+func mergeFileReferences(fileReferences []*models.FileReference) *models.FileReference {
+	var merged *models.FileReference
+	for _, fileReference := range fileReferences {
+		if fileReference == nil || fileReference.StartRef == nil || fileReference.EndRef == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &models.FileReference{StartRef: fileReference.StartRef, EndRef: fileReference.EndRef}
+			continue
+		}
+		if isAfter(merged.StartRef, fileReference.StartRef) {
+			merged.StartRef = fileReference.StartRef
+		}
+		if isAfter(fileReference.EndRef, merged.EndRef) {
+			merged.EndRef = fileReference.EndRef
+		}
+	}
+	return merged
+}
@@ -0,0 +1,226 @@
+package bitbucket
+
+import (
+	"testing"
+
+	bitbucketModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/bitbucket/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func createTriggerStep(fileReference *models.FileReference) *bitbucketModels.Step {
+	return &bitbucketModels.Step{
+		Step: &bitbucketModels.ExecutionUnitRef{
+			ExecutionUnit: &bitbucketModels.ExecutionUnit{},
+			FileReference: fileReference,
+		},
+	}
+}
+
+func createCustomVariables() *bitbucketModels.Step {
+	return &bitbucketModels.Step{
+		Variables: []*bitbucketModels.CustomStepVariable{
+			{
+				Name:          utils.GetPtr("Environment"),
+				Default:       utils.GetPtr("staging"),
+				AllowedValues: []*string{utils.GetPtr("staging"), utils.GetPtr("production")},
+				FileReference: testutils.CreateFileReference(22, 13, 26, 27),
+			},
+			{
+				Name:          utils.GetPtr("Version"),
+				FileReference: testutils.CreateFileReference(27, 13, 27, 26),
+			},
+		},
+	}
+}
+
+func TestPipelineTriggersParse(t *testing.T) {
+	testCases := []struct {
+		name              string
+		bitbucketPipeline *bitbucketModels.Pipeline
+		expectedTriggers  *models.Triggers
+	}{
+		{
+			name:              "Pipeline is nil",
+			bitbucketPipeline: nil,
+			expectedTriggers:  nil,
+		},
+		{
+			name:              "Pipeline has no pipelines",
+			bitbucketPipeline: &bitbucketModels.Pipeline{},
+			expectedTriggers:  nil,
+		},
+		{
+			name: "Pipeline has all pipeline types",
+			bitbucketPipeline: &bitbucketModels.Pipeline{
+				Pipelines: &bitbucketModels.BuildPipelines{
+					Default: []*bitbucketModels.Step{createTriggerStep(testutils.CreateFileReference(3, 7, 6, 23))},
+					Branches: &bitbucketModels.StepMap{
+						"release/*": {createTriggerStep(testutils.CreateFileReference(9, 9, 12, 25))},
+						"main":      {createTriggerStep(testutils.CreateFileReference(14, 9, 17, 25))},
+					},
+					Tags: &bitbucketModels.StepMap{
+						"v*": {createTriggerStep(testutils.CreateFileReference(31, 9, 34, 25))},
+					},
+					Bookmarks: &bitbucketModels.StepMap{
+						"feature-*": {createTriggerStep(testutils.CreateFileReference(37, 9, 40, 25))},
+					},
+					Custom: &bitbucketModels.StepMap{
+						"deploy": {
+							createCustomVariables(),
+							createTriggerStep(testutils.CreateFileReference(28, 9, 30, 25)),
+						},
+					},
+				},
+			},
+			expectedTriggers: &models.Triggers{
+				Triggers: []*models.Trigger{
+					{
+						Event:         models.PushEvent,
+						Branches:      &models.Filter{DenyList: []string{"main", "release/*"}},
+						Jobs:          []string{"job-default"},
+						FileReference: testutils.CreateFileReference(3, 7, 6, 23),
+					},
+					{
+						Event:         models.PushEvent,
+						Branches:      &models.Filter{AllowList: []string{"main"}},
+						Jobs:          []string{"job-branches-main"},
+						FileReference: testutils.CreateFileReference(14, 9, 17, 25),
+					},
+					{
+						Event:         models.PushEvent,
+						Branches:      &models.Filter{AllowList: []string{"release/*"}},
+						Jobs:          []string{"job-branches-release/*"},
+						FileReference: testutils.CreateFileReference(9, 9, 12, 25),
+					},
+					{
+						Event:         models.PushEvent,
+						Tags:          &models.Filter{AllowList: []string{"v*"}},
+						Jobs:          []string{"job-tags-v*"},
+						FileReference: testutils.CreateFileReference(31, 9, 34, 25),
+					},
+					{
+						Event:         models.PushEvent,
+						Branches:      &models.Filter{AllowList: []string{"feature-*"}},
+						Jobs:          []string{"job-bookmarks-feature-*"},
+						FileReference: testutils.CreateFileReference(37, 9, 40, 25),
+					},
+					{
+						Event: models.ManualEvent,
+						Parameters: []models.Parameter{
+							{
+								Name:          utils.GetPtr("Environment"),
+								Default:       "staging",
+								Options:       []string{"staging", "production"},
+								FileReference: testutils.CreateFileReference(22, 13, 26, 27),
+							},
+							{
+								Name:          utils.GetPtr("Version"),
+								FileReference: testutils.CreateFileReference(27, 13, 27, 26),
+							},
+						},
+						Jobs:          []string{"job-custom-deploy"},
+						FileReference: testutils.CreateFileReference(22, 13, 30, 25),
+					},
+				},
+				FileReference: testutils.CreateFileReference(3, 7, 40, 25),
+			},
+		},
+		{
+			name: "Pipelines with the same name in different sections",
+			bitbucketPipeline: &bitbucketModels.Pipeline{
+				Pipelines: &bitbucketModels.BuildPipelines{
+					PullRequests: &bitbucketModels.StepMap{
+						"master": {createTriggerStep(testutils.CreateFileReference(3, 9, 6, 25))},
+					},
+					Branches: &bitbucketModels.StepMap{
+						"master": {createTriggerStep(testutils.CreateFileReference(8, 9, 11, 25))},
+					},
+				},
+			},
+			expectedTriggers: &models.Triggers{
+				Triggers: []*models.Trigger{
+					{
+						Event:         models.PullRequestEvent,
+						Branches:      &models.Filter{AllowList: []string{"master"}},
+						Jobs:          []string{"job-pull-requests-master"},
+						FileReference: testutils.CreateFileReference(3, 9, 6, 25),
+					},
+					{
+						Event:         models.PushEvent,
+						Branches:      &models.Filter{AllowList: []string{"master"}},
+						Jobs:          []string{"job-branches-master"},
+						FileReference: testutils.CreateFileReference(8, 9, 11, 25),
+					},
+				},
+				FileReference: testutils.CreateFileReference(3, 9, 11, 25),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			triggers := parsePipelineTriggers(testCase.bitbucketPipeline)
+			testutils.DeepCompare(t, testCase.expectedTriggers, triggers)
+		})
+	}
+}
+
+func TestPipelineParametersParse(t *testing.T) {
+	testCases := []struct {
+		name               string
+		bitbucketPipeline  *bitbucketModels.Pipeline
+		expectedParameters []*models.Parameter
+	}{
+		{
+			name:               "Pipeline is nil",
+			bitbucketPipeline:  nil,
+			expectedParameters: nil,
+		},
+		{
+			name: "Pipeline has no custom pipelines",
+			bitbucketPipeline: &bitbucketModels.Pipeline{
+				Pipelines: &bitbucketModels.BuildPipelines{
+					Default: []*bitbucketModels.Step{createTriggerStep(testutils.CreateFileReference(3, 7, 6, 23))},
+				},
+			},
+			expectedParameters: nil,
+		},
+		{
+			name: "Custom pipeline has variables",
+			bitbucketPipeline: &bitbucketModels.Pipeline{
+				Pipelines: &bitbucketModels.BuildPipelines{
+					Custom: &bitbucketModels.StepMap{
+						"deploy": {
+							createCustomVariables(),
+							createTriggerStep(testutils.CreateFileReference(28, 9, 30, 25)),
+						},
+						"notify": {
+							createTriggerStep(testutils.CreateFileReference(32, 9, 34, 25)),
+						},
+					},
+				},
+			},
+			expectedParameters: []*models.Parameter{
+				{
+					Name:          utils.GetPtr("Environment"),
+					Default:       "staging",
+					Options:       []string{"staging", "production"},
+					FileReference: testutils.CreateFileReference(22, 13, 26, 27),
+				},
+				{
+					Name:          utils.GetPtr("Version"),
+					FileReference: testutils.CreateFileReference(27, 13, 27, 26),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			parameters := parsePipelineParameters(testCase.bitbucketPipeline)
+			testutils.DeepCompare(t, testCase.expectedParameters, parameters)
+		})
+	}
+}
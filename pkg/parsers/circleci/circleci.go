@@ -0,0 +1,68 @@
+package circleci
+
+import (
+	"sort"
+	"strings"
+
+	circleciModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	parserUtils "github.com/argonsecurity/pipeline-parser/pkg/parsers/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+type CircleCIParser struct{}
+
+func (c *CircleCIParser) Parse(config *circleciModels.Config) (*models.Pipeline, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	var pipeline models.Pipeline
+
+	pipeline.Imports = parseOrbs(config.Orbs)
+	pipeline.Jobs = parseJobs(config)
+	pipeline.Triggers = parseTriggers(config.Workflows)
+
+	return &pipeline, nil
+}
+
+// parseOrbs returns the orbs of the config as imports, e.g. `node: circleci/node@5.1.0`
+func parseOrbs(orbs *circleciModels.Orbs) []*models.Import {
+	if orbs == nil {
+		return nil
+	}
+
+	aliases := utils.GetMapKeys(*orbs)
+	sort.Strings(aliases)
+
+	var imports []*models.Import
+	for _, alias := range aliases {
+		orb := (*orbs)[alias]
+		if orb.Reference == nil {
+			continue
+		}
+
+		source := &models.ImportSource{
+			RepositoryAlias: utils.GetPtr(alias),
+			Type:            models.SourceTypeRemote,
+		}
+		if namespace, name, found := strings.Cut(*orb.Reference, "/"); found {
+			source.Organization = utils.GetPtr(namespace)
+			source.Repository = utils.GetPtr(name)
+		} else {
+			source.Repository = orb.Reference
+		}
+
+		orbImport := &models.Import{
+			Source:        source,
+			Version:       orb.Version,
+			VersionType:   models.None,
+			FileReference: orb.FileReference,
+		}
+		if orb.Version != nil {
+			orbImport.VersionType = parserUtils.DetectVersionType(*orb.Version)
+		}
+		imports = append(imports, orbImport)
+	}
+	return imports
+}
@@ -0,0 +1,71 @@
+package circleci
+
+import (
+	"testing"
+
+	circleciModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseOrbs(t *testing.T) {
+	testCases := []struct {
+		name            string
+		orbs            *circleciModels.Orbs
+		expectedImports []*models.Import
+	}{
+		{
+			name:            "No orbs",
+			orbs:            nil,
+			expectedImports: nil,
+		},
+		{
+			name: "Orbs with and without versions",
+			orbs: &circleciModels.Orbs{
+				"slack": {
+					Name:          "slack",
+					Reference:     utils.GetPtr("circleci/slack"),
+					Version:       utils.GetPtr("4.12.5"),
+					FileReference: testutils.CreateFileReference(2, 3, 2, 30),
+				},
+				"inline": {
+					Name: "inline",
+				},
+				"local": {
+					Name:      "local",
+					Reference: utils.GetPtr("my-orb"),
+				},
+			},
+			expectedImports: []*models.Import{
+				{
+					Source: &models.ImportSource{
+						Repository:      utils.GetPtr("my-orb"),
+						RepositoryAlias: utils.GetPtr("local"),
+						Type:            models.SourceTypeRemote,
+					},
+					VersionType: models.None,
+				},
+				{
+					Source: &models.ImportSource{
+						Organization:    utils.GetPtr("circleci"),
+						Repository:      utils.GetPtr("slack"),
+						RepositoryAlias: utils.GetPtr("slack"),
+						Type:            models.SourceTypeRemote,
+					},
+					Version:       utils.GetPtr("4.12.5"),
+					VersionType:   models.TagVersion,
+					FileReference: testutils.CreateFileReference(2, 3, 2, 30),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseOrbs(testCase.orbs)
+
+			testutils.DeepCompare(t, testCase.expectedImports, got)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package circleci
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	circleciModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+const approvalJobType = "approval"
+
+// parseJobs returns a job for every job invocation in the workflows.
+// The ID of a workflow job is prefixed by its workflow, because the same job may run in multiple workflows.
+// Configs without workflows (version 2.0) run all of their jobs.
+func parseJobs(config *circleciModels.Config) []*models.Job {
+	if config.Workflows == nil {
+		if config.Jobs == nil {
+			return nil
+		}
+
+		jobNames := utils.GetMapKeys(*config.Jobs)
+		sort.Strings(jobNames)
+		return utils.Map(jobNames, func(jobName string) *models.Job {
+			return parseJob(jobName, jobName, (*config.Jobs)[jobName], config)
+		})
+	}
+
+	workflowNames := utils.GetMapKeys(*config.Workflows)
+	sort.Strings(workflowNames)
+
+	var jobs []*models.Job
+	for _, workflowName := range workflowNames {
+		workflow := (*config.Workflows)[workflowName]
+		if workflow == nil {
+			continue
+		}
+
+		for _, workflowJob := range workflow.Jobs {
+			jobs = append(jobs, parseWorkflowJob(workflowName, workflowJob, config))
+		}
+	}
+	return jobs
+}
+
+func parseWorkflowJob(workflowName string, workflowJob *circleciModels.WorkflowJob, config *circleciModels.Config) *models.Job {
+	name := workflowJob.JobName
+	if workflowJob.Name != nil {
+		name = *workflowJob.Name
+	}
+	id := getWorkflowJobID(workflowName, name)
+
+	var job *models.Job
+	if configJob := getConfigJob(config, workflowJob.JobName); configJob != nil {
+		job = parseJob(id, name, configJob, config)
+	} else {
+		job = &models.Job{
+			ID:            utils.GetPtr(id),
+			Name:          utils.GetPtr(name),
+			FileReference: workflowJob.FileReference,
+		}
+		if isOrbReference(workflowJob.JobName) {
+			job.Steps = []*models.Step{parseOrbStep(workflowJob.JobName, workflowJob.FileReference, config.Orbs)}
+		}
+	}
+
+	if workflowJob.Type != nil && *workflowJob.Type == approvalJobType {
+		job.Conditions = append(job.Conditions, &models.Condition{
+			Allow:  utils.GetPtr(true),
+			Events: []models.EventType{models.ManualEvent},
+		})
+	}
+
+	if condition := parseFilters(workflowJob.Filters); condition != nil {
+		job.Conditions = append(job.Conditions, condition)
+	}
+
+	for _, requirement := range workflowJob.Requires {
+		job.Dependencies = append(job.Dependencies, &models.JobDependency{
+			JobID: utils.GetPtr(getWorkflowJobID(workflowName, requirement)),
+		})
+	}
+
+	job.Inputs = parseParameters(workflowJob.Parameters)
+	return job
+}
+
+func parseJob(id, name string, configJob *circleciModels.Job, config *circleciModels.Config) *models.Job {
+	executor := resolveExecutor(configJob, config.Executors)
+	return &models.Job{
+		ID:                   utils.GetPtr(id),
+		Name:                 utils.GetPtr(name),
+		Steps:                parseSteps(configJob.Steps, config, nil),
+		Runner:               parseRunner(executor),
+		EnvironmentVariables: parseJobEnvironmentVariables(configJob, executor),
+		FileReference:        configJob.FileReference,
+	}
+}
+
+// resolveExecutor returns the executor of the job - either the job's own executor keys or the referenced executor of the config
+func resolveExecutor(job *circleciModels.Job, executors *circleciModels.Executors) *circleciModels.Executor {
+	if job.ExecutorRef == nil {
+		return &job.Executor
+	}
+
+	if executors != nil {
+		if executor, ok := (*executors)[job.ExecutorRef.Name]; ok && executor != nil {
+			return executor
+		}
+	}
+	return nil
+}
+
+// parseJobEnvironmentVariables merges the environment variables of the executor with the environment variables of the job
+func parseJobEnvironmentVariables(job *circleciModels.Job, executor *circleciModels.Executor) *models.EnvironmentVariablesRef {
+	environmentVariablesRef := parseEnvironmentVariables(job.Environment)
+	if executor == nil || executor == &job.Executor || executor.Environment == nil {
+		return environmentVariablesRef
+	}
+
+	if environmentVariablesRef == nil {
+		return parseEnvironmentVariables(executor.Environment)
+	}
+
+	environmentVariables := models.EnvironmentVariables{}
+	for key, value := range executor.Environment.EnvironmentVariables {
+		environmentVariables[key] = value
+	}
+	for key, value := range environmentVariablesRef.EnvironmentVariables {
+		environmentVariables[key] = value
+	}
+	environmentVariablesRef.EnvironmentVariables = environmentVariables
+	return environmentVariablesRef
+}
+
+func parseEnvironmentVariables(environment *circleciModels.EnvironmentVariablesRef) *models.EnvironmentVariablesRef {
+	if environment == nil {
+		return nil
+	}
+
+	return &models.EnvironmentVariablesRef{
+		EnvironmentVariables: environment.EnvironmentVariables,
+		FileReference:        environment.FileReference,
+	}
+}
+
+func parseFilters(filters *circleciModels.Filters) *models.Condition {
+	if filters == nil || filters.Branches == nil {
+		return nil
+	}
+
+	return &models.Condition{
+		Allow:    utils.GetPtr(true),
+		Branches: parseFilter(filters.Branches),
+	}
+}
+
+func parseFilter(filter *circleciModels.Filter) *models.Filter {
+	if filter == nil {
+		return nil
+	}
+
+	return &models.Filter{
+		AllowList: filter.Only,
+		DenyList:  filter.Ignore,
+	}
+}
+
+func parseParameters(parameters map[string]any) []*models.Parameter {
+	if len(parameters) == 0 {
+		return nil
+	}
+
+	names := utils.GetMapKeys(parameters)
+	sort.Strings(names)
+	return utils.Map(names, func(name string) *models.Parameter {
+		return &models.Parameter{
+			Name:  utils.GetPtr(name),
+			Value: parameters[name],
+		}
+	})
+}
+
+func getConfigJob(config *circleciModels.Config, jobName string) *circleciModels.Job {
+	if config.Jobs == nil {
+		return nil
+	}
+	return (*config.Jobs)[jobName]
+}
+
+func getWorkflowJobID(workflowName, jobName string) string {
+	return fmt.Sprintf("%s/%s", workflowName, jobName)
+}
+
+func isOrbReference(name string) bool {
+	return strings.Contains(name, "/")
+}
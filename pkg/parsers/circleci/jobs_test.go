@@ -0,0 +1,154 @@
+package circleci
+
+import (
+	"testing"
+
+	circleciModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseJobs(t *testing.T) {
+	testCases := []struct {
+		name         string
+		config       *circleciModels.Config
+		expectedJobs []*models.Job
+	}{
+		{
+			name:         "No jobs",
+			config:       &circleciModels.Config{},
+			expectedJobs: nil,
+		},
+		{
+			name: "Jobs without workflows",
+			config: &circleciModels.Config{
+				Jobs: &circleciModels.Jobs{
+					"test": {
+						FileReference: testutils.CreateFileReference(3, 3, 5, 20),
+					},
+					"build": {
+						Executor: circleciModels.Executor{
+							Environment: &circleciModels.EnvironmentVariablesRef{
+								EnvironmentVariables: models.EnvironmentVariables{"CI": "true"},
+							},
+						},
+						FileReference: testutils.CreateFileReference(1, 3, 2, 20),
+					},
+				},
+			},
+			expectedJobs: []*models.Job{
+				{
+					ID:   utils.GetPtr("build"),
+					Name: utils.GetPtr("build"),
+					EnvironmentVariables: &models.EnvironmentVariablesRef{
+						EnvironmentVariables: models.EnvironmentVariables{"CI": "true"},
+					},
+					FileReference: testutils.CreateFileReference(1, 3, 2, 20),
+				},
+				{
+					ID:            utils.GetPtr("test"),
+					Name:          utils.GetPtr("test"),
+					FileReference: testutils.CreateFileReference(3, 3, 5, 20),
+				},
+			},
+		},
+		{
+			name: "Workflow jobs",
+			config: &circleciModels.Config{
+				Executors: &circleciModels.Executors{
+					"linux": {
+						Docker: []*circleciModels.DockerImage{{Image: "ubuntu:22.04"}},
+						Environment: &circleciModels.EnvironmentVariablesRef{
+							EnvironmentVariables: models.EnvironmentVariables{"A": "executor", "B": "executor"},
+						},
+						FileReference: testutils.CreateFileReference(1, 3, 4, 10),
+					},
+				},
+				Jobs: &circleciModels.Jobs{
+					"deploy": {
+						Executor: circleciModels.Executor{
+							Environment: &circleciModels.EnvironmentVariablesRef{
+								EnvironmentVariables: models.EnvironmentVariables{"B": "job"},
+								FileReference:        testutils.CreateFileReference(7, 5, 8, 10),
+							},
+						},
+						ExecutorRef:   &circleciModels.ExecutorRef{Name: "linux"},
+						FileReference: testutils.CreateFileReference(5, 3, 8, 10),
+					},
+				},
+				Workflows: &circleciModels.Workflows{
+					"release": {
+						Jobs: []*circleciModels.WorkflowJob{
+							{
+								JobName:       "hold",
+								Type:          utils.GetPtr("approval"),
+								FileReference: testutils.CreateFileReference(12, 9, 13, 25),
+							},
+							{
+								JobName:  "deploy",
+								Name:     utils.GetPtr("deploy-prod"),
+								Requires: circleciModels.Requires{"hold"},
+								Filters: &circleciModels.Filters{
+									Branches: &circleciModels.Filter{Only: circleciModels.StringList{"main"}},
+								},
+								Parameters: map[string]any{"region": "us-east-1"},
+							},
+						},
+					},
+				},
+			},
+			expectedJobs: []*models.Job{
+				{
+					ID:   utils.GetPtr("release/hold"),
+					Name: utils.GetPtr("hold"),
+					Conditions: []*models.Condition{
+						{
+							Allow:  utils.GetPtr(true),
+							Events: []models.EventType{models.ManualEvent},
+						},
+					},
+					FileReference: testutils.CreateFileReference(12, 9, 13, 25),
+				},
+				{
+					ID:   utils.GetPtr("release/deploy-prod"),
+					Name: utils.GetPtr("deploy-prod"),
+					Runner: &models.Runner{
+						Type: utils.GetPtr("docker"),
+						OS:   utils.GetPtr("linux"),
+						DockerMetadata: &models.DockerMetadata{
+							Image: utils.GetPtr("ubuntu"),
+							Label: utils.GetPtr("22.04"),
+						},
+						FileReference: testutils.CreateFileReference(1, 3, 4, 10),
+					},
+					EnvironmentVariables: &models.EnvironmentVariablesRef{
+						EnvironmentVariables: models.EnvironmentVariables{"A": "executor", "B": "job"},
+						FileReference:        testutils.CreateFileReference(7, 5, 8, 10),
+					},
+					Conditions: []*models.Condition{
+						{
+							Allow:    utils.GetPtr(true),
+							Branches: &models.Filter{AllowList: []string{"main"}},
+						},
+					},
+					Dependencies: []*models.JobDependency{
+						{JobID: utils.GetPtr("release/hold")},
+					},
+					Inputs: []*models.Parameter{
+						{Name: utils.GetPtr("region"), Value: "us-east-1"},
+					},
+					FileReference: testutils.CreateFileReference(5, 3, 8, 10),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseJobs(testCase.config)
+
+			testutils.DeepCompare(t, testCase.expectedJobs, got)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package circleci
+
+import (
+	"strings"
+
+	circleciModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	parserUtils "github.com/argonsecurity/pipeline-parser/pkg/parsers/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+// parseRunner parses the executor of a job. The first docker image is the primary container that runs the steps.
+func parseRunner(executor *circleciModels.Executor) *models.Runner {
+	if executor == nil {
+		return nil
+	}
+
+	runner := &models.Runner{
+		FileReference: executor.FileReference,
+	}
+
+	switch {
+	case len(executor.Docker) > 0 && executor.Docker[0] != nil:
+		image := executor.Docker[0]
+		registry, namespace, imageName, tag := parserUtils.ParseImageName(image.Image)
+		if namespace != "" {
+			imageName = namespace + "/" + imageName
+		}
+
+		runner.Type = utils.GetPtr(string(models.DockerRunnerType))
+		runner.OS = utils.GetPtr(string(models.LinuxOS))
+		runner.DockerMetadata = &models.DockerMetadata{
+			Image:       utils.GetPtrOrNil(imageName),
+			Label:       utils.GetPtrOrNil(tag),
+			RegistryURL: utils.GetPtrOrNil(registry),
+		}
+		if runner.FileReference == nil {
+			runner.FileReference = image.FileReference
+		}
+	case executor.Machine != nil:
+		runner.Type = utils.GetPtr(string(models.VmRunnerType))
+		runner.OS = utils.GetPtr(string(models.LinuxOS))
+		if executor.Machine.Image != nil && strings.HasPrefix(*executor.Machine.Image, "windows") {
+			runner.OS = utils.GetPtr(string(models.WindowsOS))
+		}
+		if runner.FileReference == nil {
+			runner.FileReference = executor.Machine.FileReference
+		}
+	case executor.Macos != nil:
+		runner.Type = utils.GetPtr(string(models.VmRunnerType))
+		runner.OS = utils.GetPtr(string(models.MacOS))
+	default:
+		return nil
+	}
+
+	if executor.ResourceClass != nil {
+		runner.Labels = &[]string{*executor.ResourceClass}
+	}
+	return runner
+}
@@ -0,0 +1,89 @@
+package circleci
+
+import (
+	"testing"
+
+	circleciModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseRunner(t *testing.T) {
+	testCases := []struct {
+		name           string
+		executor       *circleciModels.Executor
+		expectedRunner *models.Runner
+	}{
+		{
+			name:           "Executor is nil",
+			executor:       nil,
+			expectedRunner: nil,
+		},
+		{
+			name:           "Empty executor",
+			executor:       &circleciModels.Executor{},
+			expectedRunner: nil,
+		},
+		{
+			name: "Docker executor",
+			executor: &circleciModels.Executor{
+				Docker: []*circleciModels.DockerImage{
+					{
+						Image:         "registry.example.com/team/app:1.0",
+						FileReference: testutils.CreateFileReference(2, 5, 2, 30),
+					},
+					{
+						Image: "postgres:15",
+					},
+				},
+				ResourceClass: utils.GetPtr("large"),
+			},
+			expectedRunner: &models.Runner{
+				Type:   utils.GetPtr("docker"),
+				OS:     utils.GetPtr("linux"),
+				Labels: &[]string{"large"},
+				DockerMetadata: &models.DockerMetadata{
+					Image:       utils.GetPtr("team/app"),
+					Label:       utils.GetPtr("1.0"),
+					RegistryURL: utils.GetPtr("registry.example.com"),
+				},
+				FileReference: testutils.CreateFileReference(2, 5, 2, 30),
+			},
+		},
+		{
+			name: "Windows machine executor",
+			executor: &circleciModels.Executor{
+				Machine: &circleciModels.Machine{
+					Image:         utils.GetPtr("windows-server-2022-gui:current"),
+					FileReference: testutils.CreateFileReference(3, 5, 4, 20),
+				},
+			},
+			expectedRunner: &models.Runner{
+				Type:          utils.GetPtr("vm"),
+				OS:            utils.GetPtr("windows"),
+				FileReference: testutils.CreateFileReference(3, 5, 4, 20),
+			},
+		},
+		{
+			name: "Macos executor",
+			executor: &circleciModels.Executor{
+				Macos:         &circleciModels.Macos{Xcode: utils.GetPtr("14.2.0")},
+				FileReference: testutils.CreateFileReference(1, 3, 3, 20),
+			},
+			expectedRunner: &models.Runner{
+				Type:          utils.GetPtr("vm"),
+				OS:            utils.GetPtr("macos"),
+				FileReference: testutils.CreateFileReference(1, 3, 3, 20),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseRunner(testCase.executor)
+
+			testutils.DeepCompare(t, testCase.expectedRunner, got)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package circleci
+
+import (
+	"fmt"
+	"strings"
+
+	circleciModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci/models"
+	commonModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/common/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	parserUtils "github.com/argonsecurity/pipeline-parser/pkg/parsers/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+// parseSteps parses the steps of a job or a command.
+// Commands that are defined in the config are replaced by their steps.
+func parseSteps(steps []*circleciModels.Step, config *circleciModels.Config, expandedCommands []string) []*models.Step {
+	var parsedSteps []*models.Step
+	for _, step := range steps {
+		if step == nil {
+			continue
+		}
+
+		if step.Run != nil {
+			parsedSteps = append(parsedSteps, parseRunStep(step))
+			continue
+		}
+
+		if command := getCommand(config, step.Name); command != nil {
+			if !utils.SliceContains(expandedCommands, step.Name) {
+				parsedSteps = append(parsedSteps, parseSteps(command.Steps, config, append(expandedCommands, step.Name))...)
+			}
+			continue
+		}
+
+		var parsedStep *models.Step
+		if isOrbReference(step.Name) {
+			parsedStep = parseOrbStep(step.Name, step.FileReference, config.Orbs)
+		} else {
+			parsedStep = &models.Step{
+				Name: utils.GetPtr(step.Name),
+				Type: models.TaskStepType,
+				Task: &models.Task{
+					Name:        utils.GetPtr(step.Name),
+					VersionType: models.None,
+					Type:        models.CITaskType,
+				},
+				FileReference: step.FileReference,
+			}
+		}
+		parsedStep.Task.Inputs = parseStepParameters(step.Parameters)
+		parsedSteps = append(parsedSteps, parsedStep)
+	}
+	return parsedSteps
+}
+
+func parseRunStep(step *circleciModels.Step) *models.Step {
+	return &models.Step{
+		Name:                 step.Run.Name,
+		Type:                 models.ShellStepType,
+		WorkingDirectory:     step.Run.WorkingDirectory,
+		EnvironmentVariables: parseEnvironmentVariables(step.Run.Environment),
+		Shell: &models.Shell{
+			Type:          step.Run.Shell,
+			Script:        utils.GetPtr(step.Run.Command),
+			FileReference: step.Run.FileReference,
+		},
+		FileReference: step.FileReference,
+	}
+}
+
+// parseOrbStep parses a command or a job of an orb, e.g. `node/install-packages`.
+// The orb alias is replaced by the orb reference and the version is taken from the orb declaration.
+func parseOrbStep(name string, fileReference *models.FileReference, orbs *circleciModels.Orbs) *models.Step {
+	task := &models.Task{
+		Name:        utils.GetPtr(name),
+		VersionType: models.None,
+		Type:        models.CITaskType,
+	}
+
+	alias, command, _ := strings.Cut(name, "/")
+	if orbs != nil {
+		if orb, ok := (*orbs)[alias]; ok && orb != nil && orb.Reference != nil {
+			task.Name = utils.GetPtr(fmt.Sprintf("%s/%s", *orb.Reference, command))
+			if orb.Version != nil {
+				task.Version = orb.Version
+				task.VersionType = parserUtils.DetectVersionType(*orb.Version)
+			}
+		}
+	}
+
+	return &models.Step{
+		Name:          utils.GetPtr(name),
+		Type:          models.TaskStepType,
+		Task:          task,
+		FileReference: fileReference,
+	}
+}
+
+func parseStepParameters(parameters *commonModels.Map) []*models.Parameter {
+	if parameters == nil {
+		return nil
+	}
+
+	return utils.Map(parameters.Values, func(entry *commonModels.MapEntry) *models.Parameter {
+		return &models.Parameter{
+			Name:          utils.GetPtr(entry.Key),
+			Value:         entry.Value,
+			FileReference: entry.FileReference,
+		}
+	})
+}
+
+func getCommand(config *circleciModels.Config, name string) *circleciModels.Command {
+	if config.Commands == nil {
+		return nil
+	}
+	return (*config.Commands)[name]
+}
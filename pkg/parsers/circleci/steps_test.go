@@ -0,0 +1,156 @@
+package circleci
+
+import (
+	"testing"
+
+	circleciModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci/models"
+	commonModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/common/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseSteps(t *testing.T) {
+	testCases := []struct {
+		name          string
+		steps         []*circleciModels.Step
+		config        *circleciModels.Config
+		expectedSteps []*models.Step
+	}{
+		{
+			name:          "No steps",
+			steps:         nil,
+			config:        &circleciModels.Config{},
+			expectedSteps: nil,
+		},
+		{
+			name: "Run and built-in steps",
+			steps: []*circleciModels.Step{
+				{
+					Name:          "checkout",
+					FileReference: testutils.CreateFileReference(1, 9, 1, 17),
+				},
+				{
+					Name: "run",
+					Run: &circleciModels.Run{
+						Name:             utils.GetPtr("Test"),
+						Command:          "make test",
+						Shell:            utils.GetPtr("/bin/bash"),
+						WorkingDirectory: utils.GetPtr("src"),
+						Environment: &circleciModels.EnvironmentVariablesRef{
+							EnvironmentVariables: models.EnvironmentVariables{"CI": "true"},
+						},
+						FileReference: testutils.CreateFileReference(3, 11, 7, 20),
+					},
+					FileReference: testutils.CreateFileReference(2, 9, 7, 20),
+				},
+			},
+			config: &circleciModels.Config{},
+			expectedSteps: []*models.Step{
+				{
+					Name: utils.GetPtr("checkout"),
+					Type: models.TaskStepType,
+					Task: &models.Task{
+						Name:        utils.GetPtr("checkout"),
+						VersionType: models.None,
+						Type:        models.CITaskType,
+					},
+					FileReference: testutils.CreateFileReference(1, 9, 1, 17),
+				},
+				{
+					Name:             utils.GetPtr("Test"),
+					Type:             models.ShellStepType,
+					WorkingDirectory: utils.GetPtr("src"),
+					EnvironmentVariables: &models.EnvironmentVariablesRef{
+						EnvironmentVariables: models.EnvironmentVariables{"CI": "true"},
+					},
+					Shell: &models.Shell{
+						Type:          utils.GetPtr("/bin/bash"),
+						Script:        utils.GetPtr("make test"),
+						FileReference: testutils.CreateFileReference(3, 11, 7, 20),
+					},
+					FileReference: testutils.CreateFileReference(2, 9, 7, 20),
+				},
+			},
+		},
+		{
+			name: "Recursive command is expanded once",
+			steps: []*circleciModels.Step{
+				{Name: "loop"},
+			},
+			config: &circleciModels.Config{
+				Commands: &circleciModels.Commands{
+					"loop": {
+						Steps: []*circleciModels.Step{
+							{Name: "run", Run: &circleciModels.Run{Command: "echo loop"}},
+							{Name: "loop"},
+						},
+					},
+				},
+			},
+			expectedSteps: []*models.Step{
+				{
+					Type: models.ShellStepType,
+					Shell: &models.Shell{
+						Script: utils.GetPtr("echo loop"),
+					},
+				},
+			},
+		},
+		{
+			name: "Orb command with parameters",
+			steps: []*circleciModels.Step{
+				{
+					Name: "node/install-packages",
+					Parameters: &commonModels.Map{
+						Values: []*commonModels.MapEntry{
+							{Key: "pkg-manager", Value: "yarn"},
+						},
+					},
+				},
+				{Name: "unknown/command"},
+			},
+			config: &circleciModels.Config{
+				Orbs: &circleciModels.Orbs{
+					"node": {
+						Name:      "node",
+						Reference: utils.GetPtr("circleci/node"),
+						Version:   utils.GetPtr("5.1.0"),
+					},
+				},
+			},
+			expectedSteps: []*models.Step{
+				{
+					Name: utils.GetPtr("node/install-packages"),
+					Type: models.TaskStepType,
+					Task: &models.Task{
+						Name: utils.GetPtr("circleci/node/install-packages"),
+						Inputs: []*models.Parameter{
+							{Name: utils.GetPtr("pkg-manager"), Value: "yarn"},
+						},
+						Version:     utils.GetPtr("5.1.0"),
+						VersionType: models.TagVersion,
+						Type:        models.CITaskType,
+					},
+				},
+				{
+					Name: utils.GetPtr("unknown/command"),
+					Type: models.TaskStepType,
+					Task: &models.Task{
+						Name:        utils.GetPtr("unknown/command"),
+						VersionType: models.None,
+						Type:        models.CITaskType,
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseSteps(testCase.steps, testCase.config, nil)
+
+			testutils.DeepCompare(t, testCase.expectedSteps, got)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package circleci
+
+import (
+	"sort"
+
+	circleciModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+// parseTriggers returns the scheduled triggers of the workflows.
+// Workflows without triggers run on every push.
+func parseTriggers(workflows *circleciModels.Workflows) *models.Triggers {
+	if workflows == nil || len(*workflows) == 0 {
+		return nil
+	}
+
+	workflowNames := utils.GetMapKeys(*workflows)
+	sort.Strings(workflowNames)
+
+	var triggers []*models.Trigger
+	hasPushTrigger := false
+	for _, workflowName := range workflowNames {
+		workflow := (*workflows)[workflowName]
+		if workflow == nil || len(workflow.Triggers) == 0 {
+			hasPushTrigger = true
+			continue
+		}
+
+		for _, trigger := range workflow.Triggers {
+			if trigger == nil || trigger.Schedule == nil {
+				continue
+			}
+
+			parsedTrigger := &models.Trigger{
+				Event:         models.ScheduledEvent,
+				Schedules:     &[]string{trigger.Schedule.Cron},
+				Pipelines:     []string{workflowName},
+				FileReference: trigger.FileReference,
+			}
+			if trigger.Schedule.Filters != nil {
+				parsedTrigger.Branches = parseFilter(trigger.Schedule.Filters.Branches)
+			}
+			triggers = append(triggers, parsedTrigger)
+		}
+	}
+
+	if hasPushTrigger {
+		triggers = append([]*models.Trigger{{Event: models.PushEvent}}, triggers...)
+	}
+
+	return &models.Triggers{
+		Triggers: triggers,
+	}
+}
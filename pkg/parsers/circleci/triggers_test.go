@@ -0,0 +1,77 @@
+package circleci
+
+import (
+	"testing"
+
+	circleciModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/circleci/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+)
+
+func TestParseTriggers(t *testing.T) {
+	testCases := []struct {
+		name             string
+		workflows        *circleciModels.Workflows
+		expectedTriggers *models.Triggers
+	}{
+		{
+			name:             "No workflows",
+			workflows:        nil,
+			expectedTriggers: nil,
+		},
+		{
+			name: "Workflow without triggers",
+			workflows: &circleciModels.Workflows{
+				"main": {},
+			},
+			expectedTriggers: &models.Triggers{
+				Triggers: []*models.Trigger{
+					{Event: models.PushEvent},
+				},
+			},
+		},
+		{
+			name: "Scheduled workflow",
+			workflows: &circleciModels.Workflows{
+				"nightly": {
+					Triggers: []*circleciModels.WorkflowTrigger{
+						{
+							Schedule: &circleciModels.Schedule{
+								Cron: "0 0 * * *",
+								Filters: &circleciModels.Filters{
+									Branches: &circleciModels.Filter{
+										Only:   circleciModels.StringList{"main"},
+										Ignore: circleciModels.StringList{"dev"},
+									},
+								},
+							},
+							FileReference: testutils.CreateFileReference(3, 7, 8, 20),
+						},
+					},
+				},
+			},
+			expectedTriggers: &models.Triggers{
+				Triggers: []*models.Trigger{
+					{
+						Event:     models.ScheduledEvent,
+						Schedules: &[]string{"0 0 * * *"},
+						Pipelines: []string{"nightly"},
+						Branches: &models.Filter{
+							AllowList: []string{"main"},
+							DenyList:  []string{"dev"},
+						},
+						FileReference: testutils.CreateFileReference(3, 7, 8, 20),
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseTriggers(testCase.workflows)
+
+			testutils.DeepCompare(t, testCase.expectedTriggers, got)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	githubModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/github/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+const (
+	uploadArtifactAction   = "actions/upload-artifact"
+	downloadArtifactAction = "actions/download-artifact"
+	cacheAction            = "actions/cache"
+	restoreCacheAction     = "actions/cache/restore"
+	saveCacheAction        = "actions/cache/save"
+
+	defaultArtifactName = "artifact"
+)
+
+// parseStepArtifacts returns the artifacts and caches produced and consumed by an action step
+func parseStepArtifacts(actionName string, with *githubModels.With, fileReference *models.FileReference) ([]*models.Artifact, []*models.Artifact) {
+	switch strings.ToLower(actionName) {
+	case uploadArtifactAction:
+		artifact := &models.Artifact{
+			Type:          models.FileArtifactType,
+			Name:          utils.GetPtr(defaultArtifactName),
+			Paths:         splitLines(getInput(with, "path")),
+			FileReference: fileReference,
+		}
+		if name := getInput(with, "name"); name != "" {
+			artifact.Name = &name
+		}
+		if retentionDays := getInput(with, "retention-days"); retentionDays != "" {
+			artifact.ExpireIn = utils.GetPtr(fmt.Sprintf("%s days", retentionDays))
+		}
+		return []*models.Artifact{artifact}, nil
+	case downloadArtifactAction:
+		return nil, []*models.Artifact{{
+			Type:          models.FileArtifactType,
+			Name:          utils.GetPtrOrNil(getInput(with, "name")),
+			Paths:         splitLines(getInput(with, "path")),
+			FileReference: fileReference,
+		}}
+	case cacheAction, restoreCacheAction, saveCacheAction:
+		cache := &models.Artifact{
+			Type:          models.CacheArtifactType,
+			Name:          utils.GetPtrOrNil(getInput(with, "key")),
+			Paths:         splitLines(getInput(with, "path")),
+			FileReference: fileReference,
+		}
+		switch strings.ToLower(actionName) {
+		case restoreCacheAction:
+			return nil, []*models.Artifact{cache}
+		case saveCacheAction:
+			return []*models.Artifact{cache}, nil
+		}
+		return []*models.Artifact{cache}, []*models.Artifact{cache}
+	}
+	return nil, nil
+}
+
+func getInput(with *githubModels.With, name string) string {
+	if with == nil {
+		return ""
+	}
+	for _, entry := range with.Values {
+		if entry != nil && entry.Key == name && entry.Value != nil {
+			return fmt.Sprint(entry.Value)
+		}
+	}
+	return ""
+}
+
+func splitLines(value string) []string {
+	var lines []string
+	for _, line := range strings.Split(value, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,143 @@
+package github
+
+import (
+	"testing"
+
+	loadersCommonModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/common/models"
+	githubModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/github/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func createWith(values map[string]any) *githubModels.With {
+	with := &githubModels.With{}
+	for _, key := range []string{"name", "path", "key", "retention-days"} {
+		if value, ok := values[key]; ok {
+			with.Values = append(with.Values, &loadersCommonModels.MapEntry{Key: key, Value: value})
+		}
+	}
+	return with
+}
+
+func TestParseStepArtifacts(t *testing.T) {
+	fileReference := testutils.CreateFileReference(1, 2, 3, 4)
+	testCases := []struct {
+		name             string
+		actionName       string
+		with             *githubModels.With
+		expectedProduces []*models.Artifact
+		expectedConsumes []*models.Artifact
+	}{
+		{
+			name:       "Unrelated action",
+			actionName: "actions/checkout",
+			with:       createWith(map[string]any{"path": "src"}),
+		},
+		{
+			name:       "Upload artifact with defaults",
+			actionName: "actions/upload-artifact",
+			with:       createWith(map[string]any{"path": "dist/"}),
+			expectedProduces: []*models.Artifact{
+				{
+					Type:          models.FileArtifactType,
+					Name:          utils.GetPtr("artifact"),
+					Paths:         []string{"dist/"},
+					FileReference: fileReference,
+				},
+			},
+		},
+		{
+			name:       "Upload artifact with name, multiple paths and retention",
+			actionName: "actions/upload-artifact",
+			with:       createWith(map[string]any{"name": "binaries", "path": "bin/\n  out/*.so\n", "retention-days": 5}),
+			expectedProduces: []*models.Artifact{
+				{
+					Type:          models.FileArtifactType,
+					Name:          utils.GetPtr("binaries"),
+					Paths:         []string{"bin/", "out/*.so"},
+					ExpireIn:      utils.GetPtr("5 days"),
+					FileReference: fileReference,
+				},
+			},
+		},
+		{
+			name:       "Download all artifacts",
+			actionName: "actions/download-artifact",
+			with:       nil,
+			expectedConsumes: []*models.Artifact{
+				{
+					Type:          models.FileArtifactType,
+					FileReference: fileReference,
+				},
+			},
+		},
+		{
+			name:       "Download named artifact",
+			actionName: "actions/download-artifact",
+			with:       createWith(map[string]any{"name": "binaries", "path": "bin"}),
+			expectedConsumes: []*models.Artifact{
+				{
+					Type:          models.FileArtifactType,
+					Name:          utils.GetPtr("binaries"),
+					Paths:         []string{"bin"},
+					FileReference: fileReference,
+				},
+			},
+		},
+		{
+			name:       "Cache is restored and saved",
+			actionName: "actions/cache",
+			with:       createWith(map[string]any{"key": "npm-${{ hashFiles('package-lock.json') }}", "path": "~/.npm"}),
+			expectedProduces: []*models.Artifact{
+				{
+					Type:          models.CacheArtifactType,
+					Name:          utils.GetPtr("npm-${{ hashFiles('package-lock.json') }}"),
+					Paths:         []string{"~/.npm"},
+					FileReference: fileReference,
+				},
+			},
+			expectedConsumes: []*models.Artifact{
+				{
+					Type:          models.CacheArtifactType,
+					Name:          utils.GetPtr("npm-${{ hashFiles('package-lock.json') }}"),
+					Paths:         []string{"~/.npm"},
+					FileReference: fileReference,
+				},
+			},
+		},
+		{
+			name:       "Cache restore",
+			actionName: "actions/cache/restore",
+			with:       createWith(map[string]any{"key": "deps"}),
+			expectedConsumes: []*models.Artifact{
+				{
+					Type:          models.CacheArtifactType,
+					Name:          utils.GetPtr("deps"),
+					FileReference: fileReference,
+				},
+			},
+		},
+		{
+			name:       "Cache save",
+			actionName: "actions/cache/save",
+			with:       createWith(map[string]any{"key": "deps"}),
+			expectedProduces: []*models.Artifact{
+				{
+					Type:          models.CacheArtifactType,
+					Name:          utils.GetPtr("deps"),
+					FileReference: fileReference,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			produces, consumes := parseStepArtifacts(testCase.actionName, testCase.with, fileReference)
+
+			testutils.DeepCompare(t, testCase.expectedProduces, produces)
+			testutils.DeepCompare(t, testCase.expectedConsumes, consumes)
+		})
+	}
+}
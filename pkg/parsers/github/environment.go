@@ -0,0 +1,19 @@
+package github
+
+import (
+	githubModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/github/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func parseEnvironment(environment *githubModels.Environment) *models.Environment {
+	if environment == nil || environment.Name == "" {
+		return nil
+	}
+
+	return &models.Environment{
+		Name:          &environment.Name,
+		URL:           utils.GetPtrOrNil(environment.Url),
+		FileReference: environment.FileReference,
+	}
+}
@@ -0,0 +1,61 @@
+package github
+
+import (
+	"testing"
+
+	githubModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/github/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseEnvironment(t *testing.T) {
+	testCases := []struct {
+		name                string
+		environment         *githubModels.Environment
+		expectedEnvironment *models.Environment
+	}{
+		{
+			name:                "Environment is nil",
+			environment:         nil,
+			expectedEnvironment: nil,
+		},
+		{
+			name:                "Environment without name",
+			environment:         &githubModels.Environment{Url: "https://example.com"},
+			expectedEnvironment: nil,
+		},
+		{
+			name: "Environment with name only",
+			environment: &githubModels.Environment{
+				Name:          "production",
+				FileReference: testutils.CreateFileReference(5, 18, 5, 28),
+			},
+			expectedEnvironment: &models.Environment{
+				Name:          utils.GetPtr("production"),
+				FileReference: testutils.CreateFileReference(5, 18, 5, 28),
+			},
+		},
+		{
+			name: "Environment with name and url",
+			environment: &githubModels.Environment{
+				Name:          "staging",
+				Url:           "${{ steps.deploy.outputs.url }}",
+				FileReference: testutils.CreateFileReference(5, 7, 6, 43),
+			},
+			expectedEnvironment: &models.Environment{
+				Name:          utils.GetPtr("staging"),
+				URL:           utils.GetPtr("${{ steps.deploy.outputs.url }}"),
+				FileReference: testutils.CreateFileReference(5, 7, 6, 43),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseEnvironment(testCase.environment)
+
+			testutils.DeepCompare(t, testCase.expectedEnvironment, got)
+		})
+	}
+}
@@ -64,12 +64,29 @@ func parseCIJob(jobName string, job *githubModels.Job) (*models.Job, error) {
 
 	if job.Steps != nil {
 		parsedJob.Steps = parseJobSteps(job.Steps)
+		parsedJob.Produces, parsedJob.Consumes = parserUtils.CollectStepsArtifacts(parsedJob.Steps)
+	}
+
+	if job.Outputs != nil {
+		parsedJob.Outputs = parseJobOutputs(job.Outputs)
 	}
 
 	if job.RunsOn != nil {
 		parsedJob.Runner = parseRunsOnToRunner(job.RunsOn)
 	}
 
+	if job.Container != nil {
+		parsedJob.Runner = parseContainerToRunner(job.Container, parsedJob.Runner)
+	}
+
+	if job.Services != nil {
+		parsedJob.Services = parseServices(job.Services)
+	}
+
+	if job.Environment != nil {
+		parsedJob.Environment = parseEnvironment(job.Environment)
+	}
+
 	if job.Needs != nil {
 		parsedJob.Dependencies = parseDependencies(job.Needs)
 	}
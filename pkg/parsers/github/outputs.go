@@ -0,0 +1,57 @@
+package github
+
+import (
+	"regexp"
+	"strings"
+
+	githubModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/github/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+var (
+	githubOutputRegex    = regexp.MustCompile(`(?:echo|printf)\s+(?:-\w+\s+)*["']?([A-Za-z_][\w-]*)(?:=|<<)`)
+	githubSetOutputRegex = regexp.MustCompile(`::set-output\s+name=([\w-]+)::`)
+)
+
+func parseJobOutputs(outputs *githubModels.JobOutputs) []*models.Output {
+	if outputs == nil {
+		return nil
+	}
+
+	return utils.Map(*outputs, func(output *githubModels.JobOutput) *models.Output {
+		return &models.Output{
+			Name:          utils.GetPtr(output.Name),
+			Value:         utils.GetPtr(output.Value),
+			FileReference: output.FileReference,
+		}
+	})
+}
+
+// parseScriptOutputs returns the outputs a run script sets, either by writing to $GITHUB_OUTPUT or with the deprecated set-output command
+func parseScriptOutputs(script string, fileReference *models.FileReference) []*models.Output {
+	var outputs []*models.Output
+	names := map[string]bool{}
+	addOutput := func(name string) {
+		if names[name] {
+			return
+		}
+		names[name] = true
+		outputs = append(outputs, &models.Output{
+			Name:          utils.GetPtr(name),
+			FileReference: fileReference,
+		})
+	}
+
+	for _, line := range strings.Split(script, "\n") {
+		if strings.Contains(line, "GITHUB_OUTPUT") {
+			for _, match := range githubOutputRegex.FindAllStringSubmatch(line, -1) {
+				addOutput(match[1])
+			}
+		}
+		for _, match := range githubSetOutputRegex.FindAllStringSubmatch(line, -1) {
+			addOutput(match[1])
+		}
+	}
+	return outputs
+}
@@ -0,0 +1,97 @@
+package github
+
+import (
+	"testing"
+
+	githubModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/github/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseJobOutputs(t *testing.T) {
+	testCases := []struct {
+		name            string
+		outputs         *githubModels.JobOutputs
+		expectedOutputs []*models.Output
+	}{
+		{
+			name:            "Outputs are nil",
+			outputs:         nil,
+			expectedOutputs: nil,
+		},
+		{
+			name: "Outputs keep their order",
+			outputs: &githubModels.JobOutputs{
+				{
+					Name:          "version",
+					Value:         "${{ steps.version.outputs.version }}",
+					FileReference: testutils.CreateFileReference(1, 2, 1, 50),
+				},
+				{
+					Name:          "digest",
+					Value:         "${{ steps.build.outputs.digest }}",
+					FileReference: testutils.CreateFileReference(2, 2, 2, 46),
+				},
+			},
+			expectedOutputs: []*models.Output{
+				{
+					Name:          utils.GetPtr("version"),
+					Value:         utils.GetPtr("${{ steps.version.outputs.version }}"),
+					FileReference: testutils.CreateFileReference(1, 2, 1, 50),
+				},
+				{
+					Name:          utils.GetPtr("digest"),
+					Value:         utils.GetPtr("${{ steps.build.outputs.digest }}"),
+					FileReference: testutils.CreateFileReference(2, 2, 2, 46),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseJobOutputs(testCase.outputs)
+
+			testutils.DeepCompare(t, testCase.expectedOutputs, got)
+		})
+	}
+}
+
+func TestParseScriptOutputs(t *testing.T) {
+	fileReference := testutils.CreateFileReference(1, 2, 3, 4)
+
+	testCases := []struct {
+		name            string
+		script          string
+		expectedOutputs []*models.Output
+	}{
+		{
+			name:            "Script without outputs",
+			script:          "echo version=1.0.0",
+			expectedOutputs: nil,
+		},
+		{
+			name: "GITHUB_OUTPUT and set-output",
+			script: `echo "version=1.0.0" >> $GITHUB_OUTPUT
+echo 'digest=sha256' >> "$GITHUB_OUTPUT"
+echo "notes<<EOF" >> ${GITHUB_OUTPUT}
+echo "version=2.0.0" >> $GITHUB_OUTPUT
+echo "::set-output name=legacy::value"`,
+			expectedOutputs: []*models.Output{
+				{Name: utils.GetPtr("version"), FileReference: fileReference},
+				{Name: utils.GetPtr("digest"), FileReference: fileReference},
+				{Name: utils.GetPtr("notes"), FileReference: fileReference},
+				{Name: utils.GetPtr("legacy"), FileReference: fileReference},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseScriptOutputs(testCase.script, fileReference)
+
+			testutils.DeepCompare(t, testCase.expectedOutputs, got)
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package github
 
 import (
+	"regexp"
+
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	parserUtils "github.com/argonsecurity/pipeline-parser/pkg/parsers/utils"
 
 	githubModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/github/models"
 )
 
+var githubSecretRegex = regexp.MustCompile(`^\$\{\{\s*secrets\.([\w-]+)\s*\}\}$`)
+
 func parseRunsOnToRunner(runsOn *githubModels.RunsOn) *models.Runner {
 	if runsOn == nil {
 		return nil
@@ -20,3 +25,33 @@ func parseRunsOnToRunner(runsOn *githubModels.RunsOn) *models.Runner {
 	}
 	return runner
 }
+
+// parseContainerToRunner sets the image of the container the job's steps run in, creating the runner if the job has no runs-on
+func parseContainerToRunner(container *githubModels.Container, runner *models.Runner) *models.Runner {
+	if container == nil || container.Image == "" {
+		return runner
+	}
+
+	if runner == nil {
+		runner = &models.Runner{FileReference: container.FileReference}
+	}
+	runner.DockerMetadata = parseContainerDockerMetadata(container)
+	return runner
+}
+
+func parseContainerDockerMetadata(container *githubModels.Container) *models.DockerMetadata {
+	dockerMetadata := parserUtils.ParseDockerMetadata(container.Image)
+	if dockerMetadata != nil && container.Credentials != nil {
+		dockerMetadata.RegistryCredentialsID = getSecretName(container.Credentials.Password)
+	}
+	return dockerMetadata
+}
+
+// getSecretName returns the name of the secret a value references, e.g. X for ${{ secrets.X }}.
+// Values that are not a secret reference return nil, so literal passwords never reach the output.
+func getSecretName(value string) *string {
+	if match := githubSecretRegex.FindStringSubmatch(value); match != nil {
+		return &match[1]
+	}
+	return nil
+}
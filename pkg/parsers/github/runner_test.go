@@ -73,3 +73,80 @@ func TestParseRunsOnToRunner(t *testing.T) {
 		})
 	}
 }
+
+func TestParseContainerToRunner(t *testing.T) {
+	testCases := []struct {
+		name           string
+		container      *githubModels.Container
+		runner         *models.Runner
+		expectedRunner *models.Runner
+	}{
+		{
+			name:           "Container is nil",
+			container:      nil,
+			runner:         &models.Runner{OS: utils.GetPtr("linux")},
+			expectedRunner: &models.Runner{OS: utils.GetPtr("linux")},
+		},
+		{
+			name: "Container with runs-on",
+			container: &githubModels.Container{
+				Image: "node:18",
+			},
+			runner: &models.Runner{OS: utils.GetPtr("linux")},
+			expectedRunner: &models.Runner{
+				OS: utils.GetPtr("linux"),
+				DockerMetadata: &models.DockerMetadata{
+					Image: utils.GetPtr("node"),
+					Label: utils.GetPtr("18"),
+				},
+			},
+		},
+		{
+			name: "Container without runs-on",
+			container: &githubModels.Container{
+				Image: "registry.example.com/team/app:1.0",
+				Credentials: &githubModels.Credentials{
+					Username: "user",
+					Password: "${{ secrets.REGISTRY_PASSWORD }}",
+				},
+				FileReference: testutils.CreateFileReference(3, 5, 6, 10),
+			},
+			runner: nil,
+			expectedRunner: &models.Runner{
+				DockerMetadata: &models.DockerMetadata{
+					Image:                 utils.GetPtr("team/app"),
+					Label:                 utils.GetPtr("1.0"),
+					RegistryURL:           utils.GetPtr("registry.example.com"),
+					RegistryCredentialsID: utils.GetPtr("REGISTRY_PASSWORD"),
+				},
+				FileReference: testutils.CreateFileReference(3, 5, 6, 10),
+			},
+		},
+		{
+			name: "Container with a literal password",
+			container: &githubModels.Container{
+				Image: "registry.example.com/team/app:1.0",
+				Credentials: &githubModels.Credentials{
+					Username: "user",
+					Password: "hunter2",
+				},
+			},
+			runner: nil,
+			expectedRunner: &models.Runner{
+				DockerMetadata: &models.DockerMetadata{
+					Image:       utils.GetPtr("team/app"),
+					Label:       utils.GetPtr("1.0"),
+					RegistryURL: utils.GetPtr("registry.example.com"),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseContainerToRunner(testCase.container, testCase.runner)
+
+			testutils.DeepCompare(t, testCase.expectedRunner, got)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package github
+
+import (
+	"fmt"
+	"sort"
+
+	githubModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/github/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func parseServices(services map[string]*githubModels.Container) []*models.Service {
+	names := utils.GetMapKeys(services)
+	sort.Strings(names)
+
+	var parsedServices []*models.Service
+	for _, name := range names {
+		if service := parseService(name, services[name]); service != nil {
+			parsedServices = append(parsedServices, service)
+		}
+	}
+	return parsedServices
+}
+
+func parseService(name string, service *githubModels.Container) *models.Service {
+	if service == nil {
+		return nil
+	}
+
+	parsedService := &models.Service{
+		Name:                 utils.GetPtr(name),
+		DockerMetadata:       parseContainerDockerMetadata(service),
+		Volumes:              service.Volumes,
+		Options:              utils.GetPtrOrNil(service.Options),
+		EnvironmentVariables: parseEnvironmentVariablesRef(service.Env),
+		FileReference:        service.FileReference,
+	}
+
+	for _, port := range service.Ports {
+		parsedService.Ports = append(parsedService.Ports, fmt.Sprint(port))
+	}
+	return parsedService
+}
@@ -0,0 +1,87 @@
+package github
+
+import (
+	"testing"
+
+	githubModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/github/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseServices(t *testing.T) {
+	testCases := []struct {
+		name             string
+		services         map[string]*githubModels.Container
+		expectedServices []*models.Service
+	}{
+		{
+			name:             "Services are nil",
+			services:         nil,
+			expectedServices: nil,
+		},
+		{
+			name: "Services are sorted by name",
+			services: map[string]*githubModels.Container{
+				"redis": {
+					Image:         "redis",
+					FileReference: testutils.CreateFileReference(5, 7, 5, 12),
+				},
+				"postgres": {
+					Image: "ghcr.io/org/postgres:14",
+					Credentials: &githubModels.Credentials{
+						Username: "${{ github.actor }}",
+						Password: "${{ secrets.GITHUB_TOKEN }}",
+					},
+					Env: &githubModels.EnvironmentVariablesRef{
+						EnvironmentVariables: models.EnvironmentVariables{
+							"POSTGRES_PASSWORD": "postgres",
+						},
+						FileReference: testutils.CreateFileReference(3, 9, 3, 35),
+					},
+					Ports:         []interface{}{5432, "8080:80"},
+					Volumes:       []string{"data:/var/lib/postgresql/data"},
+					Options:       "--health-cmd pg_isready",
+					FileReference: testutils.CreateFileReference(1, 7, 4, 20),
+				},
+				"empty": nil,
+			},
+			expectedServices: []*models.Service{
+				{
+					Name: utils.GetPtr("postgres"),
+					DockerMetadata: &models.DockerMetadata{
+						Image:                 utils.GetPtr("org/postgres"),
+						Label:                 utils.GetPtr("14"),
+						RegistryURL:           utils.GetPtr("ghcr.io"),
+						RegistryCredentialsID: utils.GetPtr("GITHUB_TOKEN"),
+					},
+					Ports:   []string{"5432", "8080:80"},
+					Volumes: []string{"data:/var/lib/postgresql/data"},
+					Options: utils.GetPtr("--health-cmd pg_isready"),
+					EnvironmentVariables: &models.EnvironmentVariablesRef{
+						EnvironmentVariables: models.EnvironmentVariables{
+							"POSTGRES_PASSWORD": "postgres",
+						},
+						FileReference: testutils.CreateFileReference(3, 9, 3, 35),
+					},
+					FileReference: testutils.CreateFileReference(1, 7, 4, 20),
+				},
+				{
+					Name: utils.GetPtr("redis"),
+					DockerMetadata: &models.DockerMetadata{
+						Image: utils.GetPtr("redis"),
+					},
+					FileReference: testutils.CreateFileReference(5, 7, 5, 12),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseServices(testCase.services)
+
+			testutils.DeepCompare(t, testCase.expectedServices, got)
+		})
+	}
+}
@@ -60,6 +60,7 @@ func parseJobStep(step githubModels.Step) *models.Step {
 		if step.Shell != "" {
 			parsedStep.Shell.Type = &step.Shell
 		}
+		parsedStep.Outputs = parseScriptOutputs(step.Run.Script, step.Run.FileReference)
 		parsedStep.Type = models.ShellStepType
 	} else if step.Uses != "" {
 		actionName, version, versionType, taskType := parseActionHeader(step.Uses)
@@ -73,6 +74,7 @@ func parseJobStep(step githubModels.Step) *models.Step {
 		if step.With != nil {
 			parsedStep.Task.Inputs = parserUtils.ParseMapToParameters(loadersCommonModels.Map(*step.With))
 		}
+		parsedStep.Produces, parsedStep.Consumes = parseStepArtifacts(actionName, step.With, step.FileReference)
 
 		parsedStep.Type = models.TaskStepType
 	}
@@ -0,0 +1,25 @@
+package common
+
+import (
+	gitlabModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/gitlab/models/common"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	parsersUtils "github.com/argonsecurity/pipeline-parser/pkg/parsers/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func ParseServices(services []*gitlabModels.Service) []*models.Service {
+	var parsedServices []*models.Service
+	for _, service := range services {
+		if service == nil || service.Name == "" {
+			continue
+		}
+
+		parsedServices = append(parsedServices, &models.Service{
+			Name:                 utils.GetPtrOrNil(service.Alias),
+			DockerMetadata:       parsersUtils.ParseDockerMetadata(service.Name),
+			EnvironmentVariables: ParseEnvironmentVariables(service.Variables),
+			FileReference:        service.FileReference,
+		})
+	}
+	return parsedServices
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"testing"
+
+	gitlabModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/gitlab/models/common"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseServices(t *testing.T) {
+	testCases := []struct {
+		name             string
+		services         []*gitlabModels.Service
+		expectedServices []*models.Service
+	}{
+		{
+			name:             "Services are nil",
+			services:         nil,
+			expectedServices: nil,
+		},
+		{
+			name: "Services with and without alias",
+			services: []*gitlabModels.Service{
+				{
+					Name:          "docker:20.10.12-dind",
+					FileReference: testutils.CreateFileReference(3, 7, 3, 27),
+				},
+				{
+					Name:  "registry.example.com/team/postgres:14",
+					Alias: "db",
+					Variables: &gitlabModels.EnvironmentVariablesRef{
+						Variables: &gitlabModels.Variables{
+							"POSTGRES_DB": "test",
+						},
+						FileReference: testutils.CreateFileReference(6, 1, 7, 30),
+					},
+					FileReference: testutils.CreateFileReference(4, 9, 7, 30),
+				},
+				nil,
+				{},
+			},
+			expectedServices: []*models.Service{
+				{
+					DockerMetadata: &models.DockerMetadata{
+						Image: utils.GetPtr("docker"),
+						Label: utils.GetPtr("20.10.12-dind"),
+					},
+					FileReference: testutils.CreateFileReference(3, 7, 3, 27),
+				},
+				{
+					Name: utils.GetPtr("db"),
+					DockerMetadata: &models.DockerMetadata{
+						Image:       utils.GetPtr("team/postgres"),
+						Label:       utils.GetPtr("14"),
+						RegistryURL: utils.GetPtr("registry.example.com"),
+					},
+					EnvironmentVariables: &models.EnvironmentVariablesRef{
+						EnvironmentVariables: models.EnvironmentVariables{
+							"POSTGRES_DB": "test",
+						},
+						FileReference: testutils.CreateFileReference(6, 1, 7, 30),
+					},
+					FileReference: testutils.CreateFileReference(4, 9, 7, 30),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := ParseServices(testCase.services)
+
+			testutils.DeepCompare(t, testCase.expectedServices, got)
+		})
+	}
+}
@@ -26,6 +26,7 @@ func (g *GitLabParser) Parse(gitlabCIConfiguration *gitlabModels.GitlabCIConfigu
 	if err != nil {
 		return nil, err
 	}
+	pipeline.Stages = parseStages(gitlabCIConfiguration.Stages, pipeline.Jobs)
 
 	if len(gitlabCIConfiguration.Jobs) > 0 {
 		_, err := utils.MapToSliceErr(gitlabCIConfiguration.Jobs, func(jobID string, job *gitlabModels.Job) ([]*models.Import, error) {
@@ -57,6 +58,7 @@ func parseDefaults(gitlabCIConfiguration *gitlabModels.GitlabCIConfiguration) *m
 	defaults := &models.Defaults{
 		EnvironmentVariables: common.ParseEnvironmentVariables(gitlabCIConfiguration.Variables),
 		Runner:               common.ParseRunner(gitlabCIConfiguration.Image),
+		Services:             parseDefaultServices(gitlabCIConfiguration),
 		PostSteps:            common.ParseScript(gitlabCIConfiguration.AfterScript),
 		PreSteps:             common.ParseScript(gitlabCIConfiguration.BeforeScript),
 		Scans:                parseScans(gitlabCIConfiguration),
@@ -64,6 +66,13 @@ func parseDefaults(gitlabCIConfiguration *gitlabModels.GitlabCIConfiguration) *m
 	return defaults
 }
 
+func parseDefaultServices(gitlabCIConfiguration *gitlabModels.GitlabCIConfiguration) []*models.Service {
+	if gitlabCIConfiguration.Default != nil && gitlabCIConfiguration.Default.Services != nil {
+		return common.ParseServices(gitlabCIConfiguration.Default.Services)
+	}
+	return common.ParseServices(gitlabCIConfiguration.Services)
+}
+
 func appendJobTriggerIncludes(job *gitlabModels.Job, imports *[]*models.Import) ([]*models.Import, error) {
 	if job.Trigger != nil && job.Trigger.Include != nil {
 		if jobImport := ParseImports(job.Trigger.Include); jobImport != nil {
@@ -0,0 +1,178 @@
+package job
+
+import (
+	"fmt"
+	"sort"
+
+	gitlabModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/gitlab/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/loaders/gitlab/models/common"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+const (
+	defaultCacheKey = "default"
+
+	pullCachePolicy = "pull"
+	pushCachePolicy = "push"
+)
+
+// parseArtifacts sets the artifacts and caches a job produces and consumes, including the ones it inherits from the jobs it extends
+func parseArtifacts(jobID string, job *models.Job, gitlabJobs map[string]*gitlabModels.Job) {
+	jobIDs := append(append([]string{}, job.Extends...), jobID)
+	inherited := getInheritedArtifactKeywords(jobIDs, gitlabJobs)
+	job.Produces = parseProducedArtifacts(inherited)
+	job.Consumes = parseConsumedArtifacts(inherited)
+}
+
+// getInheritedArtifactKeywords merges the artifact related keywords of the jobs in their merge order, where later jobs override earlier ones
+func getInheritedArtifactKeywords(jobIDs []string, gitlabJobs map[string]*gitlabModels.Job) *gitlabModels.Job {
+	inherited := &gitlabModels.Job{}
+	for _, jobID := range jobIDs {
+		job, ok := gitlabJobs[jobID]
+		if !ok || job == nil {
+			continue
+		}
+		if job.Artifacts != nil {
+			inherited.Artifacts = job.Artifacts
+		}
+		if job.Cache != nil {
+			inherited.Cache = job.Cache
+		}
+		if job.Dependencies != nil {
+			inherited.Dependencies = job.Dependencies
+		}
+		if job.Needs != nil {
+			inherited.Needs = job.Needs
+		}
+	}
+	return inherited
+}
+
+func parseProducedArtifacts(job *gitlabModels.Job) []*models.Artifact {
+	var artifacts []*models.Artifact
+	if job.Artifacts != nil {
+		if len(job.Artifacts.Paths) > 0 || job.Artifacts.Untracked {
+			artifacts = append(artifacts, &models.Artifact{
+				Type:          models.FileArtifactType,
+				Name:          utils.GetPtrOrNil(job.Artifacts.Name),
+				Paths:         job.Artifacts.Paths,
+				ExpireIn:      utils.GetPtrOrNil(job.Artifacts.ExpireIn),
+				FileReference: job.Artifacts.FileReference,
+			})
+		}
+		artifacts = append(artifacts, parseReports(job.Artifacts)...)
+	}
+
+	if job.Cache != nil && job.Cache.Policy != pullCachePolicy {
+		artifacts = append(artifacts, parseCache(job.Cache))
+	}
+	return artifacts
+}
+
+// parseReports returns an artifact for every report of the job, named after the report type
+func parseReports(artifacts *gitlabModels.Artifacts) []*models.Artifact {
+	if artifacts.Reports == nil {
+		return nil
+	}
+
+	reports := map[string]any{
+		"codequality":         artifacts.Reports.Codequality,
+		"container_scanning":  artifacts.Reports.ContainerScanning,
+		"dast":                artifacts.Reports.Dast,
+		"dependency_scanning": artifacts.Reports.DependencyScanning,
+		"dotenv":              artifacts.Reports.Dotenv,
+		"junit":               artifacts.Reports.Junit,
+		"license_management":  artifacts.Reports.LicenseManagement,
+		"license_scanning":    artifacts.Reports.LicenseScanning,
+		"lsif":                artifacts.Reports.Lsif,
+		"metrics":             artifacts.Reports.Metrics,
+		"performance":         artifacts.Reports.Performance,
+		"requirements":        artifacts.Reports.Requirements,
+		"sast":                artifacts.Reports.Sast,
+		"secret_detection":    artifacts.Reports.SecretDetection,
+		"terraform":           artifacts.Reports.Terraform,
+	}
+	if artifacts.Reports.CoverageReport != nil {
+		reports["coverage_report"] = artifacts.Reports.CoverageReport.Path
+	}
+
+	reportTypes := utils.GetMapKeys(reports)
+	sort.Strings(reportTypes)
+
+	var parsedReports []*models.Artifact
+	for _, reportType := range reportTypes {
+		paths := getReportPaths(reports[reportType])
+		if len(paths) == 0 {
+			continue
+		}
+		parsedReports = append(parsedReports, &models.Artifact{
+			Type:          models.FileArtifactType,
+			Name:          utils.GetPtr(reportType),
+			Paths:         paths,
+			ExpireIn:      utils.GetPtrOrNil(artifacts.ExpireIn),
+			FileReference: artifacts.FileReference,
+		})
+	}
+	return parsedReports
+}
+
+func getReportPaths(report any) []string {
+	switch report := report.(type) {
+	case string:
+		if report != "" {
+			return []string{report}
+		}
+	case []any:
+		return utils.Map(report, func(path any) string {
+			return fmt.Sprint(path)
+		})
+	}
+	return nil
+}
+
+// parseConsumedArtifacts returns the artifacts of the jobs listed in dependencies or needs, or of all the jobs of previous stages when neither is set
+func parseConsumedArtifacts(job *gitlabModels.Job) []*models.Artifact {
+	var artifacts []*models.Artifact
+	if job.Dependencies != nil {
+		for _, dependency := range job.Dependencies {
+			artifacts = append(artifacts, &models.Artifact{
+				Type:  models.FileArtifactType,
+				JobID: utils.GetPtr(dependency),
+			})
+		}
+	} else if job.Needs != nil {
+		for _, need := range *job.Needs {
+			if need == nil || need.Job == "" || !need.Artifacts || need.Pipeline != "" || need.Project != "" {
+				continue
+			}
+			artifacts = append(artifacts, &models.Artifact{
+				Type:  models.FileArtifactType,
+				JobID: utils.GetPtr(need.Job),
+			})
+		}
+	} else {
+		artifacts = append(artifacts, &models.Artifact{Type: models.FileArtifactType})
+	}
+
+	if job.Cache != nil && job.Cache.Policy != pushCachePolicy {
+		artifacts = append(artifacts, parseCache(job.Cache))
+	}
+	return artifacts
+}
+
+func parseCache(cache *common.Cache) *models.Artifact {
+	artifact := &models.Artifact{
+		Type:          models.CacheArtifactType,
+		Paths:         cache.Paths,
+		FileReference: cache.FileReference,
+	}
+
+	switch key := cache.Key.(type) {
+	case nil:
+		artifact.Name = utils.GetPtr(defaultCacheKey)
+	case string:
+		artifact.Name = utils.GetPtr(key)
+	}
+	return artifact
+}
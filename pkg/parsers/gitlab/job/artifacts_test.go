@@ -0,0 +1,144 @@
+package job
+
+import (
+	"testing"
+
+	gitlabModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/gitlab/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/loaders/gitlab/models/common"
+	"github.com/argonsecurity/pipeline-parser/pkg/loaders/gitlab/models/job"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseArtifacts(t *testing.T) {
+	testCases := []struct {
+		name             string
+		jobID            string
+		job              *models.Job
+		gitlabJobs       map[string]*gitlabModels.Job
+		expectedProduces []*models.Artifact
+		expectedConsumes []*models.Artifact
+	}{
+		{
+			name:       "Job without artifacts consumes the artifacts of previous stages",
+			jobID:      "test",
+			job:        &models.Job{},
+			gitlabJobs: map[string]*gitlabModels.Job{"test": {}},
+			expectedConsumes: []*models.Artifact{
+				{Type: models.FileArtifactType},
+			},
+		},
+		{
+			name:  "Job with artifacts, reports and cache",
+			jobID: "build",
+			job:   &models.Job{},
+			gitlabJobs: map[string]*gitlabModels.Job{
+				"build": {
+					Artifacts: &gitlabModels.Artifacts{
+						Name:     "dist",
+						Paths:    []string{"dist/"},
+						ExpireIn: "1 week",
+						Reports: &gitlabModels.Reports{
+							Junit:  []any{"report.xml", "other.xml"},
+							Dotenv: "build.env",
+						},
+						FileReference: testutils.CreateFileReference(3, 3, 9, 20),
+					},
+					Cache: &common.Cache{
+						Key:           "deps",
+						Paths:         []string{"node_modules/"},
+						FileReference: testutils.CreateFileReference(10, 3, 13, 20),
+					},
+					Needs: &job.Needs{},
+				},
+			},
+			expectedProduces: []*models.Artifact{
+				{
+					Type:          models.FileArtifactType,
+					Name:          utils.GetPtr("dist"),
+					Paths:         []string{"dist/"},
+					ExpireIn:      utils.GetPtr("1 week"),
+					FileReference: testutils.CreateFileReference(3, 3, 9, 20),
+				},
+				{
+					Type:          models.FileArtifactType,
+					Name:          utils.GetPtr("dotenv"),
+					Paths:         []string{"build.env"},
+					ExpireIn:      utils.GetPtr("1 week"),
+					FileReference: testutils.CreateFileReference(3, 3, 9, 20),
+				},
+				{
+					Type:          models.FileArtifactType,
+					Name:          utils.GetPtr("junit"),
+					Paths:         []string{"report.xml", "other.xml"},
+					ExpireIn:      utils.GetPtr("1 week"),
+					FileReference: testutils.CreateFileReference(3, 3, 9, 20),
+				},
+				{
+					Type:          models.CacheArtifactType,
+					Name:          utils.GetPtr("deps"),
+					Paths:         []string{"node_modules/"},
+					FileReference: testutils.CreateFileReference(10, 3, 13, 20),
+				},
+			},
+			expectedConsumes: []*models.Artifact{
+				{
+					Type:          models.CacheArtifactType,
+					Name:          utils.GetPtr("deps"),
+					Paths:         []string{"node_modules/"},
+					FileReference: testutils.CreateFileReference(10, 3, 13, 20),
+				},
+			},
+		},
+		{
+			name:  "Job consumes the artifacts of its needs",
+			jobID: "deploy",
+			job:   &models.Job{},
+			gitlabJobs: map[string]*gitlabModels.Job{
+				"deploy": {
+					Needs: &job.Needs{
+						{Job: "build", Artifacts: true},
+						{Job: "lint", Artifacts: false},
+						{Job: "upstream", Pipeline: "other", Artifacts: true},
+					},
+					Cache: &common.Cache{Policy: "pull"},
+				},
+			},
+			expectedConsumes: []*models.Artifact{
+				{Type: models.FileArtifactType, JobID: utils.GetPtr("build")},
+				{Type: models.CacheArtifactType, Name: utils.GetPtr("default")},
+			},
+		},
+		{
+			name:  "Job inherits dependencies and cache from the jobs it extends",
+			jobID: "deploy",
+			job:   &models.Job{Extends: []string{".base", ".cache"}},
+			gitlabJobs: map[string]*gitlabModels.Job{
+				".base": {
+					Dependencies: []string{"build"},
+					Cache:        &common.Cache{Key: "base"},
+				},
+				".cache": {
+					Cache: &common.Cache{Key: map[string]any{"files": []any{"go.sum"}}, Policy: "push"},
+				},
+				"deploy": {},
+			},
+			expectedProduces: []*models.Artifact{
+				{Type: models.CacheArtifactType},
+			},
+			expectedConsumes: []*models.Artifact{
+				{Type: models.FileArtifactType, JobID: utils.GetPtr("build")},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			parseArtifacts(testCase.jobID, testCase.job, testCase.gitlabJobs)
+
+			testutils.DeepCompare(t, testCase.expectedProduces, testCase.job.Produces)
+			testutils.DeepCompare(t, testCase.expectedConsumes, testCase.job.Consumes)
+		})
+	}
+}
@@ -17,7 +17,8 @@ func parseJobDependencies(job *gitlabModels.Job) []*models.JobDependency {
 	if job.Dependencies != nil {
 		dependencies = append(dependencies, utils.Map(job.Dependencies, func(dependency string) *models.JobDependency {
 			return &models.JobDependency{
-				JobID: &dependency,
+				JobID:         &dependency,
+				ArtifactsOnly: true,
 			}
 		})...)
 	}
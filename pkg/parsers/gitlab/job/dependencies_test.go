@@ -28,13 +28,16 @@ func TestParseDependencies(t *testing.T) {
 			},
 			expectedJobDependencies: []*models.JobDependency{
 				{
-					JobID: utils.GetPtr("job-1"),
+					JobID:         utils.GetPtr("job-1"),
+					ArtifactsOnly: true,
 				},
 				{
-					JobID: utils.GetPtr("job-2"),
+					JobID:         utils.GetPtr("job-2"),
+					ArtifactsOnly: true,
 				},
 				{
-					JobID: utils.GetPtr("job-3"),
+					JobID:         utils.GetPtr("job-3"),
+					ArtifactsOnly: true,
 				},
 			},
 		},
@@ -80,13 +83,16 @@ func TestParseDependencies(t *testing.T) {
 			},
 			expectedJobDependencies: []*models.JobDependency{
 				{
-					JobID: utils.GetPtr("job-1"),
+					JobID:         utils.GetPtr("job-1"),
+					ArtifactsOnly: true,
 				},
 				{
-					JobID: utils.GetPtr("job-2"),
+					JobID:         utils.GetPtr("job-2"),
+					ArtifactsOnly: true,
 				},
 				{
-					JobID: utils.GetPtr("job-3"),
+					JobID:         utils.GetPtr("job-3"),
+					ArtifactsOnly: true,
 				},
 				{
 					JobID:    utils.GetPtr("job-1"),
@@ -127,7 +133,8 @@ func TestParseJobDependencies(t *testing.T) {
 			},
 			expectedJobDependencies: []*models.JobDependency{
 				{
-					JobID: utils.GetPtr("job-1"),
+					JobID:         utils.GetPtr("job-1"),
+					ArtifactsOnly: true,
 				},
 			},
 		},
@@ -138,13 +145,16 @@ func TestParseJobDependencies(t *testing.T) {
 			},
 			expectedJobDependencies: []*models.JobDependency{
 				{
-					JobID: utils.GetPtr("job-1"),
+					JobID:         utils.GetPtr("job-1"),
+					ArtifactsOnly: true,
 				},
 				{
-					JobID: utils.GetPtr("job-2"),
+					JobID:         utils.GetPtr("job-2"),
+					ArtifactsOnly: true,
 				},
 				{
-					JobID: utils.GetPtr("job-3"),
+					JobID:         utils.GetPtr("job-3"),
+					ArtifactsOnly: true,
 				},
 			},
 		},
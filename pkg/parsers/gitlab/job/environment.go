@@ -0,0 +1,22 @@
+package job
+
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/loaders/gitlab/models/job"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func parseEnvironment(environment *job.Environment) *models.Environment {
+	if environment == nil || environment.Name == "" {
+		return nil
+	}
+
+	return &models.Environment{
+		Name:          &environment.Name,
+		URL:           utils.GetPtrOrNil(environment.Url),
+		Action:        utils.GetPtrOrNil(environment.Action),
+		OnStop:        utils.GetPtrOrNil(environment.OnStop),
+		Tier:          utils.GetPtrOrNil(environment.DeploymentTier),
+		FileReference: environment.FileReference,
+	}
+}
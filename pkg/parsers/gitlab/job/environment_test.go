@@ -0,0 +1,57 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/loaders/gitlab/models/job"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseEnvironment(t *testing.T) {
+	testCases := []struct {
+		name                string
+		environment         *job.Environment
+		expectedEnvironment *models.Environment
+	}{
+		{
+			name:                "Environment is nil",
+			environment:         nil,
+			expectedEnvironment: nil,
+		},
+		{
+			name:                "Environment without name",
+			environment:         &job.Environment{Url: "https://example.com"},
+			expectedEnvironment: nil,
+		},
+		{
+			name: "Environment with all fields",
+			environment: &job.Environment{
+				Name:           "review/$CI_COMMIT_REF_SLUG",
+				Url:            "https://$CI_ENVIRONMENT_SLUG.example.com",
+				OnStop:         "stop_review",
+				Action:         "start",
+				AutoStopIn:     "1 week",
+				DeploymentTier: "development",
+				FileReference:  testutils.CreateFileReference(5, 3, 11, 32),
+			},
+			expectedEnvironment: &models.Environment{
+				Name:          utils.GetPtr("review/$CI_COMMIT_REF_SLUG"),
+				URL:           utils.GetPtr("https://$CI_ENVIRONMENT_SLUG.example.com"),
+				Action:        utils.GetPtr("start"),
+				OnStop:        utils.GetPtr("stop_review"),
+				Tier:          utils.GetPtr("development"),
+				FileReference: testutils.CreateFileReference(5, 3, 11, 32),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseEnvironment(testCase.environment)
+
+			testutils.DeepCompare(t, testCase.expectedEnvironment, got)
+		})
+	}
+}
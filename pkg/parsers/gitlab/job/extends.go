@@ -0,0 +1,224 @@
+package job
+
+import (
+	gitlabModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/gitlab/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+// resolveExtends merges every job with the jobs it extends, following GitLab's extends semantics.
+// Parents that are not defined in the configuration (e.g. templates pulled in through include) are kept in the job's
+// Extends chain, and the job is left unmerged, so it can be merged with all of its parents in order once the imported
+// pipelines are loaded.
+func resolveExtends(jobs map[string]*models.Job, gitlabJobs map[string]*gitlabModels.Job) {
+	resolved := map[string]bool{}
+	deferred := map[string]bool{}
+	for jobID := range jobs {
+		resolveJobExtends(jobID, jobs, gitlabJobs, resolved, deferred, map[string]bool{})
+	}
+}
+
+func resolveJobExtends(jobID string, jobs map[string]*models.Job, gitlabJobs map[string]*gitlabModels.Job, resolved, deferred, visiting map[string]bool) {
+	if resolved[jobID] || visiting[jobID] {
+		return
+	}
+	visiting[jobID] = true
+	defer func() {
+		resolved[jobID] = true
+	}()
+
+	var chain []string
+	var base *models.Job
+	for _, parentID := range parseExtends(gitlabJobs[jobID]) {
+		parent, ok := jobs[parentID]
+		if !ok || visiting[parentID] {
+			deferred[jobID] = deferred[jobID] || !ok
+			chain = appendToChain(chain, parentID)
+			continue
+		}
+
+		resolveJobExtends(parentID, jobs, gitlabJobs, resolved, deferred, visiting)
+		parent = jobs[parentID]
+		deferred[jobID] = deferred[jobID] || deferred[parentID]
+		chain = appendToChain(appendToChain(chain, parent.Extends...), parentID)
+		base = MergeJobs(base, parent)
+	}
+
+	if len(chain) == 0 {
+		return
+	}
+
+	if deferred[jobID] {
+		jobs[jobID].Extends = chain
+		return
+	}
+	merged := MergeJobs(base, jobs[jobID])
+	merged.Extends = chain
+	jobs[jobID] = merged
+}
+
+// ResolveExternalExtends merges the jobs that extend jobs defined outside of their pipeline with all of their parents,
+// in the order of their Extends chain. findJob returns the external parents, e.g. the jobs of included pipelines.
+func ResolveExternalExtends(jobs []*models.Job, findJob func(jobID string) *models.Job) {
+	indexes := map[string]int{}
+	for i, job := range jobs {
+		if job != nil && job.ID != nil {
+			indexes[*job.ID] = i
+		}
+	}
+
+	resolved := map[string]bool{}
+	var resolve func(jobID string)
+	resolve = func(jobID string) {
+		if resolved[jobID] {
+			return
+		}
+		resolved[jobID] = true
+
+		job := jobs[indexes[jobID]]
+		if !hasExternalParent(job, indexes) {
+			return
+		}
+
+		// the chain holds the ancestors of every parent before it, so merging it in order gives later parents priority
+		var chain []string
+		var base *models.Job
+		for _, parentID := range job.Extends {
+			var parent *models.Job
+			if index, ok := indexes[parentID]; ok {
+				resolve(parentID)
+				parent = jobs[index]
+			} else if parent = findJob(parentID); parent != nil {
+				chain = appendToChain(chain, parent.Extends...)
+			}
+
+			chain = appendToChain(chain, parentID)
+			if parent != nil {
+				base = MergeJobs(base, parent)
+			}
+		}
+
+		merged := MergeJobs(base, job)
+		merged.Extends = chain
+		jobs[indexes[jobID]] = merged
+	}
+
+	for jobID := range indexes {
+		resolve(jobID)
+	}
+}
+
+func hasExternalParent(job *models.Job, indexes map[string]int) bool {
+	for _, parentID := range job.Extends {
+		if _, ok := indexes[parentID]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parseExtends returns the IDs of the jobs declared in the job's extends keyword, in their merge order
+func parseExtends(job *gitlabModels.Job) []string {
+	if job == nil {
+		return nil
+	}
+
+	switch extends := job.Extends.(type) {
+	case string:
+		return []string{extends}
+	case []any:
+		parents, _ := utils.ToSlice[string](extends)
+		return parents
+	}
+	return nil
+}
+
+func appendToChain(chain []string, jobIDs ...string) []string {
+	for _, jobID := range jobIDs {
+		if !utils.SliceContains(chain, jobID) {
+			chain = append(chain, jobID)
+		}
+	}
+	return chain
+}
+
+// MergeJobs merges a parent job into a child job the way GitLab merges extended jobs -
+// hashes (variables) are deep merged while any other key that is set on the child overrides the parent.
+// The identity of the child (ID, name, file reference and extends chain) is always kept.
+func MergeJobs(parent, child *models.Job) *models.Job {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		merged := *parent
+		return &merged
+	}
+
+	merged := *child
+	merged.Steps = mergeSlice(child.Steps, parent.Steps)
+	merged.StepGroups = mergeSlice(child.StepGroups, parent.StepGroups)
+	merged.PreSteps = mergeSlice(child.PreSteps, parent.PreSteps)
+	merged.PostSteps = mergeSlice(child.PostSteps, parent.PostSteps)
+	merged.ContinueOnError = mergePtr(child.ContinueOnError, parent.ContinueOnError)
+	merged.EnvironmentVariables = mergeEnvironmentVariables(parent.EnvironmentVariables, child.EnvironmentVariables)
+	merged.Runner = mergePtr(child.Runner, parent.Runner)
+	merged.Services = mergeSlice(child.Services, parent.Services)
+	merged.Environment = mergePtr(child.Environment, parent.Environment)
+	merged.Produces = mergeSlice(child.Produces, parent.Produces)
+	merged.Consumes = mergeSlice(child.Consumes, parent.Consumes)
+	merged.Outputs = mergeSlice(child.Outputs, parent.Outputs)
+	merged.Conditions = mergeSlice(child.Conditions, parent.Conditions)
+	merged.ConcurrencyGroup = mergePtr(child.ConcurrencyGroup, parent.ConcurrencyGroup)
+	merged.Inputs = mergeSlice(child.Inputs, parent.Inputs)
+	merged.TimeoutMS = mergePtr(child.TimeoutMS, parent.TimeoutMS)
+	merged.Tags = mergeSlice(child.Tags, parent.Tags)
+	merged.TokenPermissions = mergePtr(child.TokenPermissions, parent.TokenPermissions)
+	merged.Dependencies = mergeSlice(child.Dependencies, parent.Dependencies)
+	if child.IgnoresStageOrder { // the needs of the child override the needs of the parent, even when they are empty
+		merged.Dependencies = child.Dependencies
+	}
+	merged.IgnoresStageOrder = child.IgnoresStageOrder || parent.IgnoresStageOrder
+	if merged.Metadata == (models.Metadata{}) {
+		merged.Metadata = parent.Metadata
+	}
+	merged.Matrix = mergePtr(child.Matrix, parent.Matrix)
+	merged.Imports = mergePtr(child.Imports, parent.Imports)
+	return &merged
+}
+
+func mergeEnvironmentVariables(parent, child *models.EnvironmentVariablesRef) *models.EnvironmentVariablesRef {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+
+	variables := models.EnvironmentVariables{}
+	for key, value := range parent.EnvironmentVariables {
+		variables[key] = value
+	}
+	for key, value := range child.EnvironmentVariables {
+		variables[key] = value
+	}
+
+	return &models.EnvironmentVariablesRef{
+		EnvironmentVariables: variables,
+		FileReference:        child.FileReference,
+		Imports:              mergePtr(child.Imports, parent.Imports),
+	}
+}
+
+func mergePtr[T any](child, parent *T) *T {
+	if child != nil {
+		return child
+	}
+	return parent
+}
+
+func mergeSlice[T any](child, parent []T) []T {
+	if len(child) > 0 {
+		return child
+	}
+	return parent
+}
@@ -0,0 +1,293 @@
+package job
+
+import (
+	"reflect"
+	"testing"
+
+	gitlabModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/gitlab/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseExtends(t *testing.T) {
+	testCases := []struct {
+		name            string
+		job             *gitlabModels.Job
+		expectedExtends []string
+	}{
+		{
+			name:            "Job is nil",
+			job:             nil,
+			expectedExtends: nil,
+		},
+		{
+			name:            "Job without extends",
+			job:             &gitlabModels.Job{},
+			expectedExtends: nil,
+		},
+		{
+			name:            "Job extends a single job",
+			job:             &gitlabModels.Job{Extends: ".base"},
+			expectedExtends: []string{".base"},
+		},
+		{
+			name:            "Job extends multiple jobs",
+			job:             &gitlabModels.Job{Extends: []any{".base", ".tests"}},
+			expectedExtends: []string{".base", ".tests"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseExtends(testCase.job)
+
+			testutils.DeepCompare(t, testCase.expectedExtends, got)
+		})
+	}
+}
+
+func TestResolveExtends(t *testing.T) {
+	testCases := []struct {
+		name         string
+		jobs         map[string]*models.Job
+		gitlabJobs   map[string]*gitlabModels.Job
+		expectedJobs map[string]*models.Job
+	}{
+		{
+			name: "Jobs without extends",
+			jobs: map[string]*models.Job{
+				"build": {ID: utils.GetPtr("build"), Tags: []string{"docker"}},
+			},
+			gitlabJobs: map[string]*gitlabModels.Job{
+				"build": {},
+			},
+			expectedJobs: map[string]*models.Job{
+				"build": {ID: utils.GetPtr("build"), Tags: []string{"docker"}},
+			},
+		},
+		{
+			name: "Multi level extends",
+			jobs: map[string]*models.Job{
+				".base":  {ID: utils.GetPtr(".base"), Tags: []string{"docker"}, TimeoutMS: utils.GetPtr(1)},
+				".tests": {ID: utils.GetPtr(".tests"), TimeoutMS: utils.GetPtr(2)},
+				"test":   {ID: utils.GetPtr("test")},
+			},
+			gitlabJobs: map[string]*gitlabModels.Job{
+				".base":  {},
+				".tests": {Extends: ".base"},
+				"test":   {Extends: ".tests"},
+			},
+			expectedJobs: map[string]*models.Job{
+				".base":  {ID: utils.GetPtr(".base"), Tags: []string{"docker"}, TimeoutMS: utils.GetPtr(1)},
+				".tests": {ID: utils.GetPtr(".tests"), Tags: []string{"docker"}, TimeoutMS: utils.GetPtr(2), Extends: []string{".base"}},
+				"test":   {ID: utils.GetPtr("test"), Tags: []string{"docker"}, TimeoutMS: utils.GetPtr(2), Extends: []string{".base", ".tests"}},
+			},
+		},
+		{
+			name: "Multiple parents are merged in order",
+			jobs: map[string]*models.Job{
+				".a":   {ID: utils.GetPtr(".a"), Tags: []string{"a"}, TimeoutMS: utils.GetPtr(1)},
+				".b":   {ID: utils.GetPtr(".b"), Tags: []string{"b"}},
+				"test": {ID: utils.GetPtr("test")},
+			},
+			gitlabJobs: map[string]*gitlabModels.Job{
+				".a":   {},
+				".b":   {},
+				"test": {Extends: []any{".a", ".b"}},
+			},
+			expectedJobs: map[string]*models.Job{
+				".a":   {ID: utils.GetPtr(".a"), Tags: []string{"a"}, TimeoutMS: utils.GetPtr(1)},
+				".b":   {ID: utils.GetPtr(".b"), Tags: []string{"b"}},
+				"test": {ID: utils.GetPtr("test"), Tags: []string{"b"}, TimeoutMS: utils.GetPtr(1), Extends: []string{".a", ".b"}},
+			},
+		},
+		{
+			name: "Unknown parent is kept in the chain",
+			jobs: map[string]*models.Job{
+				"test": {ID: utils.GetPtr("test")},
+			},
+			gitlabJobs: map[string]*gitlabModels.Job{
+				"test": {Extends: ".remote"},
+			},
+			expectedJobs: map[string]*models.Job{
+				"test": {ID: utils.GetPtr("test"), Extends: []string{".remote"}},
+			},
+		},
+		{
+			name: "Jobs extending an unknown parent are left unmerged",
+			jobs: map[string]*models.Job{
+				".local": {ID: utils.GetPtr(".local"), Tags: []string{"local"}},
+				".child": {ID: utils.GetPtr(".child")},
+				"test":   {ID: utils.GetPtr("test")},
+			},
+			gitlabJobs: map[string]*gitlabModels.Job{
+				".local": {},
+				".child": {Extends: []any{".remote", ".local"}},
+				"test":   {Extends: ".child"},
+			},
+			expectedJobs: map[string]*models.Job{
+				".local": {ID: utils.GetPtr(".local"), Tags: []string{"local"}},
+				".child": {ID: utils.GetPtr(".child"), Extends: []string{".remote", ".local"}},
+				"test":   {ID: utils.GetPtr("test"), Extends: []string{".remote", ".local", ".child"}},
+			},
+		},
+		{
+			name: "Cyclic extends",
+			jobs: map[string]*models.Job{
+				".a": {ID: utils.GetPtr(".a"), Tags: []string{"a"}},
+				".b": {ID: utils.GetPtr(".b")},
+			},
+			gitlabJobs: map[string]*gitlabModels.Job{
+				".a": {Extends: ".b"},
+				".b": {Extends: ".a"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resolveExtends(testCase.jobs, testCase.gitlabJobs)
+
+			if testCase.expectedJobs != nil {
+				testutils.DeepCompare(t, testCase.expectedJobs, testCase.jobs)
+			}
+		})
+	}
+}
+
+func TestMergeJobs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		parent      *models.Job
+		child       *models.Job
+		expectedJob *models.Job
+	}{
+		{
+			name:        "Parent is nil",
+			child:       &models.Job{ID: utils.GetPtr("child")},
+			expectedJob: &models.Job{ID: utils.GetPtr("child")},
+		},
+		{
+			name:        "Child is nil",
+			parent:      &models.Job{ID: utils.GetPtr("parent")},
+			expectedJob: &models.Job{ID: utils.GetPtr("parent")},
+		},
+		{
+			name: "Child overrides parent and variables are deep merged",
+			parent: &models.Job{
+				ID:               utils.GetPtr("parent"),
+				Name:             utils.GetPtr("parent"),
+				ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("test")),
+				Tags:             []string{"parent"},
+				Steps: []*models.Step{
+					{Type: models.ShellStepType, Shell: &models.Shell{Script: utils.GetPtr("parent")}},
+				},
+				EnvironmentVariables: &models.EnvironmentVariablesRef{
+					EnvironmentVariables: models.EnvironmentVariables{
+						"A": "parent",
+						"B": "parent",
+					},
+					FileReference: testutils.CreateFileReference(1, 1, 2, 2),
+				},
+				FileReference: testutils.CreateFileReference(1, 1, 5, 5),
+			},
+			child: &models.Job{
+				ID:   utils.GetPtr("child"),
+				Name: utils.GetPtr("child"),
+				Tags: []string{"child"},
+				EnvironmentVariables: &models.EnvironmentVariablesRef{
+					EnvironmentVariables: models.EnvironmentVariables{
+						"B": "child",
+					},
+					FileReference: testutils.CreateFileReference(6, 1, 7, 2),
+				},
+				FileReference: testutils.CreateFileReference(6, 1, 10, 5),
+			},
+			expectedJob: &models.Job{
+				ID:               utils.GetPtr("child"),
+				Name:             utils.GetPtr("child"),
+				ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("test")),
+				Tags:             []string{"child"},
+				Steps: []*models.Step{
+					{Type: models.ShellStepType, Shell: &models.Shell{Script: utils.GetPtr("parent")}},
+				},
+				EnvironmentVariables: &models.EnvironmentVariablesRef{
+					EnvironmentVariables: models.EnvironmentVariables{
+						"A": "parent",
+						"B": "child",
+					},
+					FileReference: testutils.CreateFileReference(6, 1, 7, 2),
+				},
+				FileReference: testutils.CreateFileReference(6, 1, 10, 5),
+			},
+		},
+		{
+			name: "Empty needs of the child override the needs of the parent",
+			parent: &models.Job{
+				ID:                utils.GetPtr("parent"),
+				Dependencies:      []*models.JobDependency{{JobID: utils.GetPtr("build")}},
+				IgnoresStageOrder: true,
+			},
+			child: &models.Job{
+				ID:                utils.GetPtr("child"),
+				IgnoresStageOrder: true,
+			},
+			expectedJob: &models.Job{
+				ID:                utils.GetPtr("child"),
+				IgnoresStageOrder: true,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := MergeJobs(testCase.parent, testCase.child)
+
+			testutils.DeepCompare(t, testCase.expectedJob, got)
+		})
+	}
+}
+
+func TestMergeJobsInheritsEveryField(t *testing.T) {
+	identityFields := map[string]bool{"ID": true, "Name": true, "FileReference": true, "Extends": true}
+
+	parent := &models.Job{}
+	parentValue := reflect.ValueOf(parent).Elem()
+	for i := 0; i < parentValue.NumField(); i++ {
+		fillValue(parentValue.Field(i))
+	}
+
+	merged := reflect.ValueOf(MergeJobs(parent, &models.Job{})).Elem()
+	for i := 0; i < parentValue.NumField(); i++ {
+		field := parentValue.Type().Field(i).Name
+		if identityFields[field] {
+			continue
+		}
+		if !reflect.DeepEqual(parentValue.Field(i).Interface(), merged.Field(i).Interface()) {
+			t.Errorf("field %s is not inherited from the parent job", field)
+		}
+	}
+}
+
+// fillValue sets a non zero value to v
+func fillValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			fillValue(v.Field(i))
+		}
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.String:
+		v.SetString("value")
+	case reflect.Int, reflect.Int64:
+		v.SetInt(1)
+	}
+}
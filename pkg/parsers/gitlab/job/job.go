@@ -11,12 +11,23 @@ import (
 )
 
 func ParseJobs(gitlabCIConfiguration *gitlabModels.GitlabCIConfiguration) ([]*models.Job, error) {
-	jobs, err := utils.MapToSliceErr(gitlabCIConfiguration.Jobs, parseJob)
-	if err != nil {
-		return nil, err
+	jobs := map[string]*models.Job{}
+	for jobID, job := range gitlabCIConfiguration.Jobs {
+		parsedJob, err := parseJob(jobID, job)
+		if err != nil {
+			return nil, err
+		}
+		jobs[jobID] = parsedJob
 	}
 
-	return jobs, nil
+	resolveExtends(jobs, gitlabCIConfiguration.Jobs)
+	for jobID, job := range jobs {
+		parseArtifacts(jobID, job, gitlabCIConfiguration.Jobs)
+	}
+
+	return utils.MapToSlice(jobs, func(_ string, job *models.Job) *models.Job {
+		return job
+	}), nil
 }
 
 func parseJob(jobID string, job *gitlabModels.Job) (*models.Job, error) {
@@ -26,12 +37,15 @@ func parseJob(jobID string, job *gitlabModels.Job) (*models.Job, error) {
 		ContinueOnError:      getJobContinueOnError(job),
 		ConcurrencyGroup:     getJobConcurrencyGroup(job),
 		Dependencies:         parseDependencies(job),
+		IgnoresStageOrder:    job.Needs != nil,
 		PreSteps:             common.ParseScript(job.BeforeScript),
 		PostSteps:            common.ParseScript(job.AfterScript),
 		Steps:                common.ParseScript(job.Script),
 		EnvironmentVariables: common.ParseEnvironmentVariables(job.Variables),
 		Tags:                 job.Tags,
 		Runner:               common.ParseRunner(job.Image),
+		Services:             common.ParseServices(job.Services),
+		Environment:          parseEnvironment(job.Environment),
 		Conditions:           getJobConditions(job),
 		FileReference:        job.FileReference,
 	}
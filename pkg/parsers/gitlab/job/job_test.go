@@ -90,6 +90,18 @@ func TestParseJob(t *testing.T) {
 				FileReference: testutils.CreateFileReference(1, 2, 3, 4),
 			},
 		},
+		{
+			name:  "Job with empty needs",
+			jobID: "1",
+			job: &gitlabModels.Job{
+				Needs: &job.Needs{},
+			},
+			expectedJob: &models.Job{
+				ID:                utils.GetPtr("1"),
+				Name:              utils.GetPtr("1"),
+				IgnoresStageOrder: true,
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
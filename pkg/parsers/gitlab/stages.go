@@ -0,0 +1,108 @@
+package gitlab
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+const (
+	preStage        = ".pre"
+	postStage       = ".post"
+	defaultJobStage = "test"
+)
+
+var (
+	defaultStages = []string{"build", "test", "deploy"}
+)
+
+// parseStages builds the pipeline stages in their execution order.
+// Every stage depends on the stage before it, as GitLab runs the jobs of a stage only after the previous stage completed.
+// Jobs that may inherit their stage from an included template are left out until UpdateStages places them.
+func parseStages(stages []string, jobs []*models.Job) []*models.Stage {
+	return buildStages(stages, jobs, true)
+}
+
+// UpdateStages places every job of the pipeline in its stage, once the jobs that extend templates of included pipelines
+// are merged with them. The stages that were already built keep their order.
+func UpdateStages(stages []*models.Stage, jobs []*models.Job) []*models.Stage {
+	var declaredStages []string
+	for _, stage := range stages {
+		if stage != nil && stage.ID != nil && *stage.ID != preStage && *stage.ID != postStage {
+			declaredStages = append(declaredStages, *stage.ID)
+		}
+	}
+	return buildStages(declaredStages, jobs, false)
+}
+
+func buildStages(stages []string, jobs []*models.Job, skipInheritedStages bool) []*models.Stage {
+	hasJobs := false
+	stageJobs := map[string][]string{}
+	for _, job := range jobs {
+		if job == nil || job.ID == nil || strings.HasPrefix(*job.ID, ".") { // hidden jobs are never executed
+			continue
+		}
+
+		hasJobs = true
+		if skipInheritedStages && mayInheritStage(job) {
+			continue
+		}
+
+		stage := defaultJobStage
+		if job.ConcurrencyGroup != nil {
+			stage = string(*job.ConcurrencyGroup)
+		}
+		stageJobs[stage] = append(stageJobs[stage], *job.ID)
+	}
+
+	if !hasJobs {
+		return nil
+	}
+
+	if len(stages) == 0 {
+		stages = defaultStages
+	}
+
+	// Stages that are used by jobs but not declared (e.g. declared in an included file) are kept after the declared stages
+	var undeclaredStages []string
+	for stage := range stageJobs {
+		if stage != preStage && stage != postStage && !utils.SliceContains(stages, stage) {
+			undeclaredStages = append(undeclaredStages, stage)
+		}
+	}
+	sort.Strings(undeclaredStages)
+
+	orderedStages := append([]string{preStage}, stages...)
+	orderedStages = append(orderedStages, undeclaredStages...)
+	orderedStages = append(orderedStages, postStage)
+
+	var parsedStages []*models.Stage
+	for _, stage := range orderedStages {
+		if (stage == preStage || stage == postStage) && len(stageJobs[stage]) == 0 {
+			continue
+		}
+
+		jobIDs := stageJobs[stage]
+		sort.Strings(jobIDs)
+
+		parsedStage := &models.Stage{
+			ID:    utils.GetPtr(stage),
+			Name:  utils.GetPtr(stage),
+			Order: len(parsedStages),
+			Jobs:  jobIDs,
+		}
+		if len(parsedStages) > 0 {
+			parsedStage.Dependencies = []string{*parsedStages[len(parsedStages)-1].ID}
+		}
+		parsedStages = append(parsedStages, parsedStage)
+	}
+	return parsedStages
+}
+
+// mayInheritStage returns whether the stage of a job is only known once the included pipelines are loaded,
+// as the job extends other jobs without setting a stage
+func mayInheritStage(job *models.Job) bool {
+	return len(job.Extends) > 0 && job.ConcurrencyGroup == nil
+}
@@ -0,0 +1,133 @@
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseStages(t *testing.T) {
+	testCases := []struct {
+		name           string
+		stages         []string
+		jobs           []*models.Job
+		expectedStages []*models.Stage
+	}{
+		{
+			name:           "No jobs",
+			stages:         []string{"build"},
+			jobs:           nil,
+			expectedStages: nil,
+		},
+		{
+			name:   "Default stages",
+			stages: nil,
+			jobs: []*models.Job{
+				{ID: utils.GetPtr("compile"), ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("build"))},
+				{ID: utils.GetPtr("unit")},
+				{ID: utils.GetPtr(".hidden"), ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("deploy"))},
+			},
+			expectedStages: []*models.Stage{
+				{ID: utils.GetPtr("build"), Name: utils.GetPtr("build"), Order: 0, Jobs: []string{"compile"}},
+				{ID: utils.GetPtr("test"), Name: utils.GetPtr("test"), Order: 1, Dependencies: []string{"build"}, Jobs: []string{"unit"}},
+				{ID: utils.GetPtr("deploy"), Name: utils.GetPtr("deploy"), Order: 2, Dependencies: []string{"test"}},
+			},
+		},
+		{
+			name:   "Declared, undeclared and implicit stages",
+			stages: []string{"lint", "release"},
+			jobs: []*models.Job{
+				{ID: utils.GetPtr("first"), ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup(".pre"))},
+				{ID: utils.GetPtr("lint-b"), ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("lint"))},
+				{ID: utils.GetPtr("lint-a"), ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("lint"))},
+				{ID: utils.GetPtr("scan"), ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("security"))},
+				{ID: utils.GetPtr("last"), ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup(".post"))},
+			},
+			expectedStages: []*models.Stage{
+				{ID: utils.GetPtr(".pre"), Name: utils.GetPtr(".pre"), Order: 0, Jobs: []string{"first"}},
+				{ID: utils.GetPtr("lint"), Name: utils.GetPtr("lint"), Order: 1, Dependencies: []string{".pre"}, Jobs: []string{"lint-a", "lint-b"}},
+				{ID: utils.GetPtr("release"), Name: utils.GetPtr("release"), Order: 2, Dependencies: []string{"lint"}},
+				{ID: utils.GetPtr("security"), Name: utils.GetPtr("security"), Order: 3, Dependencies: []string{"release"}, Jobs: []string{"scan"}},
+				{ID: utils.GetPtr(".post"), Name: utils.GetPtr(".post"), Order: 4, Dependencies: []string{"security"}, Jobs: []string{"last"}},
+			},
+		},
+		{
+			name:   "Jobs that may inherit their stage",
+			stages: []string{"build", "deploy"},
+			jobs: []*models.Job{
+				{ID: utils.GetPtr("compile"), ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("build"))},
+				{ID: utils.GetPtr("prod"), Extends: []string{".deploy"}},
+				{ID: utils.GetPtr("staging"), Extends: []string{".deploy"}, ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("deploy"))},
+			},
+			expectedStages: []*models.Stage{
+				{ID: utils.GetPtr("build"), Name: utils.GetPtr("build"), Order: 0, Jobs: []string{"compile"}},
+				{ID: utils.GetPtr("deploy"), Name: utils.GetPtr("deploy"), Order: 1, Dependencies: []string{"build"}, Jobs: []string{"staging"}},
+			},
+		},
+		{
+			name:   "Only jobs that may inherit their stage",
+			stages: []string{"build", "deploy"},
+			jobs: []*models.Job{
+				{ID: utils.GetPtr("prod"), Extends: []string{".deploy"}},
+			},
+			expectedStages: []*models.Stage{
+				{ID: utils.GetPtr("build"), Name: utils.GetPtr("build"), Order: 0},
+				{ID: utils.GetPtr("deploy"), Name: utils.GetPtr("deploy"), Order: 1, Dependencies: []string{"build"}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseStages(testCase.stages, testCase.jobs)
+
+			testutils.DeepCompare(t, testCase.expectedStages, got)
+		})
+	}
+}
+
+func TestUpdateStages(t *testing.T) {
+	testCases := []struct {
+		name           string
+		stages         []*models.Stage
+		jobs           []*models.Job
+		expectedStages []*models.Stage
+	}{
+		{
+			name:           "No jobs",
+			stages:         nil,
+			jobs:           nil,
+			expectedStages: nil,
+		},
+		{
+			name: "Jobs are placed in their inherited stages",
+			stages: []*models.Stage{
+				{ID: utils.GetPtr(".pre"), Name: utils.GetPtr(".pre"), Order: 0, Jobs: []string{"first"}},
+				{ID: utils.GetPtr("build"), Name: utils.GetPtr("build"), Order: 1, Dependencies: []string{".pre"}, Jobs: []string{"compile"}},
+				{ID: utils.GetPtr("deploy"), Name: utils.GetPtr("deploy"), Order: 2, Dependencies: []string{"build"}},
+			},
+			jobs: []*models.Job{
+				{ID: utils.GetPtr("first"), ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup(".pre"))},
+				{ID: utils.GetPtr("compile"), ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("build"))},
+				{ID: utils.GetPtr("prod"), Extends: []string{".deploy"}, ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("deploy"))},
+				{ID: utils.GetPtr("unknown"), Extends: []string{".missing"}},
+			},
+			expectedStages: []*models.Stage{
+				{ID: utils.GetPtr(".pre"), Name: utils.GetPtr(".pre"), Order: 0, Jobs: []string{"first"}},
+				{ID: utils.GetPtr("build"), Name: utils.GetPtr("build"), Order: 1, Dependencies: []string{".pre"}, Jobs: []string{"compile"}},
+				{ID: utils.GetPtr("deploy"), Name: utils.GetPtr("deploy"), Order: 2, Dependencies: []string{"build"}, Jobs: []string{"prod"}},
+				{ID: utils.GetPtr("test"), Name: utils.GetPtr("test"), Order: 3, Dependencies: []string{"deploy"}, Jobs: []string{"unknown"}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := UpdateStages(testCase.stages, testCase.jobs)
+
+			testutils.DeepCompare(t, testCase.expectedStages, got)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package jenkins
+
+import jenkinsModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins/models"
+
+// getArgumentValue returns the value of the argument with the given name, or of the unnamed argument in the given position
+func getArgumentValue(arguments []*jenkinsModels.Argument, name string, position int) *string {
+	var unnamed []*jenkinsModels.Argument
+	for _, argument := range arguments {
+		if argument.Name == nil {
+			unnamed = append(unnamed, argument)
+		} else if *argument.Name == name {
+			return &argument.Value
+		}
+	}
+
+	if position >= 0 && position < len(unnamed) {
+		return &unnamed[position].Value
+	}
+	return nil
+}
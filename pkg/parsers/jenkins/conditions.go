@@ -0,0 +1,90 @@
+package jenkins
+
+import (
+	jenkinsModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+var (
+	triggerCauseEvents = map[string]models.EventType{
+		"TimerTrigger":       models.ScheduledEvent,
+		"UserIdCause":        models.ManualEvent,
+		"SCMTrigger":         models.PushEvent,
+		"BranchEventCause":   models.PushEvent,
+		"BuildUpstreamCause": models.PipelineTriggerEvent,
+		"UpstreamCause":      models.PipelineTriggerEvent,
+	}
+)
+
+func parseConditions(when *jenkinsModels.When) []*models.Condition {
+	if when == nil {
+		return nil
+	}
+	return parseWhenConditions(when.Conditions, false)
+}
+
+// parseWhenConditions returns a condition for every when condition.
+// The conditions of allOf are all required, so they are flattened, while not negates its nested condition.
+func parseWhenConditions(conditions []*jenkinsModels.WhenCondition, isDeny bool) []*models.Condition {
+	var parsedConditions []*models.Condition
+	for _, condition := range conditions {
+		switch {
+		case condition.Type == "allOf" && !isDeny:
+			parsedConditions = append(parsedConditions, parseWhenConditions(condition.Conditions, isDeny)...)
+		case condition.Type == "not" && len(condition.Conditions) == 1:
+			negatedConditions := parseWhenConditions(condition.Conditions, !isDeny)
+			if len(negatedConditions) == 1 {
+				negatedConditions[0].Statement = condition.Statement
+			}
+			parsedConditions = append(parsedConditions, negatedConditions...)
+		default:
+			parsedConditions = append(parsedConditions, parseWhenCondition(condition, isDeny))
+		}
+	}
+	return parsedConditions
+}
+
+func parseWhenCondition(condition *jenkinsModels.WhenCondition, isDeny bool) *models.Condition {
+	parsedCondition := &models.Condition{
+		Statement: condition.Statement,
+		Allow:     utils.GetPtr(!isDeny),
+	}
+
+	switch condition.Type {
+	case "branch":
+		if pattern := getArgumentValue(condition.Arguments, "pattern", 0); pattern != nil {
+			parsedCondition.Branches = generateFilter(*pattern, isDeny)
+		}
+	case "changeset":
+		if pattern := getArgumentValue(condition.Arguments, "pattern", 0); pattern != nil {
+			parsedCondition.Paths = generateFilter(*pattern, isDeny)
+		}
+	case "environment":
+		name := getArgumentValue(condition.Arguments, "name", -1)
+		value := getArgumentValue(condition.Arguments, "value", -1)
+		if name != nil && value != nil {
+			parsedCondition.Variables = map[string]string{*name: *value}
+		}
+	case "changeRequest":
+		parsedCondition.Events = []models.EventType{models.PullRequestEvent}
+	case "triggeredBy":
+		if cause := getArgumentValue(condition.Arguments, "cause", 0); cause != nil {
+			if event, ok := triggerCauseEvents[*cause]; ok {
+				parsedCondition.Events = []models.EventType{event}
+			}
+		}
+	case "expression":
+		if condition.Expression != nil {
+			parsedCondition.Statement = *condition.Expression
+		}
+	}
+	return parsedCondition
+}
+
+func generateFilter(pattern string, isDeny bool) *models.Filter {
+	if isDeny {
+		return &models.Filter{DenyList: []string{pattern}}
+	}
+	return &models.Filter{AllowList: []string{pattern}}
+}
@@ -0,0 +1,116 @@
+package jenkins
+
+import (
+	"testing"
+
+	jenkinsModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseConditions(t *testing.T) {
+	testCases := []struct {
+		name               string
+		when               *jenkinsModels.When
+		expectedConditions []*models.Condition
+	}{
+		{
+			name:               "When is nil",
+			when:               nil,
+			expectedConditions: nil,
+		},
+		{
+			name: "allOf is flattened and not is negated",
+			when: &jenkinsModels.When{
+				Conditions: []*jenkinsModels.WhenCondition{
+					{
+						Type:      "allOf",
+						Statement: "allOf { ... }",
+						Conditions: []*jenkinsModels.WhenCondition{
+							{
+								Type:      "branch",
+								Statement: "branch pattern: 'release-*'",
+								Arguments: []*jenkinsModels.Argument{{Name: utils.GetPtr("pattern"), Value: "release-*", IsString: true}},
+							},
+							{
+								Type:      "environment",
+								Statement: "environment name: 'DEPLOY', value: 'true'",
+								Arguments: []*jenkinsModels.Argument{
+									{Name: utils.GetPtr("name"), Value: "DEPLOY", IsString: true},
+									{Name: utils.GetPtr("value"), Value: "true", IsString: true},
+								},
+							},
+						},
+					},
+					{
+						Type:      "not",
+						Statement: "not { changeset 'docs/**' }",
+						Conditions: []*jenkinsModels.WhenCondition{
+							{
+								Type:      "changeset",
+								Statement: "changeset 'docs/**'",
+								Arguments: []*jenkinsModels.Argument{{Value: "docs/**", IsString: true}},
+							},
+						},
+					},
+				},
+			},
+			expectedConditions: []*models.Condition{
+				{
+					Statement: "branch pattern: 'release-*'",
+					Allow:     utils.GetPtr(true),
+					Branches:  &models.Filter{AllowList: []string{"release-*"}},
+				},
+				{
+					Statement: "environment name: 'DEPLOY', value: 'true'",
+					Allow:     utils.GetPtr(true),
+					Variables: map[string]string{"DEPLOY": "true"},
+				},
+				{
+					Statement: "not { changeset 'docs/**' }",
+					Allow:     utils.GetPtr(false),
+					Paths:     &models.Filter{DenyList: []string{"docs/**"}},
+				},
+			},
+		},
+		{
+			name: "Events",
+			when: &jenkinsModels.When{
+				Conditions: []*jenkinsModels.WhenCondition{
+					{Type: "changeRequest", Statement: "changeRequest()"},
+					{
+						Type:      "triggeredBy",
+						Statement: "triggeredBy 'TimerTrigger'",
+						Arguments: []*jenkinsModels.Argument{{Value: "TimerTrigger", IsString: true}},
+					},
+					{Type: "anyOf", Statement: "anyOf { ... }"},
+				},
+			},
+			expectedConditions: []*models.Condition{
+				{
+					Statement: "changeRequest()",
+					Allow:     utils.GetPtr(true),
+					Events:    []models.EventType{models.PullRequestEvent},
+				},
+				{
+					Statement: "triggeredBy 'TimerTrigger'",
+					Allow:     utils.GetPtr(true),
+					Events:    []models.EventType{models.ScheduledEvent},
+				},
+				{
+					Statement: "anyOf { ... }",
+					Allow:     utils.GetPtr(true),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseConditions(testCase.when)
+
+			testutils.DeepCompare(t, testCase.expectedConditions, got)
+		})
+	}
+}
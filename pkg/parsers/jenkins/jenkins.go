@@ -0,0 +1,51 @@
+package jenkins
+
+import (
+	jenkinsModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+type JenkinsParser struct{}
+
+func (j *JenkinsParser) Parse(jenkinsPipeline *jenkinsModels.Pipeline) (*models.Pipeline, error) {
+	if jenkinsPipeline == nil {
+		return nil, nil
+	}
+
+	var pipeline models.Pipeline
+
+	pipeline.Defaults = parsePipelineDefaults(jenkinsPipeline)
+	pipeline.Jobs, pipeline.Stages = parseStages(jenkinsPipeline.Stages, parseTimeout(jenkinsPipeline.Options))
+
+	return &pipeline, nil
+}
+
+func parsePipelineDefaults(pipeline *jenkinsModels.Pipeline) *models.Defaults {
+	if pipeline.Agent == nil && pipeline.Environment == nil && pipeline.Options == nil {
+		return nil
+	}
+
+	defaults := &models.Defaults{
+		Runner:               parseRunner(pipeline.Agent),
+		EnvironmentVariables: parseEnvironment(pipeline.Environment),
+		FileReference:        pipeline.FileReference,
+	}
+
+	if timeout := parseTimeout(pipeline.Options); timeout != nil {
+		defaults.Settings = &map[string]any{
+			"timeout_ms": *timeout,
+		}
+	}
+	return defaults
+}
+
+func parseEnvironment(environment *jenkinsModels.Environment) *models.EnvironmentVariablesRef {
+	if environment == nil {
+		return nil
+	}
+
+	return &models.EnvironmentVariablesRef{
+		EnvironmentVariables: environment.EnvironmentVariables,
+		FileReference:        environment.FileReference,
+	}
+}
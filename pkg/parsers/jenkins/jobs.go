@@ -0,0 +1,153 @@
+package jenkins
+
+import (
+	"strings"
+
+	jenkinsModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+var (
+	timeUnitsMS = map[string]int{
+		"MILLISECONDS": 1,
+		"SECONDS":      1000,
+		"MINUTES":      60 * 1000,
+		"HOURS":        60 * 60 * 1000,
+		"DAYS":         24 * 60 * 60 * 1000,
+	}
+)
+
+const stagePathSeparator = "/"
+
+// parseStages returns the jobs of the pipeline and its top level stages.
+// Every stage with steps is a job, the stages in between only group them.
+// Jobs without a timeout of their own have the timeout of the pipeline.
+func parseStages(stages []*jenkinsModels.Stage, timeoutMS *int) ([]*models.Job, []*models.Stage) {
+	var jobs []*models.Job
+	var parsedStages []*models.Stage
+	for i, stage := range stages {
+		if stage == nil {
+			continue
+		}
+
+		stageJobs := parseStageJobs(stage, nil, timeoutMS)
+		parsedStage := &models.Stage{
+			ID:                   utils.GetPtr(stage.Name),
+			Name:                 utils.GetPtr(stage.Name),
+			Order:                i,
+			Conditions:           parseConditions(stage.When),
+			EnvironmentVariables: parseEnvironment(stage.Environment),
+			Jobs:                 getJobIDs(stageJobs),
+			FileReference:        stage.FileReference,
+		}
+		if len(parsedStages) > 0 {
+			parsedStage.Dependencies = []string{*parsedStages[len(parsedStages)-1].ID}
+		}
+
+		jobs = append(jobs, stageJobs...)
+		parsedStages = append(parsedStages, parsedStage)
+	}
+	return jobs, parsedStages
+}
+
+// parseStageJobs returns the jobs of a stage and its nested stages.
+// Nested stages inherit the agent, environment, conditions and timeout of their parent,
+// and sequential nested stages depend on the jobs of the stage before them.
+func parseStageJobs(stage *jenkinsModels.Stage, parent *models.Job, timeoutMS *int) []*models.Job {
+	job := parseJob(stage, parent, timeoutMS)
+	if stage.Stages == nil && stage.Parallel == nil {
+		return []*models.Job{job}
+	}
+
+	var jobs []*models.Job
+	for _, nestedStage := range stage.Parallel {
+		jobs = append(jobs, parseStageJobs(nestedStage, job, job.TimeoutMS)...)
+	}
+
+	var previousJobs []*models.Job
+	for _, nestedStage := range stage.Stages {
+		nestedJobs := parseStageJobs(nestedStage, job, job.TimeoutMS)
+		for _, nestedJob := range nestedJobs {
+			for _, previousJob := range previousJobs {
+				nestedJob.Dependencies = append(nestedJob.Dependencies, &models.JobDependency{JobID: previousJob.ID})
+			}
+		}
+
+		if len(nestedJobs) > 0 {
+			previousJobs = nestedJobs
+		}
+		jobs = append(jobs, nestedJobs...)
+	}
+	return jobs
+}
+
+// parseJob parses a stage to a job, whose ID is the path of the stage in its parent stages, e.g. Test/Linux/Build,
+// as nested stages of different parents may have the same name
+func parseJob(stage *jenkinsModels.Stage, parent *models.Job, timeoutMS *int) *models.Job {
+	job := &models.Job{
+		ID:                   utils.GetPtr(stage.Name),
+		Name:                 utils.GetPtr(stage.Name),
+		Steps:                parseSteps(stage.Steps),
+		Runner:               parseRunner(stage.Agent),
+		EnvironmentVariables: parseEnvironment(stage.Environment),
+		Conditions:           parseConditions(stage.When),
+		TimeoutMS:            parseTimeout(stage.Options),
+		FileReference:        stage.FileReference,
+	}
+	if job.TimeoutMS == nil {
+		job.TimeoutMS = timeoutMS
+	}
+
+	if parent != nil {
+		job.ID = utils.GetPtr(*parent.ID + stagePathSeparator + stage.Name)
+		if job.Runner == nil {
+			job.Runner = parent.Runner
+		}
+		job.EnvironmentVariables = mergeEnvironmentVariables(parent.EnvironmentVariables, job.EnvironmentVariables)
+		if parent.Conditions != nil {
+			job.Conditions = append(append([]*models.Condition{}, parent.Conditions...), job.Conditions...)
+		}
+	}
+	return job
+}
+
+func parseTimeout(options *jenkinsModels.Options) *int {
+	if options == nil || options.Timeout == nil {
+		return nil
+	}
+
+	unitMS, ok := timeUnitsMS[strings.ToUpper(options.Timeout.Unit)]
+	if !ok {
+		return nil
+	}
+	return utils.GetPtr(options.Timeout.Time * unitMS)
+}
+
+func mergeEnvironmentVariables(parent, child *models.EnvironmentVariablesRef) *models.EnvironmentVariablesRef {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+
+	environmentVariables := models.EnvironmentVariables{}
+	for key, value := range parent.EnvironmentVariables {
+		environmentVariables[key] = value
+	}
+	for key, value := range child.EnvironmentVariables {
+		environmentVariables[key] = value
+	}
+
+	return &models.EnvironmentVariablesRef{
+		EnvironmentVariables: environmentVariables,
+		FileReference:        child.FileReference,
+	}
+}
+
+func getJobIDs(jobs []*models.Job) []string {
+	return utils.Map(jobs, func(job *models.Job) string {
+		return *job.ID
+	})
+}
@@ -0,0 +1,211 @@
+package jenkins
+
+import (
+	"testing"
+
+	jenkinsModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseStages(t *testing.T) {
+	testCases := []struct {
+		name           string
+		stages         []*jenkinsModels.Stage
+		timeoutMS      *int
+		expectedJobs   []*models.Job
+		expectedStages []*models.Stage
+	}{
+		{
+			name:   "No stages",
+			stages: nil,
+		},
+		{
+			name: "Sequential nested stages inherit their parent and depend on each other",
+			stages: []*jenkinsModels.Stage{
+				{
+					Name:          "Build",
+					FileReference: testutils.CreateFileReference(1, 1, 2, 2),
+				},
+				{
+					Name: "Release",
+					Agent: &jenkinsModels.Agent{
+						Label: utils.GetPtr("linux"),
+					},
+					Environment: &jenkinsModels.Environment{
+						EnvironmentVariables: models.EnvironmentVariables{"A": "parent", "B": "parent"},
+					},
+					When: &jenkinsModels.When{
+						Conditions: []*jenkinsModels.WhenCondition{
+							{Type: "expression", Expression: utils.GetPtr("params.RELEASE")},
+						},
+					},
+					Stages: []*jenkinsModels.Stage{
+						{
+							Name: "Package",
+							Environment: &jenkinsModels.Environment{
+								EnvironmentVariables: models.EnvironmentVariables{"B": "child"},
+							},
+						},
+						{
+							Name: "Publish",
+							Parallel: []*jenkinsModels.Stage{
+								{Name: "Docker"},
+								{Name: "Maven", Options: &jenkinsModels.Options{Timeout: &jenkinsModels.Timeout{Time: 2, Unit: "SECONDS"}}},
+							},
+						},
+					},
+					FileReference: testutils.CreateFileReference(3, 1, 20, 2),
+				},
+			},
+			expectedJobs: []*models.Job{
+				{
+					ID:            utils.GetPtr("Build"),
+					Name:          utils.GetPtr("Build"),
+					FileReference: testutils.CreateFileReference(1, 1, 2, 2),
+				},
+				{
+					ID:     utils.GetPtr("Release/Package"),
+					Name:   utils.GetPtr("Package"),
+					Runner: &models.Runner{Labels: &[]string{"linux"}},
+					EnvironmentVariables: &models.EnvironmentVariablesRef{
+						EnvironmentVariables: models.EnvironmentVariables{"A": "parent", "B": "child"},
+					},
+					Conditions: []*models.Condition{
+						{Statement: "params.RELEASE", Allow: utils.GetPtr(true)},
+					},
+				},
+				{
+					ID:     utils.GetPtr("Release/Publish/Docker"),
+					Name:   utils.GetPtr("Docker"),
+					Runner: &models.Runner{Labels: &[]string{"linux"}},
+					EnvironmentVariables: &models.EnvironmentVariablesRef{
+						EnvironmentVariables: models.EnvironmentVariables{"A": "parent", "B": "parent"},
+					},
+					Conditions: []*models.Condition{
+						{Statement: "params.RELEASE", Allow: utils.GetPtr(true)},
+					},
+					Dependencies: []*models.JobDependency{{JobID: utils.GetPtr("Release/Package")}},
+				},
+				{
+					ID:     utils.GetPtr("Release/Publish/Maven"),
+					Name:   utils.GetPtr("Maven"),
+					Runner: &models.Runner{Labels: &[]string{"linux"}},
+					EnvironmentVariables: &models.EnvironmentVariablesRef{
+						EnvironmentVariables: models.EnvironmentVariables{"A": "parent", "B": "parent"},
+					},
+					Conditions: []*models.Condition{
+						{Statement: "params.RELEASE", Allow: utils.GetPtr(true)},
+					},
+					TimeoutMS:    utils.GetPtr(2000),
+					Dependencies: []*models.JobDependency{{JobID: utils.GetPtr("Release/Package")}},
+				},
+			},
+			expectedStages: []*models.Stage{
+				{
+					ID:            utils.GetPtr("Build"),
+					Name:          utils.GetPtr("Build"),
+					Order:         0,
+					Jobs:          []string{"Build"},
+					FileReference: testutils.CreateFileReference(1, 1, 2, 2),
+				},
+				{
+					ID:           utils.GetPtr("Release"),
+					Name:         utils.GetPtr("Release"),
+					Order:        1,
+					Dependencies: []string{"Build"},
+					Conditions: []*models.Condition{
+						{Statement: "params.RELEASE", Allow: utils.GetPtr(true)},
+					},
+					EnvironmentVariables: &models.EnvironmentVariablesRef{
+						EnvironmentVariables: models.EnvironmentVariables{"A": "parent", "B": "parent"},
+					},
+					Jobs:          []string{"Release/Package", "Release/Publish/Docker", "Release/Publish/Maven"},
+					FileReference: testutils.CreateFileReference(3, 1, 20, 2),
+				},
+			},
+		},
+		{
+			name: "Nested stages with the same name and inherited timeouts",
+			stages: []*jenkinsModels.Stage{
+				{
+					Name: "Test",
+					Parallel: []*jenkinsModels.Stage{
+						{
+							Name:   "Linux",
+							Stages: []*jenkinsModels.Stage{{Name: "Build"}},
+						},
+						{
+							Name:    "Windows",
+							Options: &jenkinsModels.Options{Timeout: &jenkinsModels.Timeout{Time: 2, Unit: "MINUTES"}},
+							Stages:  []*jenkinsModels.Stage{{Name: "Build"}},
+						},
+					},
+				},
+			},
+			timeoutMS: utils.GetPtr(3600000),
+			expectedJobs: []*models.Job{
+				{
+					ID:        utils.GetPtr("Test/Linux/Build"),
+					Name:      utils.GetPtr("Build"),
+					TimeoutMS: utils.GetPtr(3600000),
+				},
+				{
+					ID:        utils.GetPtr("Test/Windows/Build"),
+					Name:      utils.GetPtr("Build"),
+					TimeoutMS: utils.GetPtr(120000),
+				},
+			},
+			expectedStages: []*models.Stage{
+				{
+					ID:    utils.GetPtr("Test"),
+					Name:  utils.GetPtr("Test"),
+					Order: 0,
+					Jobs:  []string{"Test/Linux/Build", "Test/Windows/Build"},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			jobs, stages := parseStages(testCase.stages, testCase.timeoutMS)
+
+			testutils.DeepCompare(t, testCase.expectedJobs, jobs)
+			testutils.DeepCompare(t, testCase.expectedStages, stages)
+		})
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	testCases := []struct {
+		name            string
+		options         *jenkinsModels.Options
+		expectedTimeout *int
+	}{
+		{
+			name:            "Options is nil",
+			options:         nil,
+			expectedTimeout: nil,
+		},
+		{
+			name:            "Timeout in hours",
+			options:         &jenkinsModels.Options{Timeout: &jenkinsModels.Timeout{Time: 1, Unit: "HOURS"}},
+			expectedTimeout: utils.GetPtr(3600000),
+		},
+		{
+			name:            "Unknown unit",
+			options:         &jenkinsModels.Options{Timeout: &jenkinsModels.Timeout{Time: 1, Unit: "WEEKS"}},
+			expectedTimeout: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseTimeout(testCase.options)
+
+			testutils.DeepCompare(t, testCase.expectedTimeout, got)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package jenkins
+
+import (
+	jenkinsModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	parsersUtils "github.com/argonsecurity/pipeline-parser/pkg/parsers/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func parseRunner(agent *jenkinsModels.Agent) *models.Runner {
+	if agent == nil || agent.None {
+		return nil
+	}
+
+	runner := &models.Runner{
+		FileReference: agent.FileReference,
+	}
+
+	if agent.Label != nil {
+		runner.Labels = &[]string{*agent.Label}
+	}
+
+	if agent.Docker != nil {
+		runner.Type = utils.GetPtr(string(models.DockerRunnerType))
+		runner.DockerMetadata = parseDockerAgent(agent.Docker)
+	}
+
+	if agent.Dockerfile != nil {
+		runner.Type = utils.GetPtr(string(models.DockerRunnerType))
+	}
+	return runner
+}
+
+func parseDockerAgent(docker *jenkinsModels.DockerAgent) *models.DockerMetadata {
+	dockerMetadata := &models.DockerMetadata{
+		RegistryURL:           docker.RegistryURL,
+		RegistryCredentialsID: docker.RegistryCredentialsID,
+	}
+
+	if docker.Image != nil {
+		registry, namespace, imageName, tag := parsersUtils.ParseImageName(*docker.Image)
+		if namespace != "" {
+			imageName = namespace + "/" + imageName
+		}
+
+		dockerMetadata.Image = utils.GetPtrOrNil(imageName)
+		dockerMetadata.Label = utils.GetPtrOrNil(tag)
+		if dockerMetadata.RegistryURL == nil {
+			dockerMetadata.RegistryURL = utils.GetPtrOrNil(registry)
+		}
+	}
+	return dockerMetadata
+}
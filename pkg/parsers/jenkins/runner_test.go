@@ -0,0 +1,67 @@
+package jenkins
+
+import (
+	"testing"
+
+	jenkinsModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseRunner(t *testing.T) {
+	testCases := []struct {
+		name           string
+		agent          *jenkinsModels.Agent
+		expectedRunner *models.Runner
+	}{
+		{
+			name:           "Agent is nil",
+			agent:          nil,
+			expectedRunner: nil,
+		},
+		{
+			name:           "Agent none",
+			agent:          &jenkinsModels.Agent{None: true},
+			expectedRunner: nil,
+		},
+		{
+			name: "Agent any",
+			agent: &jenkinsModels.Agent{
+				Any:           true,
+				FileReference: testutils.CreateFileReference(1, 1, 1, 10),
+			},
+			expectedRunner: &models.Runner{
+				FileReference: testutils.CreateFileReference(1, 1, 1, 10),
+			},
+		},
+		{
+			name: "Docker agent",
+			agent: &jenkinsModels.Agent{
+				Label: utils.GetPtr("docker-hosts"),
+				Docker: &jenkinsModels.DockerAgent{
+					Image:                 utils.GetPtr("registry.example.com/team/app:1.0"),
+					RegistryCredentialsID: utils.GetPtr("registry"),
+				},
+			},
+			expectedRunner: &models.Runner{
+				Type:   utils.GetPtr("docker"),
+				Labels: &[]string{"docker-hosts"},
+				DockerMetadata: &models.DockerMetadata{
+					Image:                 utils.GetPtr("team/app"),
+					Label:                 utils.GetPtr("1.0"),
+					RegistryURL:           utils.GetPtr("registry.example.com"),
+					RegistryCredentialsID: utils.GetPtr("registry"),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseRunner(testCase.agent)
+
+			testutils.DeepCompare(t, testCase.expectedRunner, got)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package jenkins
+
+import (
+	jenkinsModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+var (
+	shellSteps = []string{"sh", "bat", "powershell", "pwsh"}
+
+	// Groovy statements that may appear in script blocks and are not steps
+	groovyStatements = []string{"def", "return", "break", "continue", "throw", "import"}
+)
+
+func parseSteps(steps []*jenkinsModels.Step) []*models.Step {
+	var parsedSteps []*models.Step
+	for _, step := range steps {
+		parsedSteps = append(parsedSteps, parseStep(step)...)
+	}
+	return parsedSteps
+}
+
+// parseStep returns the steps that a step runs.
+// Steps with a closure (e.g. script, dir, withEnv, withCredentials) are replaced by the steps in their closure.
+func parseStep(step *jenkinsModels.Step) []*models.Step {
+	if step == nil || utils.SliceContains(groovyStatements, step.Name) {
+		return nil
+	}
+
+	if step.Steps != nil {
+		steps := parseSteps(step.Steps)
+		if step.Name == "dir" {
+			if directory := getArgumentValue(step.Arguments, "path", 0); directory != nil {
+				for _, nestedStep := range steps {
+					if nestedStep.WorkingDirectory == nil {
+						nestedStep.WorkingDirectory = directory
+					}
+				}
+			}
+		}
+		return steps
+	}
+
+	if utils.SliceContains(shellSteps, step.Name) {
+		if shellStep := parseShellStep(step); shellStep != nil {
+			return []*models.Step{shellStep}
+		}
+		return nil
+	}
+
+	return []*models.Step{parseTaskStep(step)}
+}
+
+func parseShellStep(step *jenkinsModels.Step) *models.Step {
+	script := getArgumentValue(step.Arguments, "script", 0)
+	if script == nil {
+		return nil
+	}
+
+	return &models.Step{
+		Name: getArgumentValue(step.Arguments, "label", -1),
+		Type: models.ShellStepType,
+		Shell: &models.Shell{
+			Type:          utils.GetPtr(step.Name),
+			Script:        script,
+			FileReference: step.FileReference,
+		},
+		FileReference: step.FileReference,
+	}
+}
+
+func parseTaskStep(step *jenkinsModels.Step) *models.Step {
+	return &models.Step{
+		Name: utils.GetPtr(step.Name),
+		Type: models.TaskStepType,
+		Task: &models.Task{
+			Name:        utils.GetPtr(step.Name),
+			Inputs:      parseInputs(step.Arguments),
+			VersionType: models.None,
+		},
+		FileReference: step.FileReference,
+	}
+}
+
+func parseInputs(arguments []*jenkinsModels.Argument) []*models.Parameter {
+	if len(arguments) == 0 {
+		return nil
+	}
+
+	return utils.Map(arguments, func(argument *jenkinsModels.Argument) *models.Parameter {
+		return &models.Parameter{
+			Name:  argument.Name,
+			Value: argument.Value,
+		}
+	})
+}
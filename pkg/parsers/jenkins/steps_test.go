@@ -0,0 +1,114 @@
+package jenkins
+
+import (
+	"testing"
+
+	jenkinsModels "github.com/argonsecurity/pipeline-parser/pkg/loaders/jenkins/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestParseSteps(t *testing.T) {
+	testCases := []struct {
+		name          string
+		steps         []*jenkinsModels.Step
+		expectedSteps []*models.Step
+	}{
+		{
+			name:          "No steps",
+			steps:         nil,
+			expectedSteps: nil,
+		},
+		{
+			name: "Shell step with named arguments",
+			steps: []*jenkinsModels.Step{
+				{
+					Name: "bat",
+					Arguments: []*jenkinsModels.Argument{
+						{Name: utils.GetPtr("label"), Value: "Build", IsString: true},
+						{Name: utils.GetPtr("script"), Value: "build.cmd", IsString: true},
+					},
+					FileReference: testutils.CreateFileReference(1, 1, 1, 40),
+				},
+			},
+			expectedSteps: []*models.Step{
+				{
+					Name: utils.GetPtr("Build"),
+					Type: models.ShellStepType,
+					Shell: &models.Shell{
+						Type:          utils.GetPtr("bat"),
+						Script:        utils.GetPtr("build.cmd"),
+						FileReference: testutils.CreateFileReference(1, 1, 1, 40),
+					},
+					FileReference: testutils.CreateFileReference(1, 1, 1, 40),
+				},
+			},
+		},
+		{
+			name: "Closures are replaced by their steps",
+			steps: []*jenkinsModels.Step{
+				{
+					Name:      "dir",
+					Arguments: []*jenkinsModels.Argument{{Value: "app", IsString: true}},
+					Steps: []*jenkinsModels.Step{
+						{
+							Name: "script",
+							Steps: []*jenkinsModels.Step{
+								{Name: "def", Arguments: []*jenkinsModels.Argument{{Value: "x = 1"}}},
+								{Name: "sh", Arguments: []*jenkinsModels.Argument{{Value: "make", IsString: true}}},
+							},
+						},
+						{Name: "script", Steps: []*jenkinsModels.Step{}},
+					},
+				},
+			},
+			expectedSteps: []*models.Step{
+				{
+					Type:             models.ShellStepType,
+					WorkingDirectory: utils.GetPtr("app"),
+					Shell: &models.Shell{
+						Type:   utils.GetPtr("sh"),
+						Script: utils.GetPtr("make"),
+					},
+				},
+			},
+		},
+		{
+			name: "Task step",
+			steps: []*jenkinsModels.Step{
+				{
+					Name: "junit",
+					Arguments: []*jenkinsModels.Argument{
+						{Value: "reports/*.xml", IsString: true},
+						{Name: utils.GetPtr("allowEmptyResults"), Value: "true"},
+					},
+					FileReference: testutils.CreateFileReference(1, 1, 1, 50),
+				},
+			},
+			expectedSteps: []*models.Step{
+				{
+					Name: utils.GetPtr("junit"),
+					Type: models.TaskStepType,
+					Task: &models.Task{
+						Name: utils.GetPtr("junit"),
+						Inputs: []*models.Parameter{
+							{Value: "reports/*.xml"},
+							{Name: utils.GetPtr("allowEmptyResults"), Value: "true"},
+						},
+						VersionType: models.None,
+					},
+					FileReference: testutils.CreateFileReference(1, 1, 1, 50),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := parseSteps(testCase.steps)
+
+			testutils.DeepCompare(t, testCase.expectedSteps, got)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package utils
+
+import "github.com/argonsecurity/pipeline-parser/pkg/models"
+
+// CollectStepsArtifacts returns the artifacts produced and consumed by all the steps of a job
+func CollectStepsArtifacts(steps []*models.Step) ([]*models.Artifact, []*models.Artifact) {
+	var produces, consumes []*models.Artifact
+	for _, step := range steps {
+		if step == nil {
+			continue
+		}
+		produces = append(produces, step.Produces...)
+		consumes = append(consumes, step.Consumes...)
+	}
+	return produces, consumes
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestCollectStepsArtifacts(t *testing.T) {
+	testCases := []struct {
+		name             string
+		steps            []*models.Step
+		expectedProduces []*models.Artifact
+		expectedConsumes []*models.Artifact
+	}{
+		{
+			name:  "Steps are nil",
+			steps: nil,
+		},
+		{
+			name: "Steps with artifacts",
+			steps: []*models.Step{
+				{
+					Consumes: []*models.Artifact{{Type: models.CacheArtifactType, Name: utils.GetPtr("deps")}},
+					Produces: []*models.Artifact{{Type: models.CacheArtifactType, Name: utils.GetPtr("deps")}},
+				},
+				nil,
+				{},
+				{
+					Produces: []*models.Artifact{{Type: models.FileArtifactType, Name: utils.GetPtr("dist")}},
+				},
+			},
+			expectedProduces: []*models.Artifact{
+				{Type: models.CacheArtifactType, Name: utils.GetPtr("deps")},
+				{Type: models.FileArtifactType, Name: utils.GetPtr("dist")},
+			},
+			expectedConsumes: []*models.Artifact{
+				{Type: models.CacheArtifactType, Name: utils.GetPtr("deps")},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			produces, consumes := CollectStepsArtifacts(testCase.steps)
+
+			testutils.DeepCompare(t, testCase.expectedProduces, produces)
+			testutils.DeepCompare(t, testCase.expectedConsumes, consumes)
+		})
+	}
+}
@@ -29,6 +29,24 @@ func ParseImageName(imageName string) (string, string, string, string) {
 	return registry, namespace, image, tag
 }
 
+// ParseDockerMetadata splits an image name, e.g. registry.example.com/team/app:1.0, into its docker metadata
+func ParseDockerMetadata(imageName string) *models.DockerMetadata {
+	if imageName == "" {
+		return nil
+	}
+
+	registry, namespace, image, tag := ParseImageName(imageName)
+	if namespace != "" {
+		image = namespace + "/" + image
+	}
+
+	return &models.DockerMetadata{
+		Image:       utils.GetPtrOrNil(image),
+		Label:       utils.GetPtrOrNil(tag),
+		RegistryURL: utils.GetPtrOrNil(registry),
+	}
+}
+
 func ParseRunnerTag(tag string, runner *models.Runner) *models.Runner {
 	if runner == nil {
 		return runner
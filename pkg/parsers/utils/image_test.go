@@ -3,6 +3,9 @@ package utils
 import (
 	"testing"
 
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -76,3 +79,42 @@ func TestParseImageName(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDockerMetadata(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		imageName              string
+		expectedDockerMetadata *models.DockerMetadata
+	}{
+		{
+			name:                   "Empty image name",
+			imageName:              "",
+			expectedDockerMetadata: nil,
+		},
+		{
+			name:      "Image name with tag",
+			imageName: "postgres:14",
+			expectedDockerMetadata: &models.DockerMetadata{
+				Image: utils.GetPtr("postgres"),
+				Label: utils.GetPtr("14"),
+			},
+		},
+		{
+			name:      "Image name with registry, namespace and tag",
+			imageName: "ghcr.io/org/app:1.0",
+			expectedDockerMetadata: &models.DockerMetadata{
+				Image:       utils.GetPtr("org/app"),
+				Label:       utils.GetPtr("1.0"),
+				RegistryURL: utils.GetPtr("ghcr.io"),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := ParseDockerMetadata(testCase.imageName)
+
+			testutils.DeepCompare(t, testCase.expectedDockerMetadata, got)
+		})
+	}
+}
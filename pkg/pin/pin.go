@@ -0,0 +1,231 @@
+package pin
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	loadersUtils "github.com/argonsecurity/pipeline-parser/pkg/loaders/utils"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	parserUtils "github.com/argonsecurity/pipeline-parser/pkg/parsers/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	jobsKey  = "jobs"
+	stepsKey = "steps"
+	usesKey  = "uses"
+
+	dockerPrefix      = "docker://"
+	localActionPrefix = "./"
+	versionSeparator  = "@"
+)
+
+// Edit is a uses reference of a workflow that was pinned to a commit SHA
+type Edit struct {
+	Uses          string                `json:"uses"`
+	Repository    string                `json:"repository"`
+	Ref           string                `json:"ref"`
+	VersionType   models.VersionType    `json:"version_type"`
+	SHA           string                `json:"sha"`
+	FileReference *models.FileReference `json:"file_reference,omitempty"`
+}
+
+// reference is a uses scalar of a workflow that points to a tag or a branch of a repository
+type reference struct {
+	node       *yaml.Node
+	name       string
+	repository string
+	ref        string
+	inFlow     bool
+}
+
+// Pin replaces the tag and branch refs of the actions and reusable workflows of a GitHub workflow with the commit SHAs
+// they resolve to, and keeps the original ref as a trailing comment.
+// Only the pinned refs are rewritten, the rest of the file, including comments and formatting, is kept as is.
+// Local actions, docker images and refs that are already commit SHAs are left untouched.
+func Pin(data []byte, resolver Resolver) ([]byte, []*Edit, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, consts.NewErrInvalidYaml(err.Error())
+	}
+
+	references := findReferences(&root)
+	// edits are applied from the end of the file, so the positions of the remaining references stay valid
+	sort.Slice(references, func(i, j int) bool {
+		if references[i].node.Line != references[j].node.Line {
+			return references[i].node.Line > references[j].node.Line
+		}
+		return references[i].node.Column > references[j].node.Column
+	})
+
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	edits := []*Edit{}
+	for _, reference := range references {
+		sha, err := resolver.Resolve(reference.repository, reference.ref)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := rewriteReference(lines, reference, sha); err != nil {
+			return nil, nil, err
+		}
+		edits = append(edits, &Edit{
+			Uses:          reference.node.Value,
+			Repository:    reference.repository,
+			Ref:           reference.ref,
+			VersionType:   parserUtils.DetectVersionType(reference.ref),
+			SHA:           sha,
+			FileReference: loadersUtils.GetFileReference(reference.node),
+		})
+	}
+
+	// edits are reported in the order they appear in the file
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return bytes.Join(lines, nil), edits, nil
+}
+
+// findReferences returns the uses scalars of the steps and the reusable workflow jobs of a workflow that are not pinned
+func findReferences(root *yaml.Node) []*reference {
+	if len(root.Content) == 0 {
+		return nil
+	}
+
+	var references []*reference
+	jobs := getMapValue(root.Content[0], jobsKey)
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 1; i < len(jobs.Content); i += 2 {
+		job := jobs.Content[i]
+		if job.Kind != yaml.MappingNode {
+			continue
+		}
+		if reference := newReference(getMapValue(job, usesKey), job.Style&yaml.FlowStyle != 0); reference != nil {
+			references = append(references, reference)
+		}
+
+		steps := getMapValue(job, stepsKey)
+		if steps == nil || steps.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, step := range steps.Content {
+			if step.Kind != yaml.MappingNode {
+				continue
+			}
+			inFlow := steps.Style&yaml.FlowStyle != 0 || step.Style&yaml.FlowStyle != 0
+			if reference := newReference(getMapValue(step, usesKey), inFlow); reference != nil {
+				references = append(references, reference)
+			}
+		}
+	}
+	return references
+}
+
+// newReference returns the reference of a uses scalar, or nil if it does not point to a tag or a branch of a repository
+func newReference(node *yaml.Node, inFlow bool) *reference {
+	if node == nil || node.Kind != yaml.ScalarNode || strings.HasPrefix(node.Value, dockerPrefix) || strings.HasPrefix(node.Value, localActionPrefix) {
+		return nil
+	}
+
+	index := strings.LastIndex(node.Value, versionSeparator)
+	if index == -1 {
+		return nil
+	}
+	name, ref := node.Value[:index], node.Value[index+1:]
+	if versionType := parserUtils.DetectVersionType(ref); versionType != models.TagVersion && versionType != models.BranchVersion {
+		return nil
+	}
+
+	// actions in sub directories and reusable workflows are pinned by the repository that holds them, e.g. `github/codeql-action/init`
+	parts := strings.SplitN(name, "/", 3)
+	if len(parts) < 2 {
+		return nil
+	}
+
+	return &reference{
+		node:       node,
+		name:       name,
+		repository: strings.Join(parts[:2], "/"),
+		ref:        ref,
+		inFlow:     inFlow,
+	}
+}
+
+// rewriteReference replaces the scalar of the reference with the pinned reference, keeping its quoting style.
+// The original ref replaces the trailing comment of the line, unless the reference is inside a flow collection.
+func rewriteReference(lines [][]byte, reference *reference, sha string) error {
+	node := reference.node
+	if node.Line < 1 || node.Line > len(lines) {
+		return consts.NewErrInvalidYaml(fmt.Sprintf("uses '%s' is out of the file", node.Value))
+	}
+
+	line := lines[node.Line-1]
+	start := getByteOffset(line, node.Column-1)
+	quote := ""
+	switch node.Style {
+	case yaml.DoubleQuotedStyle:
+		quote = `"`
+	case yaml.SingleQuotedStyle:
+		quote = "'"
+	}
+
+	token := quote + node.Value + quote
+	if !bytes.HasPrefix(line[start:], []byte(token)) {
+		return consts.NewErrInvalidYaml(fmt.Sprintf("uses '%s' was not found at line %d column %d", node.Value, node.Line, node.Column))
+	}
+
+	pinned := quote + reference.name + versionSeparator + sha + quote
+	rest := line[start+len(token):]
+	if !reference.inFlow {
+		pinned = fmt.Sprintf("%s # %s", pinned, reference.ref)
+		rest = removeLineComment(rest)
+	}
+
+	rewritten := make([]byte, 0, len(line)+len(pinned)-len(token))
+	rewritten = append(rewritten, line[:start]...)
+	rewritten = append(rewritten, pinned...)
+	rewritten = append(rewritten, rest...)
+	lines[node.Line-1] = rewritten
+	return nil
+}
+
+// removeLineComment removes the trailing comment that follows a scalar, keeping the line ending
+func removeLineComment(rest []byte) []byte {
+	if !bytes.HasPrefix(bytes.TrimLeft(rest, " \t"), []byte("#")) {
+		return rest
+	}
+	if index := bytes.IndexAny(rest, "\r\n"); index != -1 {
+		return rest[index:]
+	}
+	return nil
+}
+
+// getByteOffset converts a zero based column, which yaml counts in characters, to a byte offset in the line
+func getByteOffset(line []byte, column int) int {
+	offset := 0
+	for i := 0; i < column && offset < len(line); i++ {
+		_, size := utf8.DecodeRune(line[offset:])
+		offset += size
+	}
+	return offset
+}
+
+func getMapValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
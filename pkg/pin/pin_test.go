@@ -0,0 +1,183 @@
+package pin
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	checkoutSHA = "b4ffde65f46336ab88eb53be808477a3936bae11"
+	codeqlSHA   = "e5f05b81d5b6ff8cfa111c80c22c5fd02a384118"
+	workflowSHA = "0c52d547c9bc32b1aa3301fd7a9cb496313a4491"
+)
+
+var lockfile = &LockfileResolver{
+	Refs: map[string]string{
+		"actions/checkout@v4":       checkoutSHA,
+		"github/codeql-action@main": codeqlSHA,
+		"org/workflows@v1.2.0":      workflowSHA,
+	},
+}
+
+func TestPin(t *testing.T) {
+	testCases := []struct {
+		name          string
+		data          string
+		expectedData  string
+		expectedEdits []*Edit
+		expectedError bool
+	}{
+		{
+			name: "Steps and reusable workflows",
+			data: `# build the project
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4 # checkout
+      - name: analyze
+        uses: "github/codeql-action/init@main"
+      - uses: actions/setup-go@` + checkoutSHA + `
+      - uses: ./.github/actions/local
+      - uses: docker://alpine:3.18
+      - run: make
+  deploy:
+    uses: 'org/workflows/.github/workflows/deploy.yml@v1.2.0'
+`,
+			expectedData: `# build the project
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@` + checkoutSHA + ` # v4
+      - name: analyze
+        uses: "github/codeql-action/init@` + codeqlSHA + `" # main
+      - uses: actions/setup-go@` + checkoutSHA + `
+      - uses: ./.github/actions/local
+      - uses: docker://alpine:3.18
+      - run: make
+  deploy:
+    uses: 'org/workflows/.github/workflows/deploy.yml@` + workflowSHA + `' # v1.2.0
+`,
+			expectedEdits: []*Edit{
+				{
+					Uses:          "actions/checkout@v4",
+					Repository:    "actions/checkout",
+					Ref:           "v4",
+					VersionType:   models.TagVersion,
+					SHA:           checkoutSHA,
+					FileReference: testutils.CreateFileReference(7, 15, 7, 34),
+				},
+				{
+					Uses:          "github/codeql-action/init@main",
+					Repository:    "github/codeql-action",
+					Ref:           "main",
+					VersionType:   models.BranchVersion,
+					SHA:           codeqlSHA,
+					FileReference: testutils.CreateFileReference(9, 15, 9, 45),
+				},
+				{
+					Uses:          "org/workflows/.github/workflows/deploy.yml@v1.2.0",
+					Repository:    "org/workflows",
+					Ref:           "v1.2.0",
+					VersionType:   models.TagVersion,
+					SHA:           workflowSHA,
+					FileReference: testutils.CreateFileReference(15, 11, 15, 60),
+				},
+			},
+		},
+		{
+			name: "Existing ref comment",
+			data: `jobs:
+  build:
+    steps:
+      - uses: actions/checkout@v4 # v4
+      - uses: actions/checkout@v4  #v4
+`,
+			expectedData: `jobs:
+  build:
+    steps:
+      - uses: actions/checkout@` + checkoutSHA + ` # v4
+      - uses: actions/checkout@` + checkoutSHA + ` # v4
+`,
+			expectedEdits: []*Edit{
+				{
+					Uses:          "actions/checkout@v4",
+					Repository:    "actions/checkout",
+					Ref:           "v4",
+					VersionType:   models.TagVersion,
+					SHA:           checkoutSHA,
+					FileReference: testutils.CreateFileReference(4, 15, 4, 34),
+				},
+				{
+					Uses:          "actions/checkout@v4",
+					Repository:    "actions/checkout",
+					Ref:           "v4",
+					VersionType:   models.TagVersion,
+					SHA:           checkoutSHA,
+					FileReference: testutils.CreateFileReference(5, 15, 5, 34),
+				},
+			},
+		},
+		{
+			name: "Flow style steps",
+			data: `jobs:
+  build:
+    steps: [{uses: actions/checkout@v4}, {run: make}]
+`,
+			expectedData: `jobs:
+  build:
+    steps: [{uses: actions/checkout@` + checkoutSHA + `}, {run: make}]
+`,
+			expectedEdits: []*Edit{
+				{
+					Uses:          "actions/checkout@v4",
+					Repository:    "actions/checkout",
+					Ref:           "v4",
+					VersionType:   models.TagVersion,
+					SHA:           checkoutSHA,
+					FileReference: testutils.CreateFileReference(3, 20, 3, 39),
+				},
+			},
+		},
+		{
+			name:          "No jobs",
+			data:          "on: push\n",
+			expectedData:  "on: push\n",
+			expectedEdits: []*Edit{},
+		},
+		{
+			name: "Unresolved ref",
+			data: `jobs:
+  build:
+    steps:
+      - uses: actions/cache@v3
+`,
+			expectedError: true,
+		},
+		{
+			name:          "Invalid yaml",
+			data:          "jobs: [",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			data, edits, err := Pin([]byte(testCase.data), lockfile)
+
+			if testCase.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedData, string(data))
+			testutils.DeepCompare(t, testCase.expectedEdits, edits)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package pin
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Resolver resolves a tag or a branch of a repository, e.g. `actions/checkout`, to the commit SHA it points to
+type Resolver interface {
+	Resolve(repository string, ref string) (string, error)
+}
+
+// GitResolver resolves refs from git mirrors of the repositories, without accessing the network.
+// The mirror of a repository is the git directory at <Root>/<owner>/<repository>, either a bare mirror or a checkout.
+// A ref that is not a local branch, tag or commit is looked up in the origin remote branches.
+type GitResolver struct {
+	Root string
+}
+
+func (g *GitResolver) Resolve(repository string, ref string) (string, error) {
+	directory, ok := utils.JoinUnderRoot(g.Root, filepath.FromSlash(repository))
+	if !ok {
+		return "", consts.NewErrResolveRef(repository, ref, "the repository is outside of the root directory")
+	}
+	// the ref is read from the workflow, so it must not be parsed as an option of git
+	if strings.HasPrefix(ref, "-") {
+		return "", consts.NewErrResolveRef(repository, ref, "invalid ref")
+	}
+
+	sha, err := gitRevParse(directory, ref)
+	if err != nil {
+		sha, err = gitRevParse(directory, "origin/"+ref)
+	}
+	if err != nil {
+		return "", consts.NewErrResolveRef(repository, ref, err.Error())
+	}
+	return sha, nil
+}
+
+func gitRevParse(directory, ref string) (string, error) {
+	cmd := exec.Command("git", "-C", directory, "rev-parse", "--verify", "--end-of-options", fmt.Sprintf("%s^{commit}", ref))
+	buf, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return "", fmt.Errorf("%s", strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return strings.TrimSpace(string(buf)), err
+}
+
+// LockfileResolver resolves refs from a static lockfile that maps `<repository>@<ref>` to a commit SHA
+type LockfileResolver struct {
+	Refs map[string]string
+}
+
+// LoadLockfile reads a YAML lockfile of `<repository>@<ref>: <sha>` entries, e.g.
+//
+//	actions/checkout@v4: b4ffde65f46336ab88eb53be808477a3936bae11
+func LoadLockfile(path string) (*LockfileResolver, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := map[string]string{}
+	if err := yaml.Unmarshal(buf, &refs); err != nil {
+		return nil, consts.NewErrInvalidYaml(err.Error())
+	}
+	return &LockfileResolver{Refs: refs}, nil
+}
+
+func (l *LockfileResolver) Resolve(repository string, ref string) (string, error) {
+	sha, ok := l.Refs[repository+versionSeparator+ref]
+	if !ok {
+		return "", consts.NewErrResolveRef(repository, ref, "not found in the lockfile")
+	}
+	return sha, nil
+}
@@ -0,0 +1,120 @@
+package pin
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createGitRepository(t *testing.T, directory string, tag string) string {
+	t.Helper()
+	run := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", directory, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		t.Fatal(err)
+	}
+	run("init", "-q")
+	if err := os.WriteFile(filepath.Join(directory, "action.yml"), []byte("name: action"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "-A")
+	run("commit", "-q", "-m", "init")
+	run("tag", "-a", tag, "-m", tag)
+	run("branch", "-M", "main")
+	return run("rev-parse", "HEAD")
+}
+
+func TestGitResolver(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	sha := createGitRepository(t, filepath.Join(root, "actions", "checkout"), "v4")
+	resolver := &GitResolver{Root: root}
+
+	tests := []struct {
+		name       string
+		repository string
+		ref        string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "annotated tag",
+			repository: "actions/checkout",
+			ref:        "v4",
+			want:       sha,
+		},
+		{
+			name:       "branch",
+			repository: "actions/checkout",
+			ref:        "main",
+			want:       sha,
+		},
+		{
+			name:       "unknown ref",
+			repository: "actions/checkout",
+			ref:        "v5",
+			wantErr:    true,
+		},
+		{
+			name:       "ref that is a git option",
+			repository: "actions/checkout",
+			ref:        "--git-dir=" + root,
+			wantErr:    true,
+		},
+		{
+			name:       "repository outside of the root",
+			repository: "../../actions/checkout",
+			ref:        "v4",
+			wantErr:    true,
+		},
+		{
+			name:       "unknown repository",
+			repository: "actions/cache",
+			ref:        "v4",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolver.Resolve(tt.repository, tt.ref)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadLockfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "actions.lock.yml")
+	if err := os.WriteFile(path, []byte("actions/checkout@v4: "+checkoutSHA+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resolver, err := LoadLockfile(path)
+	assert.NoError(t, err)
+
+	got, err := resolver.Resolve("actions/checkout", "v4")
+	assert.NoError(t, err)
+	assert.Equal(t, checkoutSHA, got)
+
+	_, err = resolver.Resolve("actions/checkout", "v3")
+	assert.Error(t, err)
+}
@@ -0,0 +1,154 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+)
+
+const (
+	policyFileExtension  = ".rego"
+	policyTestFileSuffix = "_test.rego"
+
+	// denyRule is the rule of a policy that holds its violations, as either messages or objects with a msg field
+	denyRule       = "deny"
+	messageField   = "msg"
+	dataRootPrefix = "data."
+)
+
+// Result is the outcome of evaluating a policy against a policy input
+type Result struct {
+	Policy     string   `json:"policy"`
+	Passed     bool     `json:"passed"`
+	Violations []string `json:"violations"`
+}
+
+// Evaluator evaluates Rego policies against policy inputs.
+// A policy is a Rego package, and it fails if its deny rule holds any violation.
+type Evaluator struct {
+	policies []*preparedPolicy
+}
+
+type preparedPolicy struct {
+	name  string
+	query rego.PreparedEvalQuery
+}
+
+// LoadPolicies compiles the Rego files in dir and its sub directories, skipping Rego test files
+func LoadPolicies(ctx context.Context, dir string) (*Evaluator, error) {
+	modules := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != policyFileExtension || strings.HasSuffix(path, policyTestFileSuffix) {
+			return nil
+		}
+
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		modules[path] = string(buf)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEvaluator(ctx, modules)
+}
+
+// NewEvaluator compiles Rego modules keyed by their file name
+func NewEvaluator(ctx context.Context, modules map[string]string) (*Evaluator, error) {
+	compiler, err := ast.CompileModules(modules)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := map[string]ast.Ref{}
+	for _, module := range compiler.Modules {
+		packages[module.Package.Path.String()] = module.Package.Path
+	}
+
+	evaluator := &Evaluator{}
+	for name, path := range packages {
+		query, err := rego.New(
+			rego.Compiler(compiler),
+			rego.Query(path.Append(ast.StringTerm(denyRule)).String()),
+		).PrepareForEval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		evaluator.policies = append(evaluator.policies, &preparedPolicy{name: strings.TrimPrefix(name, dataRootPrefix), query: query})
+	}
+	sort.Slice(evaluator.policies, func(i, j int) bool {
+		return evaluator.policies[i].name < evaluator.policies[j].name
+	})
+
+	return evaluator, nil
+}
+
+// Evaluate evaluates every policy against the input and returns the results ordered by policy name.
+// A policy without a deny rule passes.
+func (e *Evaluator) Evaluate(ctx context.Context, input *Input) ([]*Result, error) {
+	results := []*Result{}
+	for _, policy := range e.policies {
+		resultSet, err := policy.query.Eval(ctx, rego.EvalInput(input))
+		if err != nil {
+			return nil, err
+		}
+
+		violations := []string{}
+		for _, result := range resultSet {
+			for _, expression := range result.Expressions {
+				violations = append(violations, getViolations(expression.Value)...)
+			}
+		}
+		sort.Strings(violations)
+
+		results = append(results, &Result{
+			Policy:     policy.name,
+			Passed:     len(violations) == 0,
+			Violations: violations,
+		})
+	}
+	return results, nil
+}
+
+// getViolations returns the messages of the value of a deny rule, which is either a set or a single value
+func getViolations(value any) []string {
+	values, ok := value.([]any)
+	if !ok {
+		values = []any{value}
+	}
+
+	var violations []string
+	for _, v := range values {
+		switch violation := v.(type) {
+		case nil:
+		case bool:
+			if violation {
+				violations = append(violations, denyRule)
+			}
+		case string:
+			violations = append(violations, violation)
+		case map[string]any:
+			if message, ok := violation[messageField].(string); ok {
+				violations = append(violations, message)
+			} else {
+				violations = append(violations, fmt.Sprint(violation))
+			}
+		default:
+			violations = append(violations, fmt.Sprint(violation))
+		}
+	}
+	return violations
+}
@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	unpinnedTasksPolicy = `package pipeline.unpinned_tasks
+
+import rego.v1
+
+deny contains msg if {
+	some job in input.jobs
+	some step in job.steps
+	step.task != null
+	not step.task.pinned
+	msg := sprintf("%s uses %s@%s", [job.id, step.task.name, step.task.version])
+}
+`
+
+	selfHostedPolicy = `package pipeline.self_hosted
+
+import rego.v1
+
+deny contains {"msg": sprintf("%s runs on a self-hosted runner", [job.id])} if {
+	some job in input.jobs
+	job.runner.self_hosted
+}
+`
+
+	noDenyPolicy = `package pipeline.helpers
+
+import rego.v1
+
+is_github if input.platform == "github"
+`
+)
+
+func TestEvaluate(t *testing.T) {
+	input := &Input{
+		Platform: "github",
+		Jobs: []*Job{
+			{
+				ID: "build",
+				Steps: []*Step{
+					{Task: &Task{Name: "actions/checkout", Version: "v4"}},
+					{Task: &Task{Name: "actions/setup-go", Version: "0c52d547c9bc32b1aa3301fd7a9cb496313a4491", Pinned: true}},
+					{Script: "make"},
+				},
+			},
+		},
+	}
+
+	evaluator, err := NewEvaluator(context.Background(), map[string]string{
+		"unpinned_tasks.rego": unpinnedTasksPolicy,
+		"self_hosted.rego":    selfHostedPolicy,
+		"helpers.rego":        noDenyPolicy,
+	})
+	assert.NoError(t, err)
+
+	results, err := evaluator.Evaluate(context.Background(), input)
+	assert.NoError(t, err)
+	testutils.DeepCompare(t, []*Result{
+		{Policy: "pipeline.helpers", Passed: true, Violations: []string{}},
+		{Policy: "pipeline.self_hosted", Passed: true, Violations: []string{}},
+		{Policy: "pipeline.unpinned_tasks", Passed: false, Violations: []string{"build uses actions/checkout@v4"}},
+	}, results)
+
+	input.Jobs[0].Runner = &Runner{SelfHosted: true}
+	results, err = evaluator.Evaluate(context.Background(), input)
+	assert.NoError(t, err)
+	testutils.DeepCompare(t, &Result{Policy: "pipeline.self_hosted", Passed: false, Violations: []string{"build runs on a self-hosted runner"}}, results[1])
+}
+
+func TestLoadPolicies(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "github"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "github", "unpinned_tasks.rego"), []byte(unpinnedTasksPolicy), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "github", "unpinned_tasks_test.rego"), []byte("package pipeline.unpinned_tasks_test\n\ninvalid {"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# policies"), 0644))
+
+	evaluator, err := LoadPolicies(context.Background(), dir)
+	assert.NoError(t, err)
+
+	results, err := evaluator.Evaluate(context.Background(), &Input{})
+	assert.NoError(t, err)
+	testutils.DeepCompare(t, []*Result{{Policy: "pipeline.unpinned_tasks", Passed: true, Violations: []string{}}}, results)
+}
+
+func TestLoadPoliciesInvalidModule(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.rego"), []byte("package invalid\n\ndeny {"), 0644))
+
+	_, err := LoadPolicies(context.Background(), dir)
+	assert.Error(t, err)
+}
@@ -0,0 +1,500 @@
+package policy
+
+import (
+	"path/filepath"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+// InputVersion is the version of the policy input schema.
+// It is bumped only when a field is removed or changes its meaning, new fields may be added in the same version.
+const InputVersion = "1"
+
+// Input is the stable view of a parsed pipeline that policies are evaluated against.
+// Every field is always present: missing strings are empty, missing lists and maps are empty,
+// and missing objects are null. Enumerations are lower case strings.
+type Input struct {
+	Version     string        `json:"version"`
+	Path        string        `json:"path"`
+	Platform    string        `json:"platform"`
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Triggers    []*Trigger    `json:"triggers"`
+	Parameters  []*Parameter  `json:"parameters"`
+	Defaults    *Defaults     `json:"defaults"`
+	Stages      []*Stage      `json:"stages"`
+	Jobs        []*Job        `json:"jobs"`
+	Imports     []*Import     `json:"imports"`
+	Diagnostics []*Diagnostic `json:"diagnostics"`
+}
+
+// Location is the range of a file that an entity is defined in, lines and columns start at 1
+type Location struct {
+	StartLine   int `json:"start_line"`
+	StartColumn int `json:"start_column"`
+	EndLine     int `json:"end_line"`
+	EndColumn   int `json:"end_column"`
+}
+
+// Filter is a list of glob patterns that are allowed or denied
+type Filter struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+// Trigger is an event that runs the pipeline, such as push, pull_request, manual or scheduled
+type Trigger struct {
+	Event     string    `json:"event"`
+	Branches  *Filter   `json:"branches"`
+	Tags      *Filter   `json:"tags"`
+	Paths     *Filter   `json:"paths"`
+	Schedules []string  `json:"schedules"`
+	Disabled  bool      `json:"disabled"`
+	Jobs      []string  `json:"jobs"` // The IDs of the jobs the trigger runs, all of the jobs when empty
+	Location  *Location `json:"location"`
+}
+
+type Parameter struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Value       any       `json:"value"`
+	Default     any       `json:"default"`
+	Options     []string  `json:"options"`
+	Location    *Location `json:"location"`
+}
+
+// Permission is the access a token permission grants to a scope
+type Permission struct {
+	Read  bool `json:"read"`
+	Write bool `json:"write"`
+	Admin bool `json:"admin"`
+}
+
+type Runner struct {
+	Type       string    `json:"type"`
+	OS         string    `json:"os"`
+	Arch       string    `json:"arch"`
+	Labels     []string  `json:"labels"`
+	SelfHosted bool      `json:"self_hosted"`
+	Image      string    `json:"image"`
+	Location   *Location `json:"location"`
+}
+
+type Defaults struct {
+	EnvironmentVariables map[string]any         `json:"environment_variables"`
+	Runner               *Runner                `json:"runner"`
+	Permissions          map[string]*Permission `json:"permissions"`
+	Location             *Location              `json:"location"`
+}
+
+type Stage struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Order        int       `json:"order"`
+	Dependencies []string  `json:"dependencies"`
+	Jobs         []string  `json:"jobs"`
+	Location     *Location `json:"location"`
+}
+
+type Service struct {
+	Name     string    `json:"name"`
+	Image    string    `json:"image"`
+	Ports    []string  `json:"ports"`
+	Location *Location `json:"location"`
+}
+
+type Environment struct {
+	Name     string    `json:"name"`
+	URL      string    `json:"url"`
+	Location *Location `json:"location"`
+}
+
+type Job struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name"`
+	Dependencies         []string               `json:"dependencies"`
+	Conditions           []string               `json:"conditions"`
+	Runner               *Runner                `json:"runner"`
+	Services             []*Service             `json:"services"`
+	Environment          *Environment           `json:"environment"`
+	EnvironmentVariables map[string]any         `json:"environment_variables"`
+	Permissions          map[string]*Permission `json:"permissions"`
+	TimeoutMS            int                    `json:"timeout_ms"`
+	Build                bool                   `json:"build"`
+	Test                 bool                   `json:"test"`
+	Deploy               bool                   `json:"deploy"`
+	PreSteps             []*Step                `json:"pre_steps"`
+	Steps                []*Step                `json:"steps"`
+	PostSteps            []*Step                `json:"post_steps"`
+	Import               *Import                `json:"import"`
+	Location             *Location              `json:"location"`
+}
+
+type Step struct {
+	ID                   string         `json:"id"`
+	Name                 string         `json:"name"`
+	Type                 string         `json:"type"`
+	Shell                string         `json:"shell"`
+	Script               string         `json:"script"`
+	Task                 *Task          `json:"task"`
+	EnvironmentVariables map[string]any `json:"environment_variables"`
+	WorkingDirectory     string         `json:"working_directory"`
+	Disabled             bool           `json:"disabled"`
+	Import               *Import        `json:"import"`
+	Location             *Location      `json:"location"`
+}
+
+// Task is an action, orb, template or docker image that a step runs
+type Task struct {
+	Name        string         `json:"name"`
+	Type        string         `json:"type"`
+	Version     string         `json:"version"`
+	VersionType string         `json:"version_type"`
+	Pinned      bool           `json:"pinned"`
+	Inputs      map[string]any `json:"inputs"`
+}
+
+type ImportSource struct {
+	SCM          string `json:"scm"`
+	Type         string `json:"type"`
+	Organization string `json:"organization"`
+	Repository   string `json:"repository"`
+	Path         string `json:"path"`
+	Reference    string `json:"reference"`
+}
+
+// Import is a pipeline imported by the pipeline, a job or a step.
+// The imported pipeline is inlined if it was resolved.
+type Import struct {
+	Source         *ImportSource  `json:"source"`
+	Version        string         `json:"version"`
+	VersionType    string         `json:"version_type"`
+	Pinned         bool           `json:"pinned"`
+	Parameters     map[string]any `json:"parameters"`
+	SecretsInherit bool           `json:"secrets_inherit"`
+	Pipeline       *Input         `json:"pipeline"`
+	Location       *Location      `json:"location"`
+}
+
+type Diagnostic struct {
+	Severity string    `json:"severity"`
+	Code     string    `json:"code"`
+	Message  string    `json:"message"`
+	Location *Location `json:"location"`
+}
+
+// NewInput returns the policy input of a pipeline that was parsed from the file at path
+func NewInput(pipeline *models.Pipeline, path string) *Input {
+	if pipeline == nil {
+		return nil
+	}
+
+	return &Input{
+		Version:     InputVersion,
+		Path:        filepath.ToSlash(path),
+		Platform:    string(pipeline.Platform),
+		ID:          deref(pipeline.Id),
+		Name:        deref(pipeline.Name),
+		Triggers:    newTriggers(pipeline.Triggers),
+		Parameters:  mapSlice(pipeline.Parameters, newParameter),
+		Defaults:    newDefaults(pipeline.Defaults),
+		Stages:      mapSlice(pipeline.Stages, newStage),
+		Jobs:        mapSlice(pipeline.Jobs, newJob),
+		Imports:     mapSlice(pipeline.Imports, newImport),
+		Diagnostics: mapSlice(pipeline.Diagnostics, newDiagnostic),
+	}
+}
+
+func newTriggers(triggers *models.Triggers) []*Trigger {
+	if triggers == nil {
+		return []*Trigger{}
+	}
+
+	return mapSlice(triggers.Triggers, func(trigger *models.Trigger) *Trigger {
+		result := &Trigger{
+			Event:     string(trigger.Event),
+			Branches:  newFilter(trigger.Branches),
+			Tags:      newFilter(trigger.Tags),
+			Paths:     newFilter(trigger.Paths),
+			Schedules: []string{},
+			Disabled:  trigger.Disabled != nil && *trigger.Disabled,
+			Jobs:      append([]string{}, trigger.Jobs...),
+			Location:  newLocation(trigger.FileReference),
+		}
+		if trigger.Schedules != nil {
+			result.Schedules = append(result.Schedules, *trigger.Schedules...)
+		}
+		return result
+	})
+}
+
+func newFilter(filter *models.Filter) *Filter {
+	if filter == nil {
+		return nil
+	}
+
+	return &Filter{
+		Allow: stringSlice(filter.AllowList),
+		Deny:  stringSlice(filter.DenyList),
+	}
+}
+
+func newParameter(parameter *models.Parameter) *Parameter {
+	return &Parameter{
+		Name:        deref(parameter.Name),
+		Description: deref(parameter.Description),
+		Value:       parameter.Value,
+		Default:     parameter.Default,
+		Options:     stringSlice(parameter.Options),
+		Location:    newLocation(parameter.FileReference),
+	}
+}
+
+func newDefaults(defaults *models.Defaults) *Defaults {
+	if defaults == nil {
+		return nil
+	}
+
+	return &Defaults{
+		EnvironmentVariables: newEnvironmentVariables(defaults.EnvironmentVariables),
+		Runner:               newRunner(defaults.Runner),
+		Permissions:          newPermissions(defaults.TokenPermissions),
+		Location:             newLocation(defaults.FileReference),
+	}
+}
+
+func newStage(stage *models.Stage) *Stage {
+	return &Stage{
+		ID:           deref(stage.ID),
+		Name:         deref(stage.Name),
+		Order:        stage.Order,
+		Dependencies: stringSlice(stage.Dependencies),
+		Jobs:         stringSlice(stage.Jobs),
+		Location:     newLocation(stage.FileReference),
+	}
+}
+
+func newJob(job *models.Job) *Job {
+	result := &Job{
+		ID:                   deref(job.ID),
+		Name:                 deref(job.Name),
+		Dependencies:         []string{},
+		Conditions:           []string{},
+		Runner:               newRunner(job.Runner),
+		Services:             mapSlice(job.Services, newService),
+		Environment:          newEnvironment(job.Environment),
+		EnvironmentVariables: newEnvironmentVariables(job.EnvironmentVariables),
+		Permissions:          newPermissions(job.TokenPermissions),
+		TimeoutMS:            deref(job.TimeoutMS),
+		Build:                job.Metadata.Build,
+		Test:                 job.Metadata.Test,
+		Deploy:               job.Metadata.Deploy,
+		PreSteps:             mapSlice(job.PreSteps, newStep),
+		Steps:                mapSlice(job.Steps, newStep),
+		PostSteps:            mapSlice(job.PostSteps, newStep),
+		Import:               newImport(job.Imports),
+		Location:             newLocation(job.FileReference),
+	}
+
+	for _, dependency := range job.Dependencies {
+		if dependency != nil && dependency.JobID != nil {
+			result.Dependencies = append(result.Dependencies, *dependency.JobID)
+		}
+	}
+	for _, condition := range job.Conditions {
+		if condition != nil && condition.Statement != "" {
+			result.Conditions = append(result.Conditions, condition.Statement)
+		}
+	}
+	return result
+}
+
+func newStep(step *models.Step) *Step {
+	result := &Step{
+		ID:                   deref(step.ID),
+		Name:                 deref(step.Name),
+		Type:                 string(step.Type),
+		Task:                 newTask(step.Task),
+		EnvironmentVariables: newEnvironmentVariables(step.EnvironmentVariables),
+		WorkingDirectory:     deref(step.WorkingDirectory),
+		Disabled:             step.Disabled != nil && *step.Disabled,
+		Import:               newImport(step.Imports),
+		Location:             newLocation(step.FileReference),
+	}
+	if step.Shell != nil {
+		result.Shell = deref(step.Shell.Type)
+		result.Script = deref(step.Shell.Script)
+	}
+	return result
+}
+
+func newTask(task *models.Task) *Task {
+	if task == nil {
+		return nil
+	}
+
+	inputs := map[string]any{}
+	for _, input := range task.Inputs {
+		if input != nil && input.Name != nil {
+			inputs[*input.Name] = input.Value
+		}
+	}
+
+	return &Task{
+		Name:        deref(task.Name),
+		Type:        string(task.Type),
+		Version:     deref(task.Version),
+		VersionType: string(task.VersionType),
+		Pinned:      task.VersionType == models.CommitSHA,
+		Inputs:      inputs,
+	}
+}
+
+func newImport(imported *models.Import) *Import {
+	if imported == nil {
+		return nil
+	}
+
+	result := &Import{
+		Version:     deref(imported.Version),
+		VersionType: string(imported.VersionType),
+		Pinned:      imported.VersionType == models.CommitSHA,
+		Parameters:  map[string]any{},
+		Location:    newLocation(imported.FileReference),
+	}
+	for name, value := range imported.Parameters {
+		result.Parameters[name] = value
+	}
+	if imported.Secrets != nil {
+		result.SecretsInherit = imported.Secrets.Inherit
+	}
+
+	path := ""
+	if source := imported.Source; source != nil {
+		result.Source = &ImportSource{
+			SCM:          string(source.SCM),
+			Type:         string(source.Type),
+			Organization: deref(source.Organization),
+			Repository:   deref(source.Repository),
+			Path:         deref(source.Path),
+			Reference:    deref(source.Reference),
+		}
+		path = result.Source.Path
+	}
+	result.Pipeline = NewInput(imported.Pipeline, path)
+	return result
+}
+
+func newRunner(runner *models.Runner) *Runner {
+	if runner == nil {
+		return nil
+	}
+
+	result := &Runner{
+		Type:       deref(runner.Type),
+		OS:         deref(runner.OS),
+		Arch:       deref(runner.Arch),
+		Labels:     []string{},
+		SelfHosted: runner.SelfHosted != nil && *runner.SelfHosted,
+		Location:   newLocation(runner.FileReference),
+	}
+	if runner.Labels != nil {
+		result.Labels = append(result.Labels, *runner.Labels...)
+	}
+	if runner.DockerMetadata != nil {
+		result.Image = deref(runner.DockerMetadata.Image)
+	}
+	return result
+}
+
+func newService(service *models.Service) *Service {
+	result := &Service{
+		Name:     deref(service.Name),
+		Ports:    stringSlice(service.Ports),
+		Location: newLocation(service.FileReference),
+	}
+	if service.DockerMetadata != nil {
+		result.Image = deref(service.DockerMetadata.Image)
+	}
+	return result
+}
+
+func newEnvironment(environment *models.Environment) *Environment {
+	if environment == nil {
+		return nil
+	}
+
+	return &Environment{
+		Name:     deref(environment.Name),
+		URL:      deref(environment.URL),
+		Location: newLocation(environment.FileReference),
+	}
+}
+
+func newDiagnostic(diagnostic *models.Diagnostic) *Diagnostic {
+	return &Diagnostic{
+		Severity: string(diagnostic.Severity),
+		Code:     string(diagnostic.Code),
+		Message:  diagnostic.Message,
+		Location: newLocation(diagnostic.FileReference),
+	}
+}
+
+// newPermissions returns the token permissions keyed by scope, or nil if the token permissions are not set
+func newPermissions(permissions *models.TokenPermissions) map[string]*Permission {
+	if permissions == nil {
+		return nil
+	}
+
+	result := map[string]*Permission{}
+	for scope, permission := range permissions.Permissions {
+		result[scope] = &Permission{Read: permission.Read, Write: permission.Write, Admin: permission.Admin}
+	}
+	return result
+}
+
+func newEnvironmentVariables(environmentVariables *models.EnvironmentVariablesRef) map[string]any {
+	result := map[string]any{}
+	if environmentVariables == nil {
+		return result
+	}
+
+	for name, value := range environmentVariables.EnvironmentVariables {
+		result[name] = value
+	}
+	return result
+}
+
+func newLocation(fileReference *models.FileReference) *Location {
+	if fileReference == nil || fileReference.StartRef == nil {
+		return nil
+	}
+
+	location := &Location{
+		StartLine:   fileReference.StartRef.Line,
+		StartColumn: fileReference.StartRef.Column,
+	}
+	if fileReference.EndRef != nil {
+		location.EndLine = fileReference.EndRef.Line
+		location.EndColumn = fileReference.EndRef.Column
+	}
+	return location
+}
+
+// mapSlice converts the non nil elements of a slice, returning an empty slice rather than nil
+func mapSlice[T any, U any](s []*T, cb func(v *T) U) []U {
+	return utils.Map(utils.Filter(s, func(v *T) bool { return v != nil }), cb)
+}
+
+func stringSlice(s []string) []string {
+	return append([]string{}, s...)
+}
+
+func deref[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+	return *v
+}
@@ -0,0 +1,193 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestNewInput(t *testing.T) {
+	testCases := []struct {
+		name          string
+		pipeline      *models.Pipeline
+		path          string
+		expectedInput *Input
+	}{
+		{
+			name:          "Nil pipeline",
+			pipeline:      nil,
+			path:          "ci.yml",
+			expectedInput: nil,
+		},
+		{
+			name:     "Empty pipeline",
+			pipeline: &models.Pipeline{Platform: consts.GitLabPlatform},
+			path:     ".gitlab-ci.yml",
+			expectedInput: &Input{
+				Version:     InputVersion,
+				Path:        ".gitlab-ci.yml",
+				Platform:    string(consts.GitLabPlatform),
+				Triggers:    []*Trigger{},
+				Parameters:  []*Parameter{},
+				Stages:      []*Stage{},
+				Jobs:        []*Job{},
+				Imports:     []*Import{},
+				Diagnostics: []*Diagnostic{},
+			},
+		},
+		{
+			name: "Full pipeline",
+			pipeline: &models.Pipeline{
+				Name:     utils.GetPtr("CI"),
+				Platform: consts.GitHubPlatform,
+				Triggers: &models.Triggers{
+					Triggers: []*models.Trigger{
+						{
+							Event:         models.PushEvent,
+							Branches:      &models.Filter{AllowList: []string{"main"}},
+							FileReference: testutils.CreateFileReference(2, 3, 4, 5),
+						},
+					},
+				},
+				Defaults: &models.Defaults{
+					TokenPermissions: &models.TokenPermissions{
+						Permissions: map[string]models.Permission{"contents": {Read: true}},
+					},
+				},
+				Jobs: []*models.Job{
+					{
+						ID:           utils.GetPtr("build"),
+						Dependencies: []*models.JobDependency{{JobID: utils.GetPtr("lint")}},
+						Conditions:   []*models.Condition{{Statement: "github.ref == 'refs/heads/main'"}},
+						Runner:       &models.Runner{Labels: &[]string{"self-hosted"}, SelfHosted: utils.GetPtr(true)},
+						Metadata:     models.Metadata{Build: true},
+						Steps: []*models.Step{
+							{
+								Name: utils.GetPtr("checkout"),
+								Type: models.TaskStepType,
+								Task: &models.Task{
+									Name:        utils.GetPtr("actions/checkout"),
+									Version:     utils.GetPtr("v4"),
+									VersionType: models.TagVersion,
+									Type:        models.CITaskType,
+									Inputs:      []*models.Parameter{{Name: utils.GetPtr("ref"), Value: "main"}},
+								},
+							},
+							{
+								Type:  models.ShellStepType,
+								Shell: &models.Shell{Type: utils.GetPtr("bash"), Script: utils.GetPtr("make")},
+							},
+						},
+					},
+					{
+						ID: utils.GetPtr("deploy"),
+						Imports: &models.Import{
+							Source:      &models.ImportSource{Repository: utils.GetPtr("org/workflows"), Path: utils.GetPtr(".github/workflows/deploy.yml"), Type: models.SourceTypeRemote},
+							Version:     utils.GetPtr("8f4b7f84864484a7bf31766abe9204da3cbe65b3"),
+							VersionType: models.CommitSHA,
+							Secrets:     &models.SecretsRef{Inherit: true},
+							Pipeline:    &models.Pipeline{Platform: consts.GitHubPlatform},
+						},
+					},
+				},
+			},
+			path: ".github/workflows/ci.yml",
+			expectedInput: &Input{
+				Version:  InputVersion,
+				Path:     ".github/workflows/ci.yml",
+				Platform: string(consts.GitHubPlatform),
+				Name:     "CI",
+				Triggers: []*Trigger{
+					{
+						Event:     string(models.PushEvent),
+						Branches:  &Filter{Allow: []string{"main"}, Deny: []string{}},
+						Schedules: []string{},
+						Jobs:      []string{},
+						Location:  &Location{StartLine: 2, StartColumn: 3, EndLine: 4, EndColumn: 5},
+					},
+				},
+				Parameters: []*Parameter{},
+				Defaults: &Defaults{
+					EnvironmentVariables: map[string]any{},
+					Permissions:          map[string]*Permission{"contents": {Read: true}},
+				},
+				Stages: []*Stage{},
+				Jobs: []*Job{
+					{
+						ID:                   "build",
+						Dependencies:         []string{"lint"},
+						Conditions:           []string{"github.ref == 'refs/heads/main'"},
+						Runner:               &Runner{Labels: []string{"self-hosted"}, SelfHosted: true},
+						Services:             []*Service{},
+						EnvironmentVariables: map[string]any{},
+						Build:                true,
+						PreSteps:             []*Step{},
+						Steps: []*Step{
+							{
+								Name: "checkout",
+								Type: string(models.TaskStepType),
+								Task: &Task{
+									Name:        "actions/checkout",
+									Type:        string(models.CITaskType),
+									Version:     "v4",
+									VersionType: string(models.TagVersion),
+									Inputs:      map[string]any{"ref": "main"},
+								},
+								EnvironmentVariables: map[string]any{},
+							},
+							{
+								Type:                 string(models.ShellStepType),
+								Shell:                "bash",
+								Script:               "make",
+								EnvironmentVariables: map[string]any{},
+							},
+						},
+						PostSteps: []*Step{},
+					},
+					{
+						ID:                   "deploy",
+						Dependencies:         []string{},
+						Conditions:           []string{},
+						Services:             []*Service{},
+						EnvironmentVariables: map[string]any{},
+						PreSteps:             []*Step{},
+						Steps:                []*Step{},
+						PostSteps:            []*Step{},
+						Import: &Import{
+							Source:         &ImportSource{Type: string(models.SourceTypeRemote), Repository: "org/workflows", Path: ".github/workflows/deploy.yml"},
+							Version:        "8f4b7f84864484a7bf31766abe9204da3cbe65b3",
+							VersionType:    string(models.CommitSHA),
+							Pinned:         true,
+							Parameters:     map[string]any{},
+							SecretsInherit: true,
+							Pipeline: &Input{
+								Version:     InputVersion,
+								Path:        ".github/workflows/deploy.yml",
+								Platform:    string(consts.GitHubPlatform),
+								Triggers:    []*Trigger{},
+								Parameters:  []*Parameter{},
+								Stages:      []*Stage{},
+								Jobs:        []*Job{},
+								Imports:     []*Import{},
+								Diagnostics: []*Diagnostic{},
+							},
+						},
+					},
+				},
+				Imports:     []*Import{},
+				Diagnostics: []*Diagnostic{},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := NewInput(testCase.pipeline, testCase.path)
+
+			testutils.DeepCompare(t, testCase.expectedInput, got)
+		})
+	}
+}
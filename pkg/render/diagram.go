@@ -0,0 +1,236 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/graph"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+type nodeType string
+
+const (
+	jobNodeType     nodeType = "job"
+	triggerNodeType nodeType = "trigger"
+)
+
+type node struct {
+	id       string
+	label    string
+	nodeType nodeType
+	metadata models.Metadata
+}
+
+type edge struct {
+	from string
+	to   string
+}
+
+// cluster is a group of nodes that belong to the same pipeline, imported pipelines are nested clusters
+type cluster struct {
+	id       string
+	label    string
+	nodes    []*node
+	clusters []*cluster
+}
+
+type diagram struct {
+	root  *cluster
+	edges []*edge
+}
+
+// Render returns the textual diagram of the pipeline's jobs, their dependencies, triggers and imported pipelines
+func Render(pipeline *models.Pipeline, format consts.OutputFormat) (string, error) {
+	switch format {
+	case consts.DotFormat:
+		return Dot(pipeline), nil
+	case consts.MermaidFormat:
+		return Mermaid(pipeline), nil
+	}
+	return "", consts.NewErrInvalidOutputFormat(format)
+}
+
+func buildDiagram(pipeline *models.Pipeline) *diagram {
+	d := &diagram{
+		root: &cluster{id: "pipeline", label: getPipelineLabel(pipeline)},
+	}
+	d.addPipeline(pipeline, d.root)
+	return d
+}
+
+// addPipeline adds the pipeline's nodes to the cluster and returns the IDs of the nodes its execution starts from
+func (d *diagram) addPipeline(pipeline *models.Pipeline, c *cluster) []string {
+	if pipeline == nil {
+		return nil
+	}
+
+	g := graph.Build(pipeline)
+	nodeIDs := map[string]string{}
+	for i, job := range pipeline.Jobs {
+		jobID := getJobID(job)
+		if _, ok := g.Nodes[jobID]; !ok {
+			continue
+		}
+
+		nodeID := fmt.Sprintf("%s_job_%d", c.id, i)
+		nodeIDs[jobID] = nodeID
+		c.nodes = append(c.nodes, &node{
+			id:       nodeID,
+			label:    getJobLabel(job),
+			nodeType: jobNodeType,
+			metadata: job.Metadata,
+		})
+	}
+
+	for i, job := range pipeline.Jobs {
+		jobNodeID, ok := nodeIDs[getJobID(job)]
+		if !ok {
+			continue
+		}
+
+		for _, dependency := range g.Nodes[getJobID(job)].Dependencies {
+			if dependencyNodeID, ok := nodeIDs[dependency]; ok {
+				d.edges = append(d.edges, &edge{from: dependencyNodeID, to: jobNodeID})
+			}
+		}
+
+		if job.Imports != nil && job.Imports.Pipeline != nil {
+			importCluster := &cluster{
+				id:    fmt.Sprintf("%s_job_%d_import", c.id, i),
+				label: getImportLabel(job.Imports),
+			}
+			c.clusters = append(c.clusters, importCluster)
+			for _, entry := range d.addPipeline(job.Imports.Pipeline, importCluster) {
+				d.edges = append(d.edges, &edge{from: jobNodeID, to: entry})
+			}
+		}
+	}
+
+	var entries []string
+	if layers := g.Layers(); len(layers) > 0 {
+		for _, jobID := range layers[0] {
+			entries = append(entries, nodeIDs[jobID])
+		}
+	}
+
+	if pipeline.Triggers != nil {
+		for i, trigger := range pipeline.Triggers.Triggers {
+			if trigger == nil {
+				continue
+			}
+
+			triggerNodeID := fmt.Sprintf("%s_trigger_%d", c.id, i)
+			c.nodes = append(c.nodes, &node{
+				id:       triggerNodeID,
+				label:    getTriggerLabel(trigger),
+				nodeType: triggerNodeType,
+			})
+			for _, entry := range getTriggerTargets(trigger, entries, nodeIDs) {
+				d.edges = append(d.edges, &edge{from: triggerNodeID, to: entry})
+			}
+		}
+	}
+
+	for i, importData := range pipeline.Imports {
+		if importData == nil || importData.Pipeline == nil {
+			continue
+		}
+
+		c.clusters = append(c.clusters, &cluster{
+			id:    fmt.Sprintf("%s_import_%d", c.id, i),
+			label: getImportLabel(importData),
+		})
+		d.addPipeline(importData.Pipeline, c.clusters[len(c.clusters)-1])
+	}
+
+	return entries
+}
+
+func getPipelineLabel(pipeline *models.Pipeline) string {
+	if pipeline != nil && pipeline.Name != nil && *pipeline.Name != "" {
+		return *pipeline.Name
+	}
+	return "pipeline"
+}
+
+func getJobID(job *models.Job) string {
+	if job == nil {
+		return ""
+	}
+	if job.ID != nil && *job.ID != "" {
+		return *job.ID
+	}
+	if job.Name != nil {
+		return *job.Name
+	}
+	return ""
+}
+
+func getJobLabel(job *models.Job) string {
+	label := getJobID(job)
+	if job.Name != nil && *job.Name != "" {
+		label = *job.Name
+	}
+
+	if flags := getMetadataFlags(job.Metadata); len(flags) > 0 {
+		label = fmt.Sprintf("%s\n[%s]", label, strings.Join(flags, ", "))
+	}
+	return label
+}
+
+func getMetadataFlags(metadata models.Metadata) []string {
+	var flags []string
+	if metadata.Build {
+		flags = append(flags, "build")
+	}
+	if metadata.Test {
+		flags = append(flags, "test")
+	}
+	if metadata.Deploy {
+		flags = append(flags, "deploy")
+	}
+	return flags
+}
+
+func getTriggerLabel(trigger *models.Trigger) string {
+	label := fmt.Sprintf("on %s", trigger.Event)
+	if trigger.Branches != nil && len(trigger.Branches.AllowList) > 0 {
+		label = fmt.Sprintf("%s\n%s", label, strings.Join(trigger.Branches.AllowList, ", "))
+	}
+	if trigger.Tags != nil && len(trigger.Tags.AllowList) > 0 {
+		label = fmt.Sprintf("%s\ntags %s", label, strings.Join(trigger.Tags.AllowList, ", "))
+	}
+	return label
+}
+
+// getTriggerTargets returns the nodes of the jobs a trigger runs, which are the entry jobs of the pipeline unless the trigger names its jobs
+func getTriggerTargets(trigger *models.Trigger, entries []string, nodeIDs map[string]string) []string {
+	if len(trigger.Jobs) == 0 {
+		return entries
+	}
+
+	var targets []string
+	for _, jobID := range trigger.Jobs {
+		if nodeID, ok := nodeIDs[jobID]; ok {
+			targets = append(targets, nodeID)
+		}
+	}
+	return targets
+}
+
+func getImportLabel(importData *models.Import) string {
+	if importData.Source == nil || importData.Source.Path == nil {
+		return "import"
+	}
+
+	label := *importData.Source.Path
+	if importData.Source.Organization != nil && importData.Source.Repository != nil {
+		label = fmt.Sprintf("%s/%s/%s", *importData.Source.Organization, *importData.Source.Repository, label)
+	}
+	if importData.Version != nil {
+		label = fmt.Sprintf("%s@%s", label, *importData.Version)
+	}
+	return label
+}
@@ -0,0 +1,185 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func createPipeline() *models.Pipeline {
+	return &models.Pipeline{
+		Name: utils.GetPtr("ci"),
+		Triggers: &models.Triggers{
+			Triggers: []*models.Trigger{
+				{
+					Event:    models.PushEvent,
+					Branches: &models.Filter{AllowList: []string{"main"}},
+				},
+			},
+		},
+		Jobs: []*models.Job{
+			{
+				ID:       utils.GetPtr("build"),
+				Name:     utils.GetPtr("Build"),
+				Metadata: models.Metadata{Build: true},
+			},
+			{
+				ID:           utils.GetPtr("test"),
+				Dependencies: []*models.JobDependency{{JobID: utils.GetPtr("build")}},
+				Metadata:     models.Metadata{Test: true},
+			},
+		},
+		Imports: []*models.Import{
+			{
+				Source:  &models.ImportSource{Path: utils.GetPtr("template.yml")},
+				Version: utils.GetPtr("v1"),
+				Pipeline: &models.Pipeline{
+					Jobs: []*models.Job{{ID: utils.GetPtr("lint")}},
+				},
+			},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name           string
+		pipeline       *models.Pipeline
+		format         consts.OutputFormat
+		expectedOutput string
+		expectedError  error
+	}{
+		{
+			name:     "Dot",
+			pipeline: createPipeline(),
+			format:   consts.DotFormat,
+			expectedOutput: `digraph pipeline {
+  rankdir=LR;
+  node [shape=box];
+  "pipeline_job_0" [label="Build\n[build]", style=filled, fillcolor="lightblue"];
+  "pipeline_job_1" [label="test\n[test]", style=filled, fillcolor="khaki"];
+  "pipeline_trigger_0" [label="on push\nmain", shape=ellipse];
+  subgraph "cluster_pipeline_import_0" {
+    label="template.yml@v1";
+    "pipeline_import_0_job_0" [label="lint"];
+  }
+  "pipeline_job_0" -> "pipeline_job_1";
+  "pipeline_trigger_0" -> "pipeline_job_0";
+}
+`,
+		},
+		{
+			name:     "Mermaid",
+			pipeline: createPipeline(),
+			format:   consts.MermaidFormat,
+			expectedOutput: `flowchart LR
+  pipeline_job_0["Build<br/>[build]"]:::build
+  pipeline_job_1["test<br/>[test]"]:::test
+  pipeline_trigger_0(["on push<br/>main"])
+  subgraph pipeline_import_0["template.yml@v1"]
+    pipeline_import_0_job_0["lint"]
+  end
+  pipeline_job_0 --> pipeline_job_1
+  pipeline_trigger_0 --> pipeline_job_0
+  classDef build fill:#add8e6
+  classDef test fill:#f0e68c
+  classDef deploy fill:#f08080
+`,
+		},
+		{
+			name: "Triggers of specific jobs",
+			pipeline: &models.Pipeline{
+				Triggers: &models.Triggers{
+					Triggers: []*models.Trigger{
+						{
+							Event:    models.PushEvent,
+							Branches: &models.Filter{AllowList: []string{"main"}},
+							Jobs:     []string{"job-main"},
+						},
+						{
+							Event: models.PushEvent,
+							Tags:  &models.Filter{AllowList: []string{"v*"}},
+							Jobs:  []string{"job-v*"},
+						},
+					},
+				},
+				Jobs: []*models.Job{
+					{ID: utils.GetPtr("job-main")},
+					{ID: utils.GetPtr("job-v*")},
+				},
+			},
+			format: consts.MermaidFormat,
+			expectedOutput: `flowchart LR
+  pipeline_job_0["job-main"]
+  pipeline_job_1["job-v*"]
+  pipeline_trigger_0(["on push<br/>main"])
+  pipeline_trigger_1(["on push<br/>tags v*"])
+  pipeline_trigger_0 --> pipeline_job_0
+  pipeline_trigger_1 --> pipeline_job_1
+  classDef build fill:#add8e6
+  classDef test fill:#f0e68c
+  classDef deploy fill:#f08080
+`,
+		},
+		{
+			name:     "Empty pipeline",
+			pipeline: &models.Pipeline{},
+			format:   consts.MermaidFormat,
+			expectedOutput: `flowchart LR
+  classDef build fill:#add8e6
+  classDef test fill:#f0e68c
+  classDef deploy fill:#f08080
+`,
+		},
+		{
+			name:          "Invalid format",
+			pipeline:      createPipeline(),
+			format:        consts.JSONFormat,
+			expectedError: consts.NewErrInvalidOutputFormat(consts.JSONFormat),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := Render(testCase.pipeline, testCase.format)
+
+			testutils.DeepCompare(t, testCase.expectedError, err)
+			testutils.DeepCompare(t, testCase.expectedOutput, got)
+		})
+	}
+}
+
+func TestGetJobLabel(t *testing.T) {
+	testCases := []struct {
+		name          string
+		job           *models.Job
+		expectedLabel string
+	}{
+		{
+			name:          "Job with ID only",
+			job:           &models.Job{ID: utils.GetPtr("build")},
+			expectedLabel: "build",
+		},
+		{
+			name:          "Job name is preferred",
+			job:           &models.Job{ID: utils.GetPtr("build"), Name: utils.GetPtr("Build")},
+			expectedLabel: "Build",
+		},
+		{
+			name:          "Job with metadata",
+			job:           &models.Job{ID: utils.GetPtr("release"), Metadata: models.Metadata{Build: true, Deploy: true}},
+			expectedLabel: "release\n[build, deploy]",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := getJobLabel(testCase.job)
+
+			testutils.DeepCompare(t, testCase.expectedLabel, got)
+		})
+	}
+}
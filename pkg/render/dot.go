@@ -0,0 +1,73 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+var (
+	dotMetadataColors = map[string]string{
+		"build":  "lightblue",
+		"test":   "khaki",
+		"deploy": "lightcoral",
+	}
+)
+
+// Dot renders the pipeline as a Graphviz DOT digraph
+func Dot(pipeline *models.Pipeline) string {
+	d := buildDiagram(pipeline)
+
+	builder := &strings.Builder{}
+	builder.WriteString("digraph pipeline {\n")
+	builder.WriteString("  rankdir=LR;\n")
+	builder.WriteString("  node [shape=box];\n")
+	writeDotCluster(builder, d.root, 1, true)
+	for _, e := range d.edges {
+		fmt.Fprintf(builder, "  %s -> %s;\n", quoteDot(e.from), quoteDot(e.to))
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+func writeDotCluster(builder *strings.Builder, c *cluster, depth int, isRoot bool) {
+	indent := strings.Repeat("  ", depth)
+	if !isRoot {
+		fmt.Fprintf(builder, "%ssubgraph %s {\n", indent, quoteDot("cluster_"+c.id))
+		fmt.Fprintf(builder, "%s  label=%s;\n", indent, quoteDot(c.label))
+		depth++
+		indent = strings.Repeat("  ", depth)
+	}
+
+	for _, n := range c.nodes {
+		fmt.Fprintf(builder, "%s%s [%s];\n", indent, quoteDot(n.id), getDotNodeAttributes(n))
+	}
+
+	for _, child := range c.clusters {
+		writeDotCluster(builder, child, depth, false)
+	}
+
+	if !isRoot {
+		fmt.Fprintf(builder, "%s}\n", strings.Repeat("  ", depth-1))
+	}
+}
+
+func getDotNodeAttributes(n *node) string {
+	attributes := []string{fmt.Sprintf("label=%s", quoteDot(n.label))}
+	if n.nodeType == triggerNodeType {
+		attributes = append(attributes, "shape=ellipse")
+	}
+
+	if flags := getMetadataFlags(n.metadata); len(flags) > 0 {
+		attributes = append(attributes, "style=filled", fmt.Sprintf("fillcolor=%s", quoteDot(dotMetadataColors[flags[0]])))
+	}
+	return strings.Join(attributes, ", ")
+}
+
+func quoteDot(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	return fmt.Sprintf(`"%s"`, value)
+}
@@ -0,0 +1,72 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+var (
+	mermaidClassDefinitions = []string{
+		"classDef build fill:#add8e6",
+		"classDef test fill:#f0e68c",
+		"classDef deploy fill:#f08080",
+	}
+)
+
+// Mermaid renders the pipeline as a Mermaid flowchart
+func Mermaid(pipeline *models.Pipeline) string {
+	d := buildDiagram(pipeline)
+
+	builder := &strings.Builder{}
+	builder.WriteString("flowchart LR\n")
+	writeMermaidCluster(builder, d.root, 1, true)
+	for _, e := range d.edges {
+		fmt.Fprintf(builder, "  %s --> %s\n", e.from, e.to)
+	}
+	for _, classDefinition := range mermaidClassDefinitions {
+		fmt.Fprintf(builder, "  %s\n", classDefinition)
+	}
+	return builder.String()
+}
+
+func writeMermaidCluster(builder *strings.Builder, c *cluster, depth int, isRoot bool) {
+	indent := strings.Repeat("  ", depth)
+	if !isRoot {
+		fmt.Fprintf(builder, "%ssubgraph %s[%s]\n", indent, c.id, quoteMermaid(c.label))
+		depth++
+		indent = strings.Repeat("  ", depth)
+	}
+
+	for _, n := range c.nodes {
+		fmt.Fprintf(builder, "%s%s\n", indent, getMermaidNode(n))
+	}
+
+	for _, child := range c.clusters {
+		writeMermaidCluster(builder, child, depth, false)
+	}
+
+	if !isRoot {
+		fmt.Fprintf(builder, "%send\n", strings.Repeat("  ", depth-1))
+	}
+}
+
+func getMermaidNode(n *node) string {
+	shape := "%s[%s]"
+	if n.nodeType == triggerNodeType {
+		shape = "%s([%s])"
+	}
+
+	mermaidNode := fmt.Sprintf(shape, n.id, quoteMermaid(n.label))
+	if flags := getMetadataFlags(n.metadata); len(flags) > 0 {
+		mermaidNode = fmt.Sprintf("%s:::%s", mermaidNode, flags[0])
+	}
+	return mermaidNode
+}
+
+func quoteMermaid(value string) string {
+	value = strings.ReplaceAll(value, `"`, "#quot;")
+	value = strings.ReplaceAll(value, "\n", "<br/>")
+	return fmt.Sprintf(`"%s"`, value)
+}
@@ -0,0 +1,387 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/analyzers/injection"
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/expressions"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+const (
+	UnpinnedActionRuleID                = "unpinned-action"
+	PullRequestTargetCheckoutRuleID     = "pull-request-target-checkout"
+	WriteAllPermissionsRuleID           = "write-all-permissions"
+	SecretsInheritRuleID                = "secrets-inherit"
+	SelfHostedRunnerPublicTriggerRuleID = "self-hosted-runner-public-trigger"
+	CurlPipeShellRuleID                 = "curl-pipe-shell"
+	ScriptInjectionRuleID               = "script-injection"
+)
+
+const (
+	pullRequestTargetEvent models.EventType = "pull_request_target"
+
+	checkoutAction       = "actions/checkout"
+	checkoutRefInput     = "ref"
+	pullRequestRefPrefix = "refs/pull/"
+	localActionPrefix    = "./"
+)
+
+var (
+	builtinRules = []Rule{
+		NewRule(UnpinnedActionRuleID, "Actions, reusable workflows and pipes should be pinned to a full commit SHA or image digest", MediumSeverity, checkUnpinnedActions),
+		NewRule(PullRequestTargetCheckoutRuleID, "Workflows triggered by pull_request_target should not check out the head of the pull request", CriticalSeverity, checkPullRequestTargetCheckout),
+		NewRule(WriteAllPermissionsRuleID, "The pipeline token should not be granted write access to all scopes", HighSeverity, checkWriteAllPermissions),
+		NewRule(SecretsInheritRuleID, "Reusable workflows should be passed only the secrets they use", MediumSeverity, checkSecretsInherit),
+		NewRule(SelfHostedRunnerPublicTriggerRuleID, "Self-hosted runners should not run jobs that outside contributors can trigger", HighSeverity, checkSelfHostedRunnerPublicTrigger),
+		NewRule(CurlPipeShellRuleID, "Scripts downloaded from the network should not be piped into a shell", HighSeverity, checkCurlPipeShell),
+		NewRule(ScriptInjectionRuleID, "Untrusted inputs should not be interpolated into scripts", HighSeverity, checkScriptInjection),
+	}
+
+	// publicEvents are the events that users without write access to the repository can trigger
+	publicEvents = []models.EventType{
+		models.PullRequestEvent,
+		models.ForkEvent,
+		pullRequestTargetEvent,
+		"pull_request_review",
+		"pull_request_review_comment",
+		"issues",
+		"issue_comment",
+		"discussion",
+		"discussion_comment",
+		"watch",
+	}
+
+	// githubPermissionScopes are the scopes of the GitHub token, as named in the token permissions of the pipeline
+	githubPermissionScopes = []string{
+		models.RunPipelinePermission,
+		"checks",
+		"contents",
+		"deployments",
+		"discussions",
+		"id-token",
+		"issues",
+		"packages",
+		"pages",
+		models.PullRequestPermission,
+		"repository-projects",
+		"security-events",
+		"statuses",
+	}
+
+	curlPipeShellRegexes = []*regexp.Regexp{
+		regexp.MustCompile(`\b(curl|wget)\b[^|;&\n]*\|\s*(sudo\s+)?(\S*/)?(sh|bash|zsh|dash|ksh)\b`),
+		regexp.MustCompile(`\b(sh|bash|zsh|dash|ksh)\s+(-c\s+)?["']?(<\(|\$\()\s*(curl|wget)\b`),
+	}
+)
+
+func checkUnpinnedActions(pipeline *models.Pipeline) []*Finding {
+	if pipeline.Platform == consts.BitbucketPlatform {
+		return checkUnpinnedPipes(pipeline)
+	}
+	if pipeline.Platform != consts.GitHubPlatform {
+		return nil
+	}
+
+	var findings []*Finding
+	for _, job := range pipeline.Jobs {
+		if job == nil {
+			continue
+		}
+		if job.Imports != nil && job.Imports.Source != nil && job.Imports.Source.Type == models.SourceTypeRemote && job.Imports.VersionType != models.CommitSHA {
+			findings = append(findings, &Finding{
+				Message:       fmt.Sprintf("reusable workflow %s is not pinned to a commit SHA", getImportName(job.Imports)),
+				JobID:         job.ID,
+				FileReference: job.FileReference,
+			})
+		}
+		forEachStep(job, func(step *models.Step) {
+			task := step.Task
+			if task == nil || task.Name == nil || task.Type != models.CITaskType || strings.HasPrefix(*task.Name, localActionPrefix) {
+				return
+			}
+			if task.VersionType != models.CommitSHA {
+				findings = append(findings, &Finding{
+					Message:       fmt.Sprintf("action %s is not pinned to a commit SHA", getTaskName(task)),
+					JobID:         job.ID,
+					StepName:      step.Name,
+					FileReference: step.FileReference,
+				})
+			}
+		})
+	}
+	return findings
+}
+
+// checkUnpinnedPipes finds Bitbucket pipes that are not pinned to an image digest
+func checkUnpinnedPipes(pipeline *models.Pipeline) []*Finding {
+	var findings []*Finding
+	for _, job := range pipeline.Jobs {
+		if job == nil {
+			continue
+		}
+		forEachStep(job, func(step *models.Step) {
+			task := step.Task
+			if task == nil || task.Name == nil || task.VersionType == models.CommitSHA {
+				return
+			}
+			name := *task.Name
+			if task.Version != nil {
+				name = fmt.Sprintf("%s:%s", name, *task.Version)
+			}
+			findings = append(findings, &Finding{
+				Message:       fmt.Sprintf("pipe %s is not pinned to an image digest", name),
+				JobID:         job.ID,
+				StepName:      step.Name,
+				FileReference: step.FileReference,
+			})
+		})
+	}
+	return findings
+}
+
+func checkPullRequestTargetCheckout(pipeline *models.Pipeline) []*Finding {
+	if pipeline.Platform != consts.GitHubPlatform || !hasTriggerEvent(pipeline, pullRequestTargetEvent) {
+		return nil
+	}
+
+	var findings []*Finding
+	for _, job := range pipeline.Jobs {
+		if job == nil {
+			continue
+		}
+		forEachStep(job, func(step *models.Step) {
+			if step.Task == nil || step.Task.Name == nil || *step.Task.Name != checkoutAction {
+				return
+			}
+			for _, input := range step.Task.Inputs {
+				if input == nil || input.Name == nil || *input.Name != checkoutRefInput {
+					continue
+				}
+				if ref, ok := input.Value.(string); ok && isPullRequestHeadRef(ref) {
+					findings = append(findings, &Finding{
+						Message:       fmt.Sprintf("the head of the pull request is checked out by a workflow triggered by %s", pullRequestTargetEvent),
+						JobID:         job.ID,
+						StepName:      step.Name,
+						FileReference: step.FileReference,
+					})
+				}
+			}
+		})
+	}
+	return findings
+}
+
+// isPullRequestHeadRef returns whether a checkout ref points to the code of the pull request
+func isPullRequestHeadRef(ref string) bool {
+	if strings.Contains(ref, pullRequestRefPrefix) {
+		return true
+	}
+
+	parsed, _ := expressions.ParseTemplate(ref, nil)
+	for _, expression := range parsed {
+		for _, reference := range expression.ContextReferences() {
+			path := strings.Join(append([]string{reference.Context}, reference.Path...), ".")
+			if path == "github.head_ref" || strings.HasPrefix(path, "github.event.pull_request.head.") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func checkWriteAllPermissions(pipeline *models.Pipeline) []*Finding {
+	if pipeline.Platform != consts.GitHubPlatform {
+		return nil
+	}
+
+	var findings []*Finding
+	if pipeline.Defaults != nil && isWriteAll(pipeline.Defaults.TokenPermissions) {
+		findings = append(findings, &Finding{
+			Message:       "the pipeline grants its token write access to all scopes",
+			FileReference: pipeline.Defaults.TokenPermissions.FileReference,
+		})
+	}
+	for _, job := range pipeline.Jobs {
+		if job != nil && isWriteAll(job.TokenPermissions) {
+			findings = append(findings, &Finding{
+				Message:       fmt.Sprintf("job %s grants its token write access to all scopes", getJobID(job)),
+				JobID:         job.ID,
+				FileReference: job.TokenPermissions.FileReference,
+			})
+		}
+	}
+	return findings
+}
+
+func isWriteAll(permissions *models.TokenPermissions) bool {
+	if permissions == nil {
+		return false
+	}
+	for _, scope := range githubPermissionScopes {
+		if permission, ok := permissions.Permissions[scope]; !ok || !permission.Write {
+			return false
+		}
+	}
+	return true
+}
+
+func checkSecretsInherit(pipeline *models.Pipeline) []*Finding {
+	var findings []*Finding
+	for _, job := range pipeline.Jobs {
+		if job == nil || job.Imports == nil || job.Imports.Secrets == nil || !job.Imports.Secrets.Inherit {
+			continue
+		}
+		findings = append(findings, &Finding{
+			Message:       fmt.Sprintf("job %s passes all the secrets of the pipeline to %s", getJobID(job), getImportName(job.Imports)),
+			JobID:         job.ID,
+			FileReference: job.FileReference,
+		})
+	}
+	return findings
+}
+
+func checkSelfHostedRunnerPublicTrigger(pipeline *models.Pipeline) []*Finding {
+	var events []models.EventType
+	for _, event := range publicEvents {
+		if hasTriggerEvent(pipeline, event) {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	var defaultRunner *models.Runner
+	if pipeline.Defaults != nil {
+		defaultRunner = pipeline.Defaults.Runner
+	}
+
+	var findings []*Finding
+	for _, job := range pipeline.Jobs {
+		if job == nil {
+			continue
+		}
+		runner := job.Runner
+		if runner == nil {
+			runner = defaultRunner
+		}
+		if runner == nil || runner.SelfHosted == nil || !*runner.SelfHosted {
+			continue
+		}
+
+		fileReference := runner.FileReference
+		if fileReference == nil {
+			fileReference = job.FileReference
+		}
+		findings = append(findings, &Finding{
+			Message:       fmt.Sprintf("job %s runs on a self-hosted runner and can be triggered by %v", getJobID(job), events),
+			JobID:         job.ID,
+			FileReference: fileReference,
+		})
+	}
+	return findings
+}
+
+func checkCurlPipeShell(pipeline *models.Pipeline) []*Finding {
+	var findings []*Finding
+	for _, job := range pipeline.Jobs {
+		if job == nil {
+			continue
+		}
+		forEachStep(job, func(step *models.Step) {
+			for _, shell := range []*models.Shell{step.Shell, step.AfterScript} {
+				if shell == nil || shell.Script == nil {
+					continue
+				}
+				if command := findCurlPipeShell(*shell.Script); command != "" {
+					findings = append(findings, &Finding{
+						Message:       fmt.Sprintf("a downloaded script is executed without verification: %s", command),
+						JobID:         job.ID,
+						StepName:      step.Name,
+						FileReference: step.FileReference,
+					})
+				}
+			}
+		})
+	}
+	return findings
+}
+
+func findCurlPipeShell(script string) string {
+	for _, regex := range curlPipeShellRegexes {
+		if match := regex.FindString(script); match != "" {
+			return match
+		}
+	}
+	return ""
+}
+
+func checkScriptInjection(pipeline *models.Pipeline) []*Finding {
+	var findings []*Finding
+	for _, finding := range injection.Analyze(pipeline) {
+		jobID := finding.JobID
+		findings = append(findings, &Finding{
+			Message:       finding.Message,
+			JobID:         &jobID,
+			StepName:      finding.StepName,
+			FileReference: finding.FileReference,
+		})
+	}
+	return findings
+}
+
+func forEachStep(job *models.Job, f func(step *models.Step)) {
+	for _, steps := range [][]*models.Step{job.PreSteps, job.Steps, job.PostSteps} {
+		for _, step := range steps {
+			if step != nil {
+				f(step)
+			}
+		}
+	}
+}
+
+func hasTriggerEvent(pipeline *models.Pipeline, event models.EventType) bool {
+	if pipeline.Triggers == nil {
+		return false
+	}
+	for _, trigger := range pipeline.Triggers.Triggers {
+		if trigger != nil && trigger.Event == event {
+			return true
+		}
+	}
+	return false
+}
+
+func getJobID(job *models.Job) string {
+	if job.ID == nil {
+		return ""
+	}
+	return *job.ID
+}
+
+func getTaskName(task *models.Task) string {
+	if task.Version == nil || *task.Version == "" {
+		return *task.Name
+	}
+	return fmt.Sprintf("%s@%s", *task.Name, *task.Version)
+}
+
+func getImportName(imports *models.Import) string {
+	var parts []string
+	if imports.Source != nil {
+		for _, part := range []*string{imports.Source.Organization, imports.Source.Repository, imports.Source.Path} {
+			if part != nil && *part != "" {
+				parts = append(parts, strings.Trim(*part, "/"))
+			}
+		}
+	}
+	name := strings.Join(parts, "/")
+	if imports.Version != nil && *imports.Version != "" {
+		name = fmt.Sprintf("%s@%s", name, *imports.Version)
+	}
+	return name
+}
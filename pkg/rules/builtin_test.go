@@ -0,0 +1,485 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/handler"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func createTaskStep(name, action, version string, versionType models.VersionType, inputs ...*models.Parameter) *models.Step {
+	return &models.Step{
+		Name: utils.GetPtr(name),
+		Type: models.TaskStepType,
+		Task: &models.Task{
+			Name:        utils.GetPtr(action),
+			Version:     utils.GetPtr(version),
+			VersionType: versionType,
+			Type:        models.CITaskType,
+			Inputs:      inputs,
+		},
+		FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+	}
+}
+
+func createShellStep(name, script string) *models.Step {
+	return &models.Step{
+		Name:          utils.GetPtr(name),
+		Type:          models.ShellStepType,
+		Shell:         &models.Shell{Script: utils.GetPtr(script)},
+		FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+	}
+}
+
+func createTriggers(events ...models.EventType) *models.Triggers {
+	return &models.Triggers{
+		Triggers: utils.Map(events, func(event models.EventType) *models.Trigger {
+			return &models.Trigger{Event: event}
+		}),
+	}
+}
+
+func createPermissions(permission models.Permission, fileReference *models.FileReference) *models.TokenPermissions {
+	permissions := map[string]models.Permission{}
+	for _, scope := range githubPermissionScopes {
+		permissions[scope] = permission
+	}
+	return &models.TokenPermissions{Permissions: permissions, FileReference: fileReference}
+}
+
+func TestBuiltinRules(t *testing.T) {
+	testCases := []struct {
+		name             string
+		ruleID           string
+		pipeline         *models.Pipeline
+		expectedFindings []*Finding
+	}{
+		{
+			name:             "Pipeline is nil",
+			ruleID:           UnpinnedActionRuleID,
+			pipeline:         nil,
+			expectedFindings: nil,
+		},
+		{
+			name:   "Unpinned actions and reusable workflows",
+			ruleID: UnpinnedActionRuleID,
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Jobs: []*models.Job{
+					{
+						ID: utils.GetPtr("build"),
+						Steps: []*models.Step{
+							createTaskStep("checkout", "actions/checkout", "v3", models.TagVersion),
+							createTaskStep("pinned", "actions/setup-go", "8f4b7f84864484a7bf31766abe9204da3cbe65b3", models.CommitSHA),
+							createTaskStep("local", "./.github/actions/build", "", models.None),
+						},
+						FileReference: testutils.CreateFileReference(5, 6, 7, 8),
+					},
+					{
+						ID: utils.GetPtr("release"),
+						Imports: &models.Import{
+							Source: &models.ImportSource{
+								Organization: utils.GetPtr("org"),
+								Repository:   utils.GetPtr("workflows"),
+								Path:         utils.GetPtr(".github/workflows/release.yml"),
+								Type:         models.SourceTypeRemote,
+							},
+							Version:     utils.GetPtr("main"),
+							VersionType: models.BranchVersion,
+						},
+						FileReference: testutils.CreateFileReference(9, 10, 11, 12),
+					},
+				},
+			},
+			expectedFindings: []*Finding{
+				{
+					RuleID:        UnpinnedActionRuleID,
+					Severity:      MediumSeverity,
+					Message:       "action actions/checkout@v3 is not pinned to a commit SHA",
+					JobID:         utils.GetPtr("build"),
+					StepName:      utils.GetPtr("checkout"),
+					FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+				},
+				{
+					RuleID:        UnpinnedActionRuleID,
+					Severity:      MediumSeverity,
+					Message:       "reusable workflow org/workflows/.github/workflows/release.yml@main is not pinned to a commit SHA",
+					JobID:         utils.GetPtr("release"),
+					FileReference: testutils.CreateFileReference(9, 10, 11, 12),
+				},
+			},
+		},
+		{
+			name:   "Unpinned Bitbucket pipes",
+			ruleID: UnpinnedActionRuleID,
+			pipeline: &models.Pipeline{
+				Platform: consts.BitbucketPlatform,
+				Jobs: []*models.Job{
+					{
+						ID: utils.GetPtr("job-default"),
+						Steps: []*models.Step{
+							createTaskStep("deploy", "atlassian/aws-s3-deploy", "1.1.0", models.TagVersion),
+							createTaskStep("pinned", "atlassian/slack-notify", "sha256:2f1e5c1c7cbe2a1c2b5d8f3e0f2d0a4c1b9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c", models.CommitSHA),
+							createShellStep("build", "make"),
+						},
+					},
+				},
+			},
+			expectedFindings: []*Finding{
+				{
+					RuleID:        UnpinnedActionRuleID,
+					Severity:      MediumSeverity,
+					Message:       "pipe atlassian/aws-s3-deploy:1.1.0 is not pinned to an image digest",
+					JobID:         utils.GetPtr("job-default"),
+					StepName:      utils.GetPtr("deploy"),
+					FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+				},
+			},
+		},
+		{
+			name:   "Checkout of the pull request head on pull_request_target",
+			ruleID: PullRequestTargetCheckoutRuleID,
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Triggers: createTriggers(pullRequestTargetEvent),
+				Jobs: []*models.Job{
+					{
+						ID: utils.GetPtr("test"),
+						Steps: []*models.Step{
+							createTaskStep("base", "actions/checkout", "v3", models.TagVersion),
+							createTaskStep("head", "actions/checkout", "v3", models.TagVersion,
+								&models.Parameter{Name: utils.GetPtr("ref"), Value: "${{ github.event.pull_request.head.sha }}"}),
+							createTaskStep("merge", "actions/checkout", "v3", models.TagVersion,
+								&models.Parameter{Name: utils.GetPtr("ref"), Value: "refs/pull/${{ github.event.number }}/merge"}),
+						},
+					},
+				},
+			},
+			expectedFindings: []*Finding{
+				{
+					RuleID:        PullRequestTargetCheckoutRuleID,
+					Severity:      CriticalSeverity,
+					Message:       "the head of the pull request is checked out by a workflow triggered by pull_request_target",
+					JobID:         utils.GetPtr("test"),
+					StepName:      utils.GetPtr("head"),
+					FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+				},
+				{
+					RuleID:        PullRequestTargetCheckoutRuleID,
+					Severity:      CriticalSeverity,
+					Message:       "the head of the pull request is checked out by a workflow triggered by pull_request_target",
+					JobID:         utils.GetPtr("test"),
+					StepName:      utils.GetPtr("merge"),
+					FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+				},
+			},
+		},
+		{
+			name:   "Checkout of the pull request head on pull_request",
+			ruleID: PullRequestTargetCheckoutRuleID,
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Triggers: createTriggers(models.PullRequestEvent),
+				Jobs: []*models.Job{
+					{
+						ID: utils.GetPtr("test"),
+						Steps: []*models.Step{
+							createTaskStep("head", "actions/checkout", "v3", models.TagVersion,
+								&models.Parameter{Name: utils.GetPtr("ref"), Value: "${{ github.head_ref }}"}),
+						},
+					},
+				},
+			},
+			expectedFindings: nil,
+		},
+		{
+			name:   "Write-all token permissions",
+			ruleID: WriteAllPermissionsRuleID,
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Defaults: &models.Defaults{
+					TokenPermissions: createPermissions(models.Permission{Write: true}, testutils.CreateFileReference(2, 1, 2, 24)),
+				},
+				Jobs: []*models.Job{
+					{
+						ID:               utils.GetPtr("read"),
+						TokenPermissions: createPermissions(models.Permission{Read: true}, testutils.CreateFileReference(5, 5, 5, 27)),
+					},
+					{
+						ID: utils.GetPtr("contents"),
+						TokenPermissions: &models.TokenPermissions{
+							Permissions: map[string]models.Permission{"contents": {Write: true}},
+						},
+					},
+					{
+						ID:               utils.GetPtr("write"),
+						TokenPermissions: createPermissions(models.Permission{Write: true}, testutils.CreateFileReference(9, 5, 9, 28)),
+					},
+				},
+			},
+			expectedFindings: []*Finding{
+				{
+					RuleID:        WriteAllPermissionsRuleID,
+					Severity:      HighSeverity,
+					Message:       "the pipeline grants its token write access to all scopes",
+					FileReference: testutils.CreateFileReference(2, 1, 2, 24),
+				},
+				{
+					RuleID:        WriteAllPermissionsRuleID,
+					Severity:      HighSeverity,
+					Message:       "job write grants its token write access to all scopes",
+					JobID:         utils.GetPtr("write"),
+					FileReference: testutils.CreateFileReference(9, 5, 9, 28),
+				},
+			},
+		},
+		{
+			name:   "Inherited secrets",
+			ruleID: SecretsInheritRuleID,
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Jobs: []*models.Job{
+					{
+						ID: utils.GetPtr("deploy"),
+						Imports: &models.Import{
+							Source:  &models.ImportSource{Path: utils.GetPtr("./.github/workflows/deploy.yml"), Type: models.SourceTypeLocal},
+							Secrets: &models.SecretsRef{Inherit: true},
+						},
+						FileReference: testutils.CreateFileReference(5, 6, 7, 8),
+					},
+					{
+						ID: utils.GetPtr("test"),
+						Imports: &models.Import{
+							Source:  &models.ImportSource{Path: utils.GetPtr("./.github/workflows/test.yml"), Type: models.SourceTypeLocal},
+							Secrets: &models.SecretsRef{Secrets: map[string]any{"token": "${{ secrets.TOKEN }}"}},
+						},
+					},
+				},
+			},
+			expectedFindings: []*Finding{
+				{
+					RuleID:        SecretsInheritRuleID,
+					Severity:      MediumSeverity,
+					Message:       "job deploy passes all the secrets of the pipeline to ./.github/workflows/deploy.yml",
+					JobID:         utils.GetPtr("deploy"),
+					FileReference: testutils.CreateFileReference(5, 6, 7, 8),
+				},
+			},
+		},
+		{
+			name:   "Self-hosted runners on public triggers",
+			ruleID: SelfHostedRunnerPublicTriggerRuleID,
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Triggers: createTriggers(models.PushEvent, models.PullRequestEvent, "issue_comment"),
+				Jobs: []*models.Job{
+					{
+						ID:     utils.GetPtr("self-hosted"),
+						Runner: &models.Runner{SelfHosted: utils.GetPtr(true), FileReference: testutils.CreateFileReference(4, 5, 4, 30)},
+					},
+					{
+						ID:     utils.GetPtr("hosted"),
+						Runner: &models.Runner{SelfHosted: utils.GetPtr(false)},
+					},
+				},
+			},
+			expectedFindings: []*Finding{
+				{
+					RuleID:        SelfHostedRunnerPublicTriggerRuleID,
+					Severity:      HighSeverity,
+					Message:       "job self-hosted runs on a self-hosted runner and can be triggered by [pull_request issue_comment]",
+					JobID:         utils.GetPtr("self-hosted"),
+					FileReference: testutils.CreateFileReference(4, 5, 4, 30),
+				},
+			},
+		},
+		{
+			name:   "Self-hosted runners on private triggers",
+			ruleID: SelfHostedRunnerPublicTriggerRuleID,
+			pipeline: &models.Pipeline{
+				Platform: consts.GitLabPlatform,
+				Triggers: createTriggers(models.PushEvent),
+				Defaults: &models.Defaults{Runner: &models.Runner{SelfHosted: utils.GetPtr(true)}},
+				Jobs:     []*models.Job{{ID: utils.GetPtr("build")}},
+			},
+			expectedFindings: nil,
+		},
+		{
+			name:   "Downloaded scripts piped into a shell",
+			ruleID: CurlPipeShellRuleID,
+			pipeline: &models.Pipeline{
+				Platform: consts.GitLabPlatform,
+				Jobs: []*models.Job{
+					{
+						ID: utils.GetPtr("install"),
+						PreSteps: []*models.Step{
+							createShellStep("before_script", "curl -sSfL https://example.com/install.sh | sudo bash -s -- -v 1"),
+						},
+						Steps: []*models.Step{
+							createShellStep("download", "curl -o install.sh https://example.com/install.sh && sha256sum -c install.sh.sha256"),
+							createShellStep("process substitution", `bash <(wget -qO- https://example.com/install.sh)`),
+						},
+					},
+				},
+			},
+			expectedFindings: []*Finding{
+				{
+					RuleID:        CurlPipeShellRuleID,
+					Severity:      HighSeverity,
+					Message:       "a downloaded script is executed without verification: curl -sSfL https://example.com/install.sh | sudo bash",
+					JobID:         utils.GetPtr("install"),
+					StepName:      utils.GetPtr("before_script"),
+					FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+				},
+				{
+					RuleID:        CurlPipeShellRuleID,
+					Severity:      HighSeverity,
+					Message:       "a downloaded script is executed without verification: bash <(wget",
+					JobID:         utils.GetPtr("install"),
+					StepName:      utils.GetPtr("process substitution"),
+					FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+				},
+			},
+		},
+		{
+			name:   "Script injection",
+			ruleID: ScriptInjectionRuleID,
+			pipeline: &models.Pipeline{
+				Platform: consts.GitHubPlatform,
+				Triggers: createTriggers(models.PullRequestEvent),
+				Jobs: []*models.Job{
+					{
+						ID:    utils.GetPtr("lint"),
+						Steps: []*models.Step{createShellStep("title", `echo "${{ github.event.pull_request.title }}"`)},
+					},
+				},
+			},
+			expectedFindings: []*Finding{
+				{
+					RuleID:        ScriptInjectionRuleID,
+					Severity:      HighSeverity,
+					Message:       "untrusted input github.event.pull_request.title is interpolated into a script of job lint",
+					JobID:         utils.GetPtr("lint"),
+					StepName:      utils.GetPtr("title"),
+					FileReference: testutils.CreateFileReference(1, 2, 3, 4),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rule, err := Get(testCase.ruleID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := rule.Check(testCase.pipeline)
+
+			testutils.DeepCompare(t, testCase.expectedFindings, got)
+		})
+	}
+}
+
+func TestBuiltinRulesOnGitHubWorkflows(t *testing.T) {
+	const jobs = `
+jobs:
+  test:
+    runs-on: self-hosted
+    steps:
+      - uses: actions/checkout@v3
+        with:
+          ref: ${{ github.event.pull_request.head.sha }}
+`
+
+	testCases := []struct {
+		name             string
+		workflow         string
+		expectedMessages []string
+	}{
+		{
+			name:     "pull_request_target as a single event",
+			workflow: "on: pull_request_target" + jobs,
+			expectedMessages: []string{
+				"the head of the pull request is checked out by a workflow triggered by pull_request_target",
+				"job test runs on a self-hosted runner and can be triggered by [pull_request_target]",
+			},
+		},
+		{
+			name:     "pull_request_target in a list of events",
+			workflow: "on: [push, pull_request_target]" + jobs,
+			expectedMessages: []string{
+				"the head of the pull request is checked out by a workflow triggered by pull_request_target",
+				"job test runs on a self-hosted runner and can be triggered by [pull_request_target]",
+			},
+		},
+		{
+			name:     "pull_request_target without configuration",
+			workflow: "on:\n  push:\n  pull_request_target:" + jobs,
+			expectedMessages: []string{
+				"the head of the pull request is checked out by a workflow triggered by pull_request_target",
+				"job test runs on a self-hosted runner and can be triggered by [pull_request_target]",
+			},
+		},
+		{
+			name:             "Push only",
+			workflow:         "on: push" + jobs,
+			expectedMessages: []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			pipeline, err := handler.Handle([]byte(testCase.workflow), consts.GitHubPlatform, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			findings := Evaluate(pipeline, mustGetRules(t, PullRequestTargetCheckoutRuleID, SelfHostedRunnerPublicTriggerRuleID)...)
+
+			testutils.DeepCompare(t, testCase.expectedMessages, utils.Map(findings, func(finding *Finding) string {
+				return finding.Message
+			}))
+		})
+	}
+}
+
+func TestWriteAllPermissionsOnGitHubWorkflow(t *testing.T) {
+	workflow := `on: push
+permissions: write-all
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    permissions: write-all
+    steps:
+      - run: echo test
+`
+	pipeline, err := handler.Handle([]byte(workflow), consts.GitHubPlatform, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings := Evaluate(pipeline, mustGetRules(t, WriteAllPermissionsRuleID)...)
+
+	testutils.DeepCompare(t, []*models.FileReference{
+		testutils.CreateFileReference(2, 14, 2, 23),
+		testutils.CreateFileReference(6, 18, 6, 27),
+	}, utils.Map(findings, func(finding *Finding) *models.FileReference {
+		return finding.FileReference
+	}))
+}
+
+func mustGetRules(t *testing.T, ids ...string) []Rule {
+	rules := []Rule{}
+	for _, id := range ids {
+		rule, err := Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
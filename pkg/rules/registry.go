@@ -0,0 +1,89 @@
+package rules
+
+import (
+	"sort"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+// Registry holds the rules a pipeline is checked against
+type Registry struct {
+	rules map[string]Rule
+}
+
+// defaultRegistry holds the built-in rules
+var defaultRegistry = NewRegistry(builtinRules...)
+
+func NewRegistry(rules ...Rule) *Registry {
+	registry := &Registry{rules: map[string]Rule{}}
+	for _, rule := range rules {
+		registry.rules[rule.ID()] = rule
+	}
+	return registry
+}
+
+// Register adds a rule to the registry, failing if a rule with the same ID is already registered
+func (r *Registry) Register(rule Rule) error {
+	if _, ok := r.rules[rule.ID()]; ok {
+		return consts.NewErrDuplicateRule(rule.ID())
+	}
+	r.rules[rule.ID()] = rule
+	return nil
+}
+
+func (r *Registry) Get(id string) (Rule, error) {
+	rule, ok := r.rules[id]
+	if !ok {
+		return nil, consts.NewErrUnknownRule(id)
+	}
+	return rule, nil
+}
+
+// Rules returns the registered rules sorted by their ID
+func (r *Registry) Rules() []Rule {
+	rules := make([]Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID() < rules[j].ID()
+	})
+	return rules
+}
+
+// Evaluate checks the pipeline against every registered rule and returns the findings ordered by rule ID
+func (r *Registry) Evaluate(pipeline *models.Pipeline) []*Finding {
+	return evaluate(pipeline, r.Rules())
+}
+
+// Register adds a rule to the default registry
+func Register(rule Rule) error {
+	return defaultRegistry.Register(rule)
+}
+
+// Get returns a rule of the default registry
+func Get(id string) (Rule, error) {
+	return defaultRegistry.Get(id)
+}
+
+// Rules returns the rules of the default registry
+func Rules() []Rule {
+	return defaultRegistry.Rules()
+}
+
+// Evaluate checks the pipeline against the given rules, or against the rules of the default registry if none are given
+func Evaluate(pipeline *models.Pipeline, rules ...Rule) []*Finding {
+	if len(rules) == 0 {
+		rules = defaultRegistry.Rules()
+	}
+	return evaluate(pipeline, rules)
+}
+
+func evaluate(pipeline *models.Pipeline, rules []Rule) []*Finding {
+	var findings []*Finding
+	for _, rule := range rules {
+		findings = append(findings, rule.Check(pipeline)...)
+	}
+	return findings
+}
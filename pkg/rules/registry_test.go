@@ -0,0 +1,114 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func createJobsRule(id string, severity Severity) Rule {
+	return NewRule(id, "Pipelines should not have jobs", severity, func(pipeline *models.Pipeline) []*Finding {
+		return utils.Map(pipeline.Jobs, func(job *models.Job) *Finding {
+			return &Finding{Message: "job", JobID: job.ID}
+		})
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	pipeline := &models.Pipeline{
+		Jobs: []*models.Job{{ID: utils.GetPtr("build")}},
+	}
+
+	testCases := []struct {
+		name             string
+		rules            []Rule
+		register         Rule
+		get              string
+		expectedErr      error
+		expectedGetErr   error
+		expectedRuleIDs  []string
+		expectedFindings []*Finding
+	}{
+		{
+			name:            "Empty registry",
+			get:             "jobs",
+			expectedGetErr:  consts.NewErrUnknownRule("jobs"),
+			expectedRuleIDs: []string{},
+		},
+		{
+			name:            "Rules are sorted by ID",
+			rules:           []Rule{createJobsRule("b", LowSeverity)},
+			register:        createJobsRule("a", CriticalSeverity),
+			get:             "b",
+			expectedRuleIDs: []string{"a", "b"},
+			expectedFindings: []*Finding{
+				{RuleID: "a", Severity: CriticalSeverity, Message: "job", JobID: utils.GetPtr("build")},
+				{RuleID: "b", Severity: LowSeverity, Message: "job", JobID: utils.GetPtr("build")},
+			},
+		},
+		{
+			name:            "Duplicate rule",
+			rules:           []Rule{createJobsRule("a", LowSeverity)},
+			register:        createJobsRule("a", HighSeverity),
+			get:             "a",
+			expectedErr:     consts.NewErrDuplicateRule("a"),
+			expectedRuleIDs: []string{"a"},
+			expectedFindings: []*Finding{
+				{RuleID: "a", Severity: LowSeverity, Message: "job", JobID: utils.GetPtr("build")},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			registry := NewRegistry(testCase.rules...)
+
+			var err error
+			if testCase.register != nil {
+				err = registry.Register(testCase.register)
+			}
+			testutils.DeepCompare(t, testCase.expectedErr, err)
+
+			_, err = registry.Get(testCase.get)
+			testutils.DeepCompare(t, testCase.expectedGetErr, err)
+
+			testutils.DeepCompare(t, testCase.expectedRuleIDs, utils.Map(registry.Rules(), Rule.ID))
+			testutils.DeepCompare(t, testCase.expectedFindings, registry.Evaluate(pipeline))
+		})
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	expectedRuleIDs := []string{
+		CurlPipeShellRuleID,
+		PullRequestTargetCheckoutRuleID,
+		ScriptInjectionRuleID,
+		SecretsInheritRuleID,
+		SelfHostedRunnerPublicTriggerRuleID,
+		UnpinnedActionRuleID,
+		WriteAllPermissionsRuleID,
+	}
+	testutils.DeepCompare(t, expectedRuleIDs, utils.Map(Rules(), Rule.ID))
+	testutils.DeepCompare(t, consts.NewErrDuplicateRule(CurlPipeShellRuleID), Register(createJobsRule(CurlPipeShellRuleID, LowSeverity)))
+}
+
+func TestSeverityIsAtLeast(t *testing.T) {
+	testCases := []struct {
+		severity Severity
+		minimum  Severity
+		expected bool
+	}{
+		{severity: LowSeverity, minimum: LowSeverity, expected: true},
+		{severity: MediumSeverity, minimum: HighSeverity, expected: false},
+		{severity: CriticalSeverity, minimum: HighSeverity, expected: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(string(testCase.severity)+" >= "+string(testCase.minimum), func(t *testing.T) {
+			testutils.DeepCompare(t, testCase.expected, testCase.severity.IsAtLeast(testCase.minimum))
+		})
+	}
+}
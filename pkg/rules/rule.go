@@ -0,0 +1,93 @@
+package rules
+
+import (
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+type Severity string
+
+const (
+	LowSeverity      Severity = "low"
+	MediumSeverity   Severity = "medium"
+	HighSeverity     Severity = "high"
+	CriticalSeverity Severity = "critical"
+)
+
+// Severities are ordered from the least to the most severe
+var Severities = []Severity{
+	LowSeverity,
+	MediumSeverity,
+	HighSeverity,
+	CriticalSeverity,
+}
+
+// Rule is a security policy that is checked against a parsed pipeline
+type Rule interface {
+	ID() string
+	Description() string
+	Severity() Severity
+	Check(pipeline *models.Pipeline) []*Finding
+}
+
+// Finding is a violation of a rule
+type Finding struct {
+	RuleID        string                `json:"rule_id,omitempty"`
+	Severity      Severity              `json:"severity,omitempty"`
+	Message       string                `json:"message,omitempty"`
+	JobID         *string               `json:"job_id,omitempty"`
+	StepName      *string               `json:"step_name,omitempty"`
+	FileReference *models.FileReference `json:"file_reference,omitempty"`
+}
+
+// rule is a Rule that is implemented by a check function
+type rule struct {
+	id          string
+	description string
+	severity    Severity
+	check       func(pipeline *models.Pipeline) []*Finding
+}
+
+// NewRule creates a rule from a check function.
+// The rule ID and severity of the returned findings are set by the rule.
+func NewRule(id string, description string, severity Severity, check func(pipeline *models.Pipeline) []*Finding) Rule {
+	return &rule{id: id, description: description, severity: severity, check: check}
+}
+
+func (r *rule) ID() string {
+	return r.id
+}
+
+func (r *rule) Description() string {
+	return r.description
+}
+
+func (r *rule) Severity() Severity {
+	return r.severity
+}
+
+func (r *rule) Check(pipeline *models.Pipeline) []*Finding {
+	if pipeline == nil {
+		return nil
+	}
+
+	findings := r.check(pipeline)
+	for _, finding := range findings {
+		finding.RuleID = r.id
+		finding.Severity = r.severity
+	}
+	return findings
+}
+
+// IsAtLeast returns whether the severity is at least as severe as the given severity
+func (s Severity) IsAtLeast(severity Severity) bool {
+	return severityRank(s) >= severityRank(severity)
+}
+
+func severityRank(severity Severity) int {
+	for i, s := range Severities {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,204 @@
+package sarif
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/rules"
+)
+
+const (
+	Version = "2.1.0"
+	Schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	toolName           = "pipeline-parser"
+	toolInformationURI = "https://github.com/argonsecurity/pipeline-parser"
+)
+
+type Level string
+
+const (
+	ErrorLevel   Level = "error"
+	WarningLevel Level = "warning"
+	NoteLevel    Level = "note"
+)
+
+var (
+	severityLevels = map[rules.Severity]Level{
+		rules.CriticalSeverity: ErrorLevel,
+		rules.HighSeverity:     ErrorLevel,
+		rules.MediumSeverity:   WarningLevel,
+		rules.LowSeverity:      NoteLevel,
+	}
+
+	diagnosticSeverityLevels = map[models.DiagnosticSeverity]Level{
+		models.ErrorSeverity:   ErrorLevel,
+		models.WarningSeverity: WarningLevel,
+		models.InfoSeverity:    NoteLevel,
+	}
+)
+
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []*Run `json:"runs"`
+}
+
+type Run struct {
+	Tool    *Tool     `json:"tool"`
+	Results []*Result `json:"results"`
+}
+
+type Tool struct {
+	Driver *Driver `json:"driver"`
+}
+
+type Driver struct {
+	Name           string                 `json:"name"`
+	InformationURI string                 `json:"informationUri,omitempty"`
+	Rules          []*ReportingDescriptor `json:"rules,omitempty"`
+}
+
+// ReportingDescriptor describes a rule or a diagnostic code that results are reported for
+type ReportingDescriptor struct {
+	ID                   string         `json:"id"`
+	ShortDescription     *Message       `json:"shortDescription,omitempty"`
+	DefaultConfiguration *Configuration `json:"defaultConfiguration,omitempty"`
+}
+
+type Configuration struct {
+	Level Level `json:"level,omitempty"`
+}
+
+type Result struct {
+	RuleID    string      `json:"ruleId"`
+	Level     Level       `json:"level,omitempty"`
+	Message   *Message    `json:"message"`
+	Locations []*Location `json:"locations,omitempty"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+type Location struct {
+	PhysicalLocation *PhysicalLocation `json:"physicalLocation"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation *ArtifactLocation `json:"artifactLocation"`
+	Region           *Region           `json:"region,omitempty"`
+}
+
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// Region is a range of a file, lines and columns start at 1 and the end column is exclusive
+type Region struct {
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// File is a pipeline file with its rule findings and parse diagnostics
+type File struct {
+	Path        string
+	Findings    []*rules.Finding
+	Diagnostics []*models.Diagnostic
+}
+
+// NewLog returns a SARIF log with a single run that reports the findings and diagnostics of the files.
+// The rules of the run are the rules and diagnostic codes that have results, sorted by their ID.
+func NewLog(files ...*File) *Log {
+	run := &Run{
+		Tool:    &Tool{Driver: &Driver{Name: toolName, InformationURI: toolInformationURI}},
+		Results: []*Result{},
+	}
+
+	descriptors := map[string]*ReportingDescriptor{}
+	for _, file := range files {
+		if file == nil {
+			continue
+		}
+
+		for _, finding := range file.Findings {
+			if finding == nil {
+				continue
+			}
+			level := severityLevels[finding.Severity]
+			if _, ok := descriptors[finding.RuleID]; !ok {
+				descriptors[finding.RuleID] = newRuleDescriptor(finding.RuleID, level)
+			}
+			run.Results = append(run.Results, newResult(finding.RuleID, level, finding.Message, file.Path, finding.FileReference))
+		}
+
+		for _, diagnostic := range file.Diagnostics {
+			if diagnostic == nil {
+				continue
+			}
+			id := string(diagnostic.Code)
+			level := diagnosticSeverityLevels[diagnostic.Severity]
+			if _, ok := descriptors[id]; !ok {
+				descriptors[id] = &ReportingDescriptor{ID: id, DefaultConfiguration: &Configuration{Level: level}}
+			}
+			run.Results = append(run.Results, newResult(id, level, diagnostic.Message, file.Path, diagnostic.FileReference))
+		}
+	}
+
+	for _, descriptor := range descriptors {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, descriptor)
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool {
+		return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID
+	})
+
+	return &Log{
+		Schema:  Schema,
+		Version: Version,
+		Runs:    []*Run{run},
+	}
+}
+
+func newRuleDescriptor(ruleID string, level Level) *ReportingDescriptor {
+	descriptor := &ReportingDescriptor{ID: ruleID, DefaultConfiguration: &Configuration{Level: level}}
+	if rule, err := rules.Get(ruleID); err == nil {
+		descriptor.ShortDescription = &Message{Text: rule.Description()}
+	}
+	return descriptor
+}
+
+func newResult(ruleID string, level Level, message string, path string, fileReference *models.FileReference) *Result {
+	return &Result{
+		RuleID:  ruleID,
+		Level:   level,
+		Message: &Message{Text: message},
+		Locations: []*Location{
+			{
+				PhysicalLocation: &PhysicalLocation{
+					ArtifactLocation: &ArtifactLocation{URI: filepath.ToSlash(path)},
+					Region:           newRegion(fileReference),
+				},
+			},
+		},
+	}
+}
+
+// newRegion converts a file reference to a region, or returns nil if the file reference has no start line
+func newRegion(fileReference *models.FileReference) *Region {
+	if fileReference == nil || fileReference.StartRef == nil || fileReference.StartRef.Line < 1 {
+		return nil
+	}
+
+	region := &Region{
+		StartLine:   fileReference.StartRef.Line,
+		StartColumn: fileReference.StartRef.Column,
+	}
+	if fileReference.EndRef != nil && fileReference.EndRef.Line >= region.StartLine {
+		region.EndLine = fileReference.EndRef.Line
+		region.EndColumn = fileReference.EndRef.Column
+	}
+	return region
+}
@@ -0,0 +1,135 @@
+package sarif
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/rules"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func createLocations(uri string, region *Region) []*Location {
+	return []*Location{
+		{
+			PhysicalLocation: &PhysicalLocation{
+				ArtifactLocation: &ArtifactLocation{URI: uri},
+				Region:           region,
+			},
+		},
+	}
+}
+
+func TestNewLog(t *testing.T) {
+	driver := func(descriptors ...*ReportingDescriptor) *Tool {
+		return &Tool{Driver: &Driver{Name: toolName, InformationURI: toolInformationURI, Rules: descriptors}}
+	}
+
+	testCases := []struct {
+		name        string
+		files       []*File
+		expectedLog *Log
+	}{
+		{
+			name:  "No files",
+			files: nil,
+			expectedLog: &Log{
+				Schema:  Schema,
+				Version: Version,
+				Runs:    []*Run{{Tool: driver(), Results: []*Result{}}},
+			},
+		},
+		{
+			name: "Findings and diagnostics",
+			files: []*File{
+				{
+					Path: ".github/workflows/ci.yml",
+					Findings: []*rules.Finding{
+						{
+							RuleID:        rules.CurlPipeShellRuleID,
+							Severity:      rules.HighSeverity,
+							Message:       "curl | sh",
+							JobID:         utils.GetPtr("build"),
+							FileReference: testutils.CreateFileReference(10, 9, 10, 42),
+						},
+						{
+							RuleID:   "custom",
+							Severity: rules.LowSeverity,
+							Message:  "custom finding",
+						},
+					},
+				},
+				{
+					Path: ".gitlab-ci.yml",
+					Findings: []*rules.Finding{
+						{
+							RuleID:        rules.CurlPipeShellRuleID,
+							Severity:      rules.HighSeverity,
+							Message:       "wget | bash",
+							FileReference: &models.FileReference{StartRef: &models.FileLocation{Line: 3, Column: 5}},
+						},
+					},
+					Diagnostics: []*models.Diagnostic{
+						{
+							Severity:      models.WarningSeverity,
+							Code:          models.ImportFetchFailedCode,
+							Message:       "failed fetching template",
+							FileReference: testutils.CreateFileReference(1, 1, 2, 20),
+						},
+					},
+				},
+			},
+			expectedLog: &Log{
+				Schema:  Schema,
+				Version: Version,
+				Runs: []*Run{
+					{
+						Tool: driver(
+							&ReportingDescriptor{
+								ID:                   rules.CurlPipeShellRuleID,
+								ShortDescription:     &Message{Text: "Scripts downloaded from the network should not be piped into a shell"},
+								DefaultConfiguration: &Configuration{Level: ErrorLevel},
+							},
+							&ReportingDescriptor{ID: "custom", DefaultConfiguration: &Configuration{Level: NoteLevel}},
+							&ReportingDescriptor{ID: string(models.ImportFetchFailedCode), DefaultConfiguration: &Configuration{Level: WarningLevel}},
+						),
+						Results: []*Result{
+							{
+								RuleID:    rules.CurlPipeShellRuleID,
+								Level:     ErrorLevel,
+								Message:   &Message{Text: "curl | sh"},
+								Locations: createLocations(".github/workflows/ci.yml", &Region{StartLine: 10, StartColumn: 9, EndLine: 10, EndColumn: 42}),
+							},
+							{
+								RuleID:    "custom",
+								Level:     NoteLevel,
+								Message:   &Message{Text: "custom finding"},
+								Locations: createLocations(".github/workflows/ci.yml", nil),
+							},
+							{
+								RuleID:    rules.CurlPipeShellRuleID,
+								Level:     ErrorLevel,
+								Message:   &Message{Text: "wget | bash"},
+								Locations: createLocations(".gitlab-ci.yml", &Region{StartLine: 3, StartColumn: 5}),
+							},
+							{
+								RuleID:    string(models.ImportFetchFailedCode),
+								Level:     WarningLevel,
+								Message:   &Message{Text: "failed fetching template"},
+								Locations: createLocations(".gitlab-ci.yml", &Region{StartLine: 1, StartColumn: 1, EndLine: 2, EndColumn: 20}),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := NewLog(testCase.files...)
+
+			testutils.DeepCompare(t, testCase.expectedLog, got)
+		})
+	}
+}
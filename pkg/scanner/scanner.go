@@ -0,0 +1,135 @@
+package scanner
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/detector"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
+	"github.com/argonsecurity/pipeline-parser/pkg/handler"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+var (
+	// directories that never contain pipeline files of the repository itself
+	skippedDirectories = []string{".git", "node_modules", "vendor"}
+)
+
+// Result is the parsed pipeline of a single file, or the error that occurred while parsing it
+type Result struct {
+	Platform models.Platform  `json:"platform,omitempty"`
+	Pipeline *models.Pipeline `json:"pipeline,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
+
+type Options struct {
+	Workers      int
+	Credentials  *models.Credentials
+	Organization *string
+	BaseUrl      *string
+}
+
+type pipelineFile struct {
+	path     string
+	platform models.Platform
+}
+
+// Scan discovers the pipeline files of a repository and parses them concurrently.
+// The results are keyed by the path of the file relative to the repository root.
+// Local imports are resolved relative to the repository root.
+func Scan(root string, options Options) (map[string]*Result, error) {
+	files, err := Discover(root)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	paths := utils.GetMapKeys(files)
+	sort.Strings(paths)
+	jobs := make(chan pipelineFile)
+	results := map[string]*Result{}
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range jobs {
+				result := scanFile(root, file, options)
+				mutex.Lock()
+				results[file.path] = result
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	for _, path := range paths {
+		jobs <- pipelineFile{path: path, platform: files[path]}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, nil
+}
+
+// Discover returns the platform of every pipeline file in the repository, keyed by the path of the file relative to the repository root
+func Discover(root string) (map[string]models.Platform, error) {
+	files := map[string]models.Platform{}
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if path != root && utils.SliceContains(skippedDirectories, entry.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		if platform, ok := detector.DetectByPath(relativePath); ok {
+			files[relativePath] = platform
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func scanFile(root string, file pipelineFile, options Options) *Result {
+	result := &Result{Platform: file.platform}
+	buf, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(file.path)))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	sourceFetcher := &fetcher.SourceFetcher{
+		Local:  &fetcher.LocalFetcher{Root: root},
+		Remote: &fetcher.HTTPFetcher{Credentials: options.Credentials, Organization: options.Organization, BaseUrl: options.BaseUrl},
+	}
+	pipeline, err := handler.Handle(buf, file.platform, options.Credentials, options.Organization, options.BaseUrl, handler.WithFetcher(sourceFetcher))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Pipeline = pipeline
+	return result
+}
@@ -0,0 +1,119 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+)
+
+var (
+	repositoryFiles = map[string]string{
+		".github/workflows/ci.yml":        "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n      - run: make\n",
+		".gitlab-ci.yml":                  "include:\n  - local: /ci/build.yml\ntest:\n  script: make test\n",
+		"ci/build.yml":                    "build:\n  script: make\n",
+		"services/api/Jenkinsfile":        "node {\n  sh 'make'\n}\n",
+		"node_modules/pkg/.gitlab-ci.yml": "test:\n  script: make\n",
+		"README.md":                       "# repository\n",
+	}
+)
+
+type scanSummary struct {
+	Platform       models.Platform
+	JobIDs         []string
+	ImportedJobIDs []string
+	Error          string
+}
+
+func createRepository(t *testing.T) string {
+	root := t.TempDir()
+	for path, content := range repositoryFiles {
+		fullPath := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestDiscover(t *testing.T) {
+	root := createRepository(t)
+
+	got, err := Discover(root)
+
+	testutils.DeepCompare(t, nil, err)
+	testutils.DeepCompare(t, map[string]models.Platform{
+		".github/workflows/ci.yml": consts.GitHubPlatform,
+		".gitlab-ci.yml":           consts.GitLabPlatform,
+		"services/api/Jenkinsfile": consts.JenkinsPlatform,
+	}, got)
+}
+
+func TestScan(t *testing.T) {
+	testCases := []struct {
+		name    string
+		workers int
+	}{
+		{
+			name:    "Single worker",
+			workers: 1,
+		},
+		{
+			name:    "Default workers",
+			workers: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			root := createRepository(t)
+			workingDirectory, _ := os.Getwd()
+
+			got, err := Scan(root, Options{Workers: testCase.workers, Credentials: &models.Credentials{}})
+			testutils.DeepCompare(t, nil, err)
+
+			summaries := map[string]*scanSummary{}
+			for path, result := range got {
+				summary := &scanSummary{Platform: result.Platform, Error: result.Error}
+				if result.Pipeline != nil {
+					for _, job := range result.Pipeline.Jobs {
+						summary.JobIDs = append(summary.JobIDs, *job.ID)
+					}
+					for _, importData := range result.Pipeline.Imports {
+						if importData.Pipeline != nil {
+							for _, job := range importData.Pipeline.Jobs {
+								summary.ImportedJobIDs = append(summary.ImportedJobIDs, *job.ID)
+							}
+						}
+					}
+				}
+				summaries[path] = summary
+			}
+
+			testutils.DeepCompare(t, map[string]*scanSummary{
+				".github/workflows/ci.yml": {
+					Platform: consts.GitHubPlatform,
+					JobIDs:   []string{"build"},
+				},
+				".gitlab-ci.yml": {
+					Platform:       consts.GitLabPlatform,
+					JobIDs:         []string{"test"},
+					ImportedJobIDs: []string{"build"},
+				},
+				"services/api/Jenkinsfile": {
+					Platform: consts.JenkinsPlatform,
+					Error:    consts.NewErrInvalidJenkinsfile("declarative pipeline block not found").Error(),
+				},
+			}, summaries)
+
+			currentWorkingDirectory, _ := os.Getwd()
+			testutils.DeepCompare(t, workingDirectory, currentWorkingDirectory)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// JoinUnderRoot joins path elements to a root directory, and returns false if the cleaned result is outside of the root
+func JoinUnderRoot(root string, elements ...string) (string, bool) {
+	path := filepath.Join(append([]string{root}, elements...)...)
+	relative, err := filepath.Rel(filepath.Clean(root), path)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return path, true
+}
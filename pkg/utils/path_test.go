@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinUnderRoot(t *testing.T) {
+	tests := []struct {
+		name     string
+		root     string
+		elements []string
+		want     string
+		wantOk   bool
+	}{
+		{name: "relative path", root: "/repo", elements: []string{"org", "ci.yml"}, want: "/repo/org/ci.yml", wantOk: true},
+		{name: "absolute path is joined to the root", root: "/repo", elements: []string{"/ci.yml"}, want: "/repo/ci.yml", wantOk: true},
+		{name: "path that stays inside the root", root: "/repo", elements: []string{"a/../ci.yml"}, want: "/repo/ci.yml", wantOk: true},
+		{name: "path outside of the root", root: "/repo", elements: []string{"../etc/passwd"}},
+		{name: "element outside of the root", root: "repo", elements: []string{"..", "ci.yml"}},
+		{name: "sibling with the same prefix", root: "/repo", elements: []string{"../repo2/ci.yml"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := JoinUnderRoot(tt.root, tt.elements...)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -118,18 +118,6 @@ func TestAzure(t *testing.T) {
 				Platform: consts.AzurePlatform,
 				Defaults: &models.Defaults{},
 				Jobs: []*models.Job{
-					{
-						ID: utils.GetPtr("${{ parameters.jobs }}"),
-						Imports: &models.Import{
-							Source: &models.ImportSource{
-								Path:            utils.GetPtr("${{ parameters.jobs }}"),
-								Type:            models.SourceTypeLocal,
-								RepositoryAlias: utils.GetPtr(""),
-							},
-							FileReference: testutils.CreateFileReference(51, 3, 51, 25),
-						},
-						FileReference: testutils.CreateFileReference(51, 3, 51, 25),
-					},
 					{
 						ID:              utils.GetPtr("DeployWeb"),
 						Name:            utils.GetPtr("deploy Web App"),
@@ -142,6 +130,13 @@ func TestAzure(t *testing.T) {
 							{JobID: utils.GetPtr("job1")},
 							{JobID: utils.GetPtr("job2")},
 						},
+						Environment: &models.Environment{
+							Name:          utils.GetPtr("smarthotel-dev"),
+							FileReference: testutils.CreateFileReference(27, 16, 27, 30),
+						},
+						Metadata: models.Metadata{
+							Deploy: true,
+						},
 						FileReference: testutils.CreateFileReference(21, 3, 33, 43),
 					},
 					{
@@ -167,7 +162,7 @@ func TestAzure(t *testing.T) {
 								FileReference: testutils.CreateFileReference(12, 5, 12, 30),
 							},
 						},
-						FileReference: testutils.CreateFileReference(4, 3, 20, 19),
+						FileReference: testutils.CreateFileReference(4, 3, 16, 9),
 					},
 					{
 						ID: utils.GetPtr("jobs/build.yml"),
@@ -225,6 +220,41 @@ func TestAzure(t *testing.T) {
 						FileReference: testutils.CreateFileReference(45, 3, 50, 15),
 					},
 				},
+				Diagnostics: []*models.Diagnostic{
+					{
+						Severity:      models.ErrorSeverity,
+						Code:          models.ImportFetchFailedCode,
+						Message:       "open jobs/build.yml: no such file or directory",
+						FileReference: testutils.CreateFileReference(34, 3, 38, 28),
+						Source: &models.ImportSource{
+							Path:            utils.GetPtr("jobs/build.yml"),
+							Type:            models.SourceTypeLocal,
+							RepositoryAlias: utils.GetPtr(""),
+						},
+					},
+					{
+						Severity:      models.ErrorSeverity,
+						Code:          models.ImportFetchFailedCode,
+						Message:       "open jobs/build.yml: no such file or directory",
+						FileReference: testutils.CreateFileReference(40, 3, 44, 29),
+						Source: &models.ImportSource{
+							Path:            utils.GetPtr("jobs/build.yml"),
+							Type:            models.SourceTypeLocal,
+							RepositoryAlias: utils.GetPtr(""),
+						},
+					},
+					{
+						Severity:      models.ErrorSeverity,
+						Code:          models.ImportFetchFailedCode,
+						Message:       "open jobs/build.yml: no such file or directory",
+						FileReference: testutils.CreateFileReference(45, 3, 50, 15),
+						Source: &models.ImportSource{
+							Path:            utils.GetPtr("jobs/build.yml"),
+							Type:            models.SourceTypeLocal,
+							RepositoryAlias: utils.GetPtr(""),
+						},
+					},
+				},
 			},
 		},
 		{
@@ -297,6 +327,19 @@ func TestAzure(t *testing.T) {
 						FileReference: testutils.CreateFileReference(43, 3, 45, 13),
 					},
 				},
+				Diagnostics: []*models.Diagnostic{
+					{
+						Severity:      models.ErrorSeverity,
+						Code:          models.ImportFetchFailedCode,
+						Message:       "open parameters.yml: no such file or directory",
+						FileReference: testutils.CreateFileReference(43, 3, 45, 13),
+						Source: &models.ImportSource{
+							Path:            utils.GetPtr("parameters.yml"),
+							Type:            models.SourceTypeLocal,
+							RepositoryAlias: utils.GetPtr(""),
+						},
+					},
+				},
 			},
 		},
 		{
@@ -352,19 +395,36 @@ func TestAzure(t *testing.T) {
 				Name:     utils.GetPtr("stages"),
 				Platform: consts.AzurePlatform,
 				Defaults: &models.Defaults{},
-				Jobs: []*models.Job{
+				Stages: []*models.Stage{
 					{
-						ID:            utils.GetPtr("${{ parameters.stages }}"),
-						FileReference: testutils.CreateFileReference(19, 3, 19, 27),
-						Imports: &models.Import{
-							Source: &models.ImportSource{
-								Path:            utils.GetPtr("${{ parameters.stages }}"),
-								Type:            models.SourceTypeLocal,
-								RepositoryAlias: utils.GetPtr(""),
-							},
-							FileReference: testutils.CreateFileReference(19, 3, 19, 27),
-						},
+						ID:            utils.GetPtr("BuildWin"),
+						Name:          utils.GetPtr("Build for Windows"),
+						Order:         0,
+						FileReference: testutils.CreateFileReference(4, 3, 5, 33),
+					},
+					{
+						ID:            utils.GetPtr("BuildMac"),
+						Name:          utils.GetPtr("Build for Mac"),
+						Order:         1,
+						Dependencies:  []string{},
+						FileReference: testutils.CreateFileReference(6, 3, 8, 14),
+					},
+					{
+						ID:            utils.GetPtr("stages/build.yml"),
+						Order:         2,
+						Dependencies:  []string{"BuildMac"},
+						Jobs:          []string{"stages/build.yml"},
+						FileReference: testutils.CreateFileReference(10, 3, 12, 17),
 					},
+					{
+						ID:            utils.GetPtr("stages/test.yml"),
+						Order:         3,
+						Dependencies:  []string{"stages/build.yml"},
+						Jobs:          []string{"stages/test.yml"},
+						FileReference: testutils.CreateFileReference(14, 3, 17, 33),
+					},
+				},
+				Jobs: []*models.Job{
 					{
 						ID: utils.GetPtr("stages/build.yml"),
 						Imports: &models.Import{
@@ -397,6 +457,30 @@ func TestAzure(t *testing.T) {
 						FileReference: testutils.CreateFileReference(14, 3, 17, 33),
 					},
 				},
+				Diagnostics: []*models.Diagnostic{
+					{
+						Severity:      models.ErrorSeverity,
+						Code:          models.ImportFetchFailedCode,
+						Message:       "open stages/build.yml: no such file or directory",
+						FileReference: testutils.CreateFileReference(10, 3, 12, 17),
+						Source: &models.ImportSource{
+							Path:            utils.GetPtr("stages/build.yml"),
+							Type:            models.SourceTypeLocal,
+							RepositoryAlias: utils.GetPtr(""),
+						},
+					},
+					{
+						Severity:      models.ErrorSeverity,
+						Code:          models.ImportFetchFailedCode,
+						Message:       "open stages/test.yml: no such file or directory",
+						FileReference: testutils.CreateFileReference(14, 3, 17, 33),
+						Source: &models.ImportSource{
+							Path:            utils.GetPtr("stages/test.yml"),
+							Type:            models.SourceTypeLocal,
+							RepositoryAlias: utils.GetPtr(""),
+						},
+					},
+				},
 			},
 		},
 		{
@@ -535,21 +619,25 @@ func TestAzure(t *testing.T) {
 								},
 								FileReference: testutils.CreateFileReference(47, 3, 49, 15),
 							},
-							{
-								Name: utils.GetPtr(""),
-								Type: models.ShellStepType,
-								Shell: &models.Shell{
-									Type:   utils.GetPtr("bash"),
-									Script: utils.GetPtr("${{ parameters.trivyStep }}"),
-								},
-								FileReference: testutils.CreateFileReference(50, 3, 50, 30),
-							},
 						},
 						Metadata: models.Metadata{
 							Build: true,
 						},
 					},
 				},
+				Diagnostics: []*models.Diagnostic{
+					{
+						Severity:      models.ErrorSeverity,
+						Code:          models.ImportFetchFailedCode,
+						Message:       "open steps/build.yml: no such file or directory",
+						FileReference: testutils.CreateFileReference(47, 3, 49, 15),
+						Source: &models.ImportSource{
+							Path:            utils.GetPtr("steps/build.yml"),
+							Type:            models.SourceTypeLocal,
+							RepositoryAlias: utils.GetPtr(""),
+						},
+					},
+				},
 			},
 		},
 		{
@@ -577,6 +665,20 @@ func TestAzure(t *testing.T) {
 						FileReference: testutils.CreateFileReference(3, 3, 11, 17),
 					},
 				},
+				Stages: []*models.Stage{
+					{
+						ID:    utils.GetPtr("Build"),
+						Order: 0,
+						EnvironmentVariables: &models.EnvironmentVariablesRef{
+							EnvironmentVariables: models.EnvironmentVariables{
+								"STAGE_VAR": "that happened",
+							},
+							FileReference: testutils.CreateFileReference(16, 5, 16, 18),
+						},
+						Jobs:          []string{"FirstJob"},
+						FileReference: testutils.CreateFileReference(14, 3, 23, 53),
+					},
+				},
 				Jobs: []*models.Job{
 					{
 						ID:              utils.GetPtr("FirstJob"),
@@ -604,6 +706,19 @@ func TestAzure(t *testing.T) {
 						FileReference: testutils.CreateFileReference(19, 5, 23, 53),
 					},
 				},
+				Diagnostics: []*models.Diagnostic{
+					{
+						Severity:      models.ErrorSeverity,
+						Code:          models.ImportFetchFailedCode,
+						Message:       "open variables/var.yml: no such file or directory",
+						FileReference: testutils.CreateFileReference(9, 3, 11, 17),
+						Source: &models.ImportSource{
+							Path:            utils.GetPtr("variables/var.yml"),
+							Type:            models.SourceTypeLocal,
+							RepositoryAlias: utils.GetPtr(""),
+						},
+					},
+				},
 			},
 		},
 		{
@@ -612,6 +727,21 @@ func TestAzure(t *testing.T) {
 				Name:     utils.GetPtr("stages"),
 				Platform: consts.AzurePlatform,
 				Defaults: &models.Defaults{},
+				Stages: []*models.Stage{
+					{
+						ID:            utils.GetPtr("BuildWin"),
+						Name:          utils.GetPtr("Build for Windows"),
+						Order:         0,
+						FileReference: testutils.CreateFileReference(4, 5, 5, 35),
+					},
+					{
+						ID:            utils.GetPtr("BuildMac"),
+						Name:          utils.GetPtr("Build for Mac"),
+						Order:         1,
+						Dependencies:  []string{},
+						FileReference: testutils.CreateFileReference(6, 5, 8, 16),
+					},
+				},
 				Jobs: []*models.Job{
 					{
 						Name:   utils.GetPtr("default"),
@@ -656,7 +786,7 @@ func TestAzure(t *testing.T) {
 							Type:            models.SourceTypeRemote,
 						},
 						Parameters: map[string]any{
-							"runMode": "${{parameters.runMode}}",
+							"runMode": nil,
 						},
 						FileReference: testutils.CreateFileReference(13, 3, 23, 19),
 					},
@@ -680,6 +810,41 @@ func TestAzure(t *testing.T) {
 						FileReference: testutils.CreateFileReference(13, 3, 23, 19),
 					},
 				},
+				Diagnostics: []*models.Diagnostic{
+					{
+						Severity:      models.ErrorSeverity,
+						Code:          models.ImportFetchFailedCode,
+						Message:       "failed fetching ORG/Templates/blueprints/template.yml: 404 Not Found",
+						FileReference: testutils.CreateFileReference(13, 3, 23, 19),
+						Source: &models.ImportSource{
+							Path:            utils.GetPtr("blueprints/template.yml"),
+							Type:            models.SourceTypeRemote,
+							RepositoryAlias: utils.GetPtr("CeTemplates"),
+						},
+					},
+					{
+						Severity:      models.ErrorSeverity,
+						Code:          models.ImportFetchFailedCode,
+						Message:       "open /pipelines/steps/pre-build-steps.yml: no such file or directory",
+						FileReference: testutils.CreateFileReference(13, 3, 23, 19),
+						Source: &models.ImportSource{
+							Path:            utils.GetPtr("/pipelines/steps/pre-build-steps.yml"),
+							Type:            models.SourceTypeLocal,
+							RepositoryAlias: utils.GetPtr("self"),
+						},
+					},
+					{
+						Severity:      models.ErrorSeverity,
+						Code:          models.ImportFetchFailedCode,
+						Message:       "open test-steps2.yml: no such file or directory",
+						FileReference: testutils.CreateFileReference(13, 3, 23, 19),
+						Source: &models.ImportSource{
+							Path:            utils.GetPtr("test-steps2.yml"),
+							Type:            models.SourceTypeLocal,
+							RepositoryAlias: utils.GetPtr(""),
+						},
+					},
+				},
 			},
 		},
 		{
@@ -688,6 +853,14 @@ func TestAzure(t *testing.T) {
 				Name:     utils.GetPtr(""),
 				Platform: consts.AzurePlatform,
 				Defaults: &models.Defaults{},
+				Stages: []*models.Stage{
+					{
+						ID:            utils.GetPtr("/../../test/fixtures/azure/testdata/imported-stage.yaml"),
+						Order:         0,
+						Jobs:          []string{"/../../test/fixtures/azure/testdata/imported-stage.yaml"},
+						FileReference: testutils.CreateFileReference(7, 5, 9, 17),
+					},
+				},
 				Jobs: []*models.Job{
 					{
 						ID:            utils.GetPtr("/../../test/fixtures/azure/testdata/imported-stage.yaml"),
@@ -742,9 +915,155 @@ func TestAzure(t *testing.T) {
 						FileReference: testutils.CreateFileReference(12, 3, 14, 19),
 					},
 				},
+				Diagnostics: []*models.Diagnostic{
+					{
+						Severity:      models.ErrorSeverity,
+						Code:          models.ImportFetchFailedCode,
+						Message:       "open /../../test/fixtures/azure/testdata/imported-stage.yaml: no such file or directory",
+						FileReference: testutils.CreateFileReference(7, 5, 9, 17),
+						Source: &models.ImportSource{
+							Path:            utils.GetPtr("/../../test/fixtures/azure/testdata/imported-stage.yaml"),
+							Type:            models.SourceTypeLocal,
+							RepositoryAlias: utils.GetPtr(""),
+						},
+					},
+				},
+			},
+		},
+		{
+			Filename: "template-expressions.yaml",
+			Expected: &models.Pipeline{
+				Name:     utils.GetPtr(""),
+				Platform: consts.AzurePlatform,
+				Defaults: &models.Defaults{},
+				Jobs: []*models.Job{
+					{
+						Name:   utils.GetPtr("default"),
+						Runner: &models.Runner{},
+					},
+				},
+				Imports: []*models.Import{
+					{
+						Source: &models.ImportSource{
+							Path:            utils.GetPtr("../../test/fixtures/azure/testdata/expressions-template.yaml"),
+							RepositoryAlias: utils.GetPtr("self"),
+							Type:            models.SourceTypeLocal,
+						},
+						Parameters: map[string]any{
+							"environments": []any{"dev", "prod"},
+							"runTests":     false,
+						},
+						Pipeline: &models.Pipeline{
+							Name: utils.GetPtr(""),
+							Parameters: []*models.Parameter{
+								{
+									Name:          utils.GetPtr("environments"),
+									Default:       []any{},
+									FileReference: testutils.CreateFileReference(2, 5, 4, 14),
+								},
+								{
+									Name:          utils.GetPtr("runTests"),
+									Default:       true,
+									FileReference: testutils.CreateFileReference(5, 5, 7, 18),
+								},
+								{
+									Name:          utils.GetPtr("buildConfiguration"),
+									Default:       "Release",
+									FileReference: testutils.CreateFileReference(8, 5, 10, 21),
+								},
+							},
+							Defaults: &models.Defaults{
+								EnvironmentVariables: &models.EnvironmentVariablesRef{
+									EnvironmentVariables: models.EnvironmentVariables{
+										"toolsVersion": "8.0",
+									},
+									FileReference: testutils.CreateFileReference(13, 3, 13, 6),
+								},
+							},
+							Jobs: []*models.Job{
+								{
+									ID:              utils.GetPtr("Build"),
+									Name:            utils.GetPtr("Build Release"),
+									ContinueOnError: utils.GetPtr("false"),
+									TimeoutMS:       utils.GetPtr(3600000),
+									Metadata: models.Metadata{
+										Build: true,
+									},
+									Steps: []*models.Step{
+										{
+											Name: utils.GetPtr(""),
+											Type: "shell",
+											Shell: &models.Shell{
+												Type:   utils.GetPtr(""),
+												Script: utils.GetPtr("dotnet build --configuration Release"),
+											},
+											FileReference: testutils.CreateFileReference(19, 9, 19, 53),
+										},
+										{
+											Name: utils.GetPtr(""),
+											Type: "shell",
+											Shell: &models.Shell{
+												Type:   utils.GetPtr(""),
+												Script: utils.GetPtr("echo skipping tests"),
+											},
+											FileReference: testutils.CreateFileReference(23, 13, 23, 40),
+										},
+										{
+											Name: utils.GetPtr(""),
+											Type: "shell",
+											Shell: &models.Shell{
+												Type:   utils.GetPtr(""),
+												Script: utils.GetPtr("echo tools 8.0"),
+											},
+											FileReference: testutils.CreateFileReference(24, 9, 24, 31),
+										},
+									},
+									FileReference: testutils.CreateFileReference(16, 5, 24, 31),
+								},
+								{
+									ID:              utils.GetPtr("Deploy_dev"),
+									Name:            utils.GetPtr(""),
+									ContinueOnError: utils.GetPtr("false"),
+									TimeoutMS:       utils.GetPtr(3600000),
+									Steps: []*models.Step{
+										{
+											Name: utils.GetPtr(""),
+											Type: "shell",
+											Shell: &models.Shell{
+												Type:   utils.GetPtr(""),
+												Script: utils.GetPtr("./deploy.sh dev"),
+											},
+											FileReference: testutils.CreateFileReference(28, 13, 28, 36),
+										},
+									},
+									FileReference: testutils.CreateFileReference(26, 9, 28, 36),
+								},
+								{
+									ID:              utils.GetPtr("Deploy_prod"),
+									Name:            utils.GetPtr(""),
+									ContinueOnError: utils.GetPtr("false"),
+									TimeoutMS:       utils.GetPtr(3600000),
+									Steps: []*models.Step{
+										{
+											Name: utils.GetPtr(""),
+											Type: "shell",
+											Shell: &models.Shell{
+												Type:   utils.GetPtr(""),
+												Script: utils.GetPtr("./deploy.sh prod"),
+											},
+											FileReference: testutils.CreateFileReference(28, 13, 28, 37),
+										},
+									},
+									FileReference: testutils.CreateFileReference(26, 9, 28, 37),
+								},
+							},
+						},
+						FileReference: testutils.CreateFileReference(4, 3, 9, 20),
+					},
+				},
 			},
 		},
 	}
 
-	executeTestCases(t, testCases, "azure", consts.AzurePlatform, "azure-org", "https://dev.azure.com")
+	executeTestCases(t, testCases, "azure", consts.AzurePlatform, "azure-org", "")
 }
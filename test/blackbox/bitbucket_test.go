@@ -15,6 +15,23 @@ func TestBitbucket(t *testing.T) {
 			Filename: "simple-pipeline.yml",
 			Expected: &models.Pipeline{
 				Platform: consts.BitbucketPlatform,
+				Triggers: &models.Triggers{
+					Triggers: []*models.Trigger{
+						{
+							Event:         models.PushEvent,
+							Branches:      &models.Filter{DenyList: []string{"master"}},
+							Jobs:          []string{"job-default"},
+							FileReference: testutils.CreateFileReference(14, 11, 27, 36),
+						},
+						{
+							Event:         models.PushEvent,
+							Branches:      &models.Filter{AllowList: []string{"master"}},
+							Jobs:          []string{"job-branches-master"},
+							FileReference: testutils.CreateFileReference(30, 9, 54, 21),
+						},
+					},
+					FileReference: testutils.CreateFileReference(14, 11, 54, 21),
+				},
 				Defaults: &models.Defaults{
 					Runner: &models.Runner{
 						DockerMetadata: &models.DockerMetadata{
@@ -24,12 +41,43 @@ func TestBitbucket(t *testing.T) {
 				},
 				Jobs: []*models.Job{
 					{
-						FileReference: testutils.CreateFileReference(14, 11, 27, 36),
-						ID:            utils.GetPtr("job-default"),
-						Name:          utils.GetPtr("default"),
+						FileReference: testutils.CreateFileReference(30, 9, 54, 21),
+						ID:            utils.GetPtr("job-branches-master"),
+						Name:          utils.GetPtr("master"),
+						TimeoutMS:     utils.GetPtr(7200000),
+						Environment: &models.Environment{
+							Name:          utils.GetPtr("Production"),
+							FileReference: testutils.CreateFileReference(42, 9, 54, 21),
+						},
+						Produces: []*models.Artifact{
+							{
+								Type:          models.FileArtifactType,
+								Paths:         []string{"*.tar"},
+								FileReference: testutils.CreateFileReference(30, 9, 41, 20),
+							},
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("docker"),
+								FileReference: testutils.CreateFileReference(30, 9, 41, 20),
+							},
+						},
+						Consumes: []*models.Artifact{
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("docker"),
+								FileReference: testutils.CreateFileReference(30, 9, 41, 20),
+							},
+							{Type: models.FileArtifactType},
+						},
+						Services: []*models.Service{
+							{
+								Name: utils.GetPtr("docker"),
+							},
+						},
 						Metadata: models.Metadata{
-							Build: true,
-							Test:  true,
+							Build:  true,
+							Test:   true,
+							Deploy: true,
 						},
 						Steps: []*models.Step{
 							{
@@ -41,32 +89,77 @@ func TestBitbucket(t *testing.T) {
 								},
 								Shell: &models.Shell{
 									Type:          utils.GetPtr("shell"),
-									Script:        utils.GetPtr("IMAGE_NAME=$BITBUCKET_REPO_SLUG\ndocker build . --file Dockerfile --tag ${IMAGE_NAME}"),
-									FileReference: testutils.CreateFileReference(17, 17, 18, 69),
+									Script:        utils.GetPtr("IMAGE_NAME=$BITBUCKET_REPO_SLUG\ndocker build . --file Dockerfile --tag ${IMAGE_NAME}\ndocker save ${IMAGE_NAME} --output \"${IMAGE_NAME}.tar\""),
+									FileReference: testutils.CreateFileReference(33, 15, 35, 69),
 								},
-								FileReference: testutils.CreateFileReference(14, 11, 22, 23),
+								Produces: []*models.Artifact{
+									{
+										Type:          models.FileArtifactType,
+										Paths:         []string{"*.tar"},
+										FileReference: testutils.CreateFileReference(30, 9, 41, 20),
+									},
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("docker"),
+										FileReference: testutils.CreateFileReference(30, 9, 41, 20),
+									},
+								},
+								Consumes: []*models.Artifact{
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("docker"),
+										FileReference: testutils.CreateFileReference(30, 9, 41, 20),
+									},
+								},
+								FileReference: testutils.CreateFileReference(30, 9, 41, 20),
 							},
 							{
-								Name: utils.GetPtr("Lint the Dockerfile"),
 								Type: "shell",
+								Name: utils.GetPtr("Deploy to Production"),
 								Shell: &models.Shell{
 									Type:          utils.GetPtr("shell"),
-									Script:        utils.GetPtr("hadolint Dockerfile"),
-									FileReference: testutils.CreateFileReference(27, 17, 27, 36),
-								},
-								Runner: &models.Runner{
-									DockerMetadata: &models.DockerMetadata{
-										Image: utils.GetPtr("hadolint/hadolint:latest-debian"),
-									},
+									Script:        utils.GetPtr("echo ${DOCKERHUB_PASSWORD} | docker login --username \"$DOCKERHUB_USERNAME\" --password-stdin\nIMAGE_NAME=$BITBUCKET_REPO_SLUG\ndocker load --input \"${IMAGE_NAME}.tar\"\nVERSION=\"prod-0.1.${BITBUCKET_BUILD_NUMBER}\"\nIMAGE=${DOCKERHUB_NAMESPACE}/${IMAGE_NAME}\ndocker tag \"${IMAGE_NAME}\" \"${IMAGE}:${VERSION}\"\ndocker push \"${IMAGE}:${VERSION}\""),
+									FileReference: testutils.CreateFileReference(46, 15, 52, 48),
 								},
-								FileReference: testutils.CreateFileReference(23, 11, 27, 36),
+								Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
+								FileReference: testutils.CreateFileReference(42, 9, 54, 21),
+							},
+						},
+						StepGroups: []*models.StepGroup{
+							{
+								Steps:         []int{0},
+								FileReference: testutils.CreateFileReference(30, 9, 41, 20),
+							},
+							{
+								Steps:         []int{1},
+								FileReference: testutils.CreateFileReference(42, 9, 54, 21),
 							},
 						},
 					},
 					{
-						FileReference: testutils.CreateFileReference(30, 9, 54, 21),
-						ID:            utils.GetPtr("job-master"),
-						Name:          utils.GetPtr("master"),
+						FileReference: testutils.CreateFileReference(14, 11, 27, 36),
+						ID:            utils.GetPtr("job-default"),
+						Name:          utils.GetPtr("default"),
+						TimeoutMS:     utils.GetPtr(7200000),
+						Produces: []*models.Artifact{
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("docker"),
+								FileReference: testutils.CreateFileReference(14, 11, 22, 23),
+							},
+						},
+						Consumes: []*models.Artifact{
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("docker"),
+								FileReference: testutils.CreateFileReference(14, 11, 22, 23),
+							},
+						},
+						Services: []*models.Service{
+							{
+								Name: utils.GetPtr("docker"),
+							},
+						},
 						Metadata: models.Metadata{
 							Build: true,
 							Test:  true,
@@ -81,20 +174,46 @@ func TestBitbucket(t *testing.T) {
 								},
 								Shell: &models.Shell{
 									Type:          utils.GetPtr("shell"),
-									Script:        utils.GetPtr("IMAGE_NAME=$BITBUCKET_REPO_SLUG\ndocker build . --file Dockerfile --tag ${IMAGE_NAME}\ndocker save ${IMAGE_NAME} --output \"${IMAGE_NAME}.tar\""),
-									FileReference: testutils.CreateFileReference(33, 15, 35, 69),
+									Script:        utils.GetPtr("IMAGE_NAME=$BITBUCKET_REPO_SLUG\ndocker build . --file Dockerfile --tag ${IMAGE_NAME}"),
+									FileReference: testutils.CreateFileReference(17, 17, 18, 69),
 								},
-								FileReference: testutils.CreateFileReference(30, 9, 41, 20),
+								Produces: []*models.Artifact{
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("docker"),
+										FileReference: testutils.CreateFileReference(14, 11, 22, 23),
+									},
+								},
+								Consumes: []*models.Artifact{
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("docker"),
+										FileReference: testutils.CreateFileReference(14, 11, 22, 23),
+									},
+								},
+								FileReference: testutils.CreateFileReference(14, 11, 22, 23),
 							},
 							{
+								Name: utils.GetPtr("Lint the Dockerfile"),
 								Type: "shell",
-								Name: utils.GetPtr("Deploy to Production"),
 								Shell: &models.Shell{
 									Type:          utils.GetPtr("shell"),
-									Script:        utils.GetPtr("echo ${DOCKERHUB_PASSWORD} | docker login --username \"$DOCKERHUB_USERNAME\" --password-stdin\nIMAGE_NAME=$BITBUCKET_REPO_SLUG\ndocker load --input \"${IMAGE_NAME}.tar\"\nVERSION=\"prod-0.1.${BITBUCKET_BUILD_NUMBER}\"\nIMAGE=${DOCKERHUB_NAMESPACE}/${IMAGE_NAME}\ndocker tag \"${IMAGE_NAME}\" \"${IMAGE}:${VERSION}\"\ndocker push \"${IMAGE}:${VERSION}\""),
-									FileReference: testutils.CreateFileReference(46, 15, 52, 48),
+									Script:        utils.GetPtr("hadolint Dockerfile"),
+									FileReference: testutils.CreateFileReference(27, 17, 27, 36),
 								},
-								FileReference: testutils.CreateFileReference(42, 9, 54, 21),
+								Runner: &models.Runner{
+									DockerMetadata: &models.DockerMetadata{
+										Image: utils.GetPtr("hadolint/hadolint:latest-debian"),
+									},
+								},
+								FileReference: testutils.CreateFileReference(23, 11, 27, 36),
+							},
+						},
+						StepGroups: []*models.StepGroup{
+							{
+								Steps:         []int{0, 1},
+								Parallel:      true,
+								FileReference: testutils.CreateFileReference(14, 11, 27, 36),
 							},
 						},
 					},
@@ -105,6 +224,28 @@ func TestBitbucket(t *testing.T) {
 			Filename: "alias-pipeline.yml",
 			Expected: &models.Pipeline{
 				Platform: consts.BitbucketPlatform,
+				Triggers: &models.Triggers{
+					Triggers: []*models.Trigger{
+						{
+							Event:         models.ManualEvent,
+							Jobs:          []string{"job-custom-deploy-staging"},
+							FileReference: testutils.CreateFileReference(10, 7, 27, 24),
+						},
+						{
+							Event:         models.PullRequestEvent,
+							Branches:      &models.Filter{AllowList: []string{"**"}},
+							Jobs:          []string{"job-pull-requests-**"},
+							FileReference: testutils.CreateFileReference(10, 7, 23, 61),
+						},
+						{
+							Event:         models.PushEvent,
+							Branches:      &models.Filter{AllowList: []string{"master"}},
+							Jobs:          []string{"job-branches-master"},
+							FileReference: testutils.CreateFileReference(10, 7, 27, 24),
+						},
+					},
+					FileReference: testutils.CreateFileReference(10, 7, 27, 24),
+				},
 				Defaults: &models.Defaults{
 					Runner: &models.Runner{
 						DockerMetadata: &models.DockerMetadata{
@@ -114,9 +255,42 @@ func TestBitbucket(t *testing.T) {
 				},
 				Jobs: []*models.Job{
 					{
-						FileReference: testutils.CreateFileReference(10, 7, 23, 61),
-						ID:            utils.GetPtr("job-**"),
-						Name:          utils.GetPtr("**"),
+						FileReference: testutils.CreateFileReference(10, 7, 27, 24),
+						ID:            utils.GetPtr("job-branches-master"),
+						Name:          utils.GetPtr("master"),
+						TimeoutMS:     utils.GetPtr(7200000),
+						Produces: []*models.Artifact{
+							{
+								Type:          models.FileArtifactType,
+								Paths:         []string{"demo/**", "dist/**"},
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("node"),
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("cypress"),
+								Paths:         []string{"/root/.cache/Cypress"},
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+						},
+						Consumes: []*models.Artifact{
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("node"),
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("cypress"),
+								Paths:         []string{"/root/.cache/Cypress"},
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+							{Type: models.FileArtifactType},
+						},
 						Metadata: models.Metadata{
 							Build: true,
 						},
@@ -127,6 +301,9 @@ func TestBitbucket(t *testing.T) {
 								Metadata: models.Metadata{
 									Build: true,
 								},
+								Runner: &models.Runner{
+									ResourceClass: utils.GetPtr("2x"),
+								},
 								Shell: &models.Shell{
 									Type:          utils.GetPtr("shell"),
 									Script:        utils.GetPtr("yarn\nyarn build"),
@@ -137,14 +314,99 @@ func TestBitbucket(t *testing.T) {
 									Script:        utils.GetPtr("npx notify -s \"Install and build\" --only-failure"),
 									FileReference: testutils.CreateFileReference(23, 13, 23, 61),
 								},
+								Produces: []*models.Artifact{
+									{
+										Type:          models.FileArtifactType,
+										Paths:         []string{"demo/**", "dist/**"},
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("node"),
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("cypress"),
+										Paths:         []string{"/root/.cache/Cypress"},
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+								},
+								Consumes: []*models.Artifact{
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("node"),
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("cypress"),
+										Paths:         []string{"/root/.cache/Cypress"},
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+								},
 								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
 							},
+							{
+								Name: utils.GetPtr("deploy"),
+								Type: "shell",
+								Shell: &models.Shell{
+									Type:          utils.GetPtr("shell"),
+									Script:        utils.GetPtr("echo deploy"),
+									FileReference: testutils.CreateFileReference(27, 13, 27, 24),
+								},
+								Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
+								FileReference: testutils.CreateAliasFileReference(24, 7, 27, 24, true),
+							},
+						},
+						StepGroups: []*models.StepGroup{
+							{
+								Steps:         []int{0},
+								FileReference: testutils.CreateFileReference(10, 7, 23, 61),
+							},
+							{
+								Steps:         []int{1},
+								FileReference: testutils.CreateFileReference(24, 7, 27, 24),
+							},
 						},
 					},
 					{
 						FileReference: testutils.CreateFileReference(10, 7, 27, 24),
-						ID:            utils.GetPtr("job-deploy-staging"),
+						ID:            utils.GetPtr("job-custom-deploy-staging"),
 						Name:          utils.GetPtr("deploy-staging"),
+						TimeoutMS:     utils.GetPtr(7200000),
+						Produces: []*models.Artifact{
+							{
+								Type:          models.FileArtifactType,
+								Paths:         []string{"demo/**", "dist/**"},
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("node"),
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("cypress"),
+								Paths:         []string{"/root/.cache/Cypress"},
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+						},
+						Consumes: []*models.Artifact{
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("node"),
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("cypress"),
+								Paths:         []string{"/root/.cache/Cypress"},
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+							{Type: models.FileArtifactType},
+						},
 						Metadata: models.Metadata{
 							Build: true,
 						},
@@ -155,6 +417,9 @@ func TestBitbucket(t *testing.T) {
 								Metadata: models.Metadata{
 									Build: true,
 								},
+								Runner: &models.Runner{
+									ResourceClass: utils.GetPtr("2x"),
+								},
 								Shell: &models.Shell{
 									Type:          utils.GetPtr("shell"),
 									Script:        utils.GetPtr("yarn\nyarn build"),
@@ -165,6 +430,37 @@ func TestBitbucket(t *testing.T) {
 									Script:        utils.GetPtr("npx notify -s \"Install and build\" --only-failure"),
 									FileReference: testutils.CreateFileReference(23, 13, 23, 61),
 								},
+								Produces: []*models.Artifact{
+									{
+										Type:          models.FileArtifactType,
+										Paths:         []string{"demo/**", "dist/**"},
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("node"),
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("cypress"),
+										Paths:         []string{"/root/.cache/Cypress"},
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+								},
+								Consumes: []*models.Artifact{
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("node"),
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("cypress"),
+										Paths:         []string{"/root/.cache/Cypress"},
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+								},
 								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
 							},
 							{
@@ -175,14 +471,57 @@ func TestBitbucket(t *testing.T) {
 									Script:        utils.GetPtr("echo deploy"),
 									FileReference: testutils.CreateFileReference(27, 13, 27, 24),
 								},
+								Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 								FileReference: testutils.CreateAliasFileReference(24, 7, 27, 24, true),
 							},
 						},
+						StepGroups: []*models.StepGroup{
+							{
+								Steps:         []int{0},
+								FileReference: testutils.CreateFileReference(10, 7, 23, 61),
+							},
+							{
+								Steps:         []int{1},
+								FileReference: testutils.CreateFileReference(24, 7, 27, 24),
+							},
+						},
 					},
 					{
-						FileReference: testutils.CreateFileReference(10, 7, 27, 24),
-						ID:            utils.GetPtr("job-master"),
-						Name:          utils.GetPtr("master"),
+						FileReference: testutils.CreateFileReference(10, 7, 23, 61),
+						ID:            utils.GetPtr("job-pull-requests-**"),
+						Name:          utils.GetPtr("**"),
+						TimeoutMS:     utils.GetPtr(7200000),
+						Produces: []*models.Artifact{
+							{
+								Type:          models.FileArtifactType,
+								Paths:         []string{"demo/**", "dist/**"},
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("node"),
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("cypress"),
+								Paths:         []string{"/root/.cache/Cypress"},
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+						},
+						Consumes: []*models.Artifact{
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("node"),
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("cypress"),
+								Paths:         []string{"/root/.cache/Cypress"},
+								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+							},
+						},
 						Metadata: models.Metadata{
 							Build: true,
 						},
@@ -193,6 +532,9 @@ func TestBitbucket(t *testing.T) {
 								Metadata: models.Metadata{
 									Build: true,
 								},
+								Runner: &models.Runner{
+									ResourceClass: utils.GetPtr("2x"),
+								},
 								Shell: &models.Shell{
 									Type:          utils.GetPtr("shell"),
 									Script:        utils.GetPtr("yarn\nyarn build"),
@@ -203,17 +545,44 @@ func TestBitbucket(t *testing.T) {
 									Script:        utils.GetPtr("npx notify -s \"Install and build\" --only-failure"),
 									FileReference: testutils.CreateFileReference(23, 13, 23, 61),
 								},
+								Produces: []*models.Artifact{
+									{
+										Type:          models.FileArtifactType,
+										Paths:         []string{"demo/**", "dist/**"},
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("node"),
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("cypress"),
+										Paths:         []string{"/root/.cache/Cypress"},
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+								},
+								Consumes: []*models.Artifact{
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("node"),
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+									{
+										Type:          models.CacheArtifactType,
+										Name:          utils.GetPtr("cypress"),
+										Paths:         []string{"/root/.cache/Cypress"},
+										FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
+									},
+								},
 								FileReference: testutils.CreateAliasFileReference(10, 7, 23, 61, true),
 							},
+						},
+						StepGroups: []*models.StepGroup{
 							{
-								Name: utils.GetPtr("deploy"),
-								Type: "shell",
-								Shell: &models.Shell{
-									Type:          utils.GetPtr("shell"),
-									Script:        utils.GetPtr("echo deploy"),
-									FileReference: testutils.CreateFileReference(27, 13, 27, 24),
-								},
-								FileReference: testutils.CreateAliasFileReference(24, 7, 27, 24, true),
+								Steps:         []int{0},
+								FileReference: testutils.CreateFileReference(10, 7, 23, 61),
 							},
 						},
 					},
@@ -224,6 +593,17 @@ func TestBitbucket(t *testing.T) {
 			Filename: "merge-step-pipeline.yml",
 			Expected: &models.Pipeline{
 				Platform: consts.BitbucketPlatform,
+				Triggers: &models.Triggers{
+					Triggers: []*models.Trigger{
+						{
+							Event:         models.PushEvent,
+							Branches:      &models.Filter{AllowList: []string{"main"}},
+							Jobs:          []string{"job-branches-main"},
+							FileReference: testutils.CreateFileReference(19, 13, 23, 30),
+						},
+					},
+					FileReference: testutils.CreateFileReference(19, 13, 23, 30),
+				},
 				Defaults: &models.Defaults{
 					Runner: &models.Runner{
 						DockerMetadata: &models.DockerMetadata{
@@ -234,8 +614,9 @@ func TestBitbucket(t *testing.T) {
 				Jobs: []*models.Job{
 					{
 						FileReference: testutils.CreateFileReference(19, 13, 23, 30),
-						ID:            utils.GetPtr("job-main"),
+						ID:            utils.GetPtr("job-branches-main"),
 						Name:          utils.GetPtr("main"),
+						TimeoutMS:     utils.GetPtr(7200000),
 						Metadata: models.Metadata{
 							Test: true,
 						},
@@ -264,6 +645,13 @@ func TestBitbucket(t *testing.T) {
 								FileReference: testutils.CreateAliasFileReference(22, 13, 23, 30, true),
 							},
 						},
+						StepGroups: []*models.StepGroup{
+							{
+								Steps:         []int{0, 1},
+								Parallel:      true,
+								FileReference: testutils.CreateFileReference(19, 13, 23, 30),
+							},
+						},
 					},
 				},
 			},
@@ -272,6 +660,17 @@ func TestBitbucket(t *testing.T) {
 			Filename: "variables-pipeline.yml",
 			Expected: &models.Pipeline{
 				Platform: consts.BitbucketPlatform,
+				Triggers: &models.Triggers{
+					Triggers: []*models.Trigger{
+						{
+							Event:         models.PushEvent,
+							Branches:      &models.Filter{AllowList: []string{"master"}},
+							Jobs:          []string{"job-branches-master"},
+							FileReference: testutils.CreateFileReference(6, 9, 24, 25),
+						},
+					},
+					FileReference: testutils.CreateFileReference(6, 9, 24, 25),
+				},
 				Defaults: &models.Defaults{
 					Runner: &models.Runner{
 						DockerMetadata: &models.DockerMetadata{
@@ -282,15 +681,35 @@ func TestBitbucket(t *testing.T) {
 				Jobs: []*models.Job{
 					{
 						FileReference: testutils.CreateFileReference(6, 9, 24, 25),
-						ID:            utils.GetPtr("job-master"),
+						ID:            utils.GetPtr("job-branches-master"),
 						Name:          utils.GetPtr("master"),
+						TimeoutMS:     utils.GetPtr(7200000),
+						Environment: &models.Environment{
+							Name:          utils.GetPtr("Production"),
+							FileReference: testutils.CreateFileReference(6, 9, 24, 25),
+						},
+						Metadata: models.Metadata{
+							Deploy: true,
+						},
 						Steps: []*models.Step{
 							{
 								Name: utils.GetPtr("Deploy to Production"),
 								Type: "task",
 								Task: &models.Task{
-									Name:        utils.GetPtr("atlassian/aws-elasticbeanstalk-deploy:1.0.2\natlassian/aws-elasticbeanstalk-run:1.0.2"),
-									VersionType: "none",
+									Name:        utils.GetPtr("atlassian/aws-elasticbeanstalk-deploy"),
+									Version:     utils.GetPtr("1.0.2"),
+									VersionType: models.TagVersion,
+									Type:        models.CITaskType,
+									Inputs: []*models.Parameter{
+										{Name: utils.GetPtr("APPLICATION_NAME"), Value: "pipes-templates-java-spring-boot-app"},
+										{Name: utils.GetPtr("AWS_ACCESS_KEY_ID"), Value: "$AWS_ACCESS_KEY_ID"},
+										{Name: utils.GetPtr("AWS_DEFAULT_REGION"), Value: "$AWS_DEFAULT_REGION"},
+										{Name: utils.GetPtr("AWS_SECRET_ACCESS_KEY"), Value: "$AWS_SECRET_ACCESS_KEY"},
+										{Name: utils.GetPtr("ENVIRONMENT_NAME"), Value: "Production"},
+										{Name: utils.GetPtr("S3_BUCKET"), Value: "pipes-template-java-spring-boot-source"},
+										{Name: utils.GetPtr("VERSION_LABEL"), Value: "prod-0.1.$BITBUCKET_BUILD_NUMBER"},
+										{Name: utils.GetPtr("ZIP_FILE"), Value: "application.zip"},
+									},
 								},
 								EnvironmentVariables: &models.EnvironmentVariablesRef{
 									EnvironmentVariables: models.EnvironmentVariables{
@@ -302,13 +721,40 @@ func TestBitbucket(t *testing.T) {
 										"S3_BUCKET":             "pipes-template-java-spring-boot-source",
 										"ZIP_FILE":              "application.zip",
 										"VERSION_LABEL":         "prod-0.1.$BITBUCKET_BUILD_NUMBER",
-										"KEY":                   "value",
-										"FOO":                   "bar",
 									},
-									FileReference: testutils.CreateFileReference(12, 17, 24, 25),
+									FileReference: testutils.CreateFileReference(12, 17, 20, 64),
 								},
 								FileReference: testutils.CreateFileReference(6, 9, 24, 25),
 							},
+							{
+								Name: utils.GetPtr("Deploy to Production"),
+								Type: "task",
+								Task: &models.Task{
+									Name:        utils.GetPtr("atlassian/aws-elasticbeanstalk-run"),
+									Version:     utils.GetPtr("1.0.2"),
+									VersionType: models.TagVersion,
+									Type:        models.CITaskType,
+									Inputs: []*models.Parameter{
+										{Name: utils.GetPtr("FOO"), Value: "bar"},
+										{Name: utils.GetPtr("KEY"), Value: "value"},
+									},
+								},
+								EnvironmentVariables: &models.EnvironmentVariablesRef{
+									EnvironmentVariables: models.EnvironmentVariables{
+										"KEY": "value",
+										"FOO": "bar",
+									},
+									FileReference: testutils.CreateFileReference(22, 17, 24, 25),
+								},
+								FileReference: testutils.CreateFileReference(21, 21, 24, 25),
+							},
+						},
+						StepGroups: []*models.StepGroup{
+							{
+								Steps:         []int{0, 1},
+								Manual:        true,
+								FileReference: testutils.CreateFileReference(6, 9, 24, 25),
+							},
 						},
 					},
 				},
@@ -318,12 +764,24 @@ func TestBitbucket(t *testing.T) {
 			Filename: "image-step.yml",
 			Expected: &models.Pipeline{
 				Platform: consts.BitbucketPlatform,
+				Triggers: &models.Triggers{
+					Triggers: []*models.Trigger{
+						{
+							Event:         models.PullRequestEvent,
+							Branches:      &models.Filter{AllowList: []string{"master"}},
+							Jobs:          []string{"job-pull-requests-master"},
+							FileReference: testutils.CreateFileReference(4, 11, 8, 71),
+						},
+					},
+					FileReference: testutils.CreateFileReference(4, 11, 8, 71),
+				},
 				Defaults: &models.Defaults{},
 				Jobs: []*models.Job{
 					{
 						FileReference: testutils.CreateFileReference(4, 11, 8, 71),
-						ID:            utils.GetPtr("job-master"),
+						ID:            utils.GetPtr("job-pull-requests-master"),
 						Name:          utils.GetPtr("master"),
+						TimeoutMS:     utils.GetPtr(7200000),
 						Steps: []*models.Step{
 							{
 								Name: utils.GetPtr("Run Aqua scanner"),
@@ -341,6 +799,120 @@ func TestBitbucket(t *testing.T) {
 								FileReference: testutils.CreateFileReference(4, 11, 8, 71),
 							},
 						},
+						StepGroups: []*models.StepGroup{
+							{
+								Steps:         []int{0},
+								FileReference: testutils.CreateFileReference(4, 11, 8, 71),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Filename: "parallel-fail-fast.yml",
+			Expected: &models.Pipeline{
+				Platform: consts.BitbucketPlatform,
+				Triggers: &models.Triggers{
+					Triggers: []*models.Trigger{
+						{
+							Event:         models.PushEvent,
+							Jobs:          []string{"job-default"},
+							FileReference: testutils.CreateFileReference(5, 7, 24, 27),
+						},
+					},
+					FileReference: testutils.CreateFileReference(5, 7, 24, 27),
+				},
+				Defaults: &models.Defaults{
+					Runner: &models.Runner{
+						DockerMetadata: &models.DockerMetadata{
+							Image: utils.GetPtr("node:16"),
+						},
+					},
+				},
+				Jobs: []*models.Job{
+					{
+						FileReference: testutils.CreateFileReference(5, 7, 24, 27),
+						ID:            utils.GetPtr("job-default"),
+						Name:          utils.GetPtr("default"),
+						TimeoutMS:     utils.GetPtr(7200000),
+						Consumes: []*models.Artifact{
+							{Type: models.FileArtifactType},
+							{Type: models.FileArtifactType},
+							{Type: models.FileArtifactType},
+						},
+						Metadata: models.Metadata{
+							Build: true,
+							Test:  true,
+						},
+						Steps: []*models.Step{
+							{
+								Name: utils.GetPtr("Build"),
+								Type: "shell",
+								Shell: &models.Shell{
+									Type:          utils.GetPtr("shell"),
+									Script:        utils.GetPtr("npm install"),
+									FileReference: testutils.CreateFileReference(8, 13, 8, 24),
+								},
+								Metadata: models.Metadata{
+									Build: true,
+								},
+								FileReference: testutils.CreateFileReference(5, 7, 8, 24),
+							},
+							{
+								Name: utils.GetPtr("Unit tests"),
+								Type: "shell",
+								Shell: &models.Shell{
+									Type:          utils.GetPtr("shell"),
+									Script:        utils.GetPtr("npm test"),
+									FileReference: testutils.CreateFileReference(15, 19, 15, 27),
+								},
+								Metadata: models.Metadata{
+									Test: true,
+								},
+								Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
+								FileReference: testutils.CreateFileReference(12, 13, 15, 27),
+							},
+							{
+								Name: utils.GetPtr("Lint"),
+								Type: "shell",
+								Shell: &models.Shell{
+									Type:          utils.GetPtr("shell"),
+									Script:        utils.GetPtr("npx eslint ."),
+									FileReference: testutils.CreateFileReference(19, 19, 19, 31),
+								},
+								Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
+								FileReference: testutils.CreateFileReference(16, 13, 19, 31),
+							},
+							{
+								Name: utils.GetPtr("Deploy"),
+								Type: "shell",
+								Shell: &models.Shell{
+									Type:          utils.GetPtr("shell"),
+									Script:        utils.GetPtr("npm run deploy"),
+									FileReference: testutils.CreateFileReference(24, 13, 24, 27),
+								},
+								Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
+								FileReference: testutils.CreateFileReference(20, 7, 24, 27),
+							},
+						},
+						StepGroups: []*models.StepGroup{
+							{
+								Steps:         []int{0},
+								FileReference: testutils.CreateFileReference(5, 7, 8, 24),
+							},
+							{
+								Steps:         []int{1, 2},
+								Parallel:      true,
+								FailFast:      utils.GetPtr(true),
+								FileReference: testutils.CreateFileReference(12, 13, 19, 31),
+							},
+							{
+								Steps:         []int{3},
+								Manual:        true,
+								FileReference: testutils.CreateFileReference(20, 7, 24, 27),
+							},
+						},
 					},
 				},
 			},
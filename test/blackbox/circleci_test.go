@@ -0,0 +1,269 @@
+package blackbox
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestCircleCI(t *testing.T) {
+	testJobSteps := []*models.Step{
+		{
+			Name: utils.GetPtr("checkout"),
+			Type: models.TaskStepType,
+			Task: &models.Task{
+				Name:        utils.GetPtr("checkout"),
+				VersionType: models.None,
+				Type:        models.CITaskType,
+			},
+			FileReference: testutils.CreateFileReference(40, 9, 40, 17),
+		},
+		{
+			Type: models.ShellStepType,
+			Shell: &models.Shell{
+				Script:        utils.GetPtr("npm test"),
+				FileReference: testutils.CreateFileReference(41, 9, 41, 17),
+			},
+			FileReference: testutils.CreateFileReference(41, 9, 41, 22),
+		},
+	}
+	testJobEnvironmentVariables := &models.EnvironmentVariablesRef{
+		EnvironmentVariables: models.EnvironmentVariables{
+			"CI": "true",
+		},
+		FileReference: testutils.CreateFileReference(38, 7, 38, 15),
+	}
+	testJobRunner := &models.Runner{
+		Type:          utils.GetPtr("vm"),
+		OS:            utils.GetPtr("linux"),
+		FileReference: testutils.CreateFileReference(36, 7, 36, 33),
+	}
+
+	testCases := []TestCase{
+		{
+			Filename: "config.yml",
+			Expected: &models.Pipeline{
+				Platform: consts.CircleCIPlatform,
+				Triggers: &models.Triggers{
+					Triggers: []*models.Trigger{
+						{
+							Event: models.PushEvent,
+						},
+						{
+							Event:         models.ScheduledEvent,
+							Schedules:     &[]string{"0 0 * * *"},
+							Pipelines:     []string{"nightly"},
+							Branches:      &models.Filter{AllowList: []string{"main"}},
+							FileReference: testutils.CreateFileReference(67, 9, 72, 23),
+						},
+					},
+				},
+				Imports: []*models.Import{
+					{
+						Source: &models.ImportSource{
+							Organization:    utils.GetPtr("circleci"),
+							Repository:      utils.GetPtr("aws-cli"),
+							RepositoryAlias: utils.GetPtr("aws-cli"),
+							Type:            models.SourceTypeRemote,
+						},
+						Version:       utils.GetPtr("dev:alpha"),
+						VersionType:   models.BranchVersion,
+						FileReference: testutils.CreateFileReference(5, 3, 5, 29),
+					},
+					{
+						Source: &models.ImportSource{
+							Organization:    utils.GetPtr("circleci"),
+							Repository:      utils.GetPtr("node"),
+							RepositoryAlias: utils.GetPtr("node"),
+							Type:            models.SourceTypeRemote,
+						},
+						Version:       utils.GetPtr("5.1.0"),
+						VersionType:   models.TagVersion,
+						FileReference: testutils.CreateFileReference(4, 3, 4, 22),
+					},
+				},
+				Jobs: []*models.Job{
+					{
+						ID:   utils.GetPtr("build-test-deploy/build"),
+						Name: utils.GetPtr("build"),
+						Steps: []*models.Step{
+							{
+								Name: utils.GetPtr("checkout"),
+								Type: models.TaskStepType,
+								Task: &models.Task{
+									Name:        utils.GetPtr("checkout"),
+									VersionType: models.None,
+									Type:        models.CITaskType,
+								},
+								FileReference: testutils.CreateFileReference(29, 9, 29, 17),
+							},
+							{
+								Name: utils.GetPtr("node/install-packages"),
+								Type: models.TaskStepType,
+								Task: &models.Task{
+									Name: utils.GetPtr("circleci/node/install-packages"),
+									Inputs: []*models.Parameter{
+										{
+											Name:          utils.GetPtr("pkg-manager"),
+											Value:         "npm",
+											FileReference: testutils.CreateFileReference(22, 11, 22, 14),
+										},
+									},
+									Version:     utils.GetPtr("5.1.0"),
+									VersionType: models.TagVersion,
+									Type:        models.CITaskType,
+								},
+								FileReference: testutils.CreateFileReference(21, 9, 22, 27),
+							},
+							{
+								Type: models.ShellStepType,
+								Shell: &models.Shell{
+									Script:        utils.GetPtr("npm run prepare"),
+									FileReference: testutils.CreateFileReference(23, 9, 23, 24),
+								},
+								FileReference: testutils.CreateFileReference(23, 9, 23, 29),
+							},
+							{
+								Name: utils.GetPtr("Build"),
+								Type: models.ShellStepType,
+								Shell: &models.Shell{
+									Script:        utils.GetPtr("npm run build"),
+									FileReference: testutils.CreateFileReference(31, 9, 33, 33),
+								},
+								Metadata: models.Metadata{
+									Build: true,
+								},
+								FileReference: testutils.CreateFileReference(31, 9, 33, 33),
+							},
+						},
+						EnvironmentVariables: &models.EnvironmentVariablesRef{
+							EnvironmentVariables: models.EnvironmentVariables{
+								"NODE_ENV": "production",
+							},
+							FileReference: testutils.CreateFileReference(15, 7, 15, 27),
+						},
+						Runner: &models.Runner{
+							Type: utils.GetPtr("docker"),
+							OS:   utils.GetPtr("linux"),
+							DockerMetadata: &models.DockerMetadata{
+								Image: utils.GetPtr("cimg/node"),
+								Label: utils.GetPtr("18.16"),
+							},
+							FileReference: testutils.CreateFileReference(8, 3, 16, 29),
+						},
+						Metadata: models.Metadata{
+							Build: true,
+						},
+						FileReference: testutils.CreateFileReference(26, 3, 33, 33),
+					},
+					{
+						ID:   utils.GetPtr("build-test-deploy/deploy"),
+						Name: utils.GetPtr("deploy"),
+						Steps: []*models.Step{
+							{
+								Name: utils.GetPtr("aws-cli/setup"),
+								Type: models.TaskStepType,
+								Task: &models.Task{
+									Name:        utils.GetPtr("circleci/aws-cli/setup"),
+									Version:     utils.GetPtr("dev:alpha"),
+									VersionType: models.BranchVersion,
+									Type:        models.CITaskType,
+								},
+								FileReference: testutils.CreateFileReference(46, 9, 46, 22),
+							},
+							{
+								Type: models.ShellStepType,
+								Shell: &models.Shell{
+									Script:        utils.GetPtr("./deploy.sh"),
+									FileReference: testutils.CreateFileReference(47, 9, 48, 31),
+								},
+								FileReference: testutils.CreateFileReference(47, 9, 48, 31),
+							},
+						},
+						Runner: &models.Runner{
+							Type: utils.GetPtr("docker"),
+							OS:   utils.GetPtr("linux"),
+							DockerMetadata: &models.DockerMetadata{
+								Image:       utils.GetPtr("tools/deployer"),
+								Label:       utils.GetPtr("1.2"),
+								RegistryURL: utils.GetPtr("registry.example.com"),
+							},
+							FileReference: testutils.CreateFileReference(44, 9, 44, 55),
+						},
+						Conditions: []*models.Condition{
+							{
+								Allow:    utils.GetPtr(true),
+								Branches: &models.Filter{AllowList: []string{"main"}},
+							},
+						},
+						Dependencies: []*models.JobDependency{
+							{JobID: utils.GetPtr("build-test-deploy/test")},
+							{JobID: utils.GetPtr("build-test-deploy/node/test")},
+						},
+						FileReference: testutils.CreateFileReference(42, 3, 48, 31),
+					},
+					{
+						ID:   utils.GetPtr("build-test-deploy/node/test"),
+						Name: utils.GetPtr("node/test"),
+						Steps: []*models.Step{
+							{
+								Name: utils.GetPtr("node/test"),
+								Type: models.TaskStepType,
+								Task: &models.Task{
+									Name:        utils.GetPtr("circleci/node/test"),
+									Version:     utils.GetPtr("5.1.0"),
+									VersionType: models.TagVersion,
+									Type:        models.CITaskType,
+								},
+								Metadata: models.Metadata{
+									Test: true,
+								},
+								FileReference: testutils.CreateFileReference(57, 9, 58, 25),
+							},
+						},
+						Inputs: []*models.Parameter{
+							{
+								Name:  utils.GetPtr("version"),
+								Value: "18.16",
+							},
+						},
+						Metadata: models.Metadata{
+							Test: true,
+						},
+						FileReference: testutils.CreateFileReference(57, 9, 58, 25),
+					},
+					{
+						ID:                   utils.GetPtr("build-test-deploy/test"),
+						Name:                 utils.GetPtr("test"),
+						Steps:                testJobSteps,
+						EnvironmentVariables: testJobEnvironmentVariables,
+						Runner:               testJobRunner,
+						Dependencies: []*models.JobDependency{
+							{JobID: utils.GetPtr("build-test-deploy/build")},
+						},
+						Metadata: models.Metadata{
+							Test: true,
+						},
+						FileReference: testutils.CreateFileReference(34, 3, 41, 17),
+					},
+					{
+						ID:                   utils.GetPtr("nightly/test"),
+						Name:                 utils.GetPtr("test"),
+						Steps:                testJobSteps,
+						EnvironmentVariables: testJobEnvironmentVariables,
+						Runner:               testJobRunner,
+						Metadata: models.Metadata{
+							Test: true,
+						},
+						FileReference: testutils.CreateFileReference(34, 3, 41, 17),
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCases(t, testCases, "circleci", consts.CircleCIPlatform, "", "")
+}
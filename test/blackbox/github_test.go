@@ -9,7 +9,7 @@ import (
 	"github.com/argonsecurity/pipeline-parser/pkg/utils"
 )
 
-func getAllGitHubPermissions(permission models.Permission) *models.TokenPermissions {
+func getAllGitHubPermissions(permission models.Permission, fileReference *models.FileReference) *models.TokenPermissions {
 	allPermissions := map[string]models.Permission{
 		"run-pipeline":        permission,
 		"checks":              permission,
@@ -26,7 +26,8 @@ func getAllGitHubPermissions(permission models.Permission) *models.TokenPermissi
 		"statuses":            permission,
 	}
 	return &models.TokenPermissions{
-		Permissions: allPermissions,
+		Permissions:   allPermissions,
+		FileReference: fileReference,
 	}
 }
 
@@ -187,6 +188,83 @@ func TestGitHub(t *testing.T) {
 				}),
 			},
 		},
+		{
+			Filename: "outputs.yaml",
+			Expected: &models.Pipeline{
+				Name:     utils.GetPtr("outputs"),
+				Platform: consts.GitHubPlatform,
+				Jobs: SortJobs([]*models.Job{
+					{
+						ID:        utils.GetPtr("build"),
+						Name:      utils.GetPtr("build"),
+						TimeoutMS: utils.GetPtr(21600000),
+						Runner: &models.Runner{
+							OS:            utils.GetPtr("linux"),
+							Labels:        &[]string{"ubuntu-latest"},
+							SelfHosted:    utils.GetPtr(false),
+							FileReference: testutils.CreateFileReference(5, 14, 5, 27),
+						},
+						Outputs: []*models.Output{
+							{
+								Name:          utils.GetPtr("version"),
+								Value:         utils.GetPtr("${{ steps.version.outputs.version }}"),
+								FileReference: testutils.CreateFileReference(7, 7, 7, 52),
+							},
+						},
+						Steps: []*models.Step{
+							{
+								ID:   utils.GetPtr("version"),
+								Name: utils.GetPtr(""),
+								Type: models.ShellStepType,
+								Shell: &models.Shell{
+									Script:        utils.GetPtr(`echo "version=1.0.0" >> $GITHUB_OUTPUT`),
+									FileReference: testutils.CreateFileReference(10, 14, 10, 52),
+								},
+								Outputs: []*models.Output{
+									{
+										Name:          utils.GetPtr("version"),
+										FileReference: testutils.CreateFileReference(10, 14, 10, 52),
+									},
+								},
+								FileReference: testutils.CreateFileReference(9, 9, 10, 52),
+							},
+						},
+						Metadata: models.Metadata{
+							Build: true,
+						},
+						FileReference: testutils.CreateFileReference(4, 3, 10, 52),
+					},
+					{
+						ID:        utils.GetPtr("deploy"),
+						Name:      utils.GetPtr("deploy"),
+						TimeoutMS: utils.GetPtr(21600000),
+						Runner: &models.Runner{
+							OS:            utils.GetPtr("linux"),
+							Labels:        &[]string{"ubuntu-latest"},
+							SelfHosted:    utils.GetPtr(false),
+							FileReference: testutils.CreateFileReference(13, 14, 13, 27),
+						},
+						Dependencies: []*models.JobDependency{
+							{
+								JobID: utils.GetPtr("build"),
+							},
+						},
+						Steps: []*models.Step{
+							{
+								Name: utils.GetPtr(""),
+								Type: models.ShellStepType,
+								Shell: &models.Shell{
+									Script:        utils.GetPtr("./deploy.sh ${{ needs.build.outputs.version }}"),
+									FileReference: testutils.CreateFileReference(16, 14, 16, 60),
+								},
+								FileReference: testutils.CreateFileReference(16, 9, 16, 60),
+							},
+						},
+						FileReference: testutils.CreateFileReference(12, 3, 16, 60),
+					},
+				}),
+			},
+		},
 		{
 			Filename: "all-triggers.yaml",
 			Expected: &models.Pipeline{
@@ -271,7 +349,7 @@ func TestGitHub(t *testing.T) {
 						FileReference:    testutils.CreateFileReference(8, 3, 10, 26),
 						ID:               utils.GetPtr("job1"),
 						Name:             utils.GetPtr("Job 1"),
-						TokenPermissions: getAllGitHubPermissions(models.Permission{Read: true}),
+						TokenPermissions: getAllGitHubPermissions(models.Permission{Read: true}, testutils.CreateFileReference(10, 18, 10, 26)),
 						TimeoutMS:        utils.GetPtr(21600000),
 					},
 				}),
@@ -14,6 +14,27 @@ func TestGitLab(t *testing.T) {
 		{
 			Filename: "gradle.yaml",
 			Expected: SortPipeline(&models.Pipeline{
+				Stages: []*models.Stage{
+					{
+						ID:    utils.GetPtr("build"),
+						Name:  utils.GetPtr("build"),
+						Order: 0,
+						Jobs:  []string{"build"},
+					},
+					{
+						ID:           utils.GetPtr("test"),
+						Name:         utils.GetPtr("test"),
+						Order:        1,
+						Dependencies: []string{"build"},
+						Jobs:         []string{"test"},
+					},
+					{
+						ID:           utils.GetPtr("deploy"),
+						Name:         utils.GetPtr("deploy"),
+						Order:        2,
+						Dependencies: []string{"test"},
+					},
+				},
 				Platform: consts.GitLabPlatform,
 				Jobs: []*models.Job{
 					{
@@ -32,6 +53,7 @@ func TestGitLab(t *testing.T) {
 						Metadata: models.Metadata{
 							Test: true,
 						},
+						Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 						FileReference: testutils.CreateFileReference(33, 1, 35, 23),
 					},
 					{
@@ -50,6 +72,15 @@ func TestGitLab(t *testing.T) {
 						Metadata: models.Metadata{
 							Build: true,
 						},
+						Produces: []*models.Artifact{
+							{
+								Type:          models.CacheArtifactType,
+								Name:          utils.GetPtr("$CI_COMMIT_REF_NAME"),
+								Paths:         []string{"build", ".gradle"},
+								FileReference: testutils.CreateFileReference(26, 3, 31, 16),
+							},
+						},
+						Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 						FileReference: testutils.CreateFileReference(23, 1, 31, 16),
 					},
 				},
@@ -89,34 +120,76 @@ func TestGitLab(t *testing.T) {
 		{
 			Filename: "terraform.yaml",
 			Expected: SortPipeline(&models.Pipeline{
+				Stages: []*models.Stage{
+					{
+						ID:    utils.GetPtr("validate"),
+						Name:  utils.GetPtr("validate"),
+						Order: 0,
+					},
+					{
+						ID:           utils.GetPtr("test"),
+						Name:         utils.GetPtr("test"),
+						Order:        1,
+						Dependencies: []string{"validate"},
+						Jobs:         []string{"build", "deploy", "fmt", "validate"},
+					},
+					{
+						ID:           utils.GetPtr("build"),
+						Name:         utils.GetPtr("build"),
+						Order:        2,
+						Dependencies: []string{"test"},
+					},
+					{
+						ID:           utils.GetPtr("deploy"),
+						Name:         utils.GetPtr("deploy"),
+						Order:        3,
+						Dependencies: []string{"build"},
+					},
+				},
 				Platform: consts.GitLabPlatform,
 				Jobs: []*models.Job{
 					{
-						ID:            utils.GetPtr("fmt"),
-						Name:          utils.GetPtr("fmt"),
-						FileReference: testutils.CreateFileReference(12, 1, 14, 10),
+						ID:                utils.GetPtr("fmt"),
+						Name:              utils.GetPtr("fmt"),
+						Extends:           []string{".terraform:fmt"},
+						IgnoresStageOrder: true,
+						FileReference:     testutils.CreateFileReference(12, 1, 14, 10),
 					},
 					{
-						ID:            utils.GetPtr("validate"),
-						Name:          utils.GetPtr("validate"),
-						FileReference: testutils.CreateFileReference(16, 1, 18, 10),
+						ID:                utils.GetPtr("validate"),
+						Name:              utils.GetPtr("validate"),
+						Extends:           []string{".terraform:validate"},
+						IgnoresStageOrder: true,
+						FileReference:     testutils.CreateFileReference(16, 1, 18, 10),
 					},
 					{
 						ID:            utils.GetPtr("build"),
 						Name:          utils.GetPtr("build"),
+						Extends:       []string{".terraform:build"},
+						Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 						FileReference: testutils.CreateFileReference(20, 1, 21, 28),
 						Metadata: models.Metadata{
 							Build: true,
 						},
 					},
 					{
-						ID:   utils.GetPtr("deploy"),
-						Name: utils.GetPtr("deploy"),
+						ID:      utils.GetPtr("deploy"),
+						Name:    utils.GetPtr("deploy"),
+						Extends: []string{".terraform:deploy"},
 						Dependencies: []*models.JobDependency{
 							{
-								JobID: utils.GetPtr("build"),
+								JobID:         utils.GetPtr("build"),
+								ArtifactsOnly: true,
 							},
 						},
+						Environment: &models.Environment{
+							Name:          utils.GetPtr("$TF_STATE_NAME"),
+							FileReference: testutils.CreateFileReference(27, 3, 28, 25),
+						},
+						Metadata: models.Metadata{
+							Deploy: true,
+						},
+						Consumes:      []*models.Artifact{{Type: models.FileArtifactType, JobID: utils.GetPtr("build")}},
 						FileReference: testutils.CreateFileReference(23, 1, 28, 25),
 					},
 				},
@@ -126,6 +199,14 @@ func TestGitLab(t *testing.T) {
 		{
 			Filename: "build-job.yaml",
 			Expected: SortPipeline(&models.Pipeline{
+				Stages: []*models.Stage{
+					{
+						ID:    utils.GetPtr("build"),
+						Name:  utils.GetPtr("build"),
+						Order: 0,
+						Jobs:  []string{"python-build"},
+					},
+				},
 				Platform: consts.GitLabPlatform,
 				Triggers: &models.Triggers{
 					FileReference: testutils.CreateFileReference(22, 3, 25, 18),
@@ -171,6 +252,7 @@ func TestGitLab(t *testing.T) {
 						},
 						ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("build")),
 						Metadata:         models.Metadata{Build: true},
+						Consumes:         []*models.Artifact{{Type: models.FileArtifactType}},
 						FileReference:    testutils.CreateFileReference(4, 1, 16, 29),
 					},
 				},
@@ -215,6 +297,27 @@ func TestGitLab(t *testing.T) {
 						},
 						FileReference: testutils.CreateFileReference(1, 10, 1, 49),
 						Pipeline: SortPipeline(&models.Pipeline{
+							Stages: []*models.Stage{
+								{
+									ID:    utils.GetPtr("build"),
+									Name:  utils.GetPtr("build"),
+									Order: 0,
+									Jobs:  []string{"build"},
+								},
+								{
+									ID:           utils.GetPtr("test"),
+									Name:         utils.GetPtr("test"),
+									Order:        1,
+									Dependencies: []string{"build"},
+									Jobs:         []string{"test"},
+								},
+								{
+									ID:           utils.GetPtr("deploy"),
+									Name:         utils.GetPtr("deploy"),
+									Order:        2,
+									Dependencies: []string{"test"},
+								},
+							},
 							Jobs: []*models.Job{
 								{
 									ID:               utils.GetPtr("test"),
@@ -232,6 +335,7 @@ func TestGitLab(t *testing.T) {
 									Metadata: models.Metadata{
 										Test: true,
 									},
+									Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 									FileReference: testutils.CreateFileReference(33, 1, 35, 23),
 								},
 								{
@@ -250,6 +354,15 @@ func TestGitLab(t *testing.T) {
 									Metadata: models.Metadata{
 										Build: true,
 									},
+									Produces: []*models.Artifact{
+										{
+											Type:          models.CacheArtifactType,
+											Name:          utils.GetPtr("$CI_COMMIT_REF_NAME"),
+											Paths:         []string{"build", ".gradle"},
+											FileReference: testutils.CreateFileReference(26, 3, 31, 16),
+										},
+									},
+									Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 									FileReference: testutils.CreateFileReference(23, 1, 31, 16),
 								},
 							},
@@ -307,6 +420,27 @@ func TestGitLab(t *testing.T) {
 						VersionType:   models.BranchVersion,
 						FileReference: testutils.CreateFileReference(1, 10, 1, 73),
 						Pipeline: SortPipeline(&models.Pipeline{
+							Stages: []*models.Stage{
+								{
+									ID:    utils.GetPtr("build"),
+									Name:  utils.GetPtr("build"),
+									Order: 0,
+									Jobs:  []string{"build"},
+								},
+								{
+									ID:           utils.GetPtr("test"),
+									Name:         utils.GetPtr("test"),
+									Order:        1,
+									Dependencies: []string{"build"},
+									Jobs:         []string{"test"},
+								},
+								{
+									ID:           utils.GetPtr("deploy"),
+									Name:         utils.GetPtr("deploy"),
+									Order:        2,
+									Dependencies: []string{"test"},
+								},
+							},
 							Jobs: []*models.Job{
 								{
 									ID:               utils.GetPtr("test"),
@@ -324,6 +458,7 @@ func TestGitLab(t *testing.T) {
 									Metadata: models.Metadata{
 										Test: true,
 									},
+									Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 									FileReference: testutils.CreateFileReference(33, 1, 35, 23),
 								},
 								{
@@ -342,6 +477,15 @@ func TestGitLab(t *testing.T) {
 									Metadata: models.Metadata{
 										Build: true,
 									},
+									Produces: []*models.Artifact{
+										{
+											Type:          models.CacheArtifactType,
+											Name:          utils.GetPtr("$CI_COMMIT_REF_NAME"),
+											Paths:         []string{"build", ".gradle"},
+											FileReference: testutils.CreateFileReference(26, 3, 31, 16),
+										},
+									},
+									Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 									FileReference: testutils.CreateFileReference(23, 1, 31, 16),
 								},
 							},
@@ -403,6 +547,27 @@ func TestGitLab(t *testing.T) {
 						VersionType:   models.BranchVersion,
 						FileReference: testutils.CreateFileReference(2, 5, 4, 16),
 						Pipeline: SortPipeline(&models.Pipeline{
+							Stages: []*models.Stage{
+								{
+									ID:    utils.GetPtr("build"),
+									Name:  utils.GetPtr("build"),
+									Order: 0,
+									Jobs:  []string{"build"},
+								},
+								{
+									ID:           utils.GetPtr("test"),
+									Name:         utils.GetPtr("test"),
+									Order:        1,
+									Dependencies: []string{"build"},
+									Jobs:         []string{"test"},
+								},
+								{
+									ID:           utils.GetPtr("deploy"),
+									Name:         utils.GetPtr("deploy"),
+									Order:        2,
+									Dependencies: []string{"test"},
+								},
+							},
 							Jobs: []*models.Job{
 								{
 									ID:               utils.GetPtr("test"),
@@ -420,6 +585,7 @@ func TestGitLab(t *testing.T) {
 									Metadata: models.Metadata{
 										Test: true,
 									},
+									Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 									FileReference: testutils.CreateFileReference(33, 1, 35, 23),
 								},
 								{
@@ -438,6 +604,15 @@ func TestGitLab(t *testing.T) {
 									Metadata: models.Metadata{
 										Build: true,
 									},
+									Produces: []*models.Artifact{
+										{
+											Type:          models.CacheArtifactType,
+											Name:          utils.GetPtr("$CI_COMMIT_REF_NAME"),
+											Paths:         []string{"build", ".gradle"},
+											FileReference: testutils.CreateFileReference(26, 3, 31, 16),
+										},
+									},
+									Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 									FileReference: testutils.CreateFileReference(23, 1, 31, 16),
 								},
 							},
@@ -486,6 +661,27 @@ func TestGitLab(t *testing.T) {
 						VersionType:   models.BranchVersion,
 						FileReference: testutils.CreateFileReference(5, 5, 5, 68),
 						Pipeline: SortPipeline(&models.Pipeline{
+							Stages: []*models.Stage{
+								{
+									ID:    utils.GetPtr("build"),
+									Name:  utils.GetPtr("build"),
+									Order: 0,
+									Jobs:  []string{"build"},
+								},
+								{
+									ID:           utils.GetPtr("test"),
+									Name:         utils.GetPtr("test"),
+									Order:        1,
+									Dependencies: []string{"build"},
+									Jobs:         []string{"test"},
+								},
+								{
+									ID:           utils.GetPtr("deploy"),
+									Name:         utils.GetPtr("deploy"),
+									Order:        2,
+									Dependencies: []string{"test"},
+								},
+							},
 							Jobs: []*models.Job{
 								{
 									ID:               utils.GetPtr("test"),
@@ -503,6 +699,7 @@ func TestGitLab(t *testing.T) {
 									Metadata: models.Metadata{
 										Test: true,
 									},
+									Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 									FileReference: testutils.CreateFileReference(33, 1, 35, 23),
 								},
 								{
@@ -521,6 +718,15 @@ func TestGitLab(t *testing.T) {
 									Metadata: models.Metadata{
 										Build: true,
 									},
+									Produces: []*models.Artifact{
+										{
+											Type:          models.CacheArtifactType,
+											Name:          utils.GetPtr("$CI_COMMIT_REF_NAME"),
+											Paths:         []string{"build", ".gradle"},
+											FileReference: testutils.CreateFileReference(26, 3, 31, 16),
+										},
+									},
+									Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 									FileReference: testutils.CreateFileReference(23, 1, 31, 16),
 								},
 							},
@@ -565,6 +771,27 @@ func TestGitLab(t *testing.T) {
 						},
 						FileReference: testutils.CreateFileReference(6, 5, 6, 44),
 						Pipeline: SortPipeline(&models.Pipeline{
+							Stages: []*models.Stage{
+								{
+									ID:    utils.GetPtr("build"),
+									Name:  utils.GetPtr("build"),
+									Order: 0,
+									Jobs:  []string{"build"},
+								},
+								{
+									ID:           utils.GetPtr("test"),
+									Name:         utils.GetPtr("test"),
+									Order:        1,
+									Dependencies: []string{"build"},
+									Jobs:         []string{"test"},
+								},
+								{
+									ID:           utils.GetPtr("deploy"),
+									Name:         utils.GetPtr("deploy"),
+									Order:        2,
+									Dependencies: []string{"test"},
+								},
+							},
 							Jobs: []*models.Job{
 								{
 									ID:               utils.GetPtr("test"),
@@ -582,6 +809,7 @@ func TestGitLab(t *testing.T) {
 									Metadata: models.Metadata{
 										Test: true,
 									},
+									Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 									FileReference: testutils.CreateFileReference(33, 1, 35, 23),
 								},
 								{
@@ -600,6 +828,15 @@ func TestGitLab(t *testing.T) {
 									Metadata: models.Metadata{
 										Build: true,
 									},
+									Produces: []*models.Artifact{
+										{
+											Type:          models.CacheArtifactType,
+											Name:          utils.GetPtr("$CI_COMMIT_REF_NAME"),
+											Paths:         []string{"build", ".gradle"},
+											FileReference: testutils.CreateFileReference(26, 3, 31, 16),
+										},
+									},
+									Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 									FileReference: testutils.CreateFileReference(23, 1, 31, 16),
 								},
 							},
@@ -648,6 +885,27 @@ func TestGitLab(t *testing.T) {
 						VersionType:   models.BranchVersion,
 						FileReference: testutils.CreateFileReference(7, 5, 7, 36),
 						Pipeline: SortPipeline(&models.Pipeline{
+							Stages: []*models.Stage{
+								{
+									ID:    utils.GetPtr("build"),
+									Name:  utils.GetPtr("build"),
+									Order: 0,
+									Jobs:  []string{"build"},
+								},
+								{
+									ID:           utils.GetPtr("test"),
+									Name:         utils.GetPtr("test"),
+									Order:        1,
+									Dependencies: []string{"build"},
+									Jobs:         []string{"test"},
+								},
+								{
+									ID:           utils.GetPtr("deploy"),
+									Name:         utils.GetPtr("deploy"),
+									Order:        2,
+									Dependencies: []string{"test"},
+								},
+							},
 							Jobs: []*models.Job{
 								{
 									ID:               utils.GetPtr("test"),
@@ -665,6 +923,7 @@ func TestGitLab(t *testing.T) {
 									Metadata: models.Metadata{
 										Test: true,
 									},
+									Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 									FileReference: testutils.CreateFileReference(33, 1, 35, 23),
 								},
 								{
@@ -683,6 +942,15 @@ func TestGitLab(t *testing.T) {
 									Metadata: models.Metadata{
 										Build: true,
 									},
+									Produces: []*models.Artifact{
+										{
+											Type:          models.CacheArtifactType,
+											Name:          utils.GetPtr("$CI_COMMIT_REF_NAME"),
+											Paths:         []string{"build", ".gradle"},
+											FileReference: testutils.CreateFileReference(26, 3, 31, 16),
+										},
+									},
+									Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 									FileReference: testutils.CreateFileReference(23, 1, 31, 16),
 								},
 							},
@@ -742,17 +1010,59 @@ func TestGitLab(t *testing.T) {
 				},
 				Jobs:     []*models.Job{},
 				Defaults: &models.Defaults{},
+				Diagnostics: []*models.Diagnostic{
+					{
+						Severity:      models.ErrorSeverity,
+						Code:          models.ImportFetchFailedCode,
+						Message:       "failed fetching : missing required fields for remote import",
+						FileReference: testutils.CreateFileReference(1, 10, 1, 41),
+						Source: &models.ImportSource{
+							SCM:          consts.GitLabPlatform,
+							Organization: utils.GetPtr(""),
+							Repository:   utils.GetPtr(""),
+							Path:         utils.GetPtr(""),
+							Type:         models.SourceTypeRemote,
+						},
+					},
+				},
 			},
 		},
 		{
 			Filename: "trigger-include.yaml",
 			Expected: &models.Pipeline{
+				Stages: []*models.Stage{
+					{
+						ID:    utils.GetPtr("build"),
+						Name:  utils.GetPtr("build"),
+						Order: 0,
+					},
+					{
+						ID:           utils.GetPtr("test"),
+						Name:         utils.GetPtr("test"),
+						Order:        1,
+						Dependencies: []string{"build"},
+					},
+					{
+						ID:           utils.GetPtr("deploy"),
+						Name:         utils.GetPtr("deploy"),
+						Order:        2,
+						Dependencies: []string{"test"},
+					},
+					{
+						ID:           utils.GetPtr("aqua"),
+						Name:         utils.GetPtr("aqua"),
+						Order:        3,
+						Dependencies: []string{"deploy"},
+						Jobs:         []string{"trivy-parent"},
+					},
+				},
 				Platform: consts.GitLabPlatform,
 				Jobs: []*models.Job{
 					{
 						ID:               utils.GetPtr("trivy-parent"),
 						Name:             utils.GetPtr("trivy-parent"),
 						ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("aqua")),
+						Consumes:         []*models.Artifact{{Type: models.FileArtifactType}},
 						FileReference:    testutils.CreateFileReference(1, 1, 4, 52),
 					},
 				},
@@ -766,6 +1076,26 @@ func TestGitLab(t *testing.T) {
 						},
 						FileReference: testutils.CreateFileReference(4, 5, 4, 43),
 						Pipeline: SortPipeline(&models.Pipeline{
+							Stages: []*models.Stage{
+								{
+									ID:    utils.GetPtr("build"),
+									Name:  utils.GetPtr("build"),
+									Order: 0,
+								},
+								{
+									ID:           utils.GetPtr("test"),
+									Name:         utils.GetPtr("test"),
+									Order:        1,
+									Dependencies: []string{"build"},
+									Jobs:         []string{"trivy"},
+								},
+								{
+									ID:           utils.GetPtr("deploy"),
+									Name:         utils.GetPtr("deploy"),
+									Order:        2,
+									Dependencies: []string{"test"},
+								},
+							},
 							Defaults: &models.Defaults{},
 							Jobs: []*models.Job{
 								{
@@ -794,6 +1124,7 @@ func TestGitLab(t *testing.T) {
 											FileReference: testutils.CreateFileReference(4, 5, 4, 165),
 										},
 									},
+									Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
 									FileReference: testutils.CreateFileReference(1, 1, 5, 86),
 								},
 							},
@@ -802,6 +1133,168 @@ func TestGitLab(t *testing.T) {
 				},
 			},
 		},
+		{
+			Filename: "extends.yaml",
+			Expected: SortPipeline(&models.Pipeline{
+				Stages: []*models.Stage{
+					{
+						ID:    utils.GetPtr("build"),
+						Name:  utils.GetPtr("build"),
+						Order: 0,
+					},
+					{
+						ID:           utils.GetPtr("test"),
+						Name:         utils.GetPtr("test"),
+						Order:        1,
+						Dependencies: []string{"build"},
+						Jobs:         []string{"rspec"},
+					},
+					{
+						ID:           utils.GetPtr("deploy"),
+						Name:         utils.GetPtr("deploy"),
+						Order:        2,
+						Dependencies: []string{"test"},
+					},
+				},
+				Platform: consts.GitLabPlatform,
+				Jobs: []*models.Job{
+					{
+						ID:   utils.GetPtr(".base"),
+						Name: utils.GetPtr(".base"),
+						PreSteps: []*models.Step{
+							{
+								Type: models.ShellStepType,
+								Shell: &models.Shell{
+									Script: utils.GetPtr("bundle install"),
+								},
+								FileReference: testutils.CreateFileReference(8, 3, 9, 21),
+							},
+						},
+						EnvironmentVariables: &models.EnvironmentVariablesRef{
+							EnvironmentVariables: models.EnvironmentVariables{
+								"RAILS_ENV": "test",
+								"LOG_LEVEL": "info",
+							},
+							FileReference: testutils.CreateFileReference(4, 1, 7, 22),
+						},
+						Runner: &models.Runner{
+							DockerMetadata: &models.DockerMetadata{
+								Image: utils.GetPtr("ruby"),
+								Label: utils.GetPtr("3.1"),
+							},
+							FileReference: testutils.CreateFileReference(4, 3, 4, 18),
+						},
+						Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
+						FileReference: testutils.CreateFileReference(3, 1, 9, 21),
+					},
+					{
+						ID:   utils.GetPtr(".tests"),
+						Name: utils.GetPtr(".tests"),
+						Steps: []*models.Step{
+							{
+								Type: models.ShellStepType,
+								Shell: &models.Shell{
+									Script: utils.GetPtr("rake test"),
+								},
+								FileReference: testutils.CreateFileReference(16, 3, 17, 16),
+							},
+						},
+						PreSteps: []*models.Step{
+							{
+								Type: models.ShellStepType,
+								Shell: &models.Shell{
+									Script: utils.GetPtr("bundle install"),
+								},
+								FileReference: testutils.CreateFileReference(8, 3, 9, 21),
+							},
+						},
+						EnvironmentVariables: &models.EnvironmentVariablesRef{
+							EnvironmentVariables: models.EnvironmentVariables{
+								"RAILS_ENV": "test",
+								"LOG_LEVEL": "debug",
+							},
+							FileReference: testutils.CreateFileReference(13, 1, 15, 23),
+						},
+						Runner: &models.Runner{
+							DockerMetadata: &models.DockerMetadata{
+								Image: utils.GetPtr("ruby"),
+								Label: utils.GetPtr("3.1"),
+							},
+							FileReference: testutils.CreateFileReference(4, 3, 4, 18),
+						},
+						ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("test")),
+						Metadata:         models.Metadata{Test: true},
+						Extends:          []string{".base"},
+						Consumes:         []*models.Artifact{{Type: models.FileArtifactType}},
+						FileReference:    testutils.CreateFileReference(11, 1, 17, 16),
+					},
+					{
+						ID:   utils.GetPtr("rspec"),
+						Name: utils.GetPtr("rspec"),
+						Steps: []*models.Step{
+							{
+								Type: models.ShellStepType,
+								Shell: &models.Shell{
+									Script: utils.GetPtr("rspec"),
+								},
+								FileReference: testutils.CreateFileReference(23, 3, 24, 12),
+							},
+						},
+						PreSteps: []*models.Step{
+							{
+								Type: models.ShellStepType,
+								Shell: &models.Shell{
+									Script: utils.GetPtr("bundle install"),
+								},
+								FileReference: testutils.CreateFileReference(8, 3, 9, 21),
+							},
+						},
+						EnvironmentVariables: &models.EnvironmentVariablesRef{
+							EnvironmentVariables: models.EnvironmentVariables{
+								"RAILS_ENV": "test",
+								"LOG_LEVEL": "debug",
+							},
+							FileReference: testutils.CreateFileReference(13, 1, 15, 23),
+						},
+						Runner: &models.Runner{
+							DockerMetadata: &models.DockerMetadata{
+								Image: utils.GetPtr("ruby"),
+								Label: utils.GetPtr("3.1"),
+							},
+							FileReference: testutils.CreateFileReference(4, 3, 4, 18),
+						},
+						ConcurrencyGroup: utils.GetPtr(models.ConcurrencyGroup("test")),
+						Tags:             []string{"docker"},
+						Extends:          []string{".base", ".tests", ".remote-template"},
+						Consumes:         []*models.Artifact{{Type: models.FileArtifactType}},
+						FileReference:    testutils.CreateFileReference(19, 1, 24, 12),
+					},
+				},
+				Defaults: &models.Defaults{},
+				Imports: []*models.Import{
+					{
+						Source: &models.ImportSource{
+							SCM:  consts.GitLabPlatform,
+							Type: models.SourceTypeLocal,
+							Path: utils.GetPtr("/../fixtures/gitlab/testdata/templates.yaml"),
+						},
+						FileReference: testutils.CreateFileReference(1, 10, 1, 53),
+						Pipeline: &models.Pipeline{
+							Jobs: []*models.Job{
+								{
+									ID:            utils.GetPtr(".remote-template"),
+									Name:          utils.GetPtr(".remote-template"),
+									Tags:          []string{"docker"},
+									Consumes:      []*models.Artifact{{Type: models.FileArtifactType}},
+									FileReference: testutils.CreateFileReference(1, 1, 3, 13),
+								},
+							},
+							Defaults: &models.Defaults{},
+						},
+					},
+				},
+			}),
+		},
 	}
 
 	executeTestCases(t, testCases, "gitlab", consts.GitLabPlatform, "", "")
@@ -0,0 +1,206 @@
+package blackbox
+
+import (
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+	"github.com/argonsecurity/pipeline-parser/pkg/testutils"
+	"github.com/argonsecurity/pipeline-parser/pkg/utils"
+)
+
+func TestJenkins(t *testing.T) {
+	testCases := []TestCase{
+		{
+			Filename: "declarative.Jenkinsfile",
+			Expected: &models.Pipeline{
+				Platform: consts.JenkinsPlatform,
+				Defaults: &models.Defaults{
+					Runner: &models.Runner{
+						Type: utils.GetPtr("docker"),
+						DockerMetadata: &models.DockerMetadata{
+							Image: utils.GetPtr("maven"),
+							Label: utils.GetPtr("3.9-eclipse-temurin-17"),
+						},
+						FileReference: testutils.CreateFileReference(2, 5, 7, 6),
+					},
+					EnvironmentVariables: &models.EnvironmentVariablesRef{
+						EnvironmentVariables: models.EnvironmentVariables{
+							"APP_NAME":             "demo",
+							"REGISTRY_CREDENTIALS": "credentials('registry')",
+						},
+						FileReference: testutils.CreateFileReference(8, 5, 11, 6),
+					},
+					Settings: &map[string]any{
+						"timeout_ms": 3600000,
+					},
+					FileReference: testutils.CreateFileReference(1, 1, 62, 2),
+				},
+				Jobs: []*models.Job{
+					{
+						ID:   utils.GetPtr("Build"),
+						Name: utils.GetPtr("Build"),
+						Steps: []*models.Step{
+							{
+								Type: models.ShellStepType,
+								Shell: &models.Shell{
+									Type:          utils.GetPtr("sh"),
+									Script:        utils.GetPtr("mvn -B -DskipTests clean package"),
+									FileReference: testutils.CreateFileReference(18, 17, 18, 54),
+								},
+								FileReference: testutils.CreateFileReference(18, 17, 18, 54),
+							},
+						},
+						Metadata: models.Metadata{
+							Build: true,
+						},
+						TimeoutMS:     utils.GetPtr(3600000),
+						FileReference: testutils.CreateFileReference(16, 9, 20, 10),
+					},
+					{
+						ID:   utils.GetPtr("Deploy"),
+						Name: utils.GetPtr("Deploy"),
+						Steps: []*models.Step{
+							{
+								Type:             models.ShellStepType,
+								WorkingDirectory: utils.GetPtr("deploy"),
+								Shell: &models.Shell{
+									Type:          utils.GetPtr("sh"),
+									Script:        utils.GetPtr("./deploy.sh ${TARGET}"),
+									FileReference: testutils.CreateFileReference(50, 21, 50, 47),
+								},
+								FileReference: testutils.CreateFileReference(50, 21, 50, 47),
+							},
+							{
+								Name: utils.GetPtr("echo"),
+								Type: models.TaskStepType,
+								Task: &models.Task{
+									Name: utils.GetPtr("echo"),
+									Inputs: []*models.Parameter{
+										{Value: "Deploying ${version}"},
+									},
+									VersionType: models.None,
+								},
+								FileReference: testutils.CreateFileReference(55, 25, 55, 52),
+							},
+							{
+								Name: utils.GetPtr("archiveArtifacts"),
+								Type: models.TaskStepType,
+								Task: &models.Task{
+									Name: utils.GetPtr("archiveArtifacts"),
+									Inputs: []*models.Parameter{
+										{Name: utils.GetPtr("artifacts"), Value: "target/*.jar"},
+										{Name: utils.GetPtr("fingerprint"), Value: "true"},
+									},
+									VersionType: models.None,
+								},
+								FileReference: testutils.CreateFileReference(58, 17, 58, 78),
+							},
+						},
+						EnvironmentVariables: &models.EnvironmentVariablesRef{
+							EnvironmentVariables: models.EnvironmentVariables{
+								"TARGET": "production",
+							},
+							FileReference: testutils.CreateFileReference(45, 13, 47, 14),
+						},
+						Conditions: []*models.Condition{
+							{
+								Statement: "branch 'main'",
+								Allow:     utils.GetPtr(true),
+								Branches:  &models.Filter{AllowList: []string{"main"}},
+							},
+						},
+						TimeoutMS:     utils.GetPtr(3600000),
+						FileReference: testutils.CreateFileReference(41, 9, 60, 10),
+					},
+					{
+						ID:   utils.GetPtr("Test/Lint"),
+						Name: utils.GetPtr("Lint"),
+						Steps: []*models.Step{
+							{
+								Type: models.ShellStepType,
+								Shell: &models.Shell{
+									Type:          utils.GetPtr("sh"),
+									Script:        utils.GetPtr("\n                            mvn checkstyle:check\n                        "),
+									FileReference: testutils.CreateFileReference(34, 25, 36, 28),
+								},
+								FileReference: testutils.CreateFileReference(34, 25, 36, 28),
+							},
+						},
+						Runner: &models.Runner{
+							Labels:        &[]string{"linux"},
+							FileReference: testutils.CreateFileReference(29, 21, 29, 44),
+						},
+						TimeoutMS:     utils.GetPtr(600000),
+						FileReference: testutils.CreateFileReference(28, 17, 38, 18),
+					},
+					{
+						ID:   utils.GetPtr("Test/Unit"),
+						Name: utils.GetPtr("Unit"),
+						Steps: []*models.Step{
+							{
+								Type: models.ShellStepType,
+								Shell: &models.Shell{
+									Type:          utils.GetPtr("sh"),
+									Script:        utils.GetPtr("mvn test"),
+									FileReference: testutils.CreateFileReference(25, 25, 25, 38),
+								},
+								FileReference: testutils.CreateFileReference(25, 25, 25, 38),
+							},
+						},
+						TimeoutMS:     utils.GetPtr(3600000),
+						FileReference: testutils.CreateFileReference(23, 17, 27, 18),
+					},
+				},
+				Stages: []*models.Stage{
+					{
+						ID:            utils.GetPtr("Build"),
+						Name:          utils.GetPtr("Build"),
+						Order:         0,
+						Jobs:          []string{"Build"},
+						FileReference: testutils.CreateFileReference(16, 9, 20, 10),
+					},
+					{
+						ID:            utils.GetPtr("Test"),
+						Name:          utils.GetPtr("Test"),
+						Order:         1,
+						Dependencies:  []string{"Build"},
+						Jobs:          []string{"Test/Unit", "Test/Lint"},
+						FileReference: testutils.CreateFileReference(21, 9, 40, 10),
+					},
+					{
+						ID:    utils.GetPtr("Deploy"),
+						Name:  utils.GetPtr("Deploy"),
+						Order: 2,
+						Conditions: []*models.Condition{
+							{
+								Statement: "branch 'main'",
+								Allow:     utils.GetPtr(true),
+								Branches:  &models.Filter{AllowList: []string{"main"}},
+							},
+						},
+						Dependencies: []string{"Test"},
+						EnvironmentVariables: &models.EnvironmentVariablesRef{
+							EnvironmentVariables: models.EnvironmentVariables{
+								"TARGET": "production",
+							},
+							FileReference: testutils.CreateFileReference(45, 13, 47, 14),
+						},
+						Jobs:          []string{"Deploy"},
+						FileReference: testutils.CreateFileReference(41, 9, 60, 10),
+					},
+				},
+			},
+		},
+		{
+			Filename:   "missing-brace.Jenkinsfile",
+			ShouldFail: true,
+		},
+		{
+			Filename:   "scripted.Jenkinsfile",
+			ShouldFail: true,
+		},
+	}
+
+	executeTestCases(t, testCases, "jenkins", consts.JenkinsPlatform, "", "")
+}
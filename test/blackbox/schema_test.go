@@ -0,0 +1,205 @@
+package blackbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/argonsecurity/pipeline-parser/pkg/consts"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
+	"github.com/argonsecurity/pipeline-parser/pkg/handler"
+	"github.com/argonsecurity/pipeline-parser/pkg/models"
+)
+
+const schemaPath = "../../schema/pipeline.schema.json"
+
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 string                 `json:"type"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	PatternProperties    map[string]*jsonSchema `json:"patternProperties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	Required             []string               `json:"required"`
+	Defs                 map[string]*jsonSchema `json:"$defs"`
+}
+
+// UnmarshalJSON supports the boolean schemas, where true accepts any value
+func (s *jsonSchema) UnmarshalJSON(data []byte) error {
+	if string(data) == "true" {
+		*s = jsonSchema{}
+		return nil
+	}
+
+	type schema jsonSchema
+	return json.Unmarshal(data, (*schema)(s))
+}
+
+func loadSchema(t *testing.T) *jsonSchema {
+	data, err := os.ReadFile(schemaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var schema jsonSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+	return &schema
+}
+
+// TestSchemaCoversModels checks that every field of the models is described by the schema
+func TestSchemaCoversModels(t *testing.T) {
+	schema := loadSchema(t)
+	visited := map[reflect.Type]bool{}
+
+	var checkType func(modelType reflect.Type)
+	checkType = func(modelType reflect.Type) {
+		for modelType.Kind() == reflect.Pointer || modelType.Kind() == reflect.Slice || modelType.Kind() == reflect.Map {
+			modelType = modelType.Elem()
+		}
+		if modelType.Kind() != reflect.Struct || visited[modelType] || modelType.PkgPath() != reflect.TypeOf(models.Pipeline{}).PkgPath() {
+			return
+		}
+		visited[modelType] = true
+
+		definition, ok := schema.Defs[modelType.Name()]
+		if !ok {
+			t.Errorf("the schema has no definition of %s", modelType.Name())
+			return
+		}
+
+		for i := 0; i < modelType.NumField(); i++ {
+			field := modelType.Field(i)
+			name := getJSONName(field)
+			if name == "-" {
+				continue
+			}
+			if _, ok := definition.Properties[name]; !ok {
+				t.Errorf("the schema definition of %s has no property %s", modelType.Name(), name)
+			}
+			checkType(field.Type)
+		}
+	}
+	checkType(reflect.TypeOf(models.Pipeline{}))
+}
+
+// TestFixturesMatchSchema checks that the parsed fixtures of all the platforms are valid according to the schema
+func TestFixturesMatchSchema(t *testing.T) {
+	schema := loadSchema(t)
+	platforms := map[string]models.Platform{
+		"azure":     consts.AzurePlatform,
+		"bitbucket": consts.BitbucketPlatform,
+		"circleci":  consts.CircleCIPlatform,
+		"github":    consts.GitHubPlatform,
+		"gitlab":    consts.GitLabPlatform,
+		"jenkins":   consts.JenkinsPlatform,
+	}
+
+	for folder, platform := range platforms {
+		filenames, err := filepath.Glob(filepath.Join("../fixtures", folder, "*"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, filename := range filenames {
+			pipeline, err := handler.Handle(readFile(filename), platform, nil, nil, nil, handler.WithFetcher(fetcher.MemoryFetcher{}))
+			if err != nil || pipeline == nil {
+				continue
+			}
+
+			data, err := json.Marshal(pipeline)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var value any
+			if err := json.Unmarshal(data, &value); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, validationError := range schema.validate(value, "", schema.Defs) {
+				t.Errorf("%s: %s", filename, validationError)
+			}
+		}
+	}
+}
+
+// validate returns the errors of a value according to the subset of JSON schema that the pipeline schema uses
+func (s *jsonSchema) validate(value any, path string, defs map[string]*jsonSchema) []string {
+	if s.Ref != "" {
+		return defs[strings.TrimPrefix(s.Ref, "#/$defs/")].validate(value, path, defs)
+	}
+
+	if s.Type != "" && !hasJSONType(value, s.Type) {
+		return []string{fmt.Sprintf("%s: %v is not of type %s", path, value, s.Type)}
+	}
+
+	var errs []string
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for _, key := range s.Required {
+			if _, ok := typedValue[key]; !ok {
+				errs = append(errs, fmt.Sprintf("%s: missing required property %s", path, key))
+			}
+		}
+		for key, propertyValue := range typedValue {
+			propertyPath := path + "." + key
+			if property, ok := s.Properties[key]; ok {
+				errs = append(errs, property.validate(propertyValue, propertyPath, defs)...)
+				continue
+			}
+
+			matched := false
+			for pattern, property := range s.PatternProperties {
+				if regexp.MustCompile(pattern).MatchString(key) {
+					matched = true
+					errs = append(errs, property.validate(propertyValue, propertyPath, defs)...)
+				}
+			}
+			if !matched && s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				errs = append(errs, fmt.Sprintf("%s: additional property %s is not allowed", path, key))
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range typedValue {
+				errs = append(errs, s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i), defs)...)
+			}
+		}
+	}
+	return errs
+}
+
+func hasJSONType(value any, jsonType string) bool {
+	switch jsonType {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == float64(int64(number))
+	}
+	return true
+}
+
+func getJSONName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
@@ -8,9 +8,7 @@ import (
 	"sort"
 	"testing"
 
-	azureEnhancer "github.com/argonsecurity/pipeline-parser/pkg/enhancers/azure"
-	githubEnhancer "github.com/argonsecurity/pipeline-parser/pkg/enhancers/github"
-	gitlabEnhancer "github.com/argonsecurity/pipeline-parser/pkg/enhancers/gitlab"
+	"github.com/argonsecurity/pipeline-parser/pkg/fetcher"
 	"github.com/argonsecurity/pipeline-parser/pkg/handler"
 	"github.com/argonsecurity/pipeline-parser/pkg/models"
 	"github.com/go-test/deep"
@@ -23,14 +21,17 @@ func readFile(filename string) []byte {
 
 func executeTestCases(t *testing.T, testCases []TestCase, folder string, platform models.Platform, organization, baseUrl string) {
 	for _, testCase := range testCases {
-		if testCase.TestdataDir != "" {
-			h := http.FileServer(http.Dir(testCase.TestdataDir))
-			ts := httptest.NewServer(h)
-			defer ts.Close()
-			githubEnhancer.GITHUB_BASE_URL = ts.URL
-			gitlabEnhancer.GITLAB_BASE_URL = ts.URL
-			azureEnhancer.AZURE_SAAS_BASE_URL = ts.URL
+		// remote imports are always served locally, from an empty directory if the test case has no testdata
+		testdataDir := testCase.TestdataDir
+		if testdataDir == "" {
+			testdataDir = t.TempDir()
 		}
+		h := http.FileServer(http.Dir(testdataDir))
+		ts := httptest.NewServer(h)
+		defer ts.Close()
+		fetcher.GITHUB_BASE_URL = ts.URL
+		fetcher.GITLAB_BASE_URL = ts.URL
+		fetcher.AZURE_SAAS_BASE_URL = ts.URL
 
 		buf := readFile(filepath.Join("../fixtures", folder, testCase.Filename))
 		pipeline, err := handler.Handle(buf, platform, &models.Credentials{}, &organization, &baseUrl)